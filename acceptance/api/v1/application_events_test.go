@@ -0,0 +1,85 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+	v1 "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppEvents Endpoint", LApplication, func() {
+	var (
+		namespace string
+		app       string
+	)
+	containerImageURL := "epinio/sample-app"
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+		app = catalog.NewAppName()
+		env.MakeContainerImageApp(app, 1, containerImageURL)
+	})
+
+	AfterEach(func() {
+		env.DeleteApp(app)
+		env.DeleteNamespace(namespace)
+	})
+
+	It("returns the events of the app's workload, newest first", func() {
+		response, err := env.Curl("GET", fmt.Sprintf("%s%s/namespaces/%s/applications/%s/events",
+			serverURL, v1.Root, namespace, app), strings.NewReader(""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).ToNot(BeNil())
+
+		defer response.Body.Close()
+		bodyBytes, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK), string(bodyBytes))
+
+		events := models.EventList{}
+		err = json.Unmarshal(bodyBytes, &events)
+		Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+
+		// Deploying the app scales the deployment and schedules its pod
+		Expect(events).ToNot(BeEmpty())
+		for i, event := range events {
+			Expect(event.Reason).ToNot(BeEmpty())
+			Expect(event.Kind).To(BeElementOf("Deployment", "ReplicaSet", "Pod"))
+			if i > 0 {
+				Expect(event.LastSeen.After(events[i-1].LastSeen.Time)).To(BeFalse())
+			}
+		}
+	})
+
+	It("returns a 404 when the app does not exist", func() {
+		response, err := env.Curl("GET", fmt.Sprintf("%s%s/namespaces/%s/applications/bogus/events",
+			serverURL, v1.Root, namespace), strings.NewReader(""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).ToNot(BeNil())
+
+		defer response.Body.Close()
+		bodyBytes, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound), string(bodyBytes))
+	})
+})
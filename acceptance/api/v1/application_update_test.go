@@ -64,6 +64,39 @@ var _ = Describe("AppUpdate Endpoint", LApplication, func() {
 				return appShow(namespace, app).Workload.Status
 			}, "1m").Should(Equal("3/3"))
 		})
+
+		It("scales an application to zero instances and back", func() {
+			app := catalog.NewAppName()
+			env.MakeContainerImageApp(app, 1, containerImageURL)
+			defer env.DeleteApp(app)
+			Expect(appShow(namespace, app).Workload.Status).To(Equal("1/1"))
+
+			request := map[string]interface{}{"instances": 0}
+			_, statusCode := appUpdate(namespace, app, toJSON(request))
+			Expect(statusCode).To(Equal(http.StatusOK))
+
+			Eventually(func() bool {
+				return appShow(namespace, app).ScaledToZero
+			}, "1m").Should(BeTrue())
+
+			appObj := appShow(namespace, app)
+			Expect(appObj.Workload).To(BeNil())
+			Expect(appObj.Status).To(Equal(models.ApplicationCreated))
+			Expect(*appObj.Configuration.Instances).To(BeZero())
+
+			request = map[string]interface{}{"instances": 1}
+			_, statusCode = appUpdate(namespace, app, toJSON(request))
+			Expect(statusCode).To(Equal(http.StatusOK))
+
+			Eventually(func() string {
+				workload := appShow(namespace, app).Workload
+				if workload == nil {
+					return ""
+				}
+				return workload.Status
+			}, "2m").Should(Equal("1/1"))
+			Expect(appShow(namespace, app).ScaledToZero).To(BeFalse())
+		})
 	})
 
 	When("instances is invalid", func() {
@@ -1688,6 +1688,30 @@ configuration:
 			Expect(apps).ToNot(BeEmpty())
 		})
 
+		It("shows the details of an app in JSON format, with its workload metrics", func() {
+			out, err := env.Epinio("", "app", "show", appName, "--output", "json")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(ContainSubstring("Show application details"))
+
+			app := models.App{}
+			err = json.Unmarshal([]byte(out), &app)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(app.Meta.Name).To(Equal(appName))
+			Expect(app.Workload).ToNot(BeNil(), out)
+			Expect(app.Workload.Replicas).ToNot(BeEmpty(), out)
+
+			// The metrics are always present, even when zero
+			fields := map[string]interface{}{}
+			err = json.Unmarshal([]byte(out), &fields)
+			Expect(err).ToNot(HaveOccurred(), out)
+			replicas := fields["deployment"].(map[string]interface{})["replicas"].(map[string]interface{})
+			for _, replica := range replicas {
+				Expect(replica).To(HaveKey("millicpus"))
+				Expect(replica).To(HaveKey("memoryBytes"))
+				Expect(replica).To(HaveKey("restarts"))
+			}
+		})
+
 		It("shows the details of an app", func() {
 			out, err := env.Epinio("", "app", "show", appName)
 			Expect(err).ToNot(HaveOccurred(), out)
@@ -164,6 +164,20 @@ var _ = Describe("Login", LMisc, func() {
 		ExpectNamespace(tmpSettingsPath, "")
 	})
 
+	It("fails with an inaccessible namespace, without saving the settings", func() {
+		// check that the initial settings are empty
+		ExpectEmptySettings(tmpSettingsPath)
+
+		out, err := env.Epinio("", "login", "-u", "epinio", "-p", env.EpinioPassword,
+			"--namespace", "bogus", "--trust-ca", "--settings-file", tmpSettingsPath, serverURL)
+		Expect(err).To(HaveOccurred(), out)
+		Expect(out).To(ContainSubstring("namespace 'bogus'"))
+		Expect(out).ToNot(ContainSubstring("Login successful"))
+
+		// check that the settings are still empty
+		ExpectEmptySettings(tmpSettingsPath)
+	})
+
 	It("respects the port when one is present [fixed bug]", func() {
 		parsed, err := url.Parse(serverURL)
 		Expect(err).ToNot(HaveOccurred())
@@ -30,6 +30,7 @@ import (
 	"github.com/epinio/epinio/internal/cli/settings"
 	"github.com/epinio/epinio/internal/names"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"sigs.k8s.io/yaml"
 
 	. "github.com/epinio/epinio/acceptance/helpers/matchers"
 	. "github.com/onsi/ginkgo/v2"
@@ -210,6 +211,19 @@ var _ = Describe("Services", LService, func() {
 			)
 		})
 
+		It("shows a service in YAML format", func() {
+			out, err := env.Epinio("", "service", "show", service, "-o", "yaml")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(ContainSubstring("Showing Service"))
+
+			shown := models.Service{}
+			err = yaml.Unmarshal([]byte(out), &shown)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(shown.Meta.Name).To(Equal(service))
+			Expect(shown.CatalogService).To(Equal(catalogService.Meta.Name))
+			Expect(shown.Status).To(Equal(models.ServiceStatusDeployed))
+		})
+
 		Context("customized", func() {
 			It("shows the customized elements of a service", func() {
 				settings, err := env.GetSettingsFrom(testenv.EpinioYAML())
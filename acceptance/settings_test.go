@@ -165,6 +165,46 @@ var _ = Describe("Settings", LMisc, func() {
 		})
 	})
 
+	Describe("Contexts", func() {
+		It("switches between the contexts of a single file", func() {
+			data, err := os.ReadFile(testenv.EpinioYAML())
+			Expect(err).ToNot(HaveOccurred())
+			err = os.WriteFile(tmpSettingsPath, data, 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			out, err := env.Epinio("", "settings", "use-context", "other", "--create", "--settings-file", tmpSettingsPath)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "info", "--settings-file", tmpSettingsPath)
+			Expect(err).To(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "settings", "list-contexts", "--settings-file", tmpSettingsPath)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(
+				HaveATable(
+					WithHeaders("CURRENT", "NAME", "API URL", "USER", "NAMESPACE"),
+					WithRow("", "default", "https://epinio.*", env.EpinioUser, ".*"),
+					WithRow("\\*", "other", "", "", "workspace"),
+				),
+			)
+
+			out, err = env.Epinio("", "settings", "use-context", "default", "--settings-file", tmpSettingsPath)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "settings", "show", "--settings-file", tmpSettingsPath)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(
+				HaveATable(
+					WithHeaders("KEY", "VALUE"),
+					WithRow("Current Context", "default"),
+				),
+			)
+
+			out, err = env.Epinio("", "info", "--settings-file", tmpSettingsPath)
+			Expect(err).ToNot(HaveOccurred(), out)
+		})
+	})
+
 	Describe("Authorization settings", func() {
 		oldSettingsPath := testenv.EpinioYAML()
 
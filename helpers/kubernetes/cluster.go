@@ -55,6 +55,7 @@ var (
 	EpinioAPIGitCredentialsLabelKey = fmt.Sprintf("%s/%s", APISGroupName, "api-git-credentials")
 	EpinioAPISecretRoleLabelKey     = fmt.Sprintf("%s/%s", APISGroupName, "role")
 	EpinioAPIExportRegistryLabelKey = fmt.Sprintf("%s/%s", APISGroupName, "api-export-registry")
+	EpinioAPITokenLabelKey          = fmt.Sprintf("%s/%s", APISGroupName, "api-token")
 
 	EpinioAPIConfigMapRolesLabelKey   = fmt.Sprintf("%s/%s", APISGroupName, "role")
 	EpinioAPISecretRolesAnnotationKey = fmt.Sprintf("%s/%s", APISGroupName, "roles")
@@ -211,6 +212,33 @@ func (c *Cluster) IsJobFailed(ctx context.Context, jobName, namespace string) (b
 	return false, nil
 }
 
+// JobFailureReason returns the reason, and the message, of the failure of the given Job. The
+// result is empty if the Job is not in Failed state.
+func (c *Cluster) JobFailureReason(ctx context.Context, jobName, namespace string) (string, error) {
+	client, err := typedbatchv1.NewForConfig(c.RestConfig)
+	if err != nil {
+		return "", err
+	}
+
+	job, err := client.Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == apibatchv1.JobFailed && condition.Status == v1.ConditionTrue {
+			if condition.Reason == "" {
+				return "failed", nil
+			}
+			if condition.Message == "" {
+				return condition.Reason, nil
+			}
+			return condition.Reason + ": " + condition.Message, nil
+		}
+	}
+	return "", nil
+}
+
 // IsJobDone returns a condition function that indicates whether the given
 // Job is done (Completed or Failed), or not
 func (c *Cluster) IsJobDone(ctx context.Context, client *typedbatchv1.BatchV1Client, jobName, namespace string) wait.ConditionWithContextFunc {
@@ -133,6 +133,9 @@ func FetchLogs(
 	helpers.Logger.Infow("filter pods, containers")
 
 	for _, pod := range podList.Items {
+		if config.PodQuery != nil && !config.PodQuery.MatchString(pod.Name) {
+			continue
+		}
 		for _, c := range pod.Spec.InitContainers {
 			if !acceptable(c) {
 				continue
@@ -0,0 +1,137 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitoken
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Create handles the API endpoint /apitokens (POST).
+// It mints a new API token for the user, restricted to the requested role and namespace.
+// The token value is returned only here.
+func Create(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	user := requestctx.User(ctx)
+
+	if apiErr := checkNotAPIToken(user); apiErr != nil {
+		return apiErr
+	}
+
+	var request models.APITokenCreateRequest
+	err := c.BindJSON(&request)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	token, apiErr := newAPIToken(user, request, time.Now())
+	if apiErr != nil {
+		return apiErr
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if token.Namespace != "" {
+		exists, err := namespaces.Exists(ctx, cluster, token.Namespace)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		if !exists {
+			return apierror.NamespaceIsNotKnown(token.Namespace)
+		}
+	}
+
+	value, err := auth.NewAPITokenValue()
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	token, err = auth.NewAuthService(cluster).CreateAPIToken(ctx, token, value)
+	if err != nil {
+		if errors.Is(err, auth.ErrAPITokenConflict) {
+			return apierror.NewConflictError("api token", token.Name)
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.APITokenCreateResponse{
+		APIToken: toModel(token),
+		Token:    value,
+	})
+	return nil
+}
+
+// newAPIToken validates the request and returns the token to create for the user
+func newAPIToken(user auth.User, request models.APITokenCreateRequest, now time.Time) (auth.APIToken, apierror.APIErrors) {
+	if request.Name == "" {
+		return auth.APIToken{}, apierror.NewBadRequestError("name of api token to create not found")
+	}
+	if errorMsgs := validation.IsDNS1123Label(request.Name); len(errorMsgs) > 0 {
+		return auth.APIToken{}, apierror.NewBadRequestErrorf("api token name '%s' is not valid", request.Name).
+			WithDetails(errorMsgs[0])
+	}
+
+	roleID := request.Role
+	if roleID == "" {
+		defaultRole, found := auth.EpinioRoles.Default()
+		if !found {
+			return auth.APIToken{}, apierror.NewBadRequestError("role of api token not found, and there is no default role")
+		}
+		roleID = defaultRole.ID
+	}
+	if _, found := auth.EpinioRoles.FindByID(roleID); !found {
+		return auth.APIToken{}, apierror.NewBadRequestErrorf("role '%s' does not exist", roleID)
+	}
+
+	if !user.CanDelegate(roleID, request.Namespace) {
+		scope := "globally"
+		if request.Namespace != "" {
+			scope = fmt.Sprintf("in namespace '%s'", request.Namespace)
+		}
+		return auth.APIToken{}, apierror.NewAPIError(
+			fmt.Sprintf("user '%s' does not hold role '%s' %s", user.Username, roleID, scope),
+			http.StatusForbidden)
+	}
+
+	token := auth.APIToken{
+		Name:      request.Name,
+		Owner:     user.Username,
+		Role:      roleID,
+		Namespace: request.Namespace,
+	}
+
+	if request.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(request.ExpiresIn)
+		if err != nil || expiresIn <= 0 {
+			return auth.APIToken{}, apierror.NewBadRequestErrorf("api token expiry '%s' is not a positive duration", request.ExpiresIn)
+		}
+		token.ExpiresAt = now.Add(expiresIn)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,74 @@
+package apitoken
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestNewAPIToken(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	nsAdmin := auth.User{Username: "alice", Roles: auth.Roles{{ID: "admin", Namespace: "workspace"}}}
+
+	token, apiErr := newAPIToken(nsAdmin, models.APITokenCreateRequest{
+		Name:      "ci",
+		Role:      "admin",
+		Namespace: "workspace",
+		ExpiresIn: "24h",
+	}, now)
+	if apiErr != nil {
+		t.Fatalf("unexpected error: %v", apiErr)
+	}
+	if token.Owner != "alice" || token.Role != "admin" || token.Namespace != "workspace" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+	if !token.ExpiresAt.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("expected expiry in 24h, got %v", token.ExpiresAt)
+	}
+
+	for name, tc := range map[string]struct {
+		request models.APITokenCreateRequest
+		status  int
+	}{
+		"missing name":    {models.APITokenCreateRequest{Role: "admin", Namespace: "workspace"}, http.StatusBadRequest},
+		"invalid name":    {models.APITokenCreateRequest{Name: "CI Token", Role: "admin", Namespace: "workspace"}, http.StatusBadRequest},
+		"unknown role":    {models.APITokenCreateRequest{Name: "ci", Role: "unknown", Namespace: "workspace"}, http.StatusBadRequest},
+		"other namespace": {models.APITokenCreateRequest{Name: "ci", Role: "admin", Namespace: "other"}, http.StatusForbidden},
+		"global role":     {models.APITokenCreateRequest{Name: "ci", Role: "admin"}, http.StatusForbidden},
+		"invalid expiry":  {models.APITokenCreateRequest{Name: "ci", Role: "admin", Namespace: "workspace", ExpiresIn: "soon"}, http.StatusBadRequest},
+		"negative expiry": {models.APITokenCreateRequest{Name: "ci", Role: "admin", Namespace: "workspace", ExpiresIn: "-1h"}, http.StatusBadRequest},
+	} {
+		_, apiErr := newAPIToken(nsAdmin, tc.request, now)
+		if apiErr == nil {
+			t.Errorf("%s: expected an error", name)
+			continue
+		}
+		if status := apiErr.FirstStatus(); status != tc.status {
+			t.Errorf("%s: expected status %d, got %d (%v)", name, tc.status, status, apiErr)
+		}
+	}
+}
+
+func TestFilterAPITokens(t *testing.T) {
+	tokens := []auth.APIToken{
+		{Name: "deploy", Owner: "bob"},
+		{Name: "ci", Owner: "alice"},
+		{Name: "cd", Owner: "alice", ExpiresAt: time.Now()},
+	}
+
+	owned := filterAPITokens(auth.User{Username: "alice"}, tokens)
+	if len(owned) != 2 || owned[0].Name != "cd" || owned[1].Name != "ci" {
+		t.Errorf("expected the sorted tokens of alice, got %+v", owned)
+	}
+	if owned[0].ExpiresAt == nil || owned[1].ExpiresAt != nil {
+		t.Errorf("unexpected expiries: %+v", owned)
+	}
+
+	all := filterAPITokens(auth.User{Username: "root", Roles: auth.Roles{{ID: "admin"}}}, tokens)
+	if len(all) != 3 || all[2].Owner != "bob" {
+		t.Errorf("expected all tokens for an admin, got %+v", all)
+	}
+}
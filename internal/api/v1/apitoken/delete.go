@@ -0,0 +1,60 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitoken
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+)
+
+// Delete handles the API endpoint /apitokens/:name (DELETE).
+// It revokes the named API token of the user. Admins can revoke the tokens of other users, with
+// the `owner` query parameter. The revocation takes effect immediately.
+func Delete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	user := requestctx.User(ctx)
+	name := c.Param("name")
+
+	if apiErr := checkNotAPIToken(user); apiErr != nil {
+		return apiErr
+	}
+
+	owner := user.Username
+	if queryOwner := c.Query("owner"); queryOwner != "" && queryOwner != owner {
+		if !user.IsAdmin() {
+			return apierror.NewNotFoundError("api token", name)
+		}
+		owner = queryOwner
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = auth.NewAuthService(cluster).DeleteAPIToken(ctx, owner, name)
+	if err != nil {
+		if errors.Is(err, auth.ErrAPITokenNotFound) {
+			return apierror.NewNotFoundError("api token", name)
+		}
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
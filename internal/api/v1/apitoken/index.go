@@ -0,0 +1,97 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apitoken contains the handlers of the API tokens, used by service accounts
+// (i.e. CI pipelines) instead of the credentials of a person.
+package apitoken
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Index handles the API endpoint /apitokens (GET)
+// It returns the API tokens of the user. Admins get the tokens of all users.
+func Index(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	user := requestctx.User(ctx)
+
+	if apiErr := checkNotAPIToken(user); apiErr != nil {
+		return apiErr
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	tokens, err := auth.NewAuthService(cluster).GetAPITokens(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, filterAPITokens(user, tokens))
+	return nil
+}
+
+// filterAPITokens returns the tokens visible to the user, sorted by owner and name
+func filterAPITokens(user auth.User, tokens []auth.APIToken) models.APITokenList {
+	result := models.APITokenList{}
+	for _, token := range tokens {
+		if !user.IsAdmin() && token.Owner != user.Username {
+			continue
+		}
+		result = append(result, toModel(token))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Owner != result[j].Owner {
+			return result[i].Owner < result[j].Owner
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// checkNotAPIToken rejects the users authenticated with an API token. Tokens cannot mint or
+// revoke tokens.
+func checkNotAPIToken(user auth.User) apierror.APIErrors {
+	if user.APITokenName != "" {
+		return apierror.NewAPIError("api tokens cannot be managed with an api token", http.StatusForbidden)
+	}
+	return nil
+}
+
+func toModel(token auth.APIToken) models.APIToken {
+	result := models.APIToken{
+		Name:      token.Name,
+		Owner:     token.Owner,
+		Role:      token.Role,
+		Namespace: token.Namespace,
+		CreatedAt: metav1.NewTime(token.CreatedAt),
+	}
+	if !token.ExpiresAt.IsZero() {
+		expiresAt := metav1.NewTime(token.ExpiresAt)
+		result.ExpiresAt = &expiresAt
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// embeddedEnvPrefix marks the variables the Paketo environment variables buildpack embeds
+// into the image, making them part of the runtime environment.
+const embeddedEnvPrefix = "BPE_"
+
+// envNamePattern matches the valid names of environment variables
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// buildEnvironment returns the build-time environment of the stage request, i.e. the variables
+// of the referenced secret in the namespace of the app, overridden by the literal variables.
+func buildEnvironment(ctx context.Context, cluster *kubernetes.Cluster, req models.StageRequest) (models.EnvVariableMap, apierror.APIErrors) {
+	environment := models.EnvVariableMap{}
+
+	if req.BuildEnvironmentSecret != "" {
+		secret, err := cluster.GetSecret(ctx, req.App.Namespace, req.BuildEnvironmentSecret)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, apierror.NewNotFoundError("build environment secret", req.BuildEnvironmentSecret)
+			}
+			return nil, apierror.InternalError(err, "failed to read the build environment secret")
+		}
+		for name, value := range secret.Data {
+			environment[name] = string(value)
+		}
+	}
+
+	for name, value := range req.BuildEnvironment {
+		environment[name] = value
+	}
+
+	if err := validateBuildEnvironment(environment, req.EmbedBuildEnvironment); err != nil {
+		return nil, apierror.NewBadRequestError(err.Error())
+	}
+
+	return environment, nil
+}
+
+// validateBuildEnvironment checks that the names of the build-time variables are valid, and,
+// unless embedding is requested, that none of them are embedded into the image.
+func validateBuildEnvironment(environment models.EnvVariableMap, embed bool) error {
+	names := make([]string, 0, len(environment))
+	for name := range environment {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("bad build environment variable name '%s'", name)
+		}
+		if !embed && strings.HasPrefix(name, embeddedEnvPrefix) {
+			return fmt.Errorf("build environment variable '%s' would be embedded into the image, "+
+				"this has to be requested explicitly", name)
+		}
+	}
+
+	return nil
+}
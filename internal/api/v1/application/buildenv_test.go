@@ -0,0 +1,33 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestValidateBuildEnvironment(t *testing.T) {
+	err := validateBuildEnvironment(models.EnvVariableMap{"NPM_TOKEN": "secret", "BP_GO_TARGETS": "./cmd/web"}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = validateBuildEnvironment(models.EnvVariableMap{"BAD-NAME": "x"}, false)
+	if err == nil || !strings.Contains(err.Error(), "bad build environment variable name 'BAD-NAME'") {
+		t.Fatalf("expected a bad name error, got %v", err)
+	}
+}
+
+func TestValidateBuildEnvironmentEmbedding(t *testing.T) {
+	environment := models.EnvVariableMap{"BPE_DEFAULT_LOG_LEVEL": "debug"}
+
+	err := validateBuildEnvironment(environment, false)
+	if err == nil || !strings.Contains(err.Error(), "would be embedded into the image") {
+		t.Fatalf("expected an embedding error, got %v", err)
+	}
+
+	if err := validateBuildEnvironment(environment, true); err != nil {
+		t.Fatalf("unexpected error with embedding requested: %v", err)
+	}
+}
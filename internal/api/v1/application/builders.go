@@ -0,0 +1,226 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/spf13/viper"
+)
+
+// signalsMetaKey is the key of the blob meta data listing the signals detected in the sources.
+// Note that S3 returns user meta data keys capitalized.
+const (
+	signalsMetaKey     = "signals"
+	signalsMetaReadKey = "Signals"
+)
+
+// builderDetectedAnnotation marks an application whose recorded builder image was not chosen by
+// the user, but detected from the language of its sources, or the default. Such a builder is
+// detected anew on each staging, as the sources may have changed.
+const builderDetectedAnnotation = "epinio.io/builder-detected"
+
+// languageBuilder maps a signal, i.e. a file found in the sources, as in `go.mod`, or
+// `package.json`, to the builder image to use for such sources.
+type languageBuilder struct {
+	Signal string
+	Image  string
+}
+
+// languageBuilders returns the configured mapping from signals to builder images, in order of
+// precedence. Bad entries are ignored.
+func languageBuilders() []languageBuilder {
+	builders := []languageBuilder{}
+	for _, entry := range viper.GetStringSlice("language-builders") {
+		signal, image, ok := strings.Cut(entry, "=")
+		signal = strings.TrimSpace(signal)
+		image = strings.TrimSpace(image)
+		if !ok || signal == "" || image == "" {
+			helpers.Logger.Infow("ignoring bad language builder", "entry", entry)
+			continue
+		}
+		builders = append(builders, languageBuilder{Signal: signal, Image: image})
+	}
+	return builders
+}
+
+// languageSignals returns the signals to look for in the sources.
+func languageSignals(builders []languageBuilder) map[string]struct{} {
+	signals := map[string]struct{}{}
+	for _, builder := range builders {
+		signals[builder.Signal] = struct{}{}
+	}
+	return signals
+}
+
+// selectLanguageBuilder returns the builder image of the first configured signal found in the
+// sources, and that signal. The results are empty when no signal matched.
+func selectLanguageBuilder(builders []languageBuilder, detected []string) (string, string) {
+	found := map[string]struct{}{}
+	for _, signal := range detected {
+		found[signal] = struct{}{}
+	}
+	for _, builder := range builders {
+		if _, ok := found[builder.Signal]; ok {
+			return builder.Image, builder.Signal
+		}
+	}
+	return "", ""
+}
+
+// detectLanguageBuilder returns the builder image for the sources in the blob, as per the
+// signals recorded for the blob when it was stored, and the matching signal.
+func detectLanguageBuilder(ctx context.Context, s3ConnectionDetails s3manager.ConnectionDetails, blobUID string) (string, string, error) {
+	builders := languageBuilders()
+	if len(builders) == 0 {
+		return "", "", nil
+	}
+
+	manager, err := s3manager.New(s3ConnectionDetails)
+	if err != nil {
+		return "", "", err
+	}
+	blobMeta, err := manager.Meta(ctx, blobUID)
+	if err != nil {
+		return "", "", err
+	}
+
+	image, signal := selectLanguageBuilder(builders, splitSignals(blobMeta[signalsMetaReadKey]))
+	return image, signal, nil
+}
+
+// signalsMeta adds the signals to the blob meta data, if any.
+func signalsMeta(meta map[string]string, signals []string) map[string]string {
+	if len(signals) > 0 {
+		meta[signalsMetaKey] = strings.Join(signals, ",")
+	}
+	return meta
+}
+
+func splitSignals(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// signalCollector gathers the signals found in the paths of source files, each once, in order
+// of discovery.
+type signalCollector struct {
+	signals  map[string]struct{}
+	detected []string
+}
+
+func (sc *signalCollector) check(name string) {
+	name = strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "./")
+	// Signals are recognized at the top of the sources, and in a single top directory, as
+	// archives often have one.
+	if strings.Count(name, "/") > 1 {
+		return
+	}
+	signal := path.Base(name)
+	if _, ok := sc.signals[signal]; !ok {
+		return
+	}
+	for _, known := range sc.detected {
+		if known == signal {
+			return
+		}
+	}
+	sc.detected = append(sc.detected, signal)
+}
+
+// detectDirSignals returns the signals found in the directory of sources.
+func detectDirSignals(dir string, signals map[string]struct{}) []string {
+	collector := &signalCollector{signals: signals}
+	if len(signals) == 0 {
+		return collector.detected
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return collector.detected
+	}
+
+	for _, entry := range entries {
+		collector.check(entry.Name())
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			subEntries, err := os.ReadDir(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			for _, sub := range subEntries {
+				collector.check(entry.Name() + "/" + sub.Name())
+			}
+		}
+	}
+
+	return collector.detected
+}
+
+// detectArchiveSignals returns the signals found in the archive of sources. Archive types
+// without a reader in the standard library, i.e. xz, are not inspected.
+func detectArchiveSignals(file io.ReaderAt, size int64, contentType string, signals map[string]struct{}) []string {
+	collector := &signalCollector{signals: signals}
+	if len(signals) == 0 {
+		return collector.detected
+	}
+
+	if contentType == "application/zip" {
+		archive, err := zip.NewReader(file, size)
+		if err != nil {
+			return collector.detected
+		}
+		for _, entry := range archive.File {
+			collector.check(entry.Name)
+		}
+		return collector.detected
+	}
+
+	var reader io.Reader = io.NewSectionReader(file, 0, size)
+	switch contentType {
+	case "application/x-tar":
+	case "application/gzip":
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return collector.detected
+		}
+		defer func() { _ = gz.Close() }()
+		reader = gz
+	case "application/x-bzip2":
+		reader = bzip2.NewReader(reader)
+	default:
+		return collector.detected
+	}
+
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if err != nil {
+			break
+		}
+		collector.check(header.Name)
+	}
+
+	return collector.detected
+}
@@ -0,0 +1,106 @@
+package application
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLanguageBuilders(t *testing.T) {
+	viper.Set("language-builders", []string{"go.mod=go-builder", "bad", "package.json = node-builder"})
+	defer viper.Set("language-builders", nil)
+
+	builders := languageBuilders()
+	expected := []languageBuilder{
+		{Signal: "go.mod", Image: "go-builder"},
+		{Signal: "package.json", Image: "node-builder"},
+	}
+	if !reflect.DeepEqual(builders, expected) {
+		t.Fatalf("unexpected builders: %+v", builders)
+	}
+}
+
+func TestSelectLanguageBuilder(t *testing.T) {
+	builders := []languageBuilder{
+		{Signal: "go.mod", Image: "go-builder"},
+		{Signal: "package.json", Image: "node-builder"},
+	}
+
+	// The configured order decides, not the order of detection
+	image, signal := selectLanguageBuilder(builders, []string{"package.json", "go.mod"})
+	if image != "go-builder" || signal != "go.mod" {
+		t.Fatalf("unexpected selection: %s, %s", image, signal)
+	}
+
+	image, signal = selectLanguageBuilder(builders, []string{"pom.xml"})
+	if image != "" || signal != "" {
+		t.Fatalf("unexpected selection: %s, %s", image, signal)
+	}
+}
+
+func TestDetectDirSignals(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"package.json", "web/go.mod", "web/deep/pom.xml"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte{}, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	signals := map[string]struct{}{"package.json": {}, "go.mod": {}, "pom.xml": {}}
+	detected := detectDirSignals(dir, signals)
+	if !reflect.DeepEqual(detected, []string{"package.json", "go.mod"}) {
+		t.Fatalf("unexpected signals: %v", detected)
+	}
+}
+
+func TestDetectArchiveSignals(t *testing.T) {
+	signals := map[string]struct{}{"package.json": {}, "go.mod": {}}
+	names := []string{"./app/go.mod", "./app/src/package.json", "README.md"}
+
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	detected := detectArchiveSignals(bytes.NewReader(tarball.Bytes()), int64(tarball.Len()), "application/gzip", signals)
+	if !reflect.DeepEqual(detected, []string{"go.mod"}) {
+		t.Fatalf("unexpected signals in tarball: %v", detected)
+	}
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, name := range []string{"package.json", "go.mod"} {
+		if _, err := zw.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	detected = detectArchiveSignals(bytes.NewReader(archive.Bytes()), int64(archive.Len()), "application/zip", signals)
+	if !reflect.DeepEqual(detected, []string{"package.json", "go.mod"}) {
+		t.Fatalf("unexpected signals in zip: %v", detected)
+	}
+}
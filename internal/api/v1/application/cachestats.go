@@ -0,0 +1,222 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// cacheSizeScript is prepended to the build script of the staging job. It prints the size of
+// the build cache, in KiB, before the build, and again when the build script exits.
+const cacheSizeScript = `epinio_cache_size() { echo "___EPINIO_CACHE_SIZE_$1___ $(du -sk /workspace/cache 2>/dev/null | cut -f1)"; }
+epinio_cache_size BEFORE
+trap 'epinio_cache_size AFTER' EXIT`
+
+var (
+	// cacheHitPattern matches the lifecycle lines for layers restored from the build cache.
+	cacheHitPattern = regexp.MustCompile(`(?:Reusing (?:cache )?layer '([^']+)'|Restoring data for "([^"]+)" from cache)`)
+
+	// cacheMissPattern matches the lifecycle lines for layers built and added to the cache.
+	cacheMissPattern = regexp.MustCompile(`Adding (?:cache )?layer '([^']+)'`)
+
+	// cacheSizePattern matches the lines printed by the cacheSizeScript.
+	cacheSizePattern = regexp.MustCompile(`___EPINIO_CACHE_SIZE_(BEFORE|AFTER)___ (\d+)`)
+)
+
+// cacheStatsCollector accumulates the build cache stats of a staging run from its log lines.
+type cacheStatsCollector struct {
+	stats  models.StageCacheStats
+	layers map[string]int
+}
+
+// newCacheStatsCollector returns an empty collector for the specified staging run.
+func newCacheStatsCollector(namespace, stageID string) *cacheStatsCollector {
+	return &cacheStatsCollector{
+		stats: models.StageCacheStats{
+			StageID:   stageID,
+			Namespace: namespace,
+			Layers:    []models.StageCacheLayer{},
+		},
+		layers: map[string]int{},
+	}
+}
+
+// observe processes a single log line, and returns true if it changed the stats. A layer
+// restored from the cache is a hit. A layer added to the cache without being restored first
+// is a miss.
+func (cs *cacheStatsCollector) observe(line string) bool {
+	if match := cacheSizePattern.FindStringSubmatch(line); match != nil {
+		kib, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil {
+			return false
+		}
+		size := kib * 1024
+		if match[1] == "BEFORE" {
+			cs.stats.SizeBeforeBytes = &size
+		} else {
+			cs.stats.SizeAfterBytes = &size
+		}
+		return true
+	}
+
+	if match := cacheHitPattern.FindStringSubmatch(line); match != nil {
+		layer := match[1]
+		if layer == "" {
+			layer = match[2]
+		}
+		return cs.record(layer, true)
+	}
+
+	if match := cacheMissPattern.FindStringSubmatch(line); match != nil {
+		return cs.record(match[1], false)
+	}
+
+	return false
+}
+
+// isCacheSizeLine returns true if the log line was printed by the cacheSizeScript. These
+// lines are internal to the cache stats, and are not shown to the user.
+func isCacheSizeLine(line tailer.ContainerLogLine) bool {
+	return cacheSizePattern.MatchString(line.Message)
+}
+
+// record notes the layer as hit or missed. A layer is counted once, a hit taking precedence.
+func (cs *cacheStatsCollector) record(layer string, hit bool) bool {
+	index, known := cs.layers[layer]
+	if !known {
+		cs.layers[layer] = len(cs.stats.Layers)
+		cs.stats.Layers = append(cs.stats.Layers, models.StageCacheLayer{Layer: layer, Hit: hit})
+		if hit {
+			cs.stats.Hits++
+		} else {
+			cs.stats.Misses++
+		}
+		return true
+	}
+
+	if !hit || cs.stats.Layers[index].Hit {
+		return false
+	}
+
+	cs.stats.Layers[index].Hit = true
+	cs.stats.Hits++
+	cs.stats.Misses--
+	return true
+}
+
+// StagingCacheWebsocket handles the API endpoint GET /namespaces/:namespace/staging/:stage_id/cache
+// It streams the build cache stats of the specified staging run over a websocket. The stats
+// are gathered from the staging logs, and sent whenever they change. When the staging is done
+// the final stats are sent, marked as completed, and the stream ends.
+func StagingCacheWebsocket(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	namespace := c.Param("namespace")
+	stageID := c.Param("stage_id")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	jobs, apiErr := stageJobs(ctx, cluster, namespace, stageID)
+	if apiErr != nil {
+		response.Error(c, apiErr)
+		return
+	}
+
+	logParams, err := ParseLogParameters("", "", "", "", "")
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+	logParams.Follow = true
+
+	upgrader := newUpgrader()
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Stop streaming when the client goes away.
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	collector := newCacheStatsCollector(namespace, stageID)
+	sendStats := func() {
+		if streamCtx.Err() != nil {
+			return
+		}
+
+		msg, err := json.Marshal(collector.stats)
+		if err != nil {
+			helpers.Logger.Errorw("failed to marshal cache stats", "error", err)
+			return
+		}
+
+		if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+			helpers.Logger.Errorw("failed to write to websockets", "error", err)
+			cancel()
+		}
+	}
+
+	logChan := make(chan tailer.ContainerLogLine)
+	go func() {
+		defer close(logChan)
+		_, _, err := followStagingLogs(streamCtx, logChan, clusterPushLogSources(cluster, namespace, jobs), stageID, logParams)
+		if err != nil && streamCtx.Err() == nil {
+			helpers.Logger.Errorw("staging completion watcher failed", "error", err)
+		}
+	}()
+
+	// initial stats so the caller knows the socket is established
+	sendStats()
+
+	// Note: The channel is drained until closed, even after a write failure, to not block
+	// the producer.
+	for logLine := range logChan {
+		if collector.observe(logLine.Message) {
+			sendStats()
+		}
+	}
+
+	collector.stats.Completed = true
+	sendStats()
+
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	_ = conn.Close()
+}
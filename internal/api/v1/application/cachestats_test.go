@@ -0,0 +1,98 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestCacheStatsCollectorLayers(t *testing.T) {
+	collector := newCacheStatsCollector("workspace", "stage-1")
+
+	lines := []string{
+		`[restorer] Restoring data for "paketo-buildpacks/go-dist:go" from cache`,
+		`[exporter] Reusing cache layer 'paketo-buildpacks/go-dist:go'`,
+		`[exporter] Adding cache layer 'paketo-buildpacks/go-build:gocache'`,
+		`[builder] Building the app`,
+	}
+	for _, line := range lines {
+		collector.observe(line)
+	}
+
+	stats := collector.stats
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Fatalf("expected 1 hit and 1 miss, got %d hits and %d misses", stats.Hits, stats.Misses)
+	}
+	if len(stats.Layers) != 2 {
+		t.Fatalf("expected 2 layers, got %d", len(stats.Layers))
+	}
+	if stats.Layers[0].Layer != "paketo-buildpacks/go-dist:go" || !stats.Layers[0].Hit {
+		t.Fatalf("expected first layer to be a hit of go-dist, got %+v", stats.Layers[0])
+	}
+	if stats.Layers[1].Layer != "paketo-buildpacks/go-build:gocache" || stats.Layers[1].Hit {
+		t.Fatalf("expected second layer to be a miss of go-build, got %+v", stats.Layers[1])
+	}
+}
+
+func TestCacheStatsCollectorHitAfterMiss(t *testing.T) {
+	collector := newCacheStatsCollector("workspace", "stage-1")
+
+	if !collector.observe(`Adding layer 'launcher'`) {
+		t.Fatal("expected the miss to change the stats")
+	}
+	if !collector.observe(`Reusing layer 'launcher'`) {
+		t.Fatal("expected the hit to change the stats")
+	}
+	if collector.observe(`Adding layer 'launcher'`) {
+		t.Fatal("expected a repeated layer to not change the stats")
+	}
+
+	stats := collector.stats
+	if stats.Hits != 1 || stats.Misses != 0 || len(stats.Layers) != 1 {
+		t.Fatalf("expected a single hit, got %+v", stats)
+	}
+}
+
+func TestCacheStatsCollectorSizes(t *testing.T) {
+	collector := newCacheStatsCollector("workspace", "stage-1")
+
+	collector.observe("___EPINIO_CACHE_SIZE_BEFORE___ 4")
+	if collector.stats.SizeBeforeBytes == nil || *collector.stats.SizeBeforeBytes != 4096 {
+		t.Fatalf("expected size before of 4096 bytes, got %v", collector.stats.SizeBeforeBytes)
+	}
+	if collector.stats.SizeAfterBytes != nil {
+		t.Fatalf("expected no size after, got %v", *collector.stats.SizeAfterBytes)
+	}
+
+	collector.observe("___EPINIO_CACHE_SIZE_AFTER___ 10")
+	if collector.stats.SizeAfterBytes == nil || *collector.stats.SizeAfterBytes != 10240 {
+		t.Fatalf("expected size after of 10240 bytes, got %v", collector.stats.SizeAfterBytes)
+	}
+
+	// An empty size, for a missing cache, is ignored
+	if collector.observe("___EPINIO_CACHE_SIZE_AFTER___ ") {
+		t.Fatal("expected an empty size to not change the stats")
+	}
+}
+
+func TestIsCacheSizeLine(t *testing.T) {
+	for _, line := range []string{
+		"___EPINIO_CACHE_SIZE_BEFORE___ 1024",
+		"___EPINIO_CACHE_SIZE_AFTER___ 0",
+	} {
+		if !isCacheSizeLine(tailer.ContainerLogLine{Message: line}) {
+			t.Fatalf("expected %q to be filtered", line)
+		}
+	}
+
+	for _, line := range []string{
+		"[builder] Building the app",
+		"___EPINIO_CACHE_SIZE_BEFORE___",
+		models.PushLogsHandoffMarker,
+	} {
+		if isCacheSizeLine(tailer.ContainerLogLine{Message: line}) {
+			t.Fatalf("expected %q to be kept", line)
+		}
+	}
+}
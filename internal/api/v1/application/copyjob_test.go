@@ -0,0 +1,144 @@
+package application
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCreateCopyJobDefaultArgs(t *testing.T) {
+	job := createCopyJob("oci-archive:/workspace/app.tar", "docker://registry/app:v1", "auth", "")
+
+	args := job.Spec.Template.Spec.Containers[0].Args
+	expected := []string{
+		"copy",
+		"--dest-authfile=/root/containers/auth.json",
+		"oci-archive:/workspace/app.tar",
+		"docker://registry/app:v1",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("expected %v, got %v", expected, args)
+	}
+}
+
+func TestCreateCopyJobFormatAndCompressionArgs(t *testing.T) {
+	viper.Set("image-copy-format", "oci")
+	viper.Set("image-copy-compress-format", "zstd")
+	viper.Set("image-copy-compress-level", 19)
+	defer func() {
+		viper.Set("image-copy-format", "")
+		viper.Set("image-copy-compress-format", "")
+		viper.Set("image-copy-compress-level", 0)
+	}()
+
+	job := createCopyJob("oci-archive:/workspace/app.tar", "docker://registry/app:v1", "auth", "")
+
+	args := job.Spec.Template.Spec.Containers[0].Args
+	expected := []string{
+		"copy",
+		"--dest-authfile=/root/containers/auth.json",
+		"--format=oci",
+		"--dest-compress-format=zstd",
+		"--dest-compress-level=19",
+		"oci-archive:/workspace/app.tar",
+		"docker://registry/app:v1",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("expected %v, got %v", expected, args)
+	}
+}
+
+func TestCreateCopyJobDeadlineAndResources(t *testing.T) {
+	viper.Set("image-copy-timeout", 10*time.Minute)
+	viper.Set("image-copy-cpu-request", "100m")
+	viper.Set("image-copy-memory-request", "128Mi")
+	viper.Set("image-copy-cpu-limit", "1")
+	viper.Set("image-copy-memory-limit", "1Gi")
+	defer func() {
+		viper.Set("image-copy-timeout", 0)
+		viper.Set("image-copy-cpu-request", "")
+		viper.Set("image-copy-memory-request", "")
+		viper.Set("image-copy-cpu-limit", "")
+		viper.Set("image-copy-memory-limit", "")
+	}()
+
+	job := createCopyJob("oci-archive:/workspace/app.tar", "docker://registry/app:v1", "auth", "")
+
+	deadline := job.Spec.ActiveDeadlineSeconds
+	if deadline == nil || *deadline != 600 {
+		t.Fatalf("expected active deadline of 600 seconds, got %v", deadline)
+	}
+
+	resources := job.Spec.Template.Spec.Containers[0].Resources
+	got := map[string]string{
+		"cpu request":    resources.Requests.Cpu().String(),
+		"memory request": resources.Requests.Memory().String(),
+		"cpu limit":      resources.Limits.Cpu().String(),
+		"memory limit":   resources.Limits.Memory().String(),
+	}
+	expected := map[string]string{
+		"cpu request":    "100m",
+		"memory request": "128Mi",
+		"cpu limit":      "1",
+		"memory limit":   "1Gi",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected resources %v, got %v", expected, got)
+	}
+}
+
+func TestImageCopyUnbounded(t *testing.T) {
+	if deadline := imageCopyDeadline(0); deadline != nil {
+		t.Fatalf("expected no deadline for zero timeout, got %d", *deadline)
+	}
+
+	viper.Set("image-copy-cpu-limit", "lots")
+	defer viper.Set("image-copy-cpu-limit", "")
+
+	resources := imageCopyResources()
+	if resources.Requests != nil || resources.Limits != nil {
+		t.Fatalf("expected no requests and limits, got %v", resources)
+	}
+	if _, ok := resources.Limits[corev1.ResourceCPU]; ok {
+		t.Fatalf("expected invalid cpu limit to be ignored")
+	}
+}
+
+func TestEpinioServerAffinityRequired(t *testing.T) {
+	for _, mode := range []string{"", "required"} {
+		affinity := epinioServerAffinity(mode)
+		podAffinity := affinity.PodAffinity
+
+		if len(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution) != 1 {
+			t.Fatalf("mode %q: expected required affinity, got %v", mode, podAffinity)
+		}
+		if len(podAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 0 {
+			t.Fatalf("mode %q: unexpected preferred affinity, got %v", mode, podAffinity)
+		}
+
+		term := podAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0]
+		if term.TopologyKey != "kubernetes.io/hostname" ||
+			term.LabelSelector.MatchExpressions[0].Values[0] != "epinio-server" {
+			t.Fatalf("mode %q: expected epinio-server node affinity, got %v", mode, term)
+		}
+	}
+}
+
+func TestEpinioServerAffinityPreferred(t *testing.T) {
+	podAffinity := epinioServerAffinity("preferred").PodAffinity
+
+	if len(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution) != 0 {
+		t.Fatalf("unexpected required affinity, got %v", podAffinity)
+	}
+	if len(podAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Fatalf("expected preferred affinity, got %v", podAffinity)
+	}
+
+	weighted := podAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0]
+	if weighted.Weight != 100 || weighted.PodAffinityTerm.TopologyKey != "kubernetes.io/hostname" {
+		t.Fatalf("expected weighted epinio-server node affinity, got %v", weighted)
+	}
+}
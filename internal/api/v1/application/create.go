@@ -64,6 +64,51 @@ func Create(c *gin.Context) apierror.APIErrors {
 		return apierror.AppAlreadyKnown(createRequest.Name)
 	}
 
+	if createRequest.Configuration.Placement != nil {
+		err = application.ValidatePlacement(*createRequest.Configuration.Placement)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if createRequest.Configuration.Rollout != nil {
+		err = application.ValidateRollout(*createRequest.Configuration.Rollout)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if createRequest.Configuration.Process != nil {
+		err = application.ValidateProcess(*createRequest.Configuration.Process)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	err = application.ValidateProcessTypes(createRequest.Configuration.ProcessTypes)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	err = application.ProcessTypeConflict(ctx, cluster, appRef, createRequest.Configuration.ProcessTypes)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if createRequest.Configuration.IngressClass != nil {
+		err = application.ValidateIngressClass(ctx, cluster, *createRequest.Configuration.IngressClass)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if createRequest.Configuration.DNS != nil {
+		err = application.ValidateDNS(*createRequest.Configuration.DNS)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
 	// Sanity check the configurations, if any. IOW anything to be bound
 	// has to exist now.  We will check again when the application
 	// is deployed, to guard against bound configurations being removed
@@ -168,6 +213,54 @@ func Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	// Save placement, if any
+	if createRequest.Configuration.Placement != nil {
+		err = application.PlacementSet(ctx, cluster, appRef, *createRequest.Configuration.Placement)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save rolling update settings, if any
+	if createRequest.Configuration.Rollout != nil {
+		err = application.RolloutSet(ctx, cluster, appRef, *createRequest.Configuration.Rollout)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save container command and args overrides, if any
+	if createRequest.Configuration.Process != nil {
+		err = application.ProcessSet(ctx, cluster, appRef, *createRequest.Configuration.Process)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save process types, if any
+	if len(createRequest.Configuration.ProcessTypes) > 0 {
+		err = application.ProcessTypesSet(ctx, cluster, appRef, createRequest.Configuration.ProcessTypes)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save ingress class, if any
+	if createRequest.Configuration.IngressClass != nil && *createRequest.Configuration.IngressClass != "" {
+		err = application.IngressClassSet(ctx, cluster, appRef, *createRequest.Configuration.IngressClass)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// Save dns settings, if any
+	if createRequest.Configuration.DNS != nil {
+		err = application.DNSSet(ctx, cluster, appRef, *createRequest.Configuration.DNS)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
 	response.Created(c)
 	return nil
 }
@@ -0,0 +1,198 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/proxy"
+	"github.com/epinio/epinio/internal/application"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// debugStartTimeout is the time given to a debug container to start, including the pull of
+// its image.
+const debugStartTimeout = 2 * time.Minute
+
+// Debug handles the API endpoint GET /namespaces/:namespace/applications/:app/debug
+// It adds an ephemeral container running the debug image of the server to an instance of the
+// application, targeting the application container, and attaches an interactive session to
+// it. This allows debugging of minimal images without a shell. The cluster has to support
+// ephemeral containers.
+func Debug(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	instanceName := c.Query("instance")
+
+	debugImage := viper.GetString("debug-image")
+	if debugImage == "" {
+		return apierror.NewAPIError("debugging is not configured for this server", http.StatusBadRequest)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	// app exists but has no workload to debug
+	if app.Workload == nil {
+		return apierror.NewAPIError("Cannot debug application without workload", http.StatusBadRequest)
+	}
+
+	workload := application.NewWorkload(cluster, app.Meta, app.Workload.DesiredReplicas)
+	podNames, err := workload.PodNames(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if len(podNames) < 1 {
+		return apierror.NewAPIError("couldn't find any Instances to debug", http.StatusBadRequest)
+	}
+
+	podToDebug := ""
+	if instanceName != "" {
+		for _, podName := range podNames {
+			if podName == instanceName {
+				podToDebug = podName
+				break
+			}
+		}
+
+		if podToDebug == "" {
+			return apierror.NewAPIError("specified instance doesn't exist", http.StatusBadRequest)
+		}
+	} else {
+		podToDebug = podNames[0]
+	}
+
+	appData, err := workload.Get(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	containerName, apiErr := addDebugContainer(ctx, cluster, namespace, podToDebug, appData.Name, debugImage)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, time.Second, debugStartTimeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := cluster.Kubectl.CoreV1().Pods(namespace).Get(ctx, podToDebug, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return debugContainerRunning(pod, containerName)
+	})
+	if err != nil {
+		return apierror.InternalError(err, "waiting for the debug container to start")
+	}
+
+	attachURL := cluster.Kubectl.CoreV1().RESTClient().
+		Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(podToDebug).
+		SubResource("attach").
+		VersionedParams(&v1.PodAttachOptions{
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+			TTY:       true,
+			Container: containerName,
+		}, scheme.ParameterCodec).URL()
+
+	return proxy.RunProxy(ctx, c.Writer, c.Request, attachURL)
+}
+
+// addDebugContainer adds an ephemeral container running the image to the pod, targeting the
+// named container, and returns the name of the new container. Clusters without support for
+// ephemeral containers are reported as such.
+func addDebugContainer(ctx context.Context, cluster *kubernetes.Cluster, namespace, podName, target, image string) (string, apierror.APIErrors) {
+	pods := cluster.Kubectl.CoreV1().Pods(namespace)
+
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", apierror.InternalError(err)
+	}
+
+	containerName := fmt.Sprintf("debugger-%s", utilrand.String(5))
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     containerName,
+			Image:                    image,
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target,
+	})
+
+	_, err = pods.UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{})
+	if err != nil {
+		// Without the feature the subresource is either unknown, or the field is dropped
+		// by the validation of the API server.
+		if apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) || apierrors.IsInvalid(err) {
+			return "", apierror.NewAPIError("ephemeral containers are not supported by the cluster",
+				http.StatusNotImplemented).WithDetails(err.Error())
+		}
+		return "", apierror.InternalError(err, "adding the debug container")
+	}
+
+	return containerName, nil
+}
+
+// debugContainerRunning returns true when the named ephemeral container of the pod is running.
+// A container which terminated, or cannot pull its image, is an error.
+func debugContainerRunning(pod *v1.Pod, containerName string) (bool, error) {
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name != containerName {
+			continue
+		}
+
+		if status.State.Running != nil {
+			return true, nil
+		}
+		if status.State.Terminated != nil {
+			return false, fmt.Errorf("debug container terminated: %s", status.State.Terminated.Reason)
+		}
+		if waiting := status.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+				return false, fmt.Errorf("debug container cannot start: %s: %s", waiting.Reason, waiting.Message)
+			}
+		}
+	}
+
+	return false, nil
+}
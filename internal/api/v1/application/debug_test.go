@@ -0,0 +1,52 @@
+package application
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func debugPod(state v1.ContainerState) *v1.Pod {
+	return &v1.Pod{
+		Status: v1.PodStatus{
+			EphemeralContainerStatuses: []v1.ContainerStatus{
+				{Name: "debugger-other", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				{Name: "debugger-abcde", State: state},
+			},
+		},
+	}
+}
+
+func TestDebugContainerRunning(t *testing.T) {
+	running, err := debugContainerRunning(debugPod(v1.ContainerState{Running: &v1.ContainerStateRunning{}}), "debugger-abcde")
+	if err != nil || !running {
+		t.Fatalf("expected a running container, got %v, %v", running, err)
+	}
+}
+
+func TestDebugContainerStarting(t *testing.T) {
+	pod := debugPod(v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}})
+
+	running, err := debugContainerRunning(pod, "debugger-abcde")
+	if err != nil || running {
+		t.Fatalf("expected a starting container, got %v, %v", running, err)
+	}
+
+	// No status yet
+	running, err = debugContainerRunning(pod, "debugger-fghij")
+	if err != nil || running {
+		t.Fatalf("expected a missing status to be waited for, got %v, %v", running, err)
+	}
+}
+
+func TestDebugContainerFailed(t *testing.T) {
+	pod := debugPod(v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "not found"}})
+	if _, err := debugContainerRunning(pod, "debugger-abcde"); err == nil {
+		t.Fatal("expected an error for an image which cannot be pulled")
+	}
+
+	pod = debugPod(v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error"}})
+	if _, err := debugContainerRunning(pod, "debugger-abcde"); err == nil {
+		t.Fatal("expected an error for a terminated container")
+	}
+}
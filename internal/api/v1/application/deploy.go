@@ -34,7 +34,8 @@ const (
 
 // Deploy handles the API endpoint /namespaces/:namespace/applications/:app/deploy
 // It uses an application chart to create the deployment, configuration and ingress (kube)
-// resources for the app.
+// resources for the app. The custom annotations and labels of the request are saved with the
+// app, and passed to the chart for its pods, in this and all following deployments.
 func Deploy(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 
@@ -66,6 +67,14 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 		}
 	}
 
+	podMetadata := models.ApplicationPodMetadata{
+		Annotations: req.Annotations,
+		Labels:      req.Labels,
+	}
+	if err := application.ValidatePodMetadata(podMetadata); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err, "failed to get access to a kube client")
@@ -84,6 +93,14 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "failed to set application's image url")
 	}
 
+	// Without annotations and labels in the request those of the previous deployment are kept
+	if req.Annotations != nil || req.Labels != nil {
+		err = application.PodMetadataSet(ctx, cluster, req.App, podMetadata)
+		if err != nil {
+			return apierror.InternalError(err, "failed to set application's pod metadata")
+		}
+	}
+
 	desiredRoutes, found, err := unstructured.NestedStringSlice(applicationCR.Object, "spec", "routes")
 	if err != nil {
 		return apierror.InternalError(err, "failed to get the application routes")
@@ -117,14 +134,16 @@ func Deploy(c *gin.Context) apierror.APIErrors {
 // Redeploy does not serve a specific handler. It is used by the configuration and service
 // update/replace handlers to restart the active set of the named applications. Quiescent
 // applications are ignored. This is their means of forcing the applications bound to the changed
-// configuration/service to pick up these changes and use them.
-func Redeploy(ctx context.Context, cluster *kubernetes.Cluster, namespace string, appNames []string) apierror.APIErrors {
+// configuration/service to pick up these changes and use them. It returns the names of the
+// applications which were restarted.
+func Redeploy(ctx context.Context, cluster *kubernetes.Cluster, namespace string, appNames []string) ([]string, apierror.APIErrors) {
 	username := requestctx.User(ctx).Username
+	restarted := []string{}
 
 	for _, appName := range appNames {
 		app, err := application.Lookup(ctx, cluster, namespace, appName)
 		if err != nil {
-			return apierror.InternalError(err)
+			return restarted, apierror.InternalError(err)
 		}
 
 		// Restart workload, if any
@@ -137,10 +156,11 @@ func Redeploy(ctx context.Context, cluster *kubernetes.Cluster, namespace string
 			// configuration remounts it for the new/changed keys.
 			_, apiErr := deploy.DeployAppWithRestart(ctx, cluster, app.Meta, username, "")
 			if apiErr != nil {
-				return apiErr
+				return restarted, apiErr
 			}
+			restarted = append(restarted, appName)
 		}
 	}
 
-	return nil
+	return restarted, nil
 }
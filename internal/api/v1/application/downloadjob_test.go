@@ -0,0 +1,59 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewDownloadImageJobRegistryCredentials(t *testing.T) {
+	job := newDownloadImageJob("job", "registry/app:v1", "app.tar", "")
+
+	args := job.Spec.Template.Spec.Containers[0].Args
+	if args[1] != "--src-authfile=/root/containers/auth.json" {
+		t.Fatalf("expected the Epinio registry credentials, got %v", args)
+	}
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.Name == "pull-secret-volume" {
+			t.Fatalf("unexpected pull secret volume")
+		}
+	}
+}
+
+func TestNewDownloadImageJobPullSecret(t *testing.T) {
+	job := newDownloadImageJob("job", "ghcr.io/org/app:v1", "app.tar", "job-pull")
+
+	args := job.Spec.Template.Spec.Containers[0].Args
+	if args[1] != "--src-authfile=/root/source/auth.json" {
+		t.Fatalf("expected the pull secret credentials, got %v", args)
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--src-creds") {
+			t.Fatalf("credentials must not be passed as arguments, got %v", args)
+		}
+	}
+
+	var volume *corev1.Volume
+	for i := range job.Spec.Template.Spec.Volumes {
+		if job.Spec.Template.Spec.Volumes[i].Name == "pull-secret-volume" {
+			volume = &job.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	if volume == nil || volume.Secret == nil || volume.Secret.SecretName != "job-pull" {
+		t.Fatalf("expected volume of secret job-pull, got %v", volume)
+	}
+
+	found := false
+	for _, mount := range job.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if mount.Name == "pull-secret-volume" {
+			found = true
+			if !mount.ReadOnly || mount.MountPath != "/root/source/" {
+				t.Fatalf("expected read-only mount at /root/source/, got %v", mount)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected pull secret mount")
+	}
+}
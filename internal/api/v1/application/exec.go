@@ -23,11 +23,16 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 )
 
+// Exec handles the API endpoint GET /namespaces/:namespace/applications/:app/exec
+// It attaches to an instance of the application and runs an interactive shell with a terminal.
+// With `command` parameters the given command is run instead, without a terminal. Its
+// stdin, stdout, and stderr are attached, and its exit code is reported on the error stream.
 func Exec(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
 	appName := c.Param("app")
 	instanceName := c.Query("instance")
+	command := c.QueryArray("command")
 
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
@@ -92,6 +97,15 @@ func Exec(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	tty := len(command) == 0
+	if tty {
+		// https://github.com/rancher/dashboard/blob/37f40d7213ff32096bfefd02de77be6a0e7f40ab/components/nav/WindowManager/ContainerShell.vue#L22
+		command = []string{
+			"/bin/sh",
+			"-c", "TERM=xterm-256color; export TERM; exec /bin/bash",
+		}
+	}
+
 	// https://github.com/kubernetes/kubectl/blob/2acffc93b61e483bd26020df72b9aef64541bd56/pkg/cmd/exec/exec.go#L352
 	attachURL := cluster.Kubectl.CoreV1().RESTClient().
 		Post().
@@ -103,13 +117,9 @@ func Exec(c *gin.Context) apierror.APIErrors {
 			Stdin:     true,
 			Stdout:    true,
 			Stderr:    true,
-			TTY:       true,
+			TTY:       tty,
 			Container: appData.Name,
-			// https://github.com/rancher/dashboard/blob/37f40d7213ff32096bfefd02de77be6a0e7f40ab/components/nav/WindowManager/ContainerShell.vue#L22
-			Command: []string{
-				"/bin/sh",
-				"-c", "TERM=xterm-256color; export TERM; exec /bin/bash",
-			},
+			Command:   command,
 		}, scheme.ParameterCodec).URL()
 
 	return proxy.RunProxy(ctx, c.Writer, c.Request, attachURL)
@@ -0,0 +1,75 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//	http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/expiry"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Expiry handles the API endpoint POST /namespaces/:namespace/applications/:app/expiry
+// It sets, extends, or removes the time the application is automatically deleted at.
+func Expiry(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	var request models.ExpiryRequest
+	if err := c.BindJSON(&request); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	ttl, err := expiry.ParseTTL(request.TTL)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appRef := models.NewAppRef(appName, namespace)
+	exists, err := application.Exists(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	resp := models.ExpiryResponse{}
+
+	var expiresAt *time.Time
+	if ttl > 0 {
+		at := time.Now().Add(ttl).Truncate(time.Second)
+		expiresAt = &at
+		mat := metav1.NewTime(at)
+		resp.ExpiresAt = &mat
+	}
+
+	err = application.ExpirySet(ctx, cluster, appRef, expiresAt)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, resp)
+	return nil
+}
@@ -42,6 +42,7 @@ import (
 	"helm.sh/helm/v3/pkg/chartutil"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -72,6 +73,11 @@ func ExportToRegistry(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	pullAuth, apiErr := loadPullSecret(ctx, cluster, namespace, req.PullSecret)
+	if apiErr != nil {
+		return apiErr
+	}
+
 	// destination validation I - do we have a name ?
 	if req.Destination == "" {
 		return apierror.NewBadRequestError("export destination is missing/empty")
@@ -191,7 +197,7 @@ func ExportToRegistry(c *gin.Context) apierror.APIErrors {
 		"origin",
 		"docker://"+theApp.ImageURL,
 	)
-	imageFile, err := fetchAppImageFile(ctx, cluster, theApp, imageLocalFile)
+	imageFile, err := fetchAppImageFile(ctx, cluster, theApp, imageLocalFile, pullAuth)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
@@ -520,7 +526,7 @@ func createCopyJob(
 	authSecret,
 	certSecret string,
 ) *batchv1.Job {
-	// See also part.go, runDownloadImageJob - Look into DRY'ing
+	// See also part.go, newDownloadImageJob - Look into DRY'ing
 
 	nano := fmt.Sprintf("%d", time.Now().UnixNano())
 	jobName := names.GenerateResourceName("oci-push-image", nano)
@@ -582,9 +588,13 @@ func createCopyJob(
 	args := []string{
 		"copy",
 		"--dest-authfile=/root/containers/auth.json",
-		localPath,
-		destinationPath,
 	}
+	args = append(args, imageCopyOptions(
+		viper.GetString("image-copy-format"),
+		viper.GetString("image-copy-compress-format"),
+		viper.GetInt("image-copy-compress-level"),
+	)...)
+	args = append(args, localPath, destinationPath)
 
 	helpers.Logger.Infow("OCI export image copy command", "skopeo", args)
 
@@ -595,37 +605,22 @@ func createCopyJob(
 			Annotations: map[string]string{},
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: ptr.To[int32](0),
+			BackoffLimit:          ptr.To[int32](0),
+			ActiveDeadlineSeconds: imageCopyDeadline(viper.GetDuration("image-copy-timeout")),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
 					Annotations: map[string]string{},
 				},
 				Spec: corev1.PodSpec{
-					Affinity: &corev1.Affinity{
-						PodAffinity: &corev1.PodAffinity{
-							RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
-								{
-									LabelSelector: &metav1.LabelSelector{
-										MatchExpressions: []metav1.LabelSelectorRequirement{
-											{
-												Key:      "app.kubernetes.io/name",
-												Operator: "In",
-												Values:   []string{"epinio-server"},
-											},
-										},
-									},
-									TopologyKey: "kubernetes.io/hostname",
-								},
-							},
-						},
-					},
+					Affinity: epinioServerAffinity(viper.GetString("image-copy-affinity")),
 					Containers: []corev1.Container{
 						{
 							Name:         "oci-push",
 							Image:        appImageExporter,
 							Command:      []string{"skopeo"},
 							Args:         args,
+							Resources:    imageCopyResources(),
 							VolumeMounts: mounts,
 						},
 					},
@@ -639,6 +634,97 @@ func createCopyJob(
 	return job
 }
 
+// epinioServerAffinity returns the affinity placing the jobs copying images on the node of the
+// Epinio server, whose export volume they share. With mode `preferred` the jobs may run
+// elsewhere when that node is not available. Any other mode requires the node of the server.
+func epinioServerAffinity(mode string) *corev1.Affinity {
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "app.kubernetes.io/name",
+					Operator: "In",
+					Values:   []string{"epinio-server"},
+				},
+			},
+		},
+		TopologyKey: "kubernetes.io/hostname",
+	}
+
+	if mode == "preferred" {
+		return &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{Weight: 100, PodAffinityTerm: term},
+				},
+			},
+		}
+	}
+
+	return &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+		},
+	}
+}
+
+// imageCopyDeadline returns the active deadline of the jobs copying images, in seconds. A
+// zero or negative timeout leaves the jobs unbounded.
+func imageCopyDeadline(timeout time.Duration) *int64 {
+	if timeout <= 0 {
+		return nil
+	}
+	return ptr.To(int64(timeout.Seconds()))
+}
+
+// imageCopyResources returns the resource requests and limits of the jobs copying images, from
+// the server configuration. Empty quantities are left unset. Invalid quantities are logged, and
+// left unset as well.
+func imageCopyResources() corev1.ResourceRequirements {
+	quantities := func(cpu, memory string) corev1.ResourceList {
+		list := corev1.ResourceList{}
+		for name, value := range map[corev1.ResourceName]string{
+			corev1.ResourceCPU:    cpu,
+			corev1.ResourceMemory: memory,
+		} {
+			if value == "" {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				helpers.Logger.Errorw("bad image copy resource", "resource", name, "value", value, "error", err)
+				continue
+			}
+			list[name] = quantity
+		}
+		if len(list) == 0 {
+			return nil
+		}
+		return list
+	}
+
+	return corev1.ResourceRequirements{
+		Requests: quantities(viper.GetString("image-copy-cpu-request"), viper.GetString("image-copy-memory-request")),
+		Limits:   quantities(viper.GetString("image-copy-cpu-limit"), viper.GetString("image-copy-memory-limit")),
+	}
+}
+
+// imageCopyOptions returns the skopeo options for the manifest format and the layer compression
+// of the copied image. Empty values, and a zero level, keep the defaults of skopeo.
+func imageCopyOptions(format, compressFormat string, compressLevel int) []string {
+	options := []string{}
+	if format != "" {
+		options = append(options, "--format="+format)
+	}
+	if compressFormat != "" {
+		options = append(options, "--dest-compress-format="+compressFormat)
+	}
+	if compressLevel != 0 {
+		options = append(options, fmt.Sprintf("--dest-compress-level=%d", compressLevel))
+	}
+	return options
+}
+
 // runJob executes the given kube job and waits for its completion (or timeout (12 minutes (**))).
 // Note that this is a generic function which may be useful in other contexts.  In that case it
 // should be moved to the kubernetes section of the helpers package.
@@ -668,15 +754,15 @@ func runJob(
 		return errors.Wrapf(err, "error waiting for completion of %s job %s", label, job.Name)
 	}
 
-	failed, err := cluster.IsJobFailed(ctx, job.Name, helmchart.Namespace())
+	reason, err := cluster.JobFailureReason(ctx, job.Name, helmchart.Namespace())
 	if err != nil {
 		helpers.Logger.Errorw("job status check", "error", err, "job", job.Name)
 		return errors.Wrapf(err, "error checking status of %s job %s", label, job.Name)
 	}
 
-	if failed {
-		helpers.Logger.Infow("job failed", "job", job.Name)
-		return errors.New(label + " job " + job.Name + " failed")
+	if reason != "" {
+		helpers.Logger.Infow("job failed", "job", job.Name, "reason", reason)
+		return errors.New(label + " job " + job.Name + " failed: " + reason)
 	} else {
 		// Attention: Job is deleted if and only if it succeeded in time. A failed or timed
 		// out job is kept for inspection by the user and/or operator.
@@ -12,34 +12,166 @@
 package application
 
 import (
+	"sort"
+
+	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/auth"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// AppHealthy selects the applications which are not unhealthy.
+	AppHealthy = "healthy"
+	// AppUnhealthy selects the applications in error, and the applications with less ready
+	// than desired replicas.
+	AppUnhealthy = "unhealthy"
+)
+
 // FullIndex handles the API endpoint GET /applications
 // It lists all the known applications in all namespaces, with and without workload.
+// The list is returned as JSON, or YAML if requested. Applications which cannot be assembled
+// are listed with an error status. The metrics of the replicas are queried per listed application,
+// as for Index. With `warnings=true` the list is returned together with the warnings about partial
+// failures, like missing metrics.
+//
+// The `status` query parameter restricts the list to the `healthy` or `unhealthy` applications,
+// or to the applications in the given status, i.e. `created`, `staging`, `running`, or `error`.
+// With any of `limit` and `offset` the response is a page of the applications, sorted by
+// namespace and name, together with their total.
 func FullIndex(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	user := requestctx.User(ctx)
 
+	status := c.Query("status")
+	if !validAppStatusFilter(status) {
+		return apierror.NewBadRequestErrorf("bad status filter '%s'", status)
+	}
+
+	limitParam, hasLimit := c.GetQuery("limit")
+	offsetParam, hasOffset := c.GetQuery("offset")
+	paged := hasLimit || hasOffset
+
+	limit, err := response.ListNumber(limitParam)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad limit: %s", err.Error())
+	}
+	offset, err := response.ListNumber(offsetParam)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad offset: %s", err.Error())
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	allApps, err := application.List(ctx, cluster, "")
+	allApps, listWarnings, err := application.ListWithScopedWarnings(ctx, cluster, "", application.ListOptions{
+		SkipMetrics: true,
+	})
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	filteredApps := auth.FilterResources(user, allApps)
+	// The warnings about applications are filtered like the applications themselves.
+	filteredApps := filterAppsByStatus(auth.FilterResources(user, allApps), status)
+	warnings := application.FilterWarnings(listWarnings, visibleNamespaces(user))
+
+	if !paged {
+		warnings = append(warnings, addListMetrics(ctx, cluster, filteredApps)...)
+		respondAppList(c, filteredApps, warnings)
+		return nil
+	}
+
+	page, total := appPage(filteredApps, limit, offset)
+	warnings = append(warnings, addListMetrics(ctx, cluster, page)...)
+
+	for _, warning := range warnings {
+		helpers.Logger.Infow("application list incomplete", "warning", warning)
+	}
+
+	pageResponse := models.AppListPageResponse{
+		Items: page,
+		Total: total,
+	}
+	if c.Query("warnings") == "true" {
+		pageResponse.Warnings = warnings
+	}
 
-	response.OKReturn(c, filteredApps)
+	response.OKNegotiated(c, pageResponse)
 	return nil
 }
+
+// visibleNamespaces returns the namespaces whose resources the user may see, as per
+// auth.FilterResources. The result is nil for admins, who see all namespaces.
+func visibleNamespaces(user auth.User) []string {
+	if user.IsAdmin() {
+		return nil
+	}
+	if user.Namespaces == nil {
+		return []string{}
+	}
+	return user.Namespaces
+}
+
+// validAppStatusFilter returns true if the status filter is empty, or known.
+func validAppStatusFilter(status string) bool {
+	switch status {
+	case "", AppHealthy, AppUnhealthy,
+		models.ApplicationCreated, models.ApplicationStaging,
+		models.ApplicationRunning, models.ApplicationError:
+		return true
+	}
+	return false
+}
+
+// filterAppsByStatus returns the applications matching the status filter. An empty filter
+// matches all applications.
+func filterAppsByStatus(apps models.AppList, status string) models.AppList {
+	if status == "" {
+		return apps
+	}
+
+	result := models.AppList{}
+	for _, app := range apps {
+		var match bool
+		switch status {
+		case AppHealthy:
+			match = !appUnhealthy(app)
+		case AppUnhealthy:
+			match = appUnhealthy(app)
+		default:
+			match = string(app.Status) == status
+		}
+		if match {
+			result = append(result, app)
+		}
+	}
+	return result
+}
+
+// appUnhealthy returns true if the application is in error, or has less ready than desired
+// replicas.
+func appUnhealthy(app models.App) bool {
+	if app.Status == models.ApplicationError {
+		return true
+	}
+	return app.Workload != nil && app.Workload.ReadyReplicas < app.Workload.DesiredReplicas
+}
+
+// appPage returns the page of the applications, sorted by namespace and name, and the number of
+// all applications. A limit of zero places no limit. Offsets past the end result in an empty
+// page.
+func appPage(apps models.AppList, limit, offset int) (models.AppList, int) {
+	sorted := make(models.AppList, len(apps))
+	copy(sorted, apps)
+	sort.Sort(sorted)
+
+	return response.Page(sorted, limit, offset), len(sorted)
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestFilterAppsByStatus(t *testing.T) {
+	created := *models.NewApp("fresh", "workspace")
+	created.Status = models.ApplicationCreated
+
+	ready := *models.NewApp("ready", "workspace")
+	ready.Status = models.ApplicationRunning
+	ready.Workload = &models.AppDeployment{DesiredReplicas: 1, ReadyReplicas: 1}
+
+	degraded := *models.NewApp("degraded", "workspace")
+	degraded.Status = models.ApplicationRunning
+	degraded.Workload = &models.AppDeployment{DesiredReplicas: 2, ReadyReplicas: 1}
+
+	broken := *models.NewApp("broken", "other")
+	broken.Status = models.ApplicationError
+
+	apps := models.AppList{created, ready, degraded, broken}
+
+	cases := []struct {
+		status string
+		want   []string
+	}{
+		{"", []string{"fresh", "ready", "degraded", "broken"}},
+		{AppHealthy, []string{"fresh", "ready"}},
+		{AppUnhealthy, []string{"degraded", "broken"}},
+		{models.ApplicationRunning, []string{"ready", "degraded"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.status, func(t *testing.T) {
+			got := filterAppsByStatus(apps, tc.status)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %d apps", tc.want, len(got))
+			}
+			for i, app := range got {
+				if app.Meta.Name != tc.want[i] {
+					t.Fatalf("expected %v, got %s at %d", tc.want, app.Meta.Name, i)
+				}
+			}
+		})
+	}
+
+	if validAppStatusFilter("sleepy") {
+		t.Fatalf("expected unknown status filter to be rejected")
+	}
+}
+
+func TestAppPage(t *testing.T) {
+	apps := models.AppList{
+		*models.NewApp("b", "ns2"),
+		*models.NewApp("b", "ns1"),
+		*models.NewApp("a", "ns2"),
+	}
+
+	page, total := appPage(apps, 2, 1)
+	if total != 3 || len(page) != 2 {
+		t.Fatalf("unexpected page %v of %d", page, total)
+	}
+	if page[0].Meta.Namespace != "ns2" || page[0].Meta.Name != "a" || page[1].Meta.Name != "b" {
+		t.Fatalf("unexpected page order %v", page)
+	}
+
+	page, total = appPage(apps, 0, 5)
+	if total != 3 || len(page) != 0 {
+		t.Fatalf("expected an empty page, got %v of %d", page, total)
+	}
+}
+
+func TestFullIndexWarningsNonAdmin(t *testing.T) {
+	warnings := []application.ListWarning{
+		{Message: "staging status not available: timeout"},
+		{Namespace: "workspace", Message: "application 'mine' in namespace 'workspace': broken"},
+		{Namespace: "secret", Message: "application 'theirs' in namespace 'secret': broken"},
+	}
+
+	user := auth.User{
+		Username:   "alice",
+		Roles:      auth.Roles{{ID: "user"}},
+		Namespaces: []string{"workspace"},
+	}
+	visible := application.FilterWarnings(warnings, visibleNamespaces(user))
+	if len(visible) != 2 || visible[0] != warnings[0].Message || visible[1] != warnings[1].Message {
+		t.Fatalf("expected the general and the own namespace warnings, got %v", visible)
+	}
+
+	// A user without namespaces sees only the general warnings
+	visible = application.FilterWarnings(warnings, visibleNamespaces(auth.User{Username: "bob"}))
+	if len(visible) != 1 || visible[0] != warnings[0].Message {
+		t.Fatalf("expected only the general warning, got %v", visible)
+	}
+
+	admin := auth.User{Username: "admin", Roles: auth.Roles{auth.AdminRole}}
+	if visible := application.FilterWarnings(warnings, visibleNamespaces(admin)); len(visible) != 3 {
+		t.Fatalf("expected all warnings for an admin, got %v", visible)
+	}
+}
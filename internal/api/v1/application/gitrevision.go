@@ -0,0 +1,165 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	gitbridge "github.com/epinio/epinio/internal/bridge/git"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+)
+
+const (
+	// maxRevisionSuggestions is the number of close matches reported for an unknown revision
+	maxRevisionSuggestions = 5
+)
+
+// commitLike matches revisions which may be (abbreviated) commit ids. These cannot be
+// verified against the advertised references of a remote.
+var commitLike = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+// listRemoteReferences returns the references advertised by the remote repository, i.e.
+// the equivalent of `git ls-remote`. It uses the same credentials and TLS settings as the
+// clone.
+func listRemoteReferences(ctx context.Context, url string, gitconfig *gitbridge.Configuration) ([]*plumbing.Reference, error) {
+	opts := loadCloneOptions(git.CloneOptions{URL: url}, gitconfig)
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{url},
+	})
+
+	return remote.ListContext(ctx, &git.ListOptions{
+		Auth:            opts.Auth,
+		CABundle:        opts.CABundle,
+		InsecureSkipTLS: opts.InsecureSkipTLS,
+	})
+}
+
+// validateRevision checks the revision against the references of the remote. It returns
+// a bad request error listing the closest branch and tag names when the revision cannot
+// match anything. Commit ids and revision expressions (`main~1`, ...) are accepted as is,
+// the clone resolves them.
+func validateRevision(refs []*plumbing.Reference, revision string) apierror.APIErrors {
+	if strings.ContainsAny(revision, "~^@:") {
+		return nil
+	}
+
+	names := []string{}
+	for _, ref := range refs {
+		name := ref.Name()
+
+		if name.String() == revision {
+			return nil
+		}
+		if commitLike.MatchString(revision) &&
+			strings.HasPrefix(ref.Hash().String(), strings.ToLower(revision)) {
+			return nil
+		}
+		if !name.IsBranch() && !name.IsTag() {
+			continue
+		}
+		if name.Short() == revision {
+			return nil
+		}
+
+		names = append(names, name.Short())
+	}
+
+	// Any commit may be referenced, not only the tips advertised by the remote
+	if commitLike.MatchString(revision) {
+		return nil
+	}
+
+	apiErr := apierror.NewBadRequestError("revision not found")
+
+	suggestions := revisionSuggestions(names, revision)
+	if len(suggestions) > 0 {
+		return apiErr.WithDetailsf("revision [%s] does not exist, did you mean: %s",
+			revision, strings.Join(suggestions, ", "))
+	}
+	return apiErr.WithDetailsf("revision [%s] does not exist", revision)
+}
+
+// revisionSuggestions returns the names closest to the revision, by edit distance, plus
+// the names containing it. The result is sorted by distance, then by name.
+func revisionSuggestions(names []string, revision string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	// Allow about one typo per three characters, and at least two
+	limit := max(2, len(revision)/3)
+	needle := strings.ToLower(revision)
+
+	seen := map[string]struct{}{}
+	candidates := []candidate{}
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		distance := levenshtein(strings.ToLower(name), needle)
+		if distance > limit && !strings.Contains(strings.ToLower(name), needle) {
+			continue
+		}
+		candidates = append(candidates, candidate{name: name, distance: distance})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < maxRevisionSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between the two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func testReferences() []*plumbing.Reference {
+	return []*plumbing.Reference{
+		plumbing.NewReferenceFromStrings("HEAD", "1111111111111111111111111111111111111111"),
+		plumbing.NewReferenceFromStrings("refs/heads/main", "1111111111111111111111111111111111111111"),
+		plumbing.NewReferenceFromStrings("refs/heads/feature/login", "2222222222222222222222222222222222222222"),
+		plumbing.NewReferenceFromStrings("refs/heads/release-1.0", "3333333333333333333333333333333333333333"),
+		plumbing.NewReferenceFromStrings("refs/tags/v1.0.0", "4444444444444444444444444444444444444444"),
+		plumbing.NewReferenceFromStrings("refs/pull/7/head", "5555555555555555555555555555555555555555"),
+	}
+}
+
+func TestValidateRevisionKnown(t *testing.T) {
+	for _, revision := range []string{
+		"main", "feature/login", "v1.0.0", "refs/heads/main", "refs/pull/7/head",
+		"2222222", "abcdef1234", "main~1", "v1.0.0^{commit}",
+	} {
+		if err := validateRevision(testReferences(), revision); err != nil {
+			t.Errorf("revision %q: unexpected error: %v", revision, err)
+		}
+	}
+}
+
+func TestValidateRevisionUnknown(t *testing.T) {
+	err := validateRevision(testReferences(), "mian")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if err.FirstStatus() != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", err.FirstStatus())
+	}
+
+	apiErr := err.Errors()[0]
+	if apiErr.Title != "revision not found" {
+		t.Errorf("unexpected title %q", apiErr.Title)
+	}
+	if !strings.Contains(apiErr.Details, "did you mean: main") {
+		t.Errorf("expected a suggestion, got %q", apiErr.Details)
+	}
+
+	err = validateRevision(testReferences(), "does-not-exist")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if details := err.Errors()[0].Details; strings.Contains(details, "did you mean") {
+		t.Errorf("unexpected suggestions %q", details)
+	}
+}
+
+func TestRevisionSuggestions(t *testing.T) {
+	names := []string{"main", "master", "release-1.0", "release-2.0", "v1.0.0", "main"}
+
+	got := revisionSuggestions(names, "release")
+	want := []string{"release-1.0", "release-2.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got = revisionSuggestions(names, "mastr")
+	want = []string{"master"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"main", "main", 0},
+		{"main", "mian", 2},
+		{"master", "mastr", 1},
+		{"", "abc", 3},
+	}
+	for _, c := range cases {
+		if got := levenshtein(c.a, c.b); got != c.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
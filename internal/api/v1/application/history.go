@@ -0,0 +1,104 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// History handles the API endpoint GET /namespaces/:namespace/applications/:app/history
+// It returns the deploy history of the specified application, as JSON, or YAML if requested.
+func History(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	revisions, err := application.History(ctx, cluster, app.Meta)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKNegotiated(c, models.AppHistoryResponse{Revisions: revisions})
+	return nil
+}
+
+// HistoryManifest handles the API endpoint GET /namespaces/:namespace/applications/:app/history/:revision/manifest
+// It returns the manifest of the application as deployed in the specified revision, in the same
+// form as the manifest part.
+func HistoryManifest(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	revisionParam := c.Param("revision")
+
+	number, err := strconv.Atoi(revisionParam)
+	if err != nil || number < 1 {
+		return apierror.NewBadRequestErrorf("invalid revision '%s', expected a positive number", revisionParam)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	revisions, err := application.History(ctx, cluster, app.Meta)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	revision := findRevision(revisions, number)
+	if revision == nil {
+		return apierror.NewNotFoundError("revision", revisionParam).
+			WithDetailsf("application '%s' has no such revision retained", appName)
+	}
+
+	response.OKYaml(c, revision.Manifest(app.Meta))
+	return nil
+}
+
+// findRevision returns the numbered revision, or nil, if the history does not contain it.
+func findRevision(revisions []models.AppRevision, number int) *models.AppRevision {
+	for i := range revisions {
+		if revisions[i].Revision == number {
+			return &revisions[i]
+		}
+	}
+	return nil
+}
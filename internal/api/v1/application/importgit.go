@@ -86,6 +86,7 @@ import (
 	gitbridge "github.com/epinio/epinio/internal/bridge/git"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/operations"
 	"github.com/epinio/epinio/internal/s3manager"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -115,6 +116,10 @@ func ImportGit(c *gin.Context) apierror.APIErrors {
 		return errGitURL
 	}
 
+	// Clone and upload can take a while. Register the import so that it can be cancelled.
+	ctx, done := operations.Default.Start(ctx, operations.TypeImport, namespace, name)
+	defer done()
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err, "failed to get access to a kube client")
@@ -147,6 +152,17 @@ func ImportGit(c *gin.Context) apierror.APIErrors {
 		log.Infow("git config not found for giturl", "giturl", giturl)
 	}
 
+	// Check that the revision exists before the (possibly expensive) clone. Failing to
+	// list the remote is not fatal here, the clone reports the actual problem.
+	if revision != "" {
+		refs, err := listRemoteReferences(ctx, giturl, gitConfig)
+		if err != nil {
+			log.Infow("listing remote references failed", "giturl", giturl, "error", err)
+		} else if apiErr := validateRevision(refs, revision); apiErr != nil {
+			return apiErr
+		}
+	}
+
 	// clone/fetch/checkout
 	ref, err := checkoutRepository(ctx, gitRepo, giturl, revision, gitConfig)
 	if err != nil {
@@ -161,6 +177,9 @@ func ImportGit(c *gin.Context) apierror.APIErrors {
 		log.Infow("resolved branch and revision", "branch", branch, "revision", revision)
 	}
 
+	// Record the files selecting a language builder for staging, see builders.go
+	signals := detectDirSignals(gitRepo, languageSignals(languageBuilders()))
+
 	// Create a tarball
 	tmpDir, tarball, err := helpers.Tar(gitRepo, nil)
 	defer func() {
@@ -183,9 +202,9 @@ func ImportGit(c *gin.Context) apierror.APIErrors {
 	}
 
 	username := requestctx.User(ctx).Username
-	blobUID, err := manager.Upload(ctx, tarball, map[string]string{
+	blobUID, err := manager.Upload(ctx, tarball, signalsMeta(map[string]string{
 		"app": name, "namespace": namespace, "username": username,
-	})
+	}, signals))
 	if err != nil {
 		return apierror.InternalError(err, "uploading the application sources blob")
 	}
@@ -12,15 +12,23 @@
 package application
 
 import (
+	"context"
+
+	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
 )
 
 // Index handles the API endpoint GET /namespaces/:namespace/applications
 // It lists all the known applications in the specified namespace, with and without workload.
+// The list is returned as JSON, or YAML if requested. Applications which cannot be assembled
+// are listed with an error status. The metrics of the replicas are queried per application, with
+// the concurrency and per-application timeout configured for the server. With `warnings=true` the
+// list is returned together with the warnings about partial failures, like missing metrics.
 func Index(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
@@ -30,11 +38,41 @@ func Index(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	apps, err := application.List(ctx, cluster, namespace)
+	apps, warnings, err := application.ListWithWarnings(ctx, cluster, namespace, application.ListOptions{
+		SkipMetrics: true,
+	})
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	response.OKReturn(c, apps)
+	warnings = append(warnings, addListMetrics(ctx, cluster, apps)...)
+
+	respondAppList(c, apps, warnings)
 	return nil
 }
+
+// addListMetrics adds the metrics of their replicas to the listed applications, bounded as
+// configured for the server, and returns the warnings about the applications whose metrics could
+// not be queried.
+func addListMetrics(ctx context.Context, cluster *kubernetes.Cluster, apps models.AppList) []string {
+	failures := application.AddMetrics(ctx, cluster, apps, application.MetricsOptionsFromSettings())
+	return application.MetricsWarnings(apps, failures)
+}
+
+// respondAppList returns the applications, together with the warnings, if requested. The
+// warnings are logged in any case.
+func respondAppList(c *gin.Context, apps models.AppList, warnings []string) {
+	for _, warning := range warnings {
+		helpers.Logger.Infow("application list incomplete", "warning", warning)
+	}
+
+	if c.Query("warnings") != "true" {
+		response.OKNegotiated(c, apps)
+		return
+	}
+
+	response.OKNegotiated(c, models.AppListResponse{
+		Apps:     apps,
+		Warnings: warnings,
+	})
+}
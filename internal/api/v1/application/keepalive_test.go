@@ -0,0 +1,106 @@
+package application
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// keepAliveServer starts a websocket server running keepAlive, and reports the error ending
+// its read loop.
+func keepAliveServer(t *testing.T, interval time.Duration) (*httptest.Server, chan error) {
+	readErr := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		stop := keepAlive(conn, interval)
+		defer stop()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}))
+	return server, readErr
+}
+
+func dialKeepAliveServer(t *testing.T, server *httptest.Server) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	return conn
+}
+
+func TestKeepAlivePingsAnsweringClient(t *testing.T) {
+	server, readErr := keepAliveServer(t, 20*time.Millisecond)
+	defer server.Close()
+
+	conn := dialKeepAliveServer(t, server)
+	defer conn.Close()
+
+	var pings atomic.Int32
+	conn.SetPingHandler(func(data string) error {
+		pings.Add(1)
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+
+	// Reading processes the control frames, and answers the pings
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-readErr:
+		t.Fatalf("server read ended early: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if pings.Load() < 3 {
+		t.Fatalf("expected several pings, got %d", pings.Load())
+	}
+}
+
+func TestKeepAliveTimesOutSilentClient(t *testing.T) {
+	server, readErr := keepAliveServer(t, 20*time.Millisecond)
+	defer server.Close()
+
+	// The client never reads, and thus never answers a ping
+	conn := dialKeepAliveServer(t, server)
+	defer conn.Close()
+
+	select {
+	case err := <-readErr:
+		var netErr net.Error
+		if !errors.As(err, &netErr) || !netErr.Timeout() {
+			t.Fatalf("expected a timeout, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not time out the silent client")
+	}
+}
+
+func TestKeepAliveDisabled(t *testing.T) {
+	stop := keepAlive(nil, 0)
+	stop()
+}
@@ -0,0 +1,123 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//	http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+)
+
+const (
+	// defaultLogsFlushLines is the number of buffered log lines triggering a flush to the client
+	defaultLogsFlushLines = 100
+	// defaultLogsFlushInterval is the maximum time log lines are held before they are flushed
+	defaultLogsFlushInterval = 100 * time.Millisecond
+	// defaultLogsBufferLines is the number of log lines held for a slow client before dropping
+	defaultLogsBufferLines = 10000
+)
+
+// logBuffer is the bounded buffer between the log tailers and the websocket writer of a log
+// stream. Adding a line never blocks. When the buffer is full the line is dropped instead, and
+// counted. The writer takes the buffered lines in batches, and reports the dropped lines to the
+// client.
+type logBuffer struct {
+	mu         sync.Mutex
+	lines      []tailer.ContainerLogLine
+	dropped    int
+	limit      int
+	flushLines int
+
+	// ready is signaled when enough lines are buffered for a flush
+	ready chan struct{}
+}
+
+func newLogBuffer(limit, flushLines int) *logBuffer {
+	if limit <= 0 {
+		limit = defaultLogsBufferLines
+	}
+	if flushLines <= 0 {
+		flushLines = defaultLogsFlushLines
+	}
+	if flushLines > limit {
+		flushLines = limit
+	}
+
+	return &logBuffer{
+		limit:      limit,
+		flushLines: flushLines,
+		ready:      make(chan struct{}, 1),
+	}
+}
+
+// add buffers the line, or drops it if the buffer is full. Markers are never dropped, as the
+// client relies on them.
+func (b *logBuffer) add(line tailer.ContainerLogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.lines) >= b.limit && !isLogMarker(line) {
+		b.dropped++
+		return
+	}
+
+	b.lines = append(b.lines, line)
+	if len(b.lines) >= b.flushLines {
+		b.signal()
+	}
+}
+
+// reset discards all buffered lines, and replaces them with the marker, to be flushed
+// immediately.
+func (b *logBuffer) reset(marker tailer.ContainerLogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lines = []tailer.ContainerLogLine{marker}
+	b.dropped = 0
+	b.signal()
+}
+
+// take returns the buffered lines, prefixed by a throttling notice if lines were dropped since
+// the last call, and empties the buffer.
+func (b *logBuffer) take() []tailer.ContainerLogLine {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	lines := b.lines
+	if b.dropped > 0 {
+		notice := tailer.ContainerLogLine{
+			Message:   fmt.Sprintf("logs throttled, %d lines skipped", b.dropped),
+			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		}
+		lines = append([]tailer.ContainerLogLine{notice}, lines...)
+	}
+
+	b.lines = nil
+	b.dropped = 0
+	return lines
+}
+
+func (b *logBuffer) signal() {
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
+
+// isLogMarker returns true for the control lines of the stream, i.e. lines not coming from a
+// pod, like `___FILTER_COMPLETE___`.
+func isLogMarker(line tailer.ContainerLogLine) bool {
+	return line.PodName == "" && line.ContainerName == ""
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+)
+
+func podLine(i int) tailer.ContainerLogLine {
+	return tailer.ContainerLogLine{
+		Message:       fmt.Sprintf("line %d", i),
+		ContainerName: "web",
+		PodName:       "web-0",
+	}
+}
+
+func isReady(b *logBuffer) bool {
+	select {
+	case <-b.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestLogBufferSignalsFlush(t *testing.T) {
+	buffer := newLogBuffer(10, 3)
+
+	buffer.add(podLine(1))
+	buffer.add(podLine(2))
+	if isReady(buffer) {
+		t.Fatal("expected no flush signal below the flush size")
+	}
+
+	buffer.add(podLine(3))
+	if !isReady(buffer) {
+		t.Fatal("expected a flush signal at the flush size")
+	}
+
+	lines := buffer.take()
+	if len(lines) != 3 || lines[0].Message != "line 1" || lines[2].Message != "line 3" {
+		t.Fatalf("unexpected lines %v", lines)
+	}
+	if len(buffer.take()) != 0 {
+		t.Fatal("expected take to empty the buffer")
+	}
+}
+
+func TestLogBufferDropsWhenFull(t *testing.T) {
+	buffer := newLogBuffer(2, 1)
+
+	for i := 1; i <= 5; i++ {
+		buffer.add(podLine(i))
+	}
+	buffer.add(tailer.ContainerLogLine{Message: "___FILTER_COMPLETE___"})
+
+	lines := buffer.take()
+	if len(lines) != 4 {
+		t.Fatalf("expected notice, two lines and the marker, got %v", lines)
+	}
+	if lines[0].Message != "logs throttled, 3 lines skipped" {
+		t.Fatalf("unexpected notice %q", lines[0].Message)
+	}
+	if lines[1].Message != "line 1" || lines[2].Message != "line 2" {
+		t.Fatalf("expected the oldest lines to be kept, got %v", lines[1:3])
+	}
+	if lines[3].Message != "___FILTER_COMPLETE___" {
+		t.Fatalf("expected the marker to be kept, got %q", lines[3].Message)
+	}
+
+	buffer.add(podLine(6))
+	lines = buffer.take()
+	if len(lines) != 1 || lines[0].Message != "line 6" {
+		t.Fatalf("expected the dropped count to be reset, got %v", lines)
+	}
+}
+
+func TestLogBufferReset(t *testing.T) {
+	buffer := newLogBuffer(1, 1)
+	buffer.add(podLine(1))
+	buffer.add(podLine(2))
+
+	buffer.reset(tailer.ContainerLogLine{Message: "___FILTER_START___"})
+	if !isReady(buffer) {
+		t.Fatal("expected a flush signal after reset")
+	}
+
+	lines := buffer.take()
+	if len(lines) != 1 || lines[0].Message != "___FILTER_START___" {
+		t.Fatalf("expected only the marker, got %v", lines)
+	}
+}
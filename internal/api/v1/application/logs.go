@@ -15,6 +15,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -234,6 +236,17 @@ func ParseLogParametersForTest(tailStr, sinceStr, sinceTimeStr string, includeCo
 // streamed over a websocket. Dependent on the endpoint this may be
 // either regular logs, or the app's staging logs.
 //
+// For application logs the query parameter `sinceRestart=true` restricts the logs to those
+// written since the most recent restart of the application's instances.
+//
+// The query parameters `since` and `tail` select the recent lines only. With both the tail
+// bounds the lines after the time filter. With `timestamps=true` each line is prefixed with
+// its RFC3339 timestamp.
+//
+// The query parameters `instance` and `container` restrict the logs to the named pod of the
+// application, and the named container, respectively. Without them the logs of all instances
+// are streamed.
+//
 // There is also support for dynamic updating of log parameters via
 // the websocket connection. The client can send a JSON message with tail,
 // since, and since_time fields to update the log filtering parameters.
@@ -251,10 +264,11 @@ func Logs(c *gin.Context) {
 		return
 	}
 
+	var app *models.App
 	if appName != "" {
 		helpers.Logger.Debugw("retrieve application", "name", appName, "namespace", namespace)
 
-		app, err := application.Lookup(ctx, cluster, namespace, appName)
+		app, err = application.Lookup(ctx, cluster, namespace, appName)
 		if err != nil {
 			response.Error(c, apierror.InternalError(err))
 			return
@@ -306,6 +320,46 @@ func Logs(c *gin.Context) {
 	// Set follow parameter
 	follow := followStr == "true"
 	logParams.Follow = follow
+	logParams.Timestamps = c.Query("timestamps") == "true"
+	logParams.Container = c.Query("container")
+
+	// Restrict to a single instance of the application, if asked for
+	if instanceName := c.Query("instance"); instanceName != "" {
+		if app == nil {
+			response.Error(c, apierror.NewBadRequestError("instance is only supported for application logs"))
+			return
+		}
+
+		podNames, err := application.NewWorkload(cluster, app.Meta, app.Workload.DesiredReplicas).PodNames(ctx)
+		if err != nil {
+			response.Error(c, apierror.InternalError(err))
+			return
+		}
+		if apiErr := validateInstance(podNames, instanceName); apiErr != nil {
+			response.Error(c, apiErr)
+			return
+		}
+		logParams.Instance = instanceName
+	}
+
+	// Start from the most recent restart of the application, if asked for
+	if c.Query("sinceRestart") == "true" {
+		if app == nil {
+			response.Error(c, apierror.NewBadRequestError("sinceRestart is only supported for application logs"))
+			return
+		}
+		if logParams.Since != nil || logParams.SinceTime != nil {
+			response.Error(c, apierror.NewBadRequestError("sinceRestart cannot be combined with since or since_time"))
+			return
+		}
+
+		restart, err := application.NewWorkload(cluster, app.Meta, app.Workload.DesiredReplicas).LastRestart(ctx)
+		if err != nil {
+			response.Error(c, apierror.InternalError(err))
+			return
+		}
+		logParams.SinceTime = restart
+	}
 
 	// Validate container filter regex patterns before upgrading to websocket
 	// This allows us to return HTTP errors instead of silently failing
@@ -322,6 +376,9 @@ func Logs(c *gin.Context) {
 		"since_time: ", logParams.SinceTime,
 		"follow: ", logParams.Follow,
 		"follow_raw: ", followStr,
+		"timestamps: ", logParams.Timestamps,
+		"instance: ", logParams.Instance,
+		"container: ", logParams.Container,
 		"include_containers: ", logParams.IncludeContainers,
 		"exclude_containers: ", logParams.ExcludeContainers)
 
@@ -369,6 +426,15 @@ over the logChan. This is a channel of ContainerLogLine.
 If the filtering parameters are updated a new log streaming goroutine is
 started and the previous one is cancelled. The logChan is shared between
 the goroutines to prevent the need for reconnecting the websocket.
+
+The log lines pass through a bounded buffer, flushed to the client every
+`logs-flush-lines` lines or `logs-flush-interval`. A client which cannot keep up
+loses lines beyond `logs-buffer-lines`, and is told so with a "logs throttled"
+line.
+
+While streaming the server pings the client every `logs-ping-interval`, to keep
+intermediaries from closing an idle connection. The stream is closed when the
+client does not answer in time.
 */
 func streamPodLogs(
 	ctx context.Context,
@@ -379,23 +445,41 @@ func streamPodLogs(
 	cluster *kubernetes.Cluster,
 	logParams *application.LogParameters,
 ) error {
+	// Parameter updates do not change the prefixing of lines with timestamps, nor the
+	// instance and container the logs are restricted to
+	timestamps := logParams.Timestamps
+	instance := logParams.Instance
+	container := logParams.Container
+
 	logCtx, logCancelFunc := context.WithCancel(ctx)
 	logChan := make(chan tailer.ContainerLogLine)
+	readerDone := make(chan struct{})
+	pongTimeout := false
 	var wg sync.WaitGroup
 	var logWg sync.WaitGroup
 
+	buffer := newLogBuffer(viper.GetInt("logs-buffer-lines"), viper.GetInt("logs-flush-lines"))
+
+	stopKeepAlive := keepAlive(conn, viper.GetDuration("logs-ping-interval"))
+	defer stopKeepAlive()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer close(readerDone)
 		for {
 			_, message, err := conn.ReadMessage()
 			if err != nil {
+				var netErr net.Error
 				if websocket.IsCloseError(
 					err,
 					websocket.CloseNormalClosure,
 					websocket.CloseGoingAway,
 				) {
 					helpers.Logger.Debugw("websocket closed normally")
+				} else if errors.As(err, &netErr) && netErr.Timeout() {
+					helpers.Logger.Infow("websocket client did not answer ping, closing")
+					pongTimeout = true
 				} else {
 					helpers.Logger.Errorw("error reading websocket message", "error", err)
 				}
@@ -414,8 +498,8 @@ func streamPodLogs(
 					"params", update.Params,
 				)
 
-				// Send marker directly to WebSocket to tell frontend to clear logs
-				// We do this BEFORE cancelling to ensure it arrives before any buffered messages
+				// Replace the buffered lines with a marker telling the frontend to clear logs
+				// We do this BEFORE cancelling to ensure it arrives before any new messages
 				startMarker := tailer.ContainerLogLine{
 					Message:       "___FILTER_START___",
 					ContainerName: "",
@@ -423,11 +507,7 @@ func streamPodLogs(
 					Namespace:     "",
 					Timestamp:     "",
 				}
-				if msg, err := json.Marshal(startMarker); err == nil {
-					if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
-						helpers.Logger.Error(err, "failed to send filter start marker")
-					}
-				}
+				buffer.reset(startMarker)
 
 				// Cancel current log streaming
 				logCancelFunc()
@@ -453,6 +533,8 @@ func streamPodLogs(
 
 				// Use the follow parameter from the client
 				parsedParams.Follow = update.Params.Follow
+				parsedParams.Instance = instance
+				parsedParams.Container = container
 
 				logWg.Add(1)
 				go startLogStreaming(
@@ -484,6 +566,11 @@ func streamPodLogs(
 
 	defer func() {
 		logCancelFunc()
+		// Drain the log lines still in flight, the streamers may block on them otherwise
+		go func() {
+			for range logChan {
+			}
+		}()
 		wg.Wait()
 		logWg.Wait()
 		close(logChan)
@@ -491,70 +578,176 @@ func streamPodLogs(
 
 	helpers.Logger.Debugw("stream copying begin")
 
-	for logLine := range logChan {
-		helpers.Logger.Debugw("streaming", "log line", logLine)
-
-		msg, err := json.Marshal(logLine)
-		if err != nil {
-			return err
+	// The writer sends the buffered log lines to the client in batches. It runs separately from
+	// the copying below, so that a slow client does not block the log tailers.
+	stopWriter := make(chan struct{})
+	writerDone := make(chan error, 1)
+	go func() {
+		flushInterval := viper.GetDuration("logs-flush-interval")
+		if flushInterval <= 0 {
+			flushInterval = defaultLogsFlushInterval
 		}
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
 
-		err = conn.WriteMessage(websocket.TextMessage, msg)
-		if err != nil {
-			helpers.Logger.Errorw("failed to write to websockets", "error", err)
-
-			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-				return conn.Close()
+		for {
+			select {
+			case <-stopWriter:
+				writerDone <- nil
+				return
+			case <-buffer.ready:
+			case <-ticker.C:
 			}
-			if websocket.IsUnexpectedCloseError(err) {
-				connectionCloseError := conn.Close()
 
-				if connectionCloseError != nil {
-					return connectionCloseError
-				}
+			for _, logLine := range buffer.take() {
+				helpers.Logger.Debugw("streaming", "log line", logLine)
 
-				helpers.Logger.Errorw(
-					"websockets connection unexpectedly closed",
-					"error",
-					err,
-				)
-				return nil
+				if err := writeLogLine(conn, logLine); err != nil {
+					writerDone <- err
+					return
+				}
 			}
+		}
+	}()
 
-			normalCloseErr := conn.WriteControl(
-				websocket.CloseMessage,
-				websocket.FormatCloseMessage(websocket.CloseNormalClosure,
-					"",
-				),
-				time.Time{},
-			)
-			if normalCloseErr != nil {
-				err = errors.Wrap(err, normalCloseErr.Error())
+	for {
+		select {
+		case logLine := <-logChan:
+			if isCacheSizeLine(logLine) {
+				continue
 			}
-
-			abnormalCloseErr := conn.Close()
-			if abnormalCloseErr != nil {
-				err = errors.Wrap(err, abnormalCloseErr.Error())
-				helpers.Logger.Errorw("websockets connection unexpectedly closed", "error", err)
-				return conn.Close()
+			if timestamps {
+				logLine = TimestampLogLine(logLine)
 			}
-
+			buffer.add(logLine)
+		case err := <-writerDone:
 			return err
+		case <-readerDone:
+			helpers.Logger.Debugw("websocket reader done, stream copying stops")
+			close(stopWriter)
+			<-writerDone
+
+			if pongTimeout {
+				_ = conn.WriteControl(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "ping timeout"),
+					time.Now().Add(pingWriteWait),
+				)
+			}
+			return conn.Close()
 		}
 	}
+}
+
+// TimestampLogLine prefixes the message of the log line with its RFC3339 timestamp, if it has
+// one.
+func TimestampLogLine(logLine tailer.ContainerLogLine) tailer.ContainerLogLine {
+	if logLine.Timestamp == "" {
+		return logLine
+	}
+
+	logLine.Message = logLine.Timestamp + " " + logLine.Message
+	return logLine
+}
+
+// writeLogLine sends the log line to the client. A failed write closes the connection. The
+// result is nil if the client closed the connection, and the write error otherwise.
+func writeLogLine(conn *websocket.Conn, logLine tailer.ContainerLogLine) error {
+	msg, err := json.Marshal(logLine)
+	if err != nil {
+		return err
+	}
+
+	// Do not block forever on a client which stopped reading
+	_ = conn.SetWriteDeadline(time.Now().Add(pingWriteWait))
+
+	err = conn.WriteMessage(websocket.TextMessage, msg)
+	if err == nil {
+		return nil
+	}
+
+	helpers.Logger.Errorw("failed to write to websockets", "error", err)
+
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		return conn.Close()
+	}
+	if websocket.IsUnexpectedCloseError(err) {
+		connectionCloseError := conn.Close()
+
+		if connectionCloseError != nil {
+			return connectionCloseError
+		}
 
-	helpers.Logger.Debugw("stream copying done")
-	helpers.Logger.Debugw("websocket teardown")
+		helpers.Logger.Errorw(
+			"websockets connection unexpectedly closed",
+			"error",
+			err,
+		)
+		return nil
+	}
 
-	if err := conn.WriteControl(
+	normalCloseErr := conn.WriteControl(
 		websocket.CloseMessage,
 		websocket.FormatCloseMessage(websocket.CloseNormalClosure,
 			"",
-		), time.Time{}); err != nil {
-		return err
+		),
+		time.Time{},
+	)
+	if normalCloseErr != nil {
+		err = errors.Wrap(err, normalCloseErr.Error())
+	}
+
+	abnormalCloseErr := conn.Close()
+	if abnormalCloseErr != nil {
+		err = errors.Wrap(err, abnormalCloseErr.Error())
+		helpers.Logger.Errorw("websockets connection unexpectedly closed", "error", err)
+		return conn.Close()
+	}
+
+	return err
+}
+
+// pingWriteWait is the time allowed for writing a ping or close frame to the client.
+const pingWriteWait = 10 * time.Second
+
+// keepAlive pings the client over the websocket every interval. The read deadline of the
+// connection is extended whenever the client answers with a pong. A client which does not
+// answer within two intervals causes the pending read to fail with a timeout. A zero interval
+// disables the keepalive. The returned function stops the pinging.
+func keepAlive(conn *websocket.Conn, interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
 	}
 
-	return conn.Close()
+	pongWait := 2 * interval
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingWriteWait))
+				if err != nil {
+					helpers.Logger.Debugw("failed to ping websocket client", "error", err)
+					return
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
 }
 
 // startLogStreaming starts a goroutine to stream logs with the given parameters
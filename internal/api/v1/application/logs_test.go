@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
 	"github.com/epinio/epinio/internal/api/v1/application"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -739,4 +740,21 @@ var _ = Describe("Application Log API Endpoint unit tests", func() {
 			})
 		})
 	})
+
+	Describe("TimestampLogLine", func() {
+		It("prefixes the message with the timestamp", func() {
+			logLine := application.TimestampLogLine(tailer.ContainerLogLine{
+				Message:   "listening on :8080",
+				PodName:   "sample-0",
+				Timestamp: "2024-01-01T12:00:00.123456789Z",
+			})
+			Expect(logLine.Message).To(Equal("2024-01-01T12:00:00.123456789Z listening on :8080"))
+			Expect(logLine.PodName).To(Equal("sample-0"))
+		})
+
+		It("keeps lines without timestamp unchanged", func() {
+			logLine := application.TimestampLogLine(tailer.ContainerLogLine{Message: "___FILTER_START___"})
+			Expect(logLine.Message).To(Equal("___FILTER_START___"))
+		})
+	})
 })
@@ -0,0 +1,233 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+const (
+	// MaxLogSearchContext is the maximum number of context lines before and after a match
+	MaxLogSearchContext = 50
+	// DefaultLogSearchLimit is the number of matches returned when the request sets no limit
+	DefaultLogSearchLimit = 100
+	// MaxLogSearchLimit is the maximum number of matches a search can return
+	MaxLogSearchLimit = 1000
+)
+
+// LogSearch handles the API endpoint GET /namespaces/:namespace/applications/:app/logs/search
+// It scans the available logs of the application for lines matching the regular expression
+// given by the query parameter `pattern`, and returns them with `context` lines before and
+// after, like `grep -C`. At most `limit` matches are returned. The logs to scan are selected
+// by the same parameters as for the streamed logs, i.e. tail, since, since_time, sinceRestart,
+// include_containers, and exclude_containers.
+func LogSearch(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := helpers.Logger.With("component", "LogSearch")
+
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	pattern := c.Query("pattern")
+	if pattern == "" {
+		return apierror.NewBadRequestError("pattern parameter is required")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("invalid pattern: %s", err.Error())
+	}
+
+	contextLines, err := logSearchNumber(c.Query("context"), 0, MaxLogSearchContext)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("invalid context parameter: %s", err.Error())
+	}
+	limit, err := logSearchNumber(c.Query("limit"), DefaultLogSearchLimit, MaxLogSearchLimit)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("invalid limit parameter: %s", err.Error())
+	}
+	if limit == 0 {
+		limit = DefaultLogSearchLimit
+	}
+
+	logParams, err := ParseLogParameters(c.Query("tail"), c.Query("since"), c.Query("since_time"),
+		c.Query("include_containers"), c.Query("exclude_containers"))
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+	if err := validateContainerFilterPatterns(logParams); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+	if app.Workload == nil {
+		return apierror.NewAPIError("No logs available for application without workload", http.StatusBadRequest)
+	}
+
+	if c.Query("sinceRestart") == "true" {
+		if logParams.Since != nil || logParams.SinceTime != nil {
+			return apierror.NewBadRequestError("sinceRestart cannot be combined with since or since_time")
+		}
+
+		restart, err := application.NewWorkload(cluster, app.Meta, app.Workload.DesiredReplicas).LastRestart(ctx)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		logParams.SinceTime = restart
+	}
+
+	search := newLogSearch(re, contextLines, limit)
+
+	logChan := make(chan tailer.ContainerLogLine)
+	var logsErr error
+	go func() {
+		defer close(logChan)
+
+		var tailWg sync.WaitGroup
+		logsErr = application.Logs(ctx, logChan, &tailWg, cluster, appName, "", namespace, logParams)
+		tailWg.Wait()
+	}()
+
+	for line := range logChan {
+		search.add(line)
+	}
+	if logsErr != nil {
+		return apierror.InternalError(logsErr, "fetching the application logs")
+	}
+
+	result := search.result()
+	log.Infow("searched logs", "namespace", namespace, "app", appName,
+		"matches", len(result.Matches), "truncated", result.Truncated)
+
+	response.OKReturn(c, result)
+	return nil
+}
+
+// logSearchNumber parses a numeric query parameter, returning the default for an empty value.
+func logSearchNumber(value string, defaultValue, maximum int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a number", value)
+	}
+	if n < 0 || n > maximum {
+		return 0, fmt.Errorf("%d is not in the range 0 to %d", n, maximum)
+	}
+	return n, nil
+}
+
+// logSearch collects the log lines matching a pattern, with their context. The lines of the
+// containers arrive interleaved, so the context is tracked per container.
+type logSearch struct {
+	pattern      *regexp.Regexp
+	contextLines int
+	limit        int
+	matches      []models.AppLogMatch
+	truncated    bool
+	streams      map[string]*logSearchStream
+}
+
+// logSearchStream is the state of the search for the lines of a single container, i.e. the
+// most recent lines, as context for the next match, and the matches still waiting for the
+// lines after them.
+type logSearchStream struct {
+	before  []string
+	pending []int
+}
+
+func newLogSearch(pattern *regexp.Regexp, contextLines, limit int) *logSearch {
+	return &logSearch{
+		pattern:      pattern,
+		contextLines: contextLines,
+		limit:        limit,
+		matches:      []models.AppLogMatch{},
+		streams:      map[string]*logSearchStream{},
+	}
+}
+
+// add processes the next line of a container.
+func (s *logSearch) add(line tailer.ContainerLogLine) {
+	key := line.Namespace + "/" + line.PodName + "/" + line.ContainerName
+	stream, ok := s.streams[key]
+	if !ok {
+		stream = &logSearchStream{}
+		s.streams[key] = stream
+	}
+
+	// Complete the context of the preceding matches
+	pending := stream.pending[:0]
+	for _, index := range stream.pending {
+		s.matches[index].After = append(s.matches[index].After, line.Message)
+		if len(s.matches[index].After) < s.contextLines {
+			pending = append(pending, index)
+		}
+	}
+	stream.pending = pending
+
+	if s.pattern.MatchString(line.Message) {
+		if len(s.matches) < s.limit {
+			s.matches = append(s.matches, models.AppLogMatch{
+				PodName:       line.PodName,
+				ContainerName: line.ContainerName,
+				Timestamp:     line.Timestamp,
+				Line:          line.Message,
+				Before:        append([]string(nil), stream.before...),
+			})
+			if s.contextLines > 0 {
+				stream.pending = append(stream.pending, len(s.matches)-1)
+			}
+		} else {
+			s.truncated = true
+		}
+	}
+
+	if s.contextLines > 0 {
+		stream.before = append(stream.before, line.Message)
+		if len(stream.before) > s.contextLines {
+			stream.before = stream.before[1:]
+		}
+	}
+}
+
+// result returns the matches found so far.
+func (s *logSearch) result() models.AppLogSearchResponse {
+	return models.AppLogSearchResponse{
+		Matches:   s.matches,
+		Truncated: s.truncated,
+	}
+}
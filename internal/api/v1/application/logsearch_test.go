@@ -0,0 +1,92 @@
+package application
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+)
+
+func searchLine(pod, message string) tailer.ContainerLogLine {
+	return tailer.ContainerLogLine{
+		Message:       message,
+		ContainerName: "web",
+		PodName:       pod,
+	}
+}
+
+func TestLogSearchContext(t *testing.T) {
+	search := newLogSearch(regexp.MustCompile("error"), 1, 10)
+
+	// The lines of the two pods are interleaved, the context must not mix them.
+	search.add(searchLine("web-0", "starting"))
+	search.add(searchLine("web-1", "other start"))
+	search.add(searchLine("web-0", "error: disk full"))
+	search.add(searchLine("web-1", "other error"))
+	search.add(searchLine("web-0", "retrying"))
+	search.add(searchLine("web-0", "done"))
+
+	result := search.result()
+	if result.Truncated {
+		t.Fatalf("unexpected truncation")
+	}
+	if len(result.Matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", result.Matches)
+	}
+
+	first, second := result.Matches[0], result.Matches[1]
+	if first.PodName != "web-0" || first.Line != "error: disk full" ||
+		!reflect.DeepEqual(first.Before, []string{"starting"}) ||
+		!reflect.DeepEqual(first.After, []string{"retrying"}) {
+		t.Fatalf("unexpected first match: %+v", first)
+	}
+	if second.PodName != "web-1" || second.Line != "other error" ||
+		!reflect.DeepEqual(second.Before, []string{"other start"}) || second.After != nil {
+		t.Fatalf("unexpected second match: %+v", second)
+	}
+}
+
+func TestLogSearchWithoutContext(t *testing.T) {
+	search := newLogSearch(regexp.MustCompile("error"), 0, 10)
+
+	search.add(searchLine("web-0", "starting"))
+	search.add(searchLine("web-0", "error"))
+	search.add(searchLine("web-0", "done"))
+
+	result := search.result()
+	if len(result.Matches) != 1 || result.Matches[0].Before != nil || result.Matches[0].After != nil {
+		t.Fatalf("unexpected matches: %+v", result.Matches)
+	}
+}
+
+func TestLogSearchTruncates(t *testing.T) {
+	search := newLogSearch(regexp.MustCompile("error"), 2, 1)
+
+	search.add(searchLine("web-0", "error 1"))
+	search.add(searchLine("web-0", "error 2"))
+	search.add(searchLine("web-0", "ok"))
+
+	result := search.result()
+	if !result.Truncated {
+		t.Fatalf("expected truncation")
+	}
+	if len(result.Matches) != 1 || result.Matches[0].Line != "error 1" ||
+		!reflect.DeepEqual(result.Matches[0].After, []string{"error 2", "ok"}) {
+		t.Fatalf("unexpected matches: %+v", result.Matches)
+	}
+}
+
+func TestLogSearchNumber(t *testing.T) {
+	if n, err := logSearchNumber("", 7, 10); err != nil || n != 7 {
+		t.Fatalf("expected the default, got %d, %v", n, err)
+	}
+	if n, err := logSearchNumber("3", 7, 10); err != nil || n != 3 {
+		t.Fatalf("expected 3, got %d, %v", n, err)
+	}
+	for _, bad := range []string{"x", "-1", "11"} {
+		if _, err := logSearchNumber(bad, 7, 10); err == nil {
+			t.Fatalf("expected an error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// appMetric describes a per-replica gauge of the exported application metrics.
+type appMetric struct {
+	name  string
+	help  string
+	value func(replica *models.PodInfo) (int64, bool)
+}
+
+// appMetrics are the gauges exported per application replica. CPU and memory are skipped for
+// replicas without metrics, instead of being reported as zero.
+var appMetrics = []appMetric{
+	{
+		name: "epinio_app_replica_cpu_millicores",
+		help: "CPU usage of the application replica, in millicores.",
+		value: func(replica *models.PodInfo) (int64, bool) {
+			return replica.MilliCPUs, replica.MetricsOk
+		},
+	},
+	{
+		name: "epinio_app_replica_memory_bytes",
+		help: "Memory usage of the application replica, in bytes.",
+		value: func(replica *models.PodInfo) (int64, bool) {
+			return replica.MemoryBytes, replica.MetricsOk
+		},
+	},
+	{
+		name: "epinio_app_replica_restarts",
+		help: "Number of container restarts of the application replica.",
+		value: func(replica *models.PodInfo) (int64, bool) {
+			return int64(replica.Restarts), true
+		},
+	},
+	{
+		name: "epinio_app_replica_ready",
+		help: "Whether the application replica is ready (1), or not (0).",
+		value: func(replica *models.PodInfo) (int64, bool) {
+			if replica.Ready {
+				return 1, true
+			}
+			return 0, true
+		},
+	},
+}
+
+// Metrics handles the API endpoint GET /namespaces/:namespace/metrics
+// It returns the CPU, memory, restart, and readiness gauges of the replicas of all
+// applications in the namespace, in the Prometheus text exposition format, for scraping.
+func Metrics(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Broken applications have no replicas to report, and do not fail the scrape
+	apps, _, err := application.ListWithWarnings(ctx, cluster, namespace, application.ListOptions{})
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKMetrics(c, formatAppMetrics(apps))
+	return nil
+}
+
+// formatAppMetrics renders the gauges of the application replicas in the Prometheus text
+// exposition format. Applications and replicas are sorted by name, for a stable output.
+func formatAppMetrics(apps models.AppList) []byte {
+	sorted := make(models.AppList, len(apps))
+	copy(sorted, apps)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Meta.Namespace != sorted[j].Meta.Namespace {
+			return sorted[i].Meta.Namespace < sorted[j].Meta.Namespace
+		}
+		return sorted[i].Meta.Name < sorted[j].Meta.Name
+	})
+
+	var out bytes.Buffer
+	for _, metric := range appMetrics {
+		fmt.Fprintf(&out, "# HELP %s %s\n", metric.name, metric.help)
+		fmt.Fprintf(&out, "# TYPE %s gauge\n", metric.name)
+
+		for _, app := range sorted {
+			if app.Workload == nil {
+				continue
+			}
+
+			replicaNames := make([]string, 0, len(app.Workload.Replicas))
+			for name := range app.Workload.Replicas {
+				replicaNames = append(replicaNames, name)
+			}
+			sort.Strings(replicaNames)
+
+			for _, name := range replicaNames {
+				value, ok := metric.value(app.Workload.Replicas[name])
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(&out, "%s{namespace=\"%s\",app=\"%s\",replica=\"%s\"} %d\n",
+					metric.name,
+					escapeLabelValue(app.Meta.Namespace),
+					escapeLabelValue(app.Meta.Name),
+					escapeLabelValue(name),
+					value)
+			}
+		}
+	}
+
+	return out.Bytes()
+}
+
+// labelValueEscaper escapes the characters which are special in label values of the
+// exposition format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
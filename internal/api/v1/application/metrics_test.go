@@ -0,0 +1,53 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestFormatAppMetrics(t *testing.T) {
+	apps := models.AppList{
+		{
+			Meta: models.AppRef{Meta: models.Meta{Name: "web", Namespace: "workspace"}},
+			Workload: &models.AppDeployment{
+				Replicas: map[string]*models.PodInfo{
+					"web-b": {Name: "web-b", Restarts: 2},
+					"web-a": {Name: "web-a", MetricsOk: true, MilliCPUs: 15, MemoryBytes: 1024, Ready: true},
+				},
+			},
+		},
+		{
+			// Without workload there is nothing to report
+			Meta: models.AppRef{Meta: models.Meta{Name: "idle", Namespace: "workspace"}},
+		},
+	}
+
+	expected := strings.Join([]string{
+		`# HELP epinio_app_replica_cpu_millicores CPU usage of the application replica, in millicores.`,
+		`# TYPE epinio_app_replica_cpu_millicores gauge`,
+		`epinio_app_replica_cpu_millicores{namespace="workspace",app="web",replica="web-a"} 15`,
+		`# HELP epinio_app_replica_memory_bytes Memory usage of the application replica, in bytes.`,
+		`# TYPE epinio_app_replica_memory_bytes gauge`,
+		`epinio_app_replica_memory_bytes{namespace="workspace",app="web",replica="web-a"} 1024`,
+		`# HELP epinio_app_replica_restarts Number of container restarts of the application replica.`,
+		`# TYPE epinio_app_replica_restarts gauge`,
+		`epinio_app_replica_restarts{namespace="workspace",app="web",replica="web-a"} 0`,
+		`epinio_app_replica_restarts{namespace="workspace",app="web",replica="web-b"} 2`,
+		`# HELP epinio_app_replica_ready Whether the application replica is ready (1), or not (0).`,
+		`# TYPE epinio_app_replica_ready gauge`,
+		`epinio_app_replica_ready{namespace="workspace",app="web",replica="web-a"} 1`,
+		`epinio_app_replica_ready{namespace="workspace",app="web",replica="web-b"} 0`,
+	}, "\n") + "\n"
+
+	if actual := string(formatAppMetrics(apps)); actual != expected {
+		t.Fatalf("unexpected metrics:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	if actual := escapeLabelValue("a\"b\\c\nd"); actual != `a\"b\\c\nd` {
+		t.Fatalf("unexpected escaping: %s", actual)
+	}
+}
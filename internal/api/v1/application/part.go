@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ import (
 	"helm.sh/helm/v3/pkg/repo"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
@@ -187,7 +189,12 @@ func fetchAppImage(
 
 	helpers.Logger.Infow("got app chart", "chart image", theApp.ImageURL)
 
-	file, err := fetchAppImageFile(ctx, cluster, theApp, imageOutputFilename)
+	pullAuth, apiErr := loadPullSecret(ctx, cluster, theApp.Meta.Namespace, c.Query("pullsecret"))
+	if apiErr != nil {
+		return apiErr
+	}
+
+	file, err := fetchAppImageFile(ctx, cluster, theApp, imageOutputFilename, pullAuth)
 	if err != nil {
 		return apierror.NewInternalError("failed to retrieve image", err.Error())
 	}
@@ -214,11 +221,42 @@ func fetchAppImage(
 	return nil
 }
 
+// loadPullSecret returns the docker configuration of the named pull secret in the namespace,
+// for authentication against the registry holding the image of an application. The result is
+// nil if no secret is named.
+func loadPullSecret(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	namespace,
+	secretName string,
+) ([]byte, apierror.APIErrors) {
+	if secretName == "" {
+		return nil, nil
+	}
+
+	secret, err := cluster.GetSecret(ctx, namespace, secretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, apierror.NewNotFoundError("pull secret", secretName)
+		}
+		return nil, apierror.InternalError(err)
+	}
+
+	auth, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if secret.Type != corev1.SecretTypeDockerConfigJson || !ok {
+		return nil, apierror.NewBadRequestErrorf("pull secret '%s' is not of type %s",
+			secretName, corev1.SecretTypeDockerConfigJson)
+	}
+
+	return auth, nil
+}
+
 func fetchAppImageFile(
 	ctx context.Context,
 	cluster *kubernetes.Cluster,
 	theApp *models.App,
 	imageOutputFilename string,
+	pullAuth []byte,
 ) (*os.File, error) {
 	// Mixing in nanoseconds to prevent multiple requests for the same app to clash over the job name
 
@@ -231,12 +269,42 @@ func fetchAppImageFile(
 		nano,
 	)
 
+	// The pull secret is in the namespace of the app. The job runs in the Epinio namespace,
+	// and gets a copy of the credentials, named after the job, for the time of the download.
+	pullSecret := ""
+	if pullAuth != nil {
+		pullSecret = jobName
+		err := cluster.CreateSecret(ctx, helmchart.Namespace(), corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pullSecret,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "epinio",
+				},
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: pullAuth,
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create pull secret")
+		}
+		defer func() {
+			// NOTE: Use bg context here, the regular one may be canceled.
+			err := cluster.DeleteSecret(context.Background(), helmchart.Namespace(), pullSecret)
+			if err != nil {
+				helpers.Logger.Infow("pull secret delete error", "error", err, "secret", pullSecret)
+			}
+		}()
+	}
+
 	err := runDownloadImageJob(
 		ctx,
 		cluster,
 		jobName,
 		theApp.ImageURL,
 		imageOutputFilename,
+		pullSecret,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create job")
@@ -261,8 +329,25 @@ func runDownloadImageJob(
 	cluster *kubernetes.Cluster,
 	jobName,
 	imageURL,
-	imageOutputFilename string,
+	imageOutputFilename,
+	pullSecret string,
 ) error {
+	job := newDownloadImageJob(jobName, imageURL, imageOutputFilename, pullSecret)
+
+	// Note: The job stays in the Epinio namespace, regardless of the staging namespace. The
+	// `image-export-pvc` it writes to is shared with the server, and PVCs are namespaced.
+	return cluster.CreateJob(ctx, helmchart.Namespace(), job)
+}
+
+// newDownloadImageJob returns the job copying the image into the named file of the export
+// volume. A non-empty pullSecret names the secret holding the credentials for the source
+// registry. Without, the credentials of the Epinio registry are used.
+func newDownloadImageJob(
+	jobName,
+	imageURL,
+	imageOutputFilename,
+	pullSecret string,
+) *batchv1.Job {
 	appImageExporter := viper.GetString("app-image-exporter")
 
 	labels := map[string]string{
@@ -300,8 +385,35 @@ func runDownloadImageJob(
 	}, {
 		Name:      "registry-creds-volume",
 		MountPath: "/root/containers/",
+		ReadOnly:  true,
 	}}
 
+	// Credentials for a private source registry replace the ones of the Epinio registry. They
+	// are passed as file, keeping them out of the job spec and logs.
+	authFile := "/root/containers/auth.json"
+	if pullSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "pull-secret-volume",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: pullSecret,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  corev1.DockerConfigJsonKey,
+							Path: "auth.json",
+						},
+					},
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "pull-secret-volume",
+			MountPath: "/root/source/",
+			ReadOnly:  true,
+		})
+		authFile = "/root/source/auth.json"
+	}
+
 	registryCertificateSecret := viper.GetString("registry-certificate-secret")
 	if registryCertificateSecret != "" {
 		volumes = append(volumes, corev1.Volume{
@@ -325,31 +437,15 @@ func runDownloadImageJob(
 			Annotations: map[string]string{},
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: ptr.To[int32](0),
+			BackoffLimit:          ptr.To[int32](0),
+			ActiveDeadlineSeconds: imageCopyDeadline(viper.GetDuration("image-copy-timeout")),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
 					Annotations: map[string]string{},
 				},
 				Spec: corev1.PodSpec{
-					Affinity: &corev1.Affinity{
-						PodAffinity: &corev1.PodAffinity{
-							RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
-								{
-									LabelSelector: &metav1.LabelSelector{
-										MatchExpressions: []metav1.LabelSelectorRequirement{
-											{
-												Key:      "app.kubernetes.io/name",
-												Operator: "In",
-												Values:   []string{"epinio-server"},
-											},
-										},
-									},
-									TopologyKey: "kubernetes.io/hostname",
-								},
-							},
-						},
-					},
+					Affinity: epinioServerAffinity(viper.GetString("image-copy-affinity")),
 					Containers: []corev1.Container{
 						{
 							Name:    "skopeo",
@@ -357,10 +453,11 @@ func runDownloadImageJob(
 							Command: []string{"skopeo"},
 							Args: []string{
 								"copy",
-								"--src-authfile=/root/containers/auth.json",
+								"--src-authfile=" + authFile,
 								"docker://" + imageURL,
 								"docker-archive:/tmp/" + imageOutputFilename,
 							},
+							Resources:    imageCopyResources(),
 							VolumeMounts: mounts,
 						},
 					},
@@ -371,7 +468,7 @@ func runDownloadImageJob(
 		},
 	}
 
-	return cluster.CreateJob(ctx, helmchart.Namespace(), job)
+	return job
 }
 
 func getFileImageAndJobCleanup(
@@ -402,6 +499,16 @@ func getFileImageAndJobCleanup(
 		return nil, errors.Wrapf(err, "error waiting for job done %s", jobName)
 	}
 
+	// NOTE: A failed job is kept, allows for debugging.
+	reason, err := cluster.JobFailureReason(ctx, jobName, helmchart.Namespace())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error checking status of job %s", jobName)
+	}
+	if reason != "" {
+		helpers.Logger.Infow("export job failed", "job", jobName, "reason", reason)
+		return nil, fmt.Errorf("image download job %s failed: %s", jobName, reason)
+	}
+
 	// check for file existence
 	file, err := os.Open(imageExportVolume + imageOutputFilename)
 	if err != nil {
@@ -440,17 +547,43 @@ func fetchAppValues(
 	return nil
 }
 
+// fetchAppManifest returns the manifest of the application, as YAML. See manifestYAML.
 func fetchAppManifest(c *gin.Context, app *models.App) apierror.APIErrors {
-	m := models.ApplicationManifest{
+	manifest, err := manifestYAML(app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKYamlBytes(c, manifest)
+	return nil
+}
+
+// manifestYAML renders the manifest of the application. The rendering is deterministic, for the
+// same application the same bytes are returned. Maps are rendered with sorted keys, and the
+// unordered lists, i.e. the bound configurations and services, are sorted. Pushing the manifest
+// recreates an equivalent application.
+func manifestYAML(app *models.App) ([]byte, error) {
+	configuration := app.Configuration
+	configuration.Configurations = sortedCopy(configuration.Configurations)
+	configuration.Services = sortedCopy(configuration.Services)
+
+	return yaml.Marshal(models.ApplicationManifest{
 		Name:          app.Meta.Name,
-		Configuration: app.Configuration,
+		Configuration: configuration,
 		Namespace:     app.Meta.Namespace,
 		Origin:        app.Origin,
 		Staging:       app.Staging,
-	}
+	})
+}
 
-	response.OKYaml(c, m)
-	return nil
+// sortedCopy returns a sorted copy of the strings. Nil stays nil.
+func sortedCopy(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	result := append([]string{}, values...)
+	sort.Strings(result)
+	return result
 }
 
 // chartArchiveURL returns a url for the helm chart's tarball.
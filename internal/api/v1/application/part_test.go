@@ -0,0 +1,91 @@
+package application
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"gopkg.in/yaml.v2"
+)
+
+func testManifestApp() *models.App {
+	instances := int32(3)
+
+	app := models.NewApp("web", "workspace")
+	app.Configuration = models.ApplicationConfiguration{
+		Instances:      &instances,
+		Configurations: []string{"redis", "db", "cache"},
+		Services:       []string{"mysql", "minio"},
+		Environment:    models.EnvVariableMap{"ZED": "1", "ALPHA": "2", "MIDDLE": "3"},
+		Routes:         []string{"web.example.com", "api.example.com/v1"},
+		AppChart:       "standard",
+		Settings:       models.ChartValueSettings{"memory": "512Mi", "cpu": "250m", "tier": "gold"},
+	}
+	app.Origin = models.ApplicationOrigin{
+		Kind: models.OriginGit,
+		Git:  &models.GitRef{URL: "https://example.com/web.git", Revision: "abc123"},
+	}
+	app.Staging = models.ApplicationStage{Builder: "paketobuildpacks/builder:full"}
+
+	return app
+}
+
+func TestManifestYAMLDeterministic(t *testing.T) {
+	first, err := manifestYAML(testManifestApp())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, err := manifestYAML(testManifestApp())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Fatalf("expected identical manifests, got\n%s\nand\n%s", first, second)
+	}
+}
+
+func TestManifestYAMLRoundTrip(t *testing.T) {
+	app := testManifestApp()
+
+	data, err := manifestYAML(app)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	manifest := models.ApplicationManifest{}
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if manifest.Name != "web" || manifest.Namespace != "workspace" {
+		t.Fatalf("unexpected app reference %s/%s", manifest.Namespace, manifest.Name)
+	}
+	if *manifest.Configuration.Instances != 3 {
+		t.Fatalf("unexpected instances %d", *manifest.Configuration.Instances)
+	}
+	if !reflect.DeepEqual(manifest.Configuration.Configurations, []string{"cache", "db", "redis"}) {
+		t.Fatalf("unexpected configurations %v", manifest.Configuration.Configurations)
+	}
+	if !reflect.DeepEqual(manifest.Configuration.Services, []string{"minio", "mysql"}) {
+		t.Fatalf("unexpected services %v", manifest.Configuration.Services)
+	}
+	if !reflect.DeepEqual(manifest.Configuration.Environment, app.Configuration.Environment) ||
+		!reflect.DeepEqual(manifest.Configuration.Settings, app.Configuration.Settings) ||
+		!reflect.DeepEqual(manifest.Configuration.Routes, app.Configuration.Routes) ||
+		manifest.Configuration.AppChart != "standard" {
+		t.Fatalf("unexpected configuration %+v", manifest.Configuration)
+	}
+	if manifest.Origin.Git == nil || manifest.Origin.Git.Revision != "abc123" {
+		t.Fatalf("unexpected origin %+v", manifest.Origin)
+	}
+	if manifest.Staging.Builder != app.Staging.Builder {
+		t.Fatalf("unexpected staging %+v", manifest.Staging)
+	}
+
+	// The application itself is not changed by the sorting
+	if app.Configuration.Configurations[0] != "redis" {
+		t.Fatalf("expected the app configurations to be unchanged, got %v", app.Configuration.Configurations)
+	}
+}
@@ -12,13 +12,20 @@
 package application
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/proxy"
 	"github.com/epinio/epinio/internal/application"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/gin-gonic/gin"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func PortForward(c *gin.Context) apierror.APIErrors {
@@ -26,6 +33,12 @@ func PortForward(c *gin.Context) apierror.APIErrors {
 	namespace := c.Param("namespace")
 	appName := c.Param("app")
 	instanceName := c.Query("instance")
+	containerName := c.Query("container")
+
+	remotePorts, err := parseRemotePorts(c.QueryArray("port"))
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
 
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
@@ -56,20 +69,24 @@ func PortForward(c *gin.Context) apierror.APIErrors {
 		return apierror.NewAPIError("couldn't find any Pods to connect to", http.StatusBadRequest)
 	}
 
-	podToConnect := ""
+	podToConnect := podNames[0]
 	if instanceName != "" {
-		for _, podName := range podNames {
-			if podName == instanceName {
-				podToConnect = podName
-				break
-			}
+		if apiErr := validateInstance(podNames, instanceName); apiErr != nil {
+			return apiErr
+		}
+		podToConnect = instanceName
+	}
+
+	if containerName != "" || len(remotePorts) > 0 {
+		pod, err := cluster.Kubectl.CoreV1().Pods(namespace).Get(ctx, podToConnect, metav1.GetOptions{})
+		if err != nil {
+			return apierror.InternalError(err)
 		}
 
-		if podToConnect == "" {
-			return apierror.NewAPIError("specified instance doesn't exist", http.StatusBadRequest)
+		err = validatePortForwardContainer(pod, containerName, remotePorts)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
 		}
-	} else {
-		podToConnect = podNames[0]
 	}
 
 	// https://github.com/kubernetes/kubectl/blob/2acffc93b61e483bd26020df72b9aef64541bd56/pkg/cmd/portforward/portforward.go#L409
@@ -83,3 +100,94 @@ func PortForward(c *gin.Context) apierror.APIErrors {
 
 	return proxy.RunProxy(ctx, c.Writer, c.Request, forwardURL)
 }
+
+// parseRemotePorts converts the port numbers given in the request into int32s.
+func parseRemotePorts(ports []string) ([]int32, error) {
+	result := make([]int32, 0, len(ports))
+	for _, port := range ports {
+		number, err := strconv.ParseInt(port, 10, 32)
+		if err != nil || number <= 0 || number > 65535 {
+			return nil, fmt.Errorf("bad port '%s'", port)
+		}
+		result = append(result, int32(number))
+	}
+	return result, nil
+}
+
+// validateInstance checks that the named instance is one of the pods of the application.
+func validateInstance(podNames []string, instanceName string) apierror.APIErrors {
+	if slices.Contains(podNames, instanceName) {
+		return nil
+	}
+	return apierror.NewAPIError("specified instance doesn't exist", http.StatusBadRequest)
+}
+
+// validatePortForwardContainer checks the container selected for port forwarding against the
+// containers of the pod. The selected container has to exist. The forwarded ports have to be
+// exposed by the pod, i.e. declared by its containers, and by the selected container, if any.
+// A pod declaring no ports at all accepts any port. Without a selection a forwarded port
+// declared by more than one container is ambiguous.
+func validatePortForwardContainer(pod *corev1.Pod, containerName string, ports []int32) error {
+	if containerName != "" {
+		found := false
+		names := []string{}
+		for _, container := range pod.Spec.Containers {
+			names = append(names, container.Name)
+			if container.Name == containerName {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("container '%s' not found in instance '%s', available containers: %s",
+				containerName, pod.Name, strings.Join(names, ", "))
+		}
+	}
+
+	declaresPorts := false
+	for _, container := range pod.Spec.Containers {
+		if len(container.Ports) > 0 {
+			declaresPorts = true
+			break
+		}
+	}
+
+	for _, port := range ports {
+		declaring := containersDeclaringPort(pod, port)
+
+		if len(declaring) == 0 {
+			if declaresPorts {
+				return fmt.Errorf("port %d is not exposed by instance '%s'", port, pod.Name)
+			}
+			continue
+		}
+
+		if containerName == "" {
+			if len(declaring) > 1 {
+				return fmt.Errorf("port %d is ambiguous, it is declared by the containers %s, please select one",
+					port, strings.Join(declaring, ", "))
+			}
+			continue
+		}
+
+		if !slices.Contains(declaring, containerName) {
+			return fmt.Errorf("port %d is not declared by container '%s', but by %s",
+				port, containerName, strings.Join(declaring, ", "))
+		}
+	}
+
+	return nil
+}
+
+// containersDeclaringPort returns the names of the pod's containers declaring the port.
+func containersDeclaringPort(pod *corev1.Pod, port int32) []string {
+	result := []string{}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.ContainerPort == port {
+				result = append(result, container.Name)
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"net/http"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidatePortForwardContainer(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "web", Ports: []corev1.ContainerPort{{ContainerPort: 8080}, {ContainerPort: 9090}}},
+				{Name: "metrics", Ports: []corev1.ContainerPort{{ContainerPort: 9090}}},
+				{Name: "proxy"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		container string
+		ports     []int32
+		wantErr   bool
+	}{
+		{"no selection, unique port", "", []int32{8080}, false},
+		{"no selection, undeclared port", "", []int32{3000}, true},
+		{"no selection, ambiguous port", "", []int32{9090}, true},
+		{"selection resolves ambiguity", "metrics", []int32{9090}, false},
+		{"selection without ports", "proxy", nil, false},
+		{"selection with undeclared port", "proxy", []int32{3000}, true},
+		{"selection not declaring any port", "proxy", []int32{8080}, true},
+		{"selection not declaring port", "metrics", []int32{8080}, true},
+		{"unknown container", "sidecar", nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePortForwardContainer(pod, tc.container, tc.ports)
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatePortForwardContainerWithoutDeclaredPorts(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "web"}},
+		},
+	}
+
+	if err := validatePortForwardContainer(pod, "", []int32{8080}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validatePortForwardContainer(pod, "web", []int32{3000}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestParseRemotePorts(t *testing.T) {
+	ports, err := parseRemotePorts([]string{"80", "8080"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(ports) != 2 || ports[0] != 80 || ports[1] != 8080 {
+		t.Fatalf("unexpected ports %v", ports)
+	}
+
+	for _, bad := range []string{"http", "0", "70000"} {
+		if _, err := parseRemotePorts([]string{bad}); err == nil {
+			t.Fatalf("expected an error for port %q", bad)
+		}
+	}
+}
+
+func TestValidateInstance(t *testing.T) {
+	podNames := []string{"web-0", "web-1"}
+
+	if err := validateInstance(podNames, "web-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err := validateInstance(podNames, "other-0")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if status := err.FirstStatus(); status != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, status)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//	http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// pushLogsDrainPeriod is the time given to the staging log stream to deliver its last lines
+// after the staging jobs are done, before it is stopped and the app logs are streamed.
+var pushLogsDrainPeriod = 5 * time.Second
+
+// PushLogs handles the API endpoint GET /namespaces/:namespace/applications/:app/pushlogs/:stage_id
+// It streams the logs of the specified staging run over a websocket, until the staging is
+// done. It then sends a handoff marker and continues with streaming the logs of the
+// application pods, until the client closes the connection. When staging fails a failed
+// marker is sent instead, and the stream ends.
+//
+// The container filter query parameters are supported, as for the regular logs.
+func PushLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	stageID := c.Param("stage_id")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+	if app == nil {
+		response.Error(c, apierror.AppIsNotKnown(appName))
+		return
+	}
+
+	jobs, apiErr := stageJobs(ctx, cluster, namespace, stageID)
+	if apiErr != nil {
+		response.Error(c, apiErr)
+		return
+	}
+
+	logParams, err := ParseLogParameters("", "", "",
+		c.Query("include_containers"), c.Query("exclude_containers"))
+	if err != nil {
+		response.Error(c, apierror.NewBadRequestError(err.Error()))
+		return
+	}
+	logParams.Follow = true
+
+	if err := validateContainerFilterPatterns(logParams); err != nil {
+		response.Error(c, apierror.NewBadRequestError(err.Error()))
+		return
+	}
+
+	upgrader := newUpgrader()
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logChan := make(chan tailer.ContainerLogLine)
+	go streamPushLogs(streamCtx, logChan, clusterPushLogSources(cluster, namespace, jobs), appName, stageID, logParams)
+
+	writeLogStream(streamCtx, cancel, conn, logChan)
+}
+
+// writeLogStream sends the log lines of the logChan to the client, until the channel is
+// closed. It then closes the connection. The context is cancelled when the client goes away,
+// or a write fails.
+func writeLogStream(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	conn *websocket.Conn,
+	logChan chan tailer.ContainerLogLine,
+) {
+	// Stop streaming when the client goes away.
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	// Note: The channel is drained until closed, even after a write failure, to not block
+	// the producer.
+	for logLine := range logChan {
+		if ctx.Err() != nil || isCacheSizeLine(logLine) {
+			continue
+		}
+
+		msg, err := json.Marshal(logLine)
+		if err != nil {
+			helpers.Logger.Errorw("failed to marshal log line", "error", err)
+			continue
+		}
+
+		if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+			helpers.Logger.Errorw("failed to write to websockets", "error", err)
+			cancel()
+		}
+	}
+
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	_ = conn.Close()
+}
+
+// pushLogSources provides the log streams and the staging completion watched by
+// streamPushLogs.
+type pushLogSources struct {
+	// stream runs a following log stream in the background, for the staging run if the
+	// stageID is set, else for the application. The returned channel is closed when the
+	// stream has ended.
+	stream func(ctx context.Context, logChan chan tailer.ContainerLogLine, appName, stageID string, logParams *application.LogParameters) <-chan struct{}
+	// wait blocks until the staging jobs are done, and returns whether they succeeded.
+	wait func(ctx context.Context) (bool, error)
+}
+
+// clusterPushLogSources returns the log sources of the given staging jobs and their
+// application in the cluster.
+func clusterPushLogSources(cluster *kubernetes.Cluster, namespace string, jobs []batchv1.Job) pushLogSources {
+	return pushLogSources{
+		stream: func(ctx context.Context, logChan chan tailer.ContainerLogLine, appName, stageID string, logParams *application.LogParameters) <-chan struct{} {
+			return startPushLogStream(ctx, logChan, cluster, appName, stageID, namespace, logParams)
+		},
+		wait: func(ctx context.Context) (bool, error) {
+			return waitForStagingCompletion(ctx, cluster, jobs)
+		},
+	}
+}
+
+// streamPushLogs is the producer side of PushLogs. It writes the staging logs, the marker,
+// and the application logs into the logChan, and closes the channel when done.
+func streamPushLogs(
+	ctx context.Context,
+	logChan chan tailer.ContainerLogLine,
+	sources pushLogSources,
+	appName,
+	stageID string,
+	logParams *application.LogParameters,
+) {
+	defer close(logChan)
+
+	// I. Staging logs, until the staging jobs are done
+
+	success, handoff, err := followStagingLogs(ctx, logChan, sources, stageID, logParams)
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err != nil || !success {
+		if err != nil {
+			helpers.Logger.Errorw("staging completion watcher failed", "error", err)
+		}
+		logChan <- tailer.ContainerLogLine{Message: models.PushLogsFailedMarker}
+		return
+	}
+
+	// II. Handoff to the application logs, restricted to lines written after staging
+
+	logChan <- tailer.ContainerLogLine{Message: models.PushLogsHandoffMarker}
+
+	appParams := *logParams
+	appParams.SinceTime = &handoff
+
+	<-sources.stream(ctx, logChan, appName, "", &appParams)
+}
+
+// followStagingLogs writes the logs of the staging run into the logChan, until the staging jobs
+// are done, and the stream is drained. It returns the result of the staging, and the time it
+// was done at.
+func followStagingLogs(
+	ctx context.Context,
+	logChan chan tailer.ContainerLogLine,
+	sources pushLogSources,
+	stageID string,
+	logParams *application.LogParameters,
+) (bool, time.Time, error) {
+	stagingCtx, stagingCancel := context.WithCancel(ctx)
+	stagingDone := sources.stream(stagingCtx, logChan, "", stageID, logParams)
+
+	success, err := sources.wait(ctx)
+	done := time.Now()
+
+	select {
+	case <-stagingDone:
+	case <-time.After(pushLogsDrainPeriod):
+	}
+	stagingCancel()
+	<-stagingDone
+
+	return success, done, err
+}
+
+// startPushLogStream runs a following log stream in the background. The returned channel
+// is closed when the stream has ended.
+func startPushLogStream(
+	ctx context.Context,
+	logChan chan tailer.ContainerLogLine,
+	cluster *kubernetes.Cluster,
+	appName,
+	stageID,
+	namespace string,
+	logParams *application.LogParameters,
+) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		var tailWg sync.WaitGroup
+		err := application.Logs(ctx, logChan, &tailWg, cluster, appName, stageID, namespace, logParams)
+		if err != nil {
+			helpers.Logger.Errorw("setting up log routines failed", "error", err)
+		}
+		tailWg.Wait()
+	}()
+
+	return done
+}
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// fakePushLogSources returns log sources writing the given staging and application lines,
+// and reporting the given staging result. The parameters of the application stream are
+// recorded in appParams.
+func fakePushLogSources(success bool, err error, appParams **application.LogParameters) pushLogSources {
+	return pushLogSources{
+		stream: func(ctx context.Context, logChan chan tailer.ContainerLogLine, appName, stageID string, logParams *application.LogParameters) <-chan struct{} {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				if stageID != "" {
+					logChan <- tailer.ContainerLogLine{Message: "staging " + stageID}
+					return
+				}
+				*appParams = logParams
+				logChan <- tailer.ContainerLogLine{Message: "app " + appName}
+			}()
+			return done
+		},
+		wait: func(ctx context.Context) (bool, error) {
+			return success, err
+		},
+	}
+}
+
+func collectPushLogs(ctx context.Context, sources pushLogSources, logParams *application.LogParameters) []string {
+	logChan := make(chan tailer.ContainerLogLine)
+	go streamPushLogs(ctx, logChan, sources, "myapp", "s1", logParams)
+
+	messages := []string{}
+	for line := range logChan {
+		messages = append(messages, line.Message)
+	}
+	return messages
+}
+
+func equalMessages(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStreamPushLogsHandoff(t *testing.T) {
+	var appParams *application.LogParameters
+	logParams := &application.LogParameters{Follow: true}
+
+	messages := collectPushLogs(context.Background(), fakePushLogSources(true, nil, &appParams), logParams)
+
+	expected := []string{"staging s1", models.PushLogsHandoffMarker, "app myapp"}
+	if !equalMessages(messages, expected) {
+		t.Fatalf("expected %v, got %v", expected, messages)
+	}
+	if appParams == nil || appParams.SinceTime == nil || !appParams.Follow {
+		t.Fatalf("expected following app logs since the handoff, got %#v", appParams)
+	}
+	if logParams.SinceTime != nil {
+		t.Fatalf("expected the staging parameters to be left unchanged")
+	}
+}
+
+func TestStreamPushLogsFailed(t *testing.T) {
+	var appParams *application.LogParameters
+
+	messages := collectPushLogs(context.Background(), fakePushLogSources(false, nil, &appParams), &application.LogParameters{})
+
+	expected := []string{"staging s1", models.PushLogsFailedMarker}
+	if !equalMessages(messages, expected) {
+		t.Fatalf("expected %v, got %v", expected, messages)
+	}
+	if appParams != nil {
+		t.Fatalf("expected no app logs after failed staging")
+	}
+}
+
+func TestStreamPushLogsWatchError(t *testing.T) {
+	var appParams *application.LogParameters
+
+	messages := collectPushLogs(context.Background(), fakePushLogSources(true, errors.New("boom"), &appParams), &application.LogParameters{})
+
+	expected := []string{"staging s1", models.PushLogsFailedMarker}
+	if !equalMessages(messages, expected) {
+		t.Fatalf("expected %v, got %v", expected, messages)
+	}
+}
+
+func TestStreamPushLogsCancelled(t *testing.T) {
+	var appParams *application.LogParameters
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sources := fakePushLogSources(true, nil, &appParams)
+	sources.wait = func(ctx context.Context) (bool, error) {
+		cancel()
+		return false, ctx.Err()
+	}
+
+	messages := collectPushLogs(ctx, sources, &application.LogParameters{})
+
+	expected := []string{"staging s1"}
+	if !equalMessages(messages, expected) {
+		t.Fatalf("expected no marker after the client went away, got %v", messages)
+	}
+}
+
+func TestFollowStagingLogsDrainPeriod(t *testing.T) {
+	saved := pushLogsDrainPeriod
+	pushLogsDrainPeriod = 10 * time.Millisecond
+	defer func() { pushLogsDrainPeriod = saved }()
+
+	// The staging stream does not end on its own, it is stopped after the drain period.
+	sources := pushLogSources{
+		stream: func(ctx context.Context, logChan chan tailer.ContainerLogLine, appName, stageID string, logParams *application.LogParameters) <-chan struct{} {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				<-ctx.Done()
+			}()
+			return done
+		},
+		wait: func(ctx context.Context) (bool, error) {
+			return true, nil
+		},
+	}
+
+	before := time.Now()
+	success, handoff, err := followStagingLogs(context.Background(), make(chan tailer.ContainerLogLine), sources, "s1", &application.LogParameters{})
+	if err != nil || !success {
+		t.Fatalf("expected successful staging, got %v, %v", success, err)
+	}
+	if handoff.Before(before) {
+		t.Fatalf("expected the handoff time to be the completion of the staging")
+	}
+}
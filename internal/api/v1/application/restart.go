@@ -12,6 +12,7 @@
 package application
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -26,6 +27,10 @@ import (
 )
 
 // Restart handles the API endpoint POST /namespaces/:namespace/applications/:app/restart
+// It performs a rolling restart of the pods of the application and its process types, without
+// redeploying it, and returns the new desired generation of the application's deployment. A
+// restaged application is redeployed instead, to bring up the new image, as is an application
+// whose bindings, environment, or other configuration changed since its last deployment.
 func Restart(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
@@ -50,7 +55,43 @@ func Restart(c *gin.Context) apierror.APIErrors {
 		return apierror.NewAPIError("No restart possible for an application with no instances", http.StatusBadRequest)
 	}
 
-	if !strings.Contains(app.ImageURL, app.StageID) {
+	if app.Workload == nil {
+		return apierror.NewAPIError("No restart possible for an application which is not deployed", http.StatusBadRequest)
+	}
+
+	workload := application.NewWorkload(cluster, app.Meta, *app.Configuration.Instances)
+
+	appRef := models.NewAppRef(appName, namespace)
+	applicationCR, err := application.Get(ctx, cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err, "getting the application resource")
+	}
+
+	restaged, redeploy, err := restartRedeploys(*app, applicationCR.GetAnnotations())
+	if err != nil {
+		return apierror.InternalError(err, "checking the application's configuration")
+	}
+
+	if !redeploy {
+		generation, err := workload.RollingRestart(ctx)
+		if err != nil {
+			return apierror.InternalError(err, "restarting the application's deployment")
+		}
+
+		// The additional process types run the same image, and restart with the application
+		for _, processType := range app.Configuration.ProcessTypes {
+			_, err := application.NewWorkload(cluster, application.ProcessTypeRef(app.Meta, processType.Name),
+				application.ProcessTypeInstances(processType)).RollingRestart(ctx)
+			if err != nil {
+				return apierror.InternalError(err, fmt.Sprintf("restarting the deployment of process type %s", processType.Name))
+			}
+		}
+
+		response.OKReturn(c, models.AppRestartResponse{Response: models.ResponseOK, Generation: generation})
+		return nil
+	}
+
+	if restaged {
 		// The stage id should be contained in the image url (as image tag).  As it is not
 		// found we conclude that the app was restaged, and restart now has to bring this
 		// version up.
@@ -64,11 +105,6 @@ func Restart(c *gin.Context) apierror.APIErrors {
 
 		// .. and save it for `DeployApp` to find.
 
-		appRef := models.NewAppRef(appName, namespace)
-		applicationCR, err := application.Get(ctx, cluster, appRef)
-		if err != nil {
-			return apierror.InternalError(err, "getting the application resource")
-		}
 		err = deploy.UpdateImageURL(ctx, cluster, applicationCR, newImageURL)
 		if err != nil {
 			return apierror.InternalError(err, "updating application's image url")
@@ -80,6 +116,26 @@ func Restart(c *gin.Context) apierror.APIErrors {
 		return apierr
 	}
 
-	response.OK(c)
+	generation, err := workload.DesiredGeneration(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.AppRestartResponse{Response: models.ResponseOK, Generation: generation})
 	return nil
 }
+
+// restartRedeploys returns whether the restart of the application has to redeploy it, instead
+// of restarting its pods. This is the case for a restaged application, and for changes made
+// without restarting the application, like bindings done with `--no-restart`, as only a
+// redeployment brings them into the pods. The annotations are those of the application resource.
+func restartRedeploys(app models.App, annotations map[string]string) (restaged, redeploy bool, err error) {
+	restaged = !strings.Contains(app.ImageURL, app.StageID)
+
+	changed, err := application.ConfigurationChanged(app.Configuration, annotations)
+	if err != nil {
+		return false, false, err
+	}
+
+	return restaged, restaged || changed, nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func deployedApp(t *testing.T) (models.App, map[string]string) {
+	instances := int32(1)
+	app := models.App{
+		ImageURL: "registry/apps/myapp:s1",
+		StageID:  "s1",
+		Configuration: models.ApplicationConfiguration{
+			Instances: &instances,
+		},
+	}
+
+	fingerprint, err := application.ConfigurationFingerprint(app.Configuration)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return app, map[string]string{application.DeployedConfigurationAnnotation: fingerprint}
+}
+
+func TestRestartRollingRestart(t *testing.T) {
+	app, annotations := deployedApp(t)
+
+	restaged, redeploy, err := restartRedeploys(app, annotations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restaged || redeploy {
+		t.Fatalf("expected a rolling restart of the unchanged application, got restaged=%v redeploy=%v", restaged, redeploy)
+	}
+}
+
+func TestRestartAfterBindWithoutRestart(t *testing.T) {
+	app, annotations := deployedApp(t)
+
+	// The binding done with --no-restart is recorded in the configuration only
+	app.Configuration.Configurations = []string{"mydb"}
+
+	restaged, redeploy, err := restartRedeploys(app, annotations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restaged || !redeploy {
+		t.Fatalf("expected the restart to redeploy the new binding, got restaged=%v redeploy=%v", restaged, redeploy)
+	}
+}
+
+func TestRestartRestaged(t *testing.T) {
+	app, annotations := deployedApp(t)
+	app.StageID = "s2"
+
+	restaged, redeploy, err := restartRedeploys(app, annotations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !restaged || !redeploy {
+		t.Fatalf("expected the restaged application to be redeployed, got restaged=%v redeploy=%v", restaged, redeploy)
+	}
+}
@@ -20,7 +20,9 @@ import (
 )
 
 // Show handles the API endpoint GET /namespaces/:namespace/applications/:app
-// It returns the details of the specified application.
+// It returns the details of the specified application, as JSON, or YAML if requested.
+// With `metrics=false` the per-replica metrics are not gathered, making status polling cheap.
+// The details include the outcome of validating the app's chart values against its app chart.
 func Show(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
@@ -31,7 +33,9 @@ func Show(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	app, err := application.LookupWithOptions(ctx, cluster, namespace, appName, application.LookupOptions{
+		SkipMetrics: c.Query("metrics") == "false",
+	})
 	if err != nil {
 		return apierror.InternalError(err)
 	}
@@ -40,6 +44,8 @@ func Show(c *gin.Context) apierror.APIErrors {
 		return apierror.AppIsNotKnown(appName)
 	}
 
-	response.OKReturn(c, app)
+	app.ConfigurationValidation = application.ValidateConfiguration(ctx, cluster, app)
+
+	response.OKNegotiated(c, app)
 	return nil
 }
@@ -56,6 +56,7 @@ type stageParam struct {
 	models.AppRef
 	BlobUID             string
 	BuilderImage        string
+	BuilderDetected     bool
 	DownloadImage       string
 	UnpackImage         string
 	Environment         models.EnvVariableList
@@ -71,6 +72,8 @@ type stageParam struct {
 	GroupID             int64
 	Scripts             string
 	HelmValues          HelmValuesMap // Helm Values configuring the staging workload
+	Retries             int32         // Automatic re-attempts of a failed build
+	CachePVCName        string        // Claim holding the build cache, of the app or shared by the namespace
 }
 
 type HelmValuesMap struct {
@@ -106,16 +109,35 @@ func (app *stageParam) ImageURL(registryURL string) string {
 // on the "upload" endpoint). It is also mounted in the staging pod, as the
 // "source" workspace.
 // The same PVC stores the application's build cache (on a separate directory).
-func ensurePVC(ctx context.Context, cluster *kubernetes.Cluster, config StagingStorageValues, pvcName string) error {
-	_, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+// An existing PVC smaller than the configured size is expanded. See growPVC.
+func ensurePVC(ctx context.Context, cluster *kubernetes.Cluster, config StagingStorageValues, pvcName string) apierror.APIErrors {
+	pvc, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.StagingNamespace()).
 		Get(ctx, pvcName, metav1.GetOptions{})
 	if err != nil && !apierrors.IsNotFound(err) { // Unknown error, irrelevant to non-existence
-		return err
+		return apierror.InternalError(err, fmt.Sprintf("failed to get the PersistentVolumeClaim '%s'", pvcName))
 	}
 	if err == nil { // pvc already exists
-		return nil
+		return growPVC(ctx, cluster, pvc, config)
+	}
+
+	err = createPVC(ctx, cluster, config, pvcName)
+	if err != nil {
+		return apierror.InternalError(err, fmt.Sprintf("failed to create the PersistentVolumeClaim '%s'", pvcName))
 	}
 
+	return nil
+}
+
+// createPVC creates the named PVC per the configuration.
+func createPVC(ctx context.Context, cluster *kubernetes.Cluster, config StagingStorageValues, pvcName string) error {
+	_, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.StagingNamespace()).
+		Create(ctx, newPVC(config, pvcName), metav1.CreateOptions{})
+
+	return err
+}
+
+// newPVC returns the named PVC in the staging namespace, per the configuration.
+func newPVC(config StagingStorageValues, pvcName string) *corev1.PersistentVolumeClaim {
 	// Insert a default of last resort. See also note below.
 	if config.Size == "" {
 		config.Size = "1Gi"
@@ -132,7 +154,7 @@ func ensurePVC(ctx context.Context, cluster *kubernetes.Cluster, config StagingS
 	pvcObject := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pvcName,
-			Namespace: helmchart.Namespace(),
+			Namespace: helmchart.StagingNamespace(),
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes: config.AccessModes,
@@ -149,11 +171,146 @@ func ensurePVC(ctx context.Context, cluster *kubernetes.Cluster, config StagingS
 		pvcObject.Spec.StorageClassName = &config.StorageClassName
 	}
 
-	// From here on, only if the PVC is missing
-	_, err = cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
-		Create(ctx, pvcObject, metav1.CreateOptions{})
+	return pvcObject
+}
+
+// pvcNeedsGrowth returns true if the existing PVC requests less storage than the configured
+// size. Volumes are never shrunk, as kubernetes does not support that.
+func pvcNeedsGrowth(pvc *corev1.PersistentVolumeClaim, config StagingStorageValues) (bool, error) {
+	if config.Size == "" {
+		return false, nil
+	}
 
-	return err
+	wanted, err := resource.ParseQuantity(config.Size)
+	if err != nil {
+		return false, err
+	}
+
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	return current.Cmp(wanted) < 0, nil
+}
+
+// growPVC expands the existing PVC to the configured size, if it is smaller. When the cluster
+// refuses the expansion, for example because the storage class does not allow it, the
+// mismatch is reported as a bad request, instead of staging with the smaller volume.
+func growPVC(ctx context.Context, cluster *kubernetes.Cluster, pvc *corev1.PersistentVolumeClaim, config StagingStorageValues) apierror.APIErrors {
+	grow, err := pvcNeedsGrowth(pvc, config)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("invalid staging storage size '%s': %s", config.Size, err.Error())
+	}
+	if !grow {
+		return nil
+	}
+
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+
+	pvc = pvc.DeepCopy()
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse(config.Size)
+
+	_, err = cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.StagingNamespace()).
+		Update(ctx, pvc, metav1.UpdateOptions{})
+	if err != nil {
+		return apierror.NewBadRequestErrorf("staging volume '%s' has size %s, smaller than the requested %s, and cannot be expanded: %s",
+			pvc.Name, current.String(), config.Size, err.Error())
+	}
+
+	return nil
+}
+
+// sharedCache returns true if the cache volume is to be shared by the stagings of all
+// applications in a namespace. This is the case when it is requested as ReadWriteMany.
+func sharedCache(config StagingStorageValues) bool {
+	return slices.Contains(config.AccessModes, corev1.ReadWriteMany)
+}
+
+// pvcMismatch checks that the existing PVC supports the access modes and storage class
+// requested by the configuration. It returns a description of the first difference found,
+// or the empty string if the PVC matches. It is used to validate a shared cache volume
+// before it is reused by a staging.
+func pvcMismatch(pvc *corev1.PersistentVolumeClaim, config StagingStorageValues) string {
+	for _, mode := range config.AccessModes {
+		if !slices.Contains(pvc.Spec.AccessModes, mode) {
+			return fmt.Sprintf("has access modes %v, not supporting the requested %v",
+				pvc.Spec.AccessModes, config.AccessModes)
+		}
+	}
+
+	if config.StorageClassName != "" && pvc.Spec.StorageClassName != nil &&
+		*pvc.Spec.StorageClassName != config.StorageClassName {
+		return fmt.Sprintf("has storage class '%s', not the requested '%s'",
+			*pvc.Spec.StorageClassName, config.StorageClassName)
+	}
+
+	return ""
+}
+
+// ensureSharedPVC creates the shared cache PVC of a namespace if it doesn't already exist. An
+// existing PVC is reused only if it matches the requested configuration.
+func ensureSharedPVC(ctx context.Context, cluster *kubernetes.Cluster, config StagingStorageValues, pvcName string) apierror.APIErrors {
+	pvc, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.StagingNamespace()).
+		Get(ctx, pvcName, metav1.GetOptions{})
+	if err == nil {
+		if mismatch := pvcMismatch(pvc, config); mismatch != "" {
+			return apierror.NewBadRequestErrorf("shared staging cache '%s' %s", pvcName, mismatch)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return apierror.InternalError(err, "failed to get the shared PersistentVolumeClaim for the application cache")
+	}
+
+	// A concurrent staging in the same namespace may have created the PVC in the meantime.
+	err = createPVC(ctx, cluster, config, pvcName)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return apierror.InternalError(err, "failed to ensure a shared PersistentVolumeClaim for the application cache")
+	}
+
+	return nil
+}
+
+// stagingStorage returns the staging volume configuration with the size requested by the
+// application, if any, applied over the defaults of the staging configuration. Invalid
+// requests are reported as bad requests.
+func stagingStorage(config StagingStorageValues, req *models.StagingStorage) (StagingStorageValues, apierror.APIErrors) {
+	if req == nil {
+		return config, nil
+	}
+
+	if req.Size != "" {
+		if _, err := resource.ParseQuantity(req.Size); err != nil {
+			return config, apierror.NewBadRequestErrorf("invalid staging storage size '%s': %s", req.Size, err.Error())
+		}
+		config.Size = req.Size
+	}
+
+	return config, nil
+}
+
+// stagingCacheStorage is stagingStorage for the cache volume, which additionally applies the
+// access modes requested by the application. The access modes are scoped to the cache of the
+// application itself. Sharing the cache across the namespace (ReadWriteMany) is a choice of
+// the operator, made in the staging configuration, and cannot be requested per application.
+func stagingCacheStorage(config StagingStorageValues, req *models.StagingStorage) (StagingStorageValues, apierror.APIErrors) {
+	config, apiErr := stagingStorage(config, req)
+	if apiErr != nil || req == nil || len(req.AccessModes) == 0 {
+		return config, apiErr
+	}
+
+	modes := []corev1.PersistentVolumeAccessMode{}
+	for _, mode := range req.AccessModes {
+		accessMode := corev1.PersistentVolumeAccessMode(mode)
+		switch accessMode {
+		case corev1.ReadWriteOnce, corev1.ReadWriteOncePod:
+			modes = append(modes, accessMode)
+		case corev1.ReadOnlyMany, corev1.ReadWriteMany:
+			return config, apierror.NewBadRequestErrorf("staging storage access mode '%s' cannot be requested by an application", mode)
+		default:
+			return config, apierror.NewBadRequestErrorf("invalid staging storage access mode '%s'", mode)
+		}
+	}
+	config.AccessModes = modes
+
+	return config, nil
 }
 
 // Stage handles the API endpoint /namespaces/:namespace/applications/:app/stage
@@ -200,12 +357,32 @@ func Stage(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequestError("staging job for image ID still running")
 	}
 
-	// get builder image from either request, application, or default as final fallback
+	s3ConnectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return apierror.InternalError(err, "failed to fetch the S3 connection details")
+	}
+
+	blobUID, blobErr := getBlobUID(ctx, s3ConnectionDetails, req, app)
+	if blobErr != nil {
+		return blobErr
+	}
+
+	// get builder image from either request, application, the language of the sources, or
+	// default as final fallback
 
 	builderImage, builderErr := getBuilderImage(req, app)
 	if builderErr != nil {
 		return builderErr
 	}
+	var builderSignal string
+	builderDetected := builderImage == ""
+	if builderDetected {
+		builderImage, builderSignal, err = detectLanguageBuilder(ctx, s3ConnectionDetails, blobUID)
+		if err != nil {
+			return apierror.InternalError(err, "failed to detect the language builder")
+		}
+	}
 	if builderImage == "" {
 		builderImage = viper.GetString("default-builder-image")
 	}
@@ -213,13 +390,13 @@ func Stage(c *gin.Context) apierror.APIErrors {
 	// Find staging script spec based on the builder image and what images are supported by each spec
 	// This also resolves a `base` reference, if present.
 
-	config, err := DetermineStagingScripts(ctx, cluster, helmchart.Namespace(), builderImage)
+	config, err := DetermineStagingScripts(ctx, cluster, helmchart.StagingNamespace(), builderImage)
 	if err != nil {
 		return apierror.InternalError(err, "failed to retrieve staging configuration")
 	}
 
 	log.Infow("staging app", "scripts", config.Name)
-	log.Infow("staging app", "builder", builderImage)
+	log.Infow("staging app", "builder", builderImage, "detected-by", builderSignal)
 	log.Infow("staging app", "download", config.DownloadImage)
 	log.Infow("staging app", "unpack", config.UnpackImage)
 	log.Infow("staging app", "userid", config.UserID)
@@ -228,17 +405,6 @@ func Stage(c *gin.Context) apierror.APIErrors {
 	log.Infow("staging app", "Staging Values", config.HelmValues)
 	log.Infow("staging app", "namespace", namespace, "app", req)
 
-	s3ConnectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
-		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
-	if err != nil {
-		return apierror.InternalError(err, "failed to fetch the S3 connection details")
-	}
-
-	blobUID, blobErr := getBlobUID(ctx, s3ConnectionDetails, req, app)
-	if blobErr != nil {
-		return blobErr
-	}
-
 	// Create uid identifying the staging job to be
 
 	uid, err := randstr.Hex16()
@@ -276,7 +442,7 @@ func Stage(c *gin.Context) apierror.APIErrors {
 	registryCertificateSecret := viper.GetString("registry-certificate-secret")
 	registryCertificateHash := ""
 	if registryCertificateSecret != "" {
-		registryCertificateHash, err = getRegistryCertificateHash(ctx, cluster, helmchart.Namespace(), registryCertificateSecret)
+		registryCertificateHash, err = getRegistryCertificateHash(ctx, cluster, helmchart.StagingNamespace(), registryCertificateSecret)
 		if err != nil {
 			return apierror.InternalError(err, "cannot calculate Certificate hash")
 		}
@@ -291,13 +457,25 @@ func Stage(c *gin.Context) apierror.APIErrors {
 		environment[name] = value
 	}
 
+	// The build-time EV override both, for the build only. They are not saved with the app,
+	// and thus never reach the workload.
+	buildEnv, apiErr := buildEnvironment(ctx, cluster, req)
+	if apiErr != nil {
+		return apiErr
+	}
+	for name, value := range buildEnv {
+		environment[name] = value
+	}
+	log.Infow("staging app", "build-time env", len(buildEnv))
+
 	params := stageParam{
 		AppRef:              req.App,
 		BuilderImage:        builderImage,
+		BuilderDetected:     builderDetected,
 		DownloadImage:       config.DownloadImage,
 		UnpackImage:         config.UnpackImage,
 		BlobUID:             blobUID,
-		Environment:         environment.List(),
+		Environment:         environment.DependencyList(),
 		Owner:               owner,
 		RegistryURL:         registryPublicURL,
 		S3ConnectionDetails: s3ConnectionDetails,
@@ -310,31 +488,53 @@ func Stage(c *gin.Context) apierror.APIErrors {
 		GroupID:             config.GroupID,
 		Scripts:             config.Name,
 		HelmValues:          config.HelmValues,
+		Retries:             stagingRetries(),
+	}
+
+	params.HelmValues.Storage.Cache, apiErr = stagingCacheStorage(params.HelmValues.Storage.Cache, req.Storage)
+	if apiErr != nil {
+		return apiErr
+	}
+	params.HelmValues.Storage.SourceBlobs, apiErr = stagingStorage(params.HelmValues.Storage.SourceBlobs, req.Storage)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	params.CachePVCName = req.App.MakeCachePVCName()
+	if sharedCache(params.HelmValues.Storage.Cache) {
+		params.CachePVCName = models.MakeSharedCachePVCName(namespace)
 	}
 
 	if !params.HelmValues.Storage.Cache.EmptyDir {
-		err = ensurePVC(ctx, cluster, params.HelmValues.Storage.Cache, req.App.MakeCachePVCName())
-		if err != nil {
-			return apierror.InternalError(err, "failed to ensure a PersistentVolumeClaim for the application cache")
+		if sharedCache(params.HelmValues.Storage.Cache) {
+			apiErr = ensureSharedPVC(ctx, cluster, params.HelmValues.Storage.Cache, params.CachePVCName)
+			if apiErr != nil {
+				return apiErr
+			}
+		} else {
+			apiErr = ensurePVC(ctx, cluster, params.HelmValues.Storage.Cache, params.CachePVCName)
+			if apiErr != nil {
+				return apiErr
+			}
 		}
 	}
 
 	if !params.HelmValues.Storage.SourceBlobs.EmptyDir {
-		err = ensurePVC(ctx, cluster, params.HelmValues.Storage.SourceBlobs, req.App.MakeSourceBlobsPVCName())
-		if err != nil {
-			return apierror.InternalError(err, "failed to ensure a PersistentVolumeClaim for the application source blobs")
+		apiErr = ensurePVC(ctx, cluster, params.HelmValues.Storage.SourceBlobs, req.App.MakeSourceBlobsPVCName())
+		if apiErr != nil {
+			return apiErr
 		}
 	}
 
 	job, jobenv := newJobRun(params)
 
 	// Note: The secret is deleted with the job in function `Unstage()`.
-	err = cluster.CreateSecret(ctx, helmchart.Namespace(), *jobenv)
+	err = cluster.CreateSecret(ctx, helmchart.StagingNamespace(), *jobenv)
 	if err != nil {
 		return apierror.InternalError(err, fmt.Sprintf("failed to create job env: %#v", jobenv))
 	}
 
-	err = cluster.CreateJob(ctx, helmchart.Namespace(), job)
+	err = cluster.CreateJob(ctx, helmchart.StagingNamespace(), job)
 	if err != nil {
 		return apierror.InternalError(err, fmt.Sprintf("failed to create job run: %#v", job))
 	}
@@ -345,11 +545,14 @@ func Stage(c *gin.Context) apierror.APIErrors {
 
 	imageURL := params.ImageURL(params.RegistryURL)
 
-	log.Infow("staged app", "namespace", helmchart.Namespace(), "app", params.AppRef, "uid", uid, "image", imageURL)
+	log.Infow("staged app", "namespace", helmchart.StagingNamespace(), "app", params.AppRef, "uid", uid, "image", imageURL,
+		"retries", params.Retries)
 
 	response.OKReturn(c, models.StageResponse{
-		Stage:    models.NewStage(uid),
-		ImageURL: imageURL,
+		Stage:         models.NewStage(uid),
+		ImageURL:      imageURL,
+		BuilderImage:  builderImage,
+		BuilderSignal: builderSignal,
 	})
 	return nil
 }
@@ -359,7 +562,7 @@ func stageJobs(ctx context.Context, cluster *kubernetes.Cluster, namespace, stag
 	selector := fmt.Sprintf("app.kubernetes.io/component=staging,app.kubernetes.io/part-of=%s,epinio.io/stage-id=%s",
 		namespace, stageID)
 
-	jobList, err := cluster.ListJobs(ctx, helmchart.Namespace(), selector)
+	jobList, err := cluster.ListJobs(ctx, helmchart.StagingNamespace(), selector)
 	if err != nil {
 		return nil, apierror.InternalError(err)
 	}
@@ -370,15 +573,38 @@ func stageJobs(ctx context.Context, cluster *kubernetes.Cluster, namespace, stag
 	return jobList.Items, nil
 }
 
+// stagingRetries returns the configured number of automatic re-attempts of a failed build.
+func stagingRetries() int32 {
+	retries := viper.GetInt("staging-retries")
+	if retries < 0 {
+		return 0
+	}
+	return int32(retries)
+}
+
+// stageAttempts returns the number of builds run so far for the staging jobs, and the number of
+// automatic re-attempts they are allowed. Each build is a pod of a job, run anew by kubernetes
+// after a failure, until the backoff limit of the job is exhausted.
+func stageAttempts(jobs []batchv1.Job) (int32, int32) {
+	var attempts, retries int32
+	for _, job := range jobs {
+		attempts += job.Status.Failed + job.Status.Succeeded + job.Status.Active
+		if job.Spec.BackoffLimit != nil {
+			retries += *job.Spec.BackoffLimit
+		}
+	}
+	return attempts, retries
+}
+
 // waitForStagingCompletion waits until all provided jobs finish and reports success or failure.
 // It returns (true, nil) on success, (false, nil) on job failure, or (false, err) on unexpected errors.
 func waitForStagingCompletion(ctx context.Context, cluster *kubernetes.Cluster, jobs []batchv1.Job) (bool, error) {
 	for _, job := range jobs {
-		if err := cluster.WaitForJobDone(ctx, helmchart.Namespace(), job.Name, duration.ToAppBuilt()); err != nil {
+		if err := cluster.WaitForJobDone(ctx, helmchart.StagingNamespace(), job.Name, duration.ToAppBuilt()); err != nil {
 			return false, err
 		}
 
-		failed, err := cluster.IsJobFailed(ctx, job.Name, helmchart.Namespace())
+		failed, err := cluster.IsJobFailed(ctx, job.Name, helmchart.StagingNamespace())
 		if err != nil {
 			return false, err
 		}
@@ -453,12 +679,24 @@ func Staged(c *gin.Context) apierror.APIErrors {
 	if err != nil {
 		return apierror.InternalError(err)
 	}
+
+	// Refresh the jobs, for the attempts made while waiting
+	jobs, apiErr = stageJobs(ctx, cluster, namespace, id)
+	if apiErr != nil {
+		return apiErr
+	}
+	attempts, retries := stageAttempts(jobs)
+
 	if !success {
 		return apierror.NewInternalError("Failed to stage",
-			fmt.Sprintf("stage-id = %s", id))
+			fmt.Sprintf("stage-id = %s, attempts = %d, retries = %d", id, attempts, retries))
 	}
 
-	response.OK(c)
+	response.OKReturn(c, models.StagingCompleteResponse{
+		Response: models.ResponseOK,
+		Attempts: attempts,
+		Retries:  retries,
+	})
 	return nil
 }
 
@@ -495,12 +733,20 @@ func StagedWebsocket(c *gin.Context) {
 	}()
 
 	sendUpdate := func(status, message string, completed bool) error {
+		// Refresh the jobs, for the attempts made so far. On failure the last known state is used.
+		if current, apiErr := stageJobs(ctx, cluster, namespace, stageID); apiErr == nil {
+			jobs = current
+		}
+		attempts, retries := stageAttempts(jobs)
+
 		payload := models.StageCompleteEvent{
 			StageID:   stageID,
 			Namespace: namespace,
 			Status:    status,
 			Message:   message,
 			Completed: completed,
+			Attempts:  attempts,
+			Retries:   retries,
 		}
 
 		data, marshalErr := json.Marshal(payload)
@@ -643,7 +889,9 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 	unpackScript := fmt.Sprintf(`source /stage-support/%s`, helmchart.EpinioStageUnpack)
 
 	// runtime: app.BuilderImage
-	buildpackScript := fmt.Sprintf(`source /stage-support/%s`, helmchart.EpinioStageBuild)
+	// The size of the build cache is reported before and after the build, for the cache stats.
+	buildpackScript := fmt.Sprintf(`%s
+source /stage-support/%s`, cacheSizeScript, helmchart.EpinioStageBuild)
 
 	// build configuration
 	// - shared between all the phases, even if each phase uses only part of the set
@@ -688,7 +936,7 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 	// Cache Volume
 	CacheVolumeSource := corev1.VolumeSource{
 		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-			ClaimName: app.MakeCachePVCName(),
+			ClaimName: app.CachePVCName,
 			ReadOnly:  false,
 		},
 	}
@@ -808,7 +1056,7 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 			},
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit:            ptr.To[int32](0),
+			BackoffLimit:            ptr.To(app.Retries),
 			TTLSecondsAfterFinished: &app.HelmValues.TTLSecondsAfterFinished,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -943,9 +1191,11 @@ func getRegistryCertificateHash(ctx context.Context, c *kubernetes.Cluster, name
 	return hash, nil
 }
 
-// getBuilderImage returns the builder image defined on the request. If that
-// one is not defined, it tries to find the builder image previously used on the
-// Application CR. If one is not found, it returns an error.
+// getBuilderImage returns the builder image defined on the request. If that one is not defined,
+// it returns the builder image previously chosen for the Application CR by the user. It returns
+// the empty string if there is no such choice, leaving the builder to detection. Applications
+// staged before the recording of the choice have the default builder image recorded when nothing
+// was chosen. This is not treated as a choice.
 func getBuilderImage(req models.StageRequest, app *unstructured.Unstructured) (string, apierror.APIErrors) {
 	var returnErr apierror.APIErrors
 
@@ -959,6 +1209,11 @@ func getBuilderImage(req models.StageRequest, app *unstructured.Unstructured) (s
 		return "", returnErr
 	}
 
+	detected, found := app.GetAnnotations()[builderDetectedAnnotation]
+	if detected == "true" || (!found && builderImage == viper.GetString("default-builder-image")) {
+		return "", nil
+	}
+
 	return builderImage, nil
 }
 
@@ -1010,6 +1265,12 @@ func updateApp(ctx context.Context, cluster *kubernetes.Cluster, app *unstructur
 	if err := unstructured.SetNestedField(app.Object, params.BuilderImage, "spec", "builderimage"); err != nil {
 		return err
 	}
+	annotations := app.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[builderDetectedAnnotation] = strconv.FormatBool(params.BuilderDetected)
+	app.SetAnnotations(annotations)
 
 	client, err := cluster.ClientApp()
 	if err != nil {
@@ -1189,7 +1450,7 @@ func StagingScriptConfigResolve(ctx context.Context, cluster *kubernetes.Cluster
 	logger := helpers.Logger.With("component", "staging-scripts")
 	logger.Infow("locate staging scripts - inherit", "base", config.Base)
 
-	base, err := cluster.GetConfigMap(ctx, helmchart.Namespace(), config.Base)
+	base, err := cluster.GetConfigMap(ctx, helmchart.StagingNamespace(), config.Base)
 	if err != nil {
 		return apierror.InternalError(err, "failed to retrieve staging base")
 	}
@@ -1,10 +1,17 @@
 package application
 
 import (
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/spf13/viper"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 )
 
 func TestJobDoneStateSuccess(t *testing.T) {
@@ -45,3 +52,239 @@ func TestJobDoneStatePending(t *testing.T) {
 		t.Fatalf("expected done=false, success=false got done=%v success=%v", done, success)
 	}
 }
+
+func TestStageAttempts(t *testing.T) {
+	job := batchv1.Job{
+		Spec:   batchv1.JobSpec{BackoffLimit: ptr.To[int32](3)},
+		Status: batchv1.JobStatus{Failed: 2, Active: 1},
+	}
+
+	attempts, retries := stageAttempts([]batchv1.Job{job})
+	if attempts != 3 || retries != 3 {
+		t.Fatalf("expected attempts=3, retries=3 got attempts=%d retries=%d", attempts, retries)
+	}
+}
+
+func TestStagingRetries(t *testing.T) {
+	defer viper.Set("staging-retries", 0)
+
+	viper.Set("staging-retries", 2)
+	if retries := stagingRetries(); retries != 2 {
+		t.Fatalf("expected 2 retries, got %d", retries)
+	}
+
+	viper.Set("staging-retries", -1)
+	if retries := stagingRetries(); retries != 0 {
+		t.Fatalf("expected negative retries to be ignored, got %d", retries)
+	}
+}
+
+func TestStagingStorageDefaults(t *testing.T) {
+	config := StagingStorageValues{Size: "2Gi", StorageClassName: "fast"}
+
+	result, apiErr := stagingStorage(config, nil)
+	if apiErr != nil {
+		t.Fatalf("unexpected error: %v", apiErr)
+	}
+	if result.Size != "2Gi" || len(result.AccessModes) != 0 || result.StorageClassName != "fast" {
+		t.Fatalf("expected configuration unchanged, got %#v", result)
+	}
+
+	result, apiErr = stagingStorage(config, &models.StagingStorage{})
+	if apiErr != nil {
+		t.Fatalf("unexpected error: %v", apiErr)
+	}
+	if result.Size != "2Gi" {
+		t.Fatalf("expected default size to be kept, got %q", result.Size)
+	}
+}
+
+func TestStagingStorageOverride(t *testing.T) {
+	config := StagingStorageValues{StorageClassName: "fast"}
+	req := &models.StagingStorage{
+		Size:        "5Gi",
+		AccessModes: []string{"ReadWriteOncePod"},
+	}
+
+	result, apiErr := stagingCacheStorage(config, req)
+	if apiErr != nil {
+		t.Fatalf("unexpected error: %v", apiErr)
+	}
+	if result.Size != "5Gi" {
+		t.Fatalf("expected size 5Gi, got %q", result.Size)
+	}
+	if len(result.AccessModes) != 1 || result.AccessModes[0] != corev1.ReadWriteOncePod {
+		t.Fatalf("expected access modes [ReadWriteOncePod], got %v", result.AccessModes)
+	}
+	if result.StorageClassName != "fast" {
+		t.Fatalf("expected storage class to be kept, got %q", result.StorageClassName)
+	}
+
+	// The source blobs take the size, not the access modes
+	result, apiErr = stagingStorage(config, req)
+	if apiErr != nil {
+		t.Fatalf("unexpected error: %v", apiErr)
+	}
+	if result.Size != "5Gi" || len(result.AccessModes) != 0 {
+		t.Fatalf("expected size 5Gi and default access modes, got %#v", result)
+	}
+}
+
+func TestStagingStorageInvalid(t *testing.T) {
+	_, apiErr := stagingStorage(StagingStorageValues{}, &models.StagingStorage{Size: "lots"})
+	if apiErr == nil || apiErr.FirstStatus() != http.StatusBadRequest {
+		t.Fatalf("expected bad request for invalid size, got %v", apiErr)
+	}
+
+	_, apiErr = stagingCacheStorage(StagingStorageValues{}, &models.StagingStorage{AccessModes: []string{"ReadWriteSometimes"}})
+	if apiErr == nil || apiErr.FirstStatus() != http.StatusBadRequest {
+		t.Fatalf("expected bad request for invalid access mode, got %v", apiErr)
+	}
+}
+
+func TestStagingStorageSharedRejected(t *testing.T) {
+	for _, mode := range []string{"ReadWriteMany", "ReadOnlyMany"} {
+		_, apiErr := stagingCacheStorage(StagingStorageValues{}, &models.StagingStorage{AccessModes: []string{mode}})
+		if apiErr == nil || apiErr.FirstStatus() != http.StatusBadRequest {
+			t.Fatalf("expected bad request for access mode %s, got %v", mode, apiErr)
+		}
+	}
+}
+
+func TestNewPVCStagingNamespace(t *testing.T) {
+	viper.Set("namespace", "epinio")
+	defer viper.Set("namespace", "")
+
+	pvc := newPVC(StagingStorageValues{}, "cache")
+	if pvc.Namespace != "epinio" {
+		t.Fatalf("expected PVC in the epinio namespace, got %q", pvc.Namespace)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "1Gi" {
+		t.Fatalf("expected default size 1Gi, got %s", size.String())
+	}
+	if len(pvc.Spec.AccessModes) != 1 || pvc.Spec.AccessModes[0] != corev1.ReadWriteOnce {
+		t.Fatalf("expected default access modes [ReadWriteOnce], got %v", pvc.Spec.AccessModes)
+	}
+
+	viper.Set("staging-namespace", "staging")
+	defer viper.Set("staging-namespace", "")
+
+	pvc = newPVC(StagingStorageValues{Size: "3Gi", StorageClassName: "fast"}, "cache")
+	if pvc.Namespace != "staging" {
+		t.Fatalf("expected PVC in the staging namespace, got %q", pvc.Namespace)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "fast" {
+		t.Fatalf("expected storage class fast, got %v", pvc.Spec.StorageClassName)
+	}
+}
+
+func TestPVCNeedsGrowth(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("2Gi"),
+				},
+			},
+		},
+	}
+
+	for size, expected := range map[string]bool{
+		"":       false,
+		"1Gi":    false,
+		"2Gi":    false,
+		"2048Mi": false,
+		"3Gi":    true,
+		"5000Mi": true,
+	} {
+		grow, err := pvcNeedsGrowth(pvc, StagingStorageValues{Size: size})
+		if err != nil {
+			t.Fatalf("unexpected error for size %q: %v", size, err)
+		}
+		if grow != expected {
+			t.Fatalf("size %q: expected growth %v, got %v", size, expected, grow)
+		}
+	}
+
+	if _, err := pvcNeedsGrowth(pvc, StagingStorageValues{Size: "lots"}); err == nil {
+		t.Fatalf("expected error for invalid size")
+	}
+}
+
+func TestSharedCache(t *testing.T) {
+	if sharedCache(StagingStorageValues{}) {
+		t.Fatalf("expected default cache to be per application")
+	}
+	if sharedCache(StagingStorageValues{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}}) {
+		t.Fatalf("expected ReadWriteOnce cache to be per application")
+	}
+	if !sharedCache(StagingStorageValues{AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}}) {
+		t.Fatalf("expected ReadWriteMany cache to be shared")
+	}
+}
+
+func TestPVCMismatch(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			StorageClassName: ptr.To("nfs"),
+		},
+	}
+
+	rwx := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	if mismatch := pvcMismatch(pvc, StagingStorageValues{AccessModes: rwx}); mismatch != "" {
+		t.Fatalf("expected match without storage class, got %q", mismatch)
+	}
+	if mismatch := pvcMismatch(pvc, StagingStorageValues{AccessModes: rwx, StorageClassName: "nfs"}); mismatch != "" {
+		t.Fatalf("expected match with same storage class, got %q", mismatch)
+	}
+
+	mismatch := pvcMismatch(pvc, StagingStorageValues{AccessModes: rwx, StorageClassName: "local"})
+	if !strings.Contains(mismatch, "storage class 'nfs'") || !strings.Contains(mismatch, "'local'") {
+		t.Fatalf("expected storage class mismatch, got %q", mismatch)
+	}
+
+	pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	mismatch = pvcMismatch(pvc, StagingStorageValues{AccessModes: rwx})
+	if !strings.Contains(mismatch, "access modes [ReadWriteOnce]") || !strings.Contains(mismatch, "[ReadWriteMany]") {
+		t.Fatalf("expected access mode mismatch, got %q", mismatch)
+	}
+}
+
+func TestGetBuilderImage(t *testing.T) {
+	viper.Set("default-builder-image", "default-builder")
+	defer viper.Set("default-builder-image", "")
+
+	app := func(builderImage string, annotations map[string]string) *unstructured.Unstructured {
+		app := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"builderimage": builderImage},
+		}}
+		app.SetAnnotations(annotations)
+		return app
+	}
+	detected := map[string]string{builderDetectedAnnotation: "true"}
+	chosen := map[string]string{builderDetectedAnnotation: "false"}
+
+	for _, tc := range []struct {
+		name     string
+		request  string
+		app      *unstructured.Unstructured
+		expected string
+	}{
+		{"request wins", "requested", app("chosen", chosen), "requested"},
+		{"previous choice", "", app("chosen", chosen), "chosen"},
+		{"previous choice of the default", "", app("default-builder", chosen), "default-builder"},
+		{"previously detected", "", app("go-builder", detected), ""},
+		{"never staged", "", app("", nil), ""},
+		{"legacy choice", "", app("chosen", nil), "chosen"},
+		{"legacy default", "", app("default-builder", nil), ""},
+	} {
+		builderImage, errs := getBuilderImage(models.StageRequest{BuilderImage: tc.request}, tc.app)
+		if errs != nil {
+			t.Fatalf("%s: unexpected error %v", tc.name, errs)
+		}
+		if builderImage != tc.expected {
+			t.Errorf("%s: expected builder %q, got %q", tc.name, tc.expected, builderImage)
+		}
+	}
+}
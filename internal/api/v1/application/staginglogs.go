@@ -0,0 +1,150 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//	http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/helmchart"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// StagingLogsFollow handles the API endpoint GET /namespaces/:namespace/applications/:app/staging/logs
+// It streams the logs of the active staging run of the application over a websocket, until
+// the staging is done. A failed staging is signaled by a log line carrying
+// models.PushLogsFailedMarker before the stream ends. Without an active staging the request
+// is answered with a 404.
+//
+// The container filter query parameters are supported, as for the regular logs.
+func StagingLogsFollow(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+	if app == nil {
+		response.Error(c, apierror.AppIsNotKnown(appName))
+		return
+	}
+
+	jobs, err := appStagingJobs(ctx, cluster, namespace, appName)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	stageID, activeJobs := activeStaging(jobs)
+	if stageID == "" {
+		response.Error(c, apierror.NewAPIError(
+			fmt.Sprintf("application '%s' is not staging", appName), http.StatusNotFound))
+		return
+	}
+
+	logParams, err := ParseLogParameters("", "", "",
+		c.Query("include_containers"), c.Query("exclude_containers"))
+	if err != nil {
+		response.Error(c, apierror.NewBadRequestError(err.Error()))
+		return
+	}
+	logParams.Follow = true
+
+	if err := validateContainerFilterPatterns(logParams); err != nil {
+		response.Error(c, apierror.NewBadRequestError(err.Error()))
+		return
+	}
+
+	upgrader := newUpgrader()
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logChan := make(chan tailer.ContainerLogLine)
+	go func() {
+		defer close(logChan)
+
+		success, _, err := followStagingLogs(streamCtx, logChan, clusterPushLogSources(cluster, namespace, activeJobs), stageID, logParams)
+		if streamCtx.Err() != nil {
+			return
+		}
+		if err != nil || !success {
+			if err != nil {
+				helpers.Logger.Errorw("staging completion watcher failed", "error", err)
+			}
+			logChan <- tailer.ContainerLogLine{Message: models.PushLogsFailedMarker}
+		}
+	}()
+
+	writeLogStream(streamCtx, cancel, conn, logChan)
+}
+
+// appStagingJobs returns the staging jobs of the named application.
+func appStagingJobs(ctx context.Context, cluster *kubernetes.Cluster, namespace, appName string) ([]batchv1.Job, error) {
+	selector := fmt.Sprintf("app.kubernetes.io/component=staging,app.kubernetes.io/part-of=%s,app.kubernetes.io/name=%s",
+		namespace, appName)
+
+	jobList, err := cluster.ListJobs(ctx, helmchart.StagingNamespace(), selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobList.Items, nil
+}
+
+// activeStaging returns the stage id of the staging run still in progress among the jobs, and
+// the jobs of that run. The stage id is empty if no staging is in progress.
+func activeStaging(jobs []batchv1.Job) (string, []batchv1.Job) {
+	stageID := ""
+	for _, job := range jobs {
+		if done, _ := jobDoneState([]batchv1.Job{job}); !done {
+			stageID = job.GetLabels()[models.EpinioStageIDLabel]
+			break
+		}
+	}
+	if stageID == "" {
+		return "", nil
+	}
+
+	active := []batchv1.Job{}
+	for _, job := range jobs {
+		if job.GetLabels()[models.EpinioStageIDLabel] == stageID {
+			active = append(active, job)
+		}
+	}
+
+	return stageID, active
+}
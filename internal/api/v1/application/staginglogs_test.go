@@ -0,0 +1,59 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func stagingJob(name, stageID string, conditions ...batchv1.JobConditionType) batchv1.Job {
+	job := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{models.EpinioStageIDLabel: stageID},
+		},
+	}
+	for _, condition := range conditions {
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type:   condition,
+			Status: corev1.ConditionTrue,
+		})
+	}
+	return job
+}
+
+func TestActiveStagingNone(t *testing.T) {
+	jobs := []batchv1.Job{
+		stagingJob("a", "old", batchv1.JobComplete),
+		stagingJob("b", "older", batchv1.JobFailed),
+	}
+
+	stageID, active := activeStaging(jobs)
+	if stageID != "" || active != nil {
+		t.Fatalf("expected no active staging, got %q with %d jobs", stageID, len(active))
+	}
+
+	stageID, _ = activeStaging(nil)
+	if stageID != "" {
+		t.Fatalf("expected no active staging without jobs, got %q", stageID)
+	}
+}
+
+func TestActiveStaging(t *testing.T) {
+	jobs := []batchv1.Job{
+		stagingJob("a", "old", batchv1.JobComplete),
+		stagingJob("b", "new"),
+		stagingJob("c", "new", batchv1.JobFailed),
+	}
+
+	stageID, active := activeStaging(jobs)
+	if stageID != "new" {
+		t.Fatalf("expected stage id new, got %q", stageID)
+	}
+	if len(active) != 2 || active[0].Name != "b" || active[1].Name != "c" {
+		t.Fatalf("expected the jobs of stage new, got %v", active)
+	}
+}
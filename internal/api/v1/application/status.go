@@ -0,0 +1,128 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Statuses handles the API endpoint GET /namespaces/:namespace/appstatuses
+// It returns the compact status of the applications named by the `applications[]` query
+// parameters, or of all applications matching the label `selector`, in the namespace. Without
+// either all applications of the namespace are reported. Per-replica metrics are only gathered
+// and returned for `metrics=true`. They are queried per application, with the concurrency and
+// per-application timeout configured for the server. Applications whose metrics could not be
+// queried in time report this in their `metricsError`.
+func Statuses(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appNames, _ := c.GetQueryArray("applications[]")
+	withMetrics := c.Query("metrics") == "true"
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Broken applications are reported with an error status, not failing the request. The
+	// metrics are added below, for the selected applications only.
+	apps, warnings, err := application.ListWithWarnings(ctx, cluster, namespace, application.ListOptions{
+		Selector:    c.Query("selector"),
+		SkipMetrics: true,
+	})
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	selected := apps
+	if len(appNames) > 0 {
+		byName := make(map[string]models.App, len(apps))
+		for _, app := range apps {
+			byName[app.Meta.Name] = app
+		}
+
+		selected = models.AppList{}
+		for _, appName := range appNames {
+			app, found := byName[appName]
+			if !found {
+				return apierror.AppIsNotKnown(appName)
+			}
+			selected = append(selected, app)
+		}
+	}
+
+	failures := make([]error, len(selected))
+	if withMetrics {
+		failures = application.AddMetrics(ctx, cluster, selected, application.MetricsOptionsFromSettings())
+		warnings = append(warnings, application.MetricsWarnings(selected, failures)...)
+	}
+
+	statuses := models.AppStatusList{}
+	for index, app := range selected {
+		status := appStatus(app, withMetrics)
+		if failures[index] != nil {
+			status.MetricsError = failures[index].Error()
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, warning := range warnings {
+		helpers.Logger.Infow("application statuses incomplete", "warning", warning)
+	}
+
+	response.OKReturn(c, statuses)
+	return nil
+}
+
+// appStatus reduces the full application to its compact status.
+func appStatus(app models.App, withMetrics bool) models.AppStatus {
+	status := models.AppStatus{
+		Name:   app.Meta.Name,
+		Status: app.Status,
+	}
+
+	if app.Configuration.Instances != nil {
+		status.DesiredReplicas = *app.Configuration.Instances
+	}
+
+	if app.Workload == nil {
+		return status
+	}
+
+	status.DesiredReplicas = app.Workload.DesiredReplicas
+	status.ReadyReplicas = app.Workload.ReadyReplicas
+
+	// The routes are ready when all desired routes are actually present.
+	actual := make(map[string]struct{}, len(app.Workload.Routes))
+	for _, route := range app.Workload.Routes {
+		actual[route] = struct{}{}
+	}
+	status.RoutesReady = true
+	for _, route := range app.Configuration.Routes {
+		if _, found := actual[route]; !found {
+			status.RoutesReady = false
+			break
+		}
+	}
+
+	if withMetrics {
+		status.Replicas = app.Workload.Replicas
+	}
+
+	return status
+}
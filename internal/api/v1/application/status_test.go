@@ -0,0 +1,58 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestAppStatus(t *testing.T) {
+	instances := int32(2)
+
+	t.Run("app without workload", func(t *testing.T) {
+		app := *models.NewApp("web", "workspace")
+		app.Status = models.ApplicationCreated
+		app.Configuration.Instances = &instances
+
+		status := appStatus(app, false)
+		if status.Name != "web" || status.Status != models.ApplicationCreated {
+			t.Fatalf("unexpected status %+v", status)
+		}
+		if status.DesiredReplicas != 2 || status.ReadyReplicas != 0 || status.RoutesReady {
+			t.Fatalf("unexpected replica information %+v", status)
+		}
+	})
+
+	t.Run("running app with missing route", func(t *testing.T) {
+		app := *models.NewApp("web", "workspace")
+		app.Status = models.ApplicationRunning
+		app.Configuration.Routes = []string{"web.example.com", "www.example.com"}
+		app.Workload = &models.AppDeployment{
+			DesiredReplicas: 2,
+			ReadyReplicas:   1,
+			Routes:          []string{"web.example.com"},
+			Replicas:        map[string]*models.PodInfo{"web-1": {Name: "web-1"}},
+		}
+
+		status := appStatus(app, false)
+		if status.RoutesReady {
+			t.Fatalf("expected routes not to be ready")
+		}
+		if status.ReadyReplicas != 1 || status.DesiredReplicas != 2 {
+			t.Fatalf("unexpected replica information %+v", status)
+		}
+		if status.Replicas != nil {
+			t.Fatalf("expected no replicas without metrics")
+		}
+
+		app.Workload.Routes = append(app.Workload.Routes, "www.example.com")
+
+		status = appStatus(app, true)
+		if !status.RoutesReady {
+			t.Fatalf("expected routes to be ready")
+		}
+		if len(status.Replicas) != 1 {
+			t.Fatalf("expected replicas with metrics, got %v", status.Replicas)
+		}
+	})
+}
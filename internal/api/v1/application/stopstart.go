@@ -0,0 +1,124 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// Stop handles the API endpoint POST /namespaces/:namespace/applications/:app/stop
+// It scales the application to zero instances, remembering the desired number of instances
+// for Start. Configuration and routes are kept. Stopping a stopped application is a conflict.
+func Stop(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	username := requestctx.User(ctx).Username
+	log := helpers.Logger.With("namespace", namespace, "app", appName)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	previous, err := application.Stop(ctx, cluster, app.Meta)
+	if err != nil {
+		if errors.Is(err, application.ErrAppStopped) {
+			return apierror.NewAPIError(fmt.Sprintf("application '%s' is already stopped", appName), http.StatusConflict)
+		}
+		return apierror.InternalError(err)
+	}
+
+	log.Infow("stopped app", "instances", previous)
+
+	if app.Workload != nil {
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "")
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	response.OKReturn(c, models.AppStateResponse{
+		Stopped:          true,
+		Instances:        0,
+		StoppedInstances: previous,
+	})
+	return nil
+}
+
+// Start handles the API endpoint POST /namespaces/:namespace/applications/:app/start
+// It restores the desired number of instances of an application stopped by Stop. Starting an
+// application which is not stopped is a conflict.
+func Start(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	username := requestctx.User(ctx).Username
+	log := helpers.Logger.With("namespace", namespace, "app", appName)
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	restored, err := application.Start(ctx, cluster, app.Meta)
+	if err != nil {
+		if errors.Is(err, application.ErrAppNotStopped) {
+			return apierror.NewAPIError(fmt.Sprintf("application '%s' is not stopped", appName), http.StatusConflict)
+		}
+		return apierror.InternalError(err)
+	}
+
+	log.Infow("started app", "instances", restored)
+
+	// An application which was never deployed has nothing to bring up yet.
+	if app.ImageURL != "" {
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "")
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	response.OKReturn(c, models.AppStateResponse{
+		Stopped:   false,
+		Instances: restored,
+	})
+	return nil
+}
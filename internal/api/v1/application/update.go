@@ -76,6 +76,53 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 		return apierror.NewBadRequestError("instances param should be integer equal or greater than zero")
 	}
 
+	if updateRequest.Placement != nil {
+		err = application.ValidatePlacement(*updateRequest.Placement)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if updateRequest.Rollout != nil {
+		err = application.ValidateRollout(*updateRequest.Rollout)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if updateRequest.Process != nil {
+		err = application.ValidateProcess(*updateRequest.Process)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if updateRequest.ProcessTypes != nil {
+		err = application.ValidateProcessTypes(updateRequest.ProcessTypes)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+
+		err = application.ProcessTypeConflict(ctx, cluster, appRef, updateRequest.ProcessTypes)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if updateRequest.IngressClass != nil {
+		err = application.ValidateIngressClass(ctx, cluster, *updateRequest.IngressClass)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	if updateRequest.DNS != nil {
+		err = application.ValidateDNS(*updateRequest.DNS)
+		if err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
 	app, err := application.Lookup(ctx, cluster, namespace, appName)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -89,6 +136,12 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 		len(updateRequest.Settings) == 0 &&
 		updateRequest.Configurations == nil &&
 		updateRequest.Routes == nil &&
+		updateRequest.Placement == nil &&
+		updateRequest.Rollout == nil &&
+		updateRequest.Process == nil &&
+		updateRequest.ProcessTypes == nil &&
+		updateRequest.IngressClass == nil &&
+		updateRequest.DNS == nil &&
 		updateRequest.AppChart == "" {
 
 		log.Infow("updating app -- no changes")
@@ -192,6 +245,72 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 		}
 	}
 
+	// update placement
+	if updateRequest.Placement != nil {
+		log.Infow("updating app", "placement", updateRequest.Placement)
+
+		err := application.PlacementSet(ctx, cluster, appRef, *updateRequest.Placement)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update rolling update settings
+	if updateRequest.Rollout != nil {
+		log.Infow("updating app", "rollout", updateRequest.Rollout)
+
+		err := application.RolloutSet(ctx, cluster, appRef, *updateRequest.Rollout)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update container command and args overrides
+	if updateRequest.Process != nil {
+		log.Infow("updating app", "process", updateRequest.Process)
+
+		err := application.ProcessSet(ctx, cluster, appRef, *updateRequest.Process)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update process types, removing the workloads of dropped types
+	if updateRequest.ProcessTypes != nil {
+		log.Infow("updating app", "process types", updateRequest.ProcessTypes)
+
+		err := application.ProcessTypesSet(ctx, cluster, appRef, updateRequest.ProcessTypes)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		dropped := application.DroppedProcessTypes(app.Configuration.ProcessTypes, updateRequest.ProcessTypes)
+		err = application.ProcessTypesRemove(cluster, appRef, dropped)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update ingress class
+	if updateRequest.IngressClass != nil {
+		log.Infow("updating app", "ingress class", *updateRequest.IngressClass)
+
+		err := application.IngressClassSet(ctx, cluster, appRef, *updateRequest.IngressClass)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	// update dns settings
+	if updateRequest.DNS != nil {
+		log.Infow("updating app", "dns", updateRequest.DNS)
+
+		err := application.DNSSet(ctx, cluster, appRef, *updateRequest.DNS)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
 	// update settings only if chart values have been set, otherwise just leave it as it is.
 	if len(updateRequest.Settings) > 0 {
 		log.Infow("updating app", "settings", updateRequest.Settings)
@@ -205,7 +324,8 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 	// backward compatibility: if no flag provided then restart the app
 	restart := updateRequest.Restart == nil || *updateRequest.Restart
 	if restart {
-		if app.Workload != nil || desired > 0 {
+		// An application which was never deployed has nothing to bring up yet.
+		if app.Workload != nil || (desired > 0 && app.ImageURL != "") {
 			log.Infow("updating app -- restarting")
 
 			_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "")
@@ -77,6 +77,9 @@ func Upload(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequestErrorf("archive type not supported [%s]", contentType)
 	}
 
+	// Record the files selecting a language builder for staging, see builders.go
+	signals := detectArchiveSignals(file, fileheader.Size, contentType, languageSignals(languageBuilders()))
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err, "failed to get access to a kube client")
@@ -92,9 +95,9 @@ func Upload(c *gin.Context) apierror.APIErrors {
 	}
 
 	username := requestctx.User(ctx).Username
-	blobUID, err := manager.UploadStream(ctx, file, fileheader.Size, map[string]string{
+	blobUID, err := manager.UploadStream(ctx, file, fileheader.Size, signalsMeta(map[string]string{
 		"app": name, "namespace": namespace, "username": username,
-	})
+	}, signals))
 	if err != nil {
 		return apierror.InternalError(err, "uploading the application sources blob")
 	}
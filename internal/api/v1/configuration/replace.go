@@ -63,7 +63,7 @@ func Replace(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplific
 		}
 
 		// Perform restart on the candidates which are actually running
-		apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
+		_, apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
 		if apiErr != nil {
 			return apiErr
 		}
@@ -71,7 +71,7 @@ func Update(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplifica
 
 		// Perform restart on the candidates which are actually running
 
-		apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
+		_, apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
 		if apiErr != nil {
 			return apiErr
 		}
@@ -68,7 +68,7 @@ func Create(c *gin.Context) apierror.APIErrors {
 		return apierror.AppIsNotKnown(appName)
 	}
 
-	boundedConfigs, errors := CreateConfigurationBinding(ctx, cluster, namespace, *app, bindRequest.Names)
+	boundedConfigs, errors := CreateConfigurationBinding(ctx, cluster, namespace, *app, bindRequest.Names, true)
 	if errors != nil {
 		return errors
 	}
@@ -82,12 +82,16 @@ func Create(c *gin.Context) apierror.APIErrors {
 	return nil
 }
 
+// CreateConfigurationBinding binds the named configurations to the application, and updates its
+// workload, if any. Without restart the workload is left as is, and the new bindings apply on its
+// next restart or deployment.
 func CreateConfigurationBinding(
 	ctx context.Context,
 	cluster *kubernetes.Cluster,
 	namespace string,
 	app models.App,
 	configurationNames []string,
+	restart bool,
 ) ([]string, apierror.APIErrors) {
 	logger := helpers.Logger.With("component", "CreateConfigurationBinding")
 
@@ -148,8 +152,8 @@ func CreateConfigurationBinding(
 
 		logger.Infow("DeployApp")
 
-		// Update the workload, if there is any.
-		if app.Workload != nil {
+		// Update the workload, if there is any, and updating was not declined.
+		if app.Workload != nil && restart {
 			_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, requestctx.User(ctx).Username, "")
 			if apierr != nil {
 				return nil, apierr
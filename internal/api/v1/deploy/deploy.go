@@ -16,6 +16,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/epinio/epinio/helpers"
@@ -27,6 +28,7 @@ import (
 	"github.com/epinio/epinio/internal/helmchart"
 	"github.com/epinio/epinio/internal/registry"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -69,13 +71,19 @@ func deployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 		return nil, apierror.NewInternalError("cannot deploy app without imageURL")
 	}
 
-	// Iterate over the bound configurations to determine their mount path ...
+	err = application.ProcessTypeConflict(ctx, cluster, app, appObj.Configuration.ProcessTypes)
+	if err != nil {
+		return nil, apierror.NewBadRequestError(err.Error())
+	}
 
-	// (**) See below for explanation
-	sort.Strings(appObj.Configuration.Configurations)
+	apiErr := validateEnvReferences(appObj.Configuration.Environment)
+	if apiErr != nil {
+		return nil, apiErr
+	}
 
-	bound := []helm.ConfigParameter{} // Configurations and their mount paths
-	service := map[string]int{}       // Seen services, and count of their configurations
+	// Determine the origin of the bound configurations, for their mount paths ...
+
+	origins := map[string]string{} // Configurations and the services they originate from, if any
 
 	for _, configName := range appObj.Configuration.Configurations {
 		config, err := configurations.Lookup(ctx, cluster, app.Namespace, configName)
@@ -83,40 +91,25 @@ func deployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 			return nil, apierror.InternalError(err)
 		}
 
-		// Default path is config name itself
-		path := configName
-
-		// For configurations originating in a service, use the service name instead,
-		// possible extended to disambiguate multiple configurations of a single service.
-		if config.Origin != "" {
-			if serial, ok := service[config.Origin]; !ok {
-				path = config.Origin
-				service[config.Origin] = 1
-			} else {
-				// [CS-DISAMBI] With more than one configuration from the same service
-				// disambiguate using a serial number
-				//
-				// Attention! Having sorted the full set of configuration names (see
-				// above (**)), the various configurations of the service will
-				// always have the same serial (or none, for the first).
-
-				serial = serial + 1
-				service[config.Origin] = serial
-				path = fmt.Sprintf("%s-%d", config.Origin, serial)
-			}
-		}
-
-		// Record for passing into the helm core
-		bound = append(bound, helm.ConfigParameter{
-			Name: configName,
-			Path: path,
-		})
+		origins[configName] = config.Origin
 	}
 
+	bound := configurationMounts(appObj.Configuration.Configurations, origins)
+
 	routes := appObj.Configuration.Routes
 	chartName := appObj.Configuration.AppChart
 	domains := domain.MatchMapLoad(ctx, app.Namespace)
 
+	affinity, err := application.PlacementAffinity(app.Name, appObj.Configuration.Placement)
+	if err != nil {
+		return nil, apierror.InternalError(err, "computing placement")
+	}
+
+	dnsPolicy, dnsConfig, err := application.DNSPodConfig(appObj.Configuration.DNS)
+	if err != nil {
+		return nil, apierror.InternalError(err, "computing dns")
+	}
+
 	maplog := log.With("component", "domain-map")
 	maplog.Debugw("domain map begin")
 	for k, v := range domains {
@@ -145,6 +138,16 @@ func deployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 		Domains:        domains,
 		Start:          start,
 		Settings:       appObj.Configuration.Settings,
+		Affinity:       affinity,
+		Rollout:        appObj.Configuration.Rollout,
+		Process:        appObj.Configuration.Process,
+		IngressClass:   appObj.Configuration.IngressClass,
+		DNSPolicy:      dnsPolicy,
+		DNSConfig:      dnsConfig,
+	}
+	if podMetadata := appObj.Configuration.PodMetadata; podMetadata != nil {
+		deployParams.PodAnnotations = podMetadata.Annotations
+		deployParams.PodLabels = podMetadata.Labels
 	}
 
 	log.Infow("deploying app", "namespace", app.Namespace, "app", app.Name)
@@ -159,6 +162,47 @@ func deployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 		return nil, apierror.InternalError(err)
 	}
 
+	// Deploy the additional process types, from the same image, without routes.
+	for _, processType := range appObj.Configuration.ProcessTypes {
+		log.Infow("deploying app process type", "namespace", app.Namespace, "app", app.Name,
+			"process type", processType.Name)
+
+		processParams := deployParams
+		processParams.AppRef = application.ProcessTypeRef(app, processType.Name)
+		processParams.Instances = application.ProcessTypeInstances(processType)
+		processParams.Process = application.ProcessTypeProcess(processType)
+		processParams.Routes = nil
+		processParams.Domains = nil
+
+		// The spread of the web workload does not apply, the process type spreads its own pods
+		processParams.Affinity, err = application.PlacementAffinity(processParams.AppRef.Name,
+			appObj.Configuration.Placement)
+		if err != nil {
+			return nil, apierror.InternalError(err, fmt.Sprintf("computing placement of process type %s", processType.Name))
+		}
+
+		err = helm.Deploy(processParams)
+		if err != nil {
+			return nil, apierror.InternalError(err, fmt.Sprintf("deploying process type %s", processType.Name))
+		}
+	}
+
+	// Record the configuration the application is deployed with, for a restart to see whether
+	// the deployment is still current. A failure to record it only causes the next restart to
+	// redeploy.
+	err = application.DeployedConfigurationSet(ctx, cluster, app, appObj.Configuration)
+	if err != nil {
+		log.Errorw("failed to record deployed configuration", "namespace", app.Namespace, "app", app.Name, "error", err)
+	}
+
+	// Record the deployment in the history of the application. The deployment is done at
+	// this point, a failure to record it is only logged.
+	err = application.HistoryRecord(ctx, cluster, app, application.NewRevision(appObj, username),
+		viper.GetInt("app-history-limit"))
+	if err != nil {
+		log.Errorw("failed to record app history", "namespace", app.Namespace, "app", app.Name, "error", err)
+	}
+
 	// Delete previous staging jobs except for the current one
 	if stageID != "" {
 		log.Infow("app staging drop", "namespace", app.Namespace, "app", app.Name, "stage id", stageID)
@@ -202,6 +246,84 @@ func deployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 //
 // Or a pre-existing image is being deployed (coming from an outer registry, not ours)
 
+// configurationMounts returns the bound configurations with their mount paths, in lexicographic
+// order of the configuration names, regardless of the order they are given in. This keeps the
+// volumes of the deployment stable across deployments.
+func configurationMounts(configNames []string, origins map[string]string) []helm.ConfigParameter {
+	// (**) See below for explanation
+	names := append([]string{}, configNames...)
+	sort.Strings(names)
+
+	bound := []helm.ConfigParameter{} // Configurations and their mount paths
+	service := map[string]int{}       // Seen services, and count of their configurations
+
+	for _, configName := range names {
+		// Default path is config name itself
+		path := configName
+
+		// For configurations originating in a service, use the service name instead,
+		// possible extended to disambiguate multiple configurations of a single service.
+		if origin := origins[configName]; origin != "" {
+			if serial, ok := service[origin]; !ok {
+				path = origin
+				service[origin] = 1
+			} else {
+				// [CS-DISAMBI] With more than one configuration from the same service
+				// disambiguate using a serial number
+				//
+				// Attention! Having sorted the full set of configuration names (see
+				// above (**)), the various configurations of the service will
+				// always have the same serial (or none, for the first).
+
+				serial = serial + 1
+				service[origin] = serial
+				path = fmt.Sprintf("%s-%d", origin, serial)
+			}
+		}
+
+		// Record for passing into the helm core
+		bound = append(bound, helm.ConfigParameter{
+			Name: configName,
+			Path: path,
+		})
+	}
+
+	return bound
+}
+
+// validateEnvReferences checks that all the `$(NAME)` references found in the values of the
+// application's environment variables can be expanded by kubernetes, i.e. refer to other
+// variables of the application, or to the variables the application chart provides. The keys
+// of bound configurations are not variables, they are mounted as files.
+func validateEnvReferences(env models.EnvVariableMap) apierror.APIErrors {
+	issues := []string{}
+	for name, refs := range env.DanglingReferences() {
+		undefined := []string{}
+		for _, ref := range refs {
+			if !chartProvidedEnv(ref) {
+				undefined = append(undefined, ref)
+			}
+		}
+		if len(undefined) > 0 {
+			issues = append(issues, fmt.Sprintf("%s: %s", name, strings.Join(undefined, ", ")))
+		}
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	sort.Strings(issues)
+
+	return apierror.NewBadRequestError("environment variables reference undefined variables").
+		WithDetails(strings.Join(issues, "; "))
+}
+
+// chartProvidedEnv returns true for the names of the variables the application chart sets in
+// the workload, next to those of the application: the `PORT` to listen on, and the `EPINIO_`
+// variables describing the application.
+func chartProvidedEnv(name string) bool {
+	return name == "PORT" || strings.HasPrefix(name, "EPINIO_")
+}
+
 func replaceInternalRegistry(ctx context.Context, cluster *kubernetes.Cluster, imageURL string) (string, error) {
 	registryDetails, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
 	if err != nil {
@@ -0,0 +1,88 @@
+package deploy
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestConfigurationMountsAreSorted(t *testing.T) {
+	origins := map[string]string{
+		"svc-mysql-creds":    "mysql",
+		"svc-mysql-root":     "mysql",
+		"svc-redis-creds":    "redis",
+		"svc-rabbitmq-creds": "rabbitmq",
+		"plain":              "",
+	}
+
+	expected := []helm.ConfigParameter{
+		{Name: "plain", Path: "plain"},
+		{Name: "svc-mysql-creds", Path: "mysql"},
+		{Name: "svc-mysql-root", Path: "mysql-2"},
+		{Name: "svc-rabbitmq-creds", Path: "rabbitmq"},
+		{Name: "svc-redis-creds", Path: "redis"},
+	}
+
+	for _, names := range [][]string{
+		{"svc-redis-creds", "svc-mysql-root", "plain", "svc-rabbitmq-creds", "svc-mysql-creds"},
+		{"svc-mysql-creds", "svc-mysql-root", "svc-rabbitmq-creds", "svc-redis-creds", "plain"},
+		{"plain", "svc-rabbitmq-creds", "svc-redis-creds", "svc-mysql-root", "svc-mysql-creds"},
+	} {
+		input := append([]string{}, names...)
+
+		if got := configurationMounts(names, origins); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("for input %v expected %v, got %v", names, expected, got)
+		}
+		if !reflect.DeepEqual(names, input) {
+			t.Fatalf("expected the input to be left as is, got %v", names)
+		}
+	}
+}
+
+func TestValidateEnvReferences(t *testing.T) {
+	env := models.EnvVariableMap{
+		"URL":  "https://$(HOST):$(PORT)",
+		"HOST": "example.com",
+		"PORT": "8080",
+	}
+	if errs := validateEnvReferences(env); errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	// A key of a bound configuration is a mounted file, not a variable.
+	env["DSN"] = "$(DATABASE_URL)"
+	errs := validateEnvReferences(env)
+	if errs == nil {
+		t.Fatal("expected an error for the dangling reference")
+	}
+	if errs.FirstStatus() != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", errs.FirstStatus())
+	}
+	if details := errs.Errors()[0].Details; details != "DSN: DATABASE_URL" {
+		t.Fatalf("expected the dangling reference in the details, got %q", details)
+	}
+}
+
+func TestValidateEnvReferencesChartProvided(t *testing.T) {
+	env := models.EnvVariableMap{
+		"URL":    "http://localhost:$(PORT)",
+		"SELF":   "$(EPINIO_APP_NAME)",
+		"BROKEN": "$(PORT)/$(MISSING)",
+	}
+
+	errs := validateEnvReferences(env)
+	if errs == nil {
+		t.Fatal("expected an error for the undefined reference")
+	}
+	if details := errs.Errors()[0].Details; details != "BROKEN: MISSING" {
+		t.Fatalf("expected only the undefined reference in the details, got %q", details)
+	}
+
+	delete(env, "BROKEN")
+	if errs := validateEnvReferences(env); errs != nil {
+		t.Fatalf("expected the chart provided variables to be accepted, got %v", errs)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+//go:generate swagger generate spec
+
+// swagger:route GET /apitokens apitoken APITokens
+// Return list of the API tokens of the user. Admins get the tokens of all users.
+// responses:
+//   200: APITokensResponse
+
+// swagger:response APITokensResponse
+type APITokensResponse struct {
+	// in: body
+	Body models.APITokenList
+}
+
+// swagger:route POST /apitokens apitoken APITokenCreate
+// Create the posted new API token, restricted to the posted role and namespace.
+// The token value is returned only by this call.
+// responses:
+//   200: APITokenCreateResponse
+
+// swagger:parameters APITokenCreate
+type APITokenCreateParam struct {
+	// in: body
+	Body models.APITokenCreateRequest
+}
+
+// swagger:response APITokenCreateResponse
+type APITokenCreateResponse struct {
+	// in: body
+	Body models.APITokenCreateResponse
+}
+
+// swagger:route DELETE /apitokens/{Name} apitoken APITokenDelete
+// Revoke the named API token. Admins can revoke the token of another user with `Owner`.
+// responses:
+//   200: APITokenDeleteResponse
+
+// swagger:parameters APITokenDelete
+type APITokenDeleteParam struct {
+	// in: path
+	Name string
+	// in: query
+	Owner string
+}
+
+// swagger:response APITokenDeleteResponse
+type APITokenDeleteResponse struct {
+	// in: body
+	Body models.Response
+}
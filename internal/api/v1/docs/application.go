@@ -16,17 +16,43 @@ package docs
 import "github.com/epinio/epinio/pkg/api/core/v1/models"
 
 // swagger:route GET /applications application AllApps
-// Return list of applications in all namespaces.
+// Return list of applications in all namespaces. Applications which cannot be assembled are
+// listed with an error status. With `warnings=true` the list is returned as an object, together
+// with the warnings about such partial failures.
+// The `status` restricts the list to the `healthy` or `unhealthy` applications, or to the
+// applications in the given status (`created`, `staging`, `running`, `error`).
+// With any of `limit` and `offset` set the response is an `AppListPageResponse` instead, holding
+// the page of the applications, sorted by namespace and name, and their total. Offsets past the
+// end yield an empty page.
 // responses:
 //   200: AppsResponse
 
 // swagger:parameters AllApps
-type AllAppsParam struct{}
+type AllAppsParam struct {
+	// in: query
+	Format string `json:"format"`
+	// in: query
+	Warnings bool `json:"warnings"`
+	// in: query
+	Status string `json:"status"`
+	// in: query
+	Limit int `json:"limit"`
+	// in: query
+	Offset int `json:"offset"`
+}
+
+// swagger:response AppListPageResponse
+type AppListPageResponse struct {
+	// in: body
+	Body models.AppListPageResponse
+}
 
 // response: See Apps.
 
 // swagger:route GET /namespaces/{Namespace}/applications application Apps
-// Return list of applications in the `Namespace`.
+// Return list of applications in the `Namespace`. Applications which cannot be assembled are
+// listed with an error status. With `warnings=true` the list is returned as an object, together
+// with the warnings about such partial failures.
 // responses:
 //   200: AppsResponse
 
@@ -34,6 +60,10 @@ type AllAppsParam struct{}
 type AppsParam struct {
 	// in: path
 	Namespace string
+	// in: query
+	Format string `json:"format"`
+	// in: query
+	Warnings bool `json:"warnings"`
 }
 
 // swagger:response AppsResponse
@@ -63,6 +93,9 @@ type AppCreateResponse struct {
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App} application AppShow
 // Return details of the named `App` in the `Namespace`.
+// The details are returned as YAML for `format=yaml`, or an `Accept: application/yaml` header.
+// With `metrics=false` the per-replica cpu and memory metrics are not gathered. This makes
+// polling for the status and replica counts cheap.
 // responses:
 //   200: AppShowResponse
 
@@ -72,6 +105,10 @@ type AppShowParam struct {
 	Namespace string
 	// in: path
 	App string
+	// in: query
+	Format string `json:"format"`
+	// in: query
+	Metrics bool `json:"metrics"`
 }
 
 // swagger:response AppShowResponse
@@ -80,6 +117,52 @@ type AppShowResponse struct {
 	Body models.App
 }
 
+// swagger:route GET /namespaces/{Namespace}/appstatuses application AppStatuses
+// Return the compact status of the named applications in the `Namespace`, or of all applications
+// matching the label `Selector`. Per-replica metrics are only returned for `metrics=true`.
+// Applications whose metrics could not be queried in time report this in their `metricsError`.
+// responses:
+//   200: AppStatusesResponse
+
+// swagger:parameters AppStatuses
+type AppStatusesParam struct {
+	// in: path
+	Namespace string
+	// in: query
+	Applications []string `json:"applications[]"`
+	// in: query
+	Selector string `json:"selector"`
+	// in: query
+	Metrics string `json:"metrics"`
+}
+
+// swagger:response AppStatusesResponse
+type AppStatusesResponse struct {
+	// in: body
+	Body models.AppStatusList
+}
+
+// swagger:route GET /namespaces/{Namespace}/metrics application AppMetrics
+// Return the CPU, memory, restart, and readiness gauges of the replicas of all applications in
+// the `Namespace`, in the Prometheus text exposition format, for scraping. Replicas without
+// metrics omit the CPU and memory gauges.
+// produces:
+// - text/plain
+// responses:
+//   200: AppMetricsResponse
+
+// swagger:parameters AppMetrics
+type AppMetricsParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response AppMetricsResponse
+type AppMetricsResponse struct {
+	// in: body
+	Body string
+}
+
 // swagger:route GET /namespace/{Namespace}/appsmatches/{Pattern} application AppMatch
 // Return list of names for all applications whose name matches the prefix `Pattern`.
 // responses:
@@ -101,6 +184,8 @@ type AppMatchResponse struct {
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/part/{Part} application AppPart
 // Return parts of the named `App` in the `Namespace`.
+// For the `image` part the query parameter `pullsecret` names a pull secret in the
+// `Namespace`, holding the credentials for the registry of the image.
 // responses:
 //   200: AppPartResponse
 
@@ -112,6 +197,8 @@ type AppPartParam struct {
 	App string
 	// in: path
 	Part string
+	// in: query
+	PullSecret string `json:"pullsecret"`
 }
 
 // swagger:response AppPartResponse
@@ -120,19 +207,110 @@ type AppPartResponse struct {
 	Body []byte
 }
 
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/expiry application AppExpiry
+// Set the time to live of the `App` in the `Namespace`. The app is deleted automatically when it
+// expires. Posting again extends the life of the app. An empty `ttl` removes the expiry.
+// responses:
+//   200: AppExpiryResponse
+
+// swagger:parameters AppExpiry
+type AppExpiryParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Configuration models.ExpiryRequest
+}
+
+// swagger:response AppExpiryResponse
+type AppExpiryResponse struct {
+	// in: body
+	Body models.ExpiryResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/history application AppHistory
+// Return the deploy history of the named `App` in the `Namespace`, oldest revision first. Each
+// revision records when, by whom, and what was deployed, with the configuration at the time.
+// The number of revisions retained per app is configured on the server.
+// responses:
+//   200: AppHistoryResponse
+
+// swagger:parameters AppHistory
+type AppHistoryParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: query
+	Format string `json:"format"`
+}
+
+// swagger:response AppHistoryResponse
+type AppHistoryResponse struct {
+	// in: body
+	Body models.AppHistoryResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/events application AppEvents
+// Return the kubernetes events of the workload of the named `App` in the `Namespace`, i.e. of
+// its deployments, replica sets, and pods, newest first. Each event has its reason, message,
+// count, and the time it was last seen.
+// responses:
+//   200: AppEventsResponse
+
+// swagger:parameters AppEvents
+type AppEventsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppEventsResponse
+type AppEventsResponse struct {
+	// in: body
+	Body models.EventList
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/history/{Revision}/manifest application AppHistoryManifest
+// Return the manifest of the named `App` in the `Namespace` as deployed in the `Revision`, in
+// the same YAML form as the manifest part.
+// responses:
+//   200: AppPartResponse
+
+// swagger:parameters AppHistoryManifest
+type AppHistoryManifestParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: path
+	Revision int
+}
+
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/logs application AppLogs
 // Return logs of the named `App` in the `Namespace` streamed over a websocket.
 // Query parameters:
 //   - follow: Stream logs in real-time (true/false)
-//   - tail: Limit to last N lines from the end (integer)
+//   - tail: Limit to last N lines from the end (integer). Combined with a time filter it
+//     bounds the lines after that filter.
 //   - since: Show logs from duration ago (e.g., "1h", "30m")
 //   - since_time: Show logs since RFC3339 timestamp
+//   - sinceRestart: Show logs since the most recent restart of the app instances (true/false).
+//     Cannot be combined with since or since_time.
+//   - timestamps: Prefix each line with its RFC3339 timestamp (true/false)
+//   - instance: Restrict the logs to the named instance (pod) of the application. Not for staging logs.
+//   - container: Restrict the logs to the named container
 //   - include_containers: Comma-separated list of container names/patterns to include.
 //     Literal container names are automatically escaped. To use regex patterns, include
 //     regex special characters (e.g., "app-.*" to match containers starting with "app-").
 //   - exclude_containers: Comma-separated list of container names/patterns to exclude.
 //     Literal container names are automatically escaped. To use regex patterns, include
 //     regex special characters (e.g., "istio-.*" to match containers starting with "istio-").
+//
+// Lines the client cannot receive fast enough are skipped. The stream then contains a line
+// without pod and container, saying "logs throttled, N lines skipped".
 // responses:
 //   200: AppLogsResponse
 
@@ -151,6 +329,14 @@ type AppLogsParam struct {
 	// in: query
 	SinceTime string `json:"since_time"`
 	// in: query
+	SinceRestart string `json:"sinceRestart"`
+	// in: query
+	Timestamps string `json:"timestamps"`
+	// in: query
+	Instance string `json:"instance"`
+	// in: query
+	Container string `json:"container"`
+	// in: query
 	IncludeContainers string `json:"include_containers"`
 	// in: query
 	ExcludeContainers string `json:"exclude_containers"`
@@ -159,8 +345,49 @@ type AppLogsParam struct {
 // swagger:response AppLogsResponse
 type AppLogsResponse struct{}
 
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/logs/search application AppLogSearch
+// Search the available logs of the named `App` in the `Namespace` for lines matching the
+// regular expression `pattern`, and return them with `context` lines before and after, like
+// `grep -C`. At most `limit` matches are returned (default 100, maximum 1000), `truncated`
+// reports when more lines matched. The logs to search are selected as for AppLogs.
+// responses:
+//   200: AppLogSearchResponse
+
+// swagger:parameters AppLogSearch
+type AppLogSearchParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: query
+	Pattern string `json:"pattern"`
+	// in: query
+	Context int `json:"context"`
+	// in: query
+	Limit int `json:"limit"`
+	// in: query
+	Tail string `json:"tail"`
+	// in: query
+	Since string `json:"since"`
+	// in: query
+	SinceTime string `json:"since_time"`
+	// in: query
+	SinceRestart string `json:"sinceRestart"`
+	// in: query
+	IncludeContainers string `json:"include_containers"`
+	// in: query
+	ExcludeContainers string `json:"exclude_containers"`
+}
+
+// swagger:response AppLogSearchResponse
+type AppLogSearchResponse struct {
+	// in: body
+	Body models.AppLogSearchResponse
+}
+
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/exec application AppExec
-// Get a shell to the `App` in the `Namespace`.
+// Get a shell to the `App` in the `Namespace`. With a `Command` that command is run instead,
+// without a terminal, reporting its exit code on the error stream.
 // responses:
 //   200: AppExecResponse
 
@@ -172,13 +399,34 @@ type AppExecParam struct {
 	App string
 	// in: query
 	Instance string
+	// in: query
+	Command []string
 }
 
 // swagger:response AppExecResponse
 type AppExecResponse struct{}
 
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/debug application AppDebug
+// Attach to a new ephemeral debug container in an instance of the `App` in the `Namespace`.
+// Requires support for ephemeral containers by the cluster.
+// responses:
+//   200: AppDebugResponse
+
+// swagger:parameters AppDebug
+type AppDebugParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: query
+	Instance string
+}
+
+// swagger:response AppDebugResponse
+type AppDebugResponse struct{}
+
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/portforward application AppPortForward
-// Get a shell to the `App` in the `Namespace`.
+// Forward ports to an instance of the `App` in the `Namespace`.
 // responses:
 //   200: AppPortForwardResponse
 
@@ -190,6 +438,13 @@ type AppPortForwardParam struct {
 	App string
 	// in: query
 	Instance string
+	// Name of the container to forward to. Required when a forwarded port is declared by more than one container of the instance.
+	// in: query
+	Container string
+	// Remote ports to forward, e.g. 8080. They have to be declared by the containers of the instance,
+	// if the instance declares any ports.
+	// in: query
+	Port []string
 }
 
 // swagger:response AppPortForwardResponse
@@ -234,8 +489,64 @@ type StagingLogsParam struct {
 // swagger:response StagingLogsResponse
 type StagingLogsResponse struct{}
 
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/pushlogs/{StageID} application AppPushLogs
+// Return the logs of the named `StageID` of the `App` in the `Namespace`, followed by the
+// logs of the `App` itself, streamed over a websocket. The switch from staging to app logs
+// is signaled by a log line with the message `___STAGING_COMPLETE___`. A failed staging is
+// signaled by the message `___STAGING_FAILED___`, and ends the stream.
+// Query parameters:
+//   - include_containers: Comma-separated list of container names/patterns to include.
+//   - exclude_containers: Comma-separated list of container names/patterns to exclude.
+// responses:
+//   200: AppPushLogsResponse
+
+// swagger:parameters AppPushLogs
+type AppPushLogsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: path
+	StageID string
+	// in: query
+	IncludeContainers string `json:"include_containers"`
+	// in: query
+	ExcludeContainers string `json:"exclude_containers"`
+}
+
+// swagger:response AppPushLogsResponse
+type AppPushLogsResponse struct{}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/staging/logs application AppStagingLogs
+// Return the logs of the active staging run of the `App` in the `Namespace`, streamed over a
+// websocket until the staging is done. A failed staging is signaled by a log line with the
+// message `___STAGING_FAILED___`, before the stream ends. Answers with a 404 if the `App` is
+// not staging.
+// Query parameters:
+//   - include_containers: Comma-separated list of container names/patterns to include.
+//   - exclude_containers: Comma-separated list of container names/patterns to exclude.
+// responses:
+//   200: AppStagingLogsResponse
+
+// swagger:parameters AppStagingLogs
+type AppStagingLogsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: query
+	IncludeContainers string `json:"include_containers"`
+	// in: query
+	ExcludeContainers string `json:"exclude_containers"`
+}
+
+// swagger:response AppStagingLogsResponse
+type AppStagingLogsResponse struct{}
+
 // swagger:route GET /namespaces/{Namespace}/staging/{StageID}/complete application StagingComplete
 // Waits for the completion of the staging process identified by `StageID` in the `Namespace`.
+// A failed build is automatically re-attempted, up to the number of retries configured for the
+// server. The response reports the attempts made, and the retries allowed.
 // responses:
 //   200: StagingCompleteResponse
 
@@ -250,7 +561,7 @@ type StagingCompleteParam struct {
 // swagger:response StagingCompleteResponse
 type StagingCompleteResponse struct {
 	// in: body
-	Body models.Response
+	Body models.StagingCompleteResponse
 }
 
 // swagger:route GET /namespaces/{Namespace}/staging/{StageID}/complete websocket StagingCompleteWs
@@ -269,6 +580,24 @@ type StagingCompleteWsParam struct {
 // swagger:response StagingCompleteWsResponse
 type StagingCompleteWsResponse struct{}
 
+// swagger:route GET /namespaces/{Namespace}/staging/{StageID}/cache websocket StagingCacheWs
+// Stream the build cache stats of the staging run `StageID` in the `Namespace` over a websocket.
+// The stats report the cache hits and misses per layer, and the size of the cache before and
+// after the build. They are sent whenever they change, ending with the completed stats.
+// responses:
+//   200: StagingCacheWsResponse
+
+// swagger:parameters StagingCacheWs
+type StagingCacheWsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	StageID string
+}
+
+// swagger:response StagingCacheWsResponse
+type StagingCacheWsResponse struct{}
+
 // swagger:route DELETE /namespaces/{Namespace}/applications AppBatchDelete
 // Delete the named `Applications` in the `Namespace`.
 // responses:
@@ -321,7 +650,9 @@ type AppUploadResponse struct {
 }
 
 // swagger:route POST /namespaces/{Namespace}/applications/{App}/restart application AppRestart
-// Restart the named `App` in the `Namespace`.
+// Restart the named `App` in the `Namespace`, by rolling its pods, and the pods of its process
+// types, without redeploying it.
+// Returns the new desired generation of the application's deployment.
 // responses:
 //   200: AppRestartResponse
 
@@ -336,7 +667,48 @@ type AppRestartParam struct {
 // swagger:response AppRestartResponse
 type AppRestartResponse struct {
 	// in: body
-	Body models.Response
+	Body models.AppRestartResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/stop application AppStop
+// Stop the named `App` in the `Namespace`, scaling it to zero instances. The number of instances
+// is remembered for `AppStart`. Configuration and routes are kept. Stopping a stopped `App` is
+// a conflict.
+// responses:
+//   200: AppStopResponse
+
+// swagger:parameters AppStop
+type AppStopParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppStopResponse
+type AppStopResponse struct {
+	// in: body
+	Body models.AppStateResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/start application AppStart
+// Start the named `App` in the `Namespace`, restoring the number of instances it had when
+// stopped. Starting an `App` which is not stopped is a conflict.
+// responses:
+//   200: AppStartResponse
+
+// swagger:parameters AppStart
+type AppStartParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppStartResponse
+type AppStartResponse struct {
+	// in: body
+	Body models.AppStateResponse
 }
 
 // swagger:route POST /namespaces/{Namespace}/applications/{App}/import-git application AppImportGit
@@ -383,6 +755,9 @@ type AppStageResponse struct {
 
 // swagger:route POST /namespaces/{Namespace}/applications/{App}/deploy application AppDeploy
 // Create the deployment, configuration and ingress resources for the named `App` in the `Namespace`.
+// The custom `annotations` and `labels` are added to the pods of the `App`, now and in all following
+// deployments, until changed by another deployment. Keys `app.kubernetes.io/*` and `epinio.io/*` are
+// reserved, and rejected.
 // responses:
 //   200: AppDeployResponse
 
@@ -74,6 +74,10 @@ type EnvMatch0Params struct {
 
 // swagger:route POST /namespaces/{Namespace}/applications/{App}/environment app-env EnvSet
 // Create/modify the posted environment variable assignments for the `App` in the `Namespace`.
+// Values may reference other variables of the `App`, using the kubernetes `$(NAME)` syntax.
+// Keys of bound configurations cannot be referenced, they are mounted as files. Use `$$(NAME)` for a literal `$(NAME)`. On deployment
+// the variables are ordered such that referenced variables come first, and references to
+// unknown variables are rejected.
 // responses:
 //   200: EnvSetResponse
 
@@ -0,0 +1,96 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+//go:generate swagger generate spec
+
+// swagger:route POST /maintenance/registry/prune maintenance RegistryPrune
+// Delete the images of the Epinio registry which are older than `staleDays` days (default 30,
+// minimum 1), and neither referenced by an App, nor by the deploy history of an App, nor
+// running in an App pod. With `dryRun` nothing is deleted, and the response reports what would
+// be. Restricted to admins.
+// The reported `reclaimedBytes` are released by the next garbage collection of the registry.
+// responses:
+//   200: RegistryPruneResponse
+
+// swagger:parameters RegistryPrune
+type RegistryPruneParam struct {
+	// in: query
+	DryRun bool `json:"dryRun"`
+	// in: query
+	StaleDays int `json:"staleDays"`
+}
+
+// swagger:response RegistryPruneResponse
+type RegistryPruneResponse struct {
+	// in: body
+	Body models.RegistryPruneResponse
+}
+
+// swagger:route GET /maintenance/catalog/health maintenance CatalogHealth
+// Check for each catalog service that its helm repository is reachable, and provides the chart
+// of the service in the requested version. Returns the state of every catalog service.
+// Restricted to admins.
+// responses:
+//   200: CatalogHealthResponse
+
+// swagger:response CatalogHealthResponse
+type CatalogHealthResponse struct {
+	// in: body
+	Body models.CatalogHealthResponse
+}
+
+// swagger:route GET /maintenance/sessions maintenance SessionStats
+// Return the interactive sessions, i.e. exec and port-forward, active on the server, in total
+// and per user, the number of rejected sessions, and the configured limits. Restricted to
+// admins.
+// responses:
+//   200: SessionStatsResponse
+
+// swagger:response SessionStatsResponse
+type SessionStatsResponse struct {
+	// in: body
+	Body models.SessionStatsResponse
+}
+
+// swagger:route GET /maintenance/operations maintenance Operations
+// Return the long-running operations in progress, i.e. git imports, staging builds, and service
+// provisioning, oldest first. Each operation reports its type, resource, start time, and the id
+// of the request which started it. Restricted to admins.
+// responses:
+//   200: OperationsResponse
+
+// swagger:response OperationsResponse
+type OperationsResponse struct {
+	// in: body
+	Body models.OperationsResponse
+}
+
+// swagger:route POST /maintenance/operations/cancel maintenance OperationCancel
+// Cancel the long-running operation with the given id. Staging builds are cancelled by deleting
+// their jobs. Restricted to admins.
+// responses:
+//   200: OperationCancelResponse
+
+// swagger:parameters OperationCancel
+type OperationCancelParam struct {
+	// in: body
+	Body models.OperationCancelRequest
+}
+
+// swagger:response OperationCancelResponse
+type OperationCancelResponse struct {
+	// in: body
+	Body models.Response
+}
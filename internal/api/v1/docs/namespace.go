@@ -88,6 +88,67 @@ type NamespaceShowResponse struct {
 	Body models.Namespace
 }
 
+// swagger:route GET /namespaces/{Namespace}/roles namespace NamespaceRoles
+// Return the role bindings of all users in the named `Namespace`.
+// responses:
+//   200: NamespaceRolesResponse
+
+// swagger:parameters NamespaceRoles
+type NamespaceRolesParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response NamespaceRolesResponse
+type NamespaceRolesResponse struct {
+	// in: body
+	Body models.NamespaceRoleBindingList
+}
+
+// swagger:route POST /namespaces/{Namespace}/roles namespace NamespaceRoleGrant
+// Grant the posted role to the posted user, in the named `Namespace`.
+// The caller has to hold the role in the namespace, or be an admin of it.
+// Granting a role the user already holds is not an error.
+// responses:
+//   200: NamespaceRoleGrantResponse
+//   201: NamespaceRoleGrantResponse
+
+// swagger:parameters NamespaceRoleGrant
+type NamespaceRoleGrantParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceRoleGrantRequest
+}
+
+// swagger:response NamespaceRoleGrantResponse
+type NamespaceRoleGrantResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/roles/{User}/{Role} namespace NamespaceRoleRevoke
+// Revoke the `Role` of the `User` in the named `Namespace`.
+// The last admin of a namespace cannot be revoked.
+// responses:
+//   200: NamespaceRoleRevokeResponse
+
+// swagger:parameters NamespaceRoleRevoke
+type NamespaceRoleRevokeParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	User string
+	// in: path
+	Role string
+}
+
+// swagger:response NamespaceRoleRevokeResponse
+type NamespaceRoleRevokeResponse struct {
+	// in: body
+	Body models.Response
+}
+
 // swagger:route GET /namespacematches/{Pattern} namespace NamespaceMatch
 // Return list of names for all controlled namespaces whose name matches the prefix `Pattern`.
 // responses:
@@ -16,12 +16,16 @@ package docs
 import "github.com/epinio/epinio/pkg/api/core/v1/models"
 
 // swagger:route GET /catalogservices service ServiceCatalog
-// Return all available Epinio Catalog services.
+// Return all available Epinio Catalog services. Deprecated catalog services are included only
+// with `includeDeprecated` set.
 // responses:
 //   200: ServiceCatalogResponse
 
 // swagger:parameters ServiceCatalog
-type ServiceCatalogParam struct{}
+type ServiceCatalogParam struct {
+	// in: query
+	IncludeDeprecated bool `json:"includeDeprecated"`
+}
 
 // swagger:response ServiceCatalogResponse
 type ServiceCatalogResponse struct {
@@ -46,6 +50,27 @@ type ServiceCatalogShowResponse struct {
 	Body models.CatalogService
 }
 
+// swagger:route POST /catalogservices/{CatalogService}/validate service ServiceCatalogValidate
+// Validate the values of the named Epinio `CatalogService` against the `values.schema.json` of its
+// chart. Values given in the body are validated instead of the catalog's own. The result lists
+// the violations with the JSON path of the offending values. Invalid values are not an error.
+// responses:
+//   200: ServiceCatalogValidateResponse
+
+// swagger:parameters ServiceCatalogValidate
+type ServiceCatalogValidateParam struct {
+	// in: path
+	CatalogService string
+	// in: body
+	Configuration models.CatalogServiceValidateRequest
+}
+
+// swagger:response ServiceCatalogValidateResponse
+type ServiceCatalogValidateResponse struct {
+	// in: body
+	Body models.CatalogServiceValidateResponse
+}
+
 // swagger:route GET /catalogservicesmatches/{Pattern} catalogservice CatalogServiceMatch
 // Return list of names for all catalog entries whose name matches the prefix `Pattern`.
 // responses:
@@ -69,7 +94,8 @@ type CatalogMatchResponse struct {
 //   200: ServiceListResponse
 
 // swagger:route POST /namespaces/{Namespace}/services service ServiceCreate
-// Create a named service of an Epinio catalog service in the `Namespace`.
+// Create a named service of an Epinio catalog service in the `Namespace`. The response warns
+// when the catalog service is deprecated.
 // responses:
 //   200: ServiceCreateResponse
 
@@ -84,11 +110,20 @@ type ServiceCreateParam struct {
 // swagger:response ServiceCreateResponse
 type ServiceCreateResponse struct {
 	// in: body
-	Body models.Response
+	Body models.ServiceCreateResponse
 }
 
 // swagger:route PATCH /namespaces/{Namespace}/services/{Service} service ServiceUpdate
-// Update the named `Service` in the `Namespace` as per the instructions in the body
+// Update the named `Service` in the `Namespace` as per the instructions in the body.
+// With `atomic` set the service is rolled back if it does not become ready after the update.
+// With `dryRun` set nothing is changed. The response then is a `ServiceUpdatePreview`, showing
+// the chart values after the update, and the changes of the values and of the resources rendered
+// by a helm dry-run upgrade. Bound apps are not restarted.
+// With `validate` set in the body the chart values after the update are checked against the
+// `values.schema.json` of the service's chart first, if it has one. Violations are rejected with
+// a bad request naming the offending keys.
+// The response lists the applications restarted for the update when waiting for it, and else
+// the applications pending the restart.
 // responses:
 //   200: ServiceUpdateResponse
 
@@ -98,16 +133,225 @@ type ServiceUpdateParam struct {
 	Namespace string
 	// in: path
 	Service string
+	// in: query
+	Atomic string `json:"atomic"`
+	// in: query
+	DryRun string `json:"dryRun"`
 	// in: body
 	Body models.ServiceUpdateRequest
 }
 
 // swagger:response ServiceUpdateResponse
 type ServiceUpdateResponse struct {
+	// in: body
+	Body models.ServiceUpdateResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/values service ServiceValues
+// Return the effective values of the named `Service` in the `Namespace`, i.e. chart defaults merged
+// with catalog and user values. Sensitive values are redacted for users not allowed to update the service.
+// responses:
+//   200: ServiceValuesResponse
+
+// swagger:parameters ServiceValues
+type ServiceValuesParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceValuesResponse
+type ServiceValuesResponse struct {
+	// in: body
+	Body models.ServiceValuesResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/catalogservice service ServiceCatalogService
+// Return the catalog service the named `Service` in the `Namespace` was created from.
+// responses:
+//   200: ServiceCatalogServiceResponse
+
+// swagger:parameters ServiceCatalogService
+type ServiceCatalogServiceParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceCatalogServiceResponse
+type ServiceCatalogServiceResponse struct {
+	// in: body
+	Body models.CatalogService
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/originalvalues service ServiceOriginalValues
+// Return the values the named `Service` in the `Namespace` was created with, before any updates, i.e.
+// the catalog values and the user settings given at creation. Sensitive values are redacted for users
+// not allowed to update the service.
+// responses:
+//   200: ServiceOriginalValuesResponse
+
+// swagger:parameters ServiceOriginalValues
+type ServiceOriginalValuesParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceOriginalValuesResponse
+type ServiceOriginalValuesResponse struct {
+	// in: body
+	Body models.ServiceOriginalValuesResponse
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/events service ServiceEvents
+// Return the kubernetes events of the workload of the named `Service` in the `Namespace`, i.e. of
+// its pods, volumes, and controllers, ordered from newest to oldest.
+// responses:
+//   200: ServiceEventsResponse
+
+// swagger:parameters ServiceEvents
+type ServiceEventsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceEventsResponse
+type ServiceEventsResponse struct {
+	// in: body
+	Body models.EventList
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/progress websocket ServiceProgressWs
+// Stream the provisioning progress of the named `Service` in the `Namespace` over a websocket.
+// Each event reports the phase of the service's helm release, and the readiness of its pods.
+// Events are sent whenever the progress changes, ending with the completed event once the
+// service is deployed, or failed.
+// responses:
+//   200: ServiceProgressWsResponse
+
+// swagger:parameters ServiceProgressWs
+type ServiceProgressWsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceProgressWsResponse
+type ServiceProgressWsResponse struct{}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/suspend service ServiceSuspend
+// Suspend the named `Service` in the `Namespace`, i.e. scale its workload to zero, retaining its
+// data. Warns about running applications bound to the service. A suspended service cannot be
+// updated, replaced, or reset until it is resumed, these are refused with a conflict.
+// responses:
+//   200: ServiceSuspendResponse
+
+// swagger:parameters ServiceSuspend
+type ServiceSuspendParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceSuspendResponse
+type ServiceSuspendResponse struct {
+	// in: body
+	Body models.ServiceSuspendResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/clone service ServiceClone
+// Create a copy of the named `Service` in the `Namespace` in the target namespace of the body,
+// from the same catalog service, and with the same settings. The copy is named like the service,
+// unless another name is given. Fails with a conflict when the target service exists.
+// responses:
+//   200: ServiceCloneResponse
+
+// swagger:parameters ServiceClone
+type ServiceCloneParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: body
+	Configuration models.ServiceCloneRequest
+}
+
+// swagger:response ServiceCloneResponse
+type ServiceCloneResponse struct {
+	// in: body
+	Body models.ServiceCreateResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/restart service ServiceRestart
+// Restart the running applications bound to the named `Service` in the `Namespace`, without
+// changing the service. Returns the names of the restarted applications.
+// responses:
+//   200: ServiceRestartResponse
+
+// swagger:parameters ServiceRestart
+type ServiceRestartParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceRestartResponse
+type ServiceRestartResponse struct {
+	// in: body
+	Body models.ServiceRestartResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/resume service ServiceResume
+// Resume the suspended `Service` in the `Namespace`, restoring its workload.
+// responses:
+//   200: ServiceResumeResponse
+
+// swagger:parameters ServiceResume
+type ServiceResumeParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceResumeResponse
+type ServiceResumeResponse struct {
 	// in: body
 	Body models.Response
 }
 
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/expiry service ServiceExpiry
+// Set the time to live of the `Service` in the `Namespace`. The service is deleted automatically
+// when it expires, and is not bound to any application. Posting again extends the life of the
+// service. An empty `ttl` removes the expiry.
+// responses:
+//   200: ServiceExpiryResponse
+
+// swagger:parameters ServiceExpiry
+type ServiceExpiryParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: body
+	Configuration models.ExpiryRequest
+}
+
+// swagger:response ServiceExpiryResponse
+type ServiceExpiryResponse struct {
+	// in: body
+	Body models.ExpiryResponse
+}
+
 // swagger:route PUT /namespaces/{Namespace}/services/{Service} service ServiceReplace
 // Replace the named `Service` in the `Namespace` as per the instructions in the body
 // responses:
@@ -129,8 +373,33 @@ type ServiceReplaceResponse struct {
 	Body models.Response
 }
 
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/reset service ServiceReset
+// Reset the named `Service` in the `Namespace` to the values it was created with, discarding all
+// updates made since. Returns the keys of the settings which changed.
+// responses:
+//   200: ServiceResetResponse
+
+// swagger:parameters ServiceReset
+type ServiceResetParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: body
+	Body models.ServiceResetRequest
+}
+
+// swagger:response ServiceResetResponse
+type ServiceResetResponse struct {
+	// in: body
+	Body models.ServiceResetResponse
+}
+
 // swagger:route GET /namespaces/{Namespace}/services service ServiceList
 // Return list of services in the `Namespace`.
+// With any of `limit`, `offset`, and `prefix` set the response is a `ServiceListResponse`
+// instead, holding the page of the services whose names start with the prefix, sorted by name,
+// and their total. Offsets past the end yield an empty page.
 // responses:
 //   200: ServiceListResponse
 
@@ -138,6 +407,12 @@ type ServiceReplaceResponse struct {
 type ServiceListParam struct {
 	// in: path
 	Namespace string
+	// in: query
+	Limit int `json:"limit"`
+	// in: query
+	Offset int `json:"offset"`
+	// in: query
+	Prefix string `json:"prefix"`
 }
 
 // swagger:response ServiceListResponse
@@ -238,7 +513,11 @@ type ServiceAppsResponse struct {
 }
 
 // swagger:route POST /namespaces/{Namespace}/services/{Service}/bind service ServiceBind
-// Bind the named `Service` in the `Namespace` to an App.
+// Bind the named `Service` in the `Namespace` to an App. The response lists the configurations
+// the service contributes to the App, with their keys and mount paths. For an App which is not
+// deployed yet the binding is only recorded, and `applyOnDeploy` is set. The first deployment of
+// the App applies it. With `restart` false a deployed App is not restarted, the binding is only
+// recorded, and `applyOnRestart` is set. The next restart or deployment of the App applies it.
 // responses:
 //   200: ServiceBindResponse
 
@@ -255,7 +534,7 @@ type ServiceBindParam struct {
 // swagger:response ServiceBindResponse
 type ServiceBindResponse struct {
 	// in: body
-	Body models.Response
+	Body models.ServiceBindResponse
 }
 
 // swagger:route POST /namespaces/{Namespace}/services/{Service}/unbind service ServiceUnbind
@@ -279,6 +558,57 @@ type ServiceUnbindResponse struct {
 	Body models.Response
 }
 
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/servicebindings service ServiceBatchBind
+// Bind multiple services to the named `App` in the `Namespace`, as a single change. A deployed
+// App is restarted once. By default nothing is bound when any service fails. In partial mode,
+// requested by `partial` in the body or the query, the failing services are skipped, and
+// `results` reports the status of each service. With `restart` false a deployed App is not
+// restarted, and `applyOnRestart` is set.
+// responses:
+//   200: ServiceBatchBindResponse
+
+// swagger:parameters ServiceBatchBind
+type ServiceBatchBindParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: query
+	Partial bool `json:"partial"`
+	// in: body
+	Configuration models.ServiceBatchBindRequest
+}
+
+// swagger:response ServiceBatchBindResponse
+type ServiceBatchBindResponse struct {
+	// in: body
+	Body models.ServiceBatchBindResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/servicerebind service ServiceRebind
+// Move the named `App` in the `Namespace` between services. The services in `remove` are
+// unbound, and the services in `add` are bound, as a single change. A deployed App is
+// restarted once. The response lists the configurations the added services contribute to the
+// App. For an App which is not deployed yet `applyOnDeploy` is set.
+// responses:
+//   200: ServiceRebindResponse
+
+// swagger:parameters ServiceRebind
+type ServiceRebindParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Configuration models.ServiceRebindRequest
+}
+
+// swagger:response ServiceRebindResponse
+type ServiceRebindResponse struct {
+	// in: body
+	Body models.ServiceBindResponse
+}
+
 // swagger:parameters ServicePortForward
 type ServicePortForwardParam struct {
 	// in: path
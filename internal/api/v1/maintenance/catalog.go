@@ -0,0 +1,99 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// CatalogHealth handles the API endpoint /maintenance/catalog/health (GET)
+// It checks for each catalog service that its helm repository is reachable, and provides the
+// chart of the service. The checks run in parallel. An unhealthy service is reported, not an
+// error of the request.
+func CatalogHealth(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := helpers.Logger.With("component", "CatalogHealth")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogServices, err := kubeServiceClient.ListCatalogServices(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result := models.CatalogHealthResponse{
+		Services: checkCatalogServices(catalogServices, helm.CheckCatalogServiceRepo),
+	}
+
+	for _, status := range result.Services {
+		if !status.Healthy {
+			logger.Infow("catalog service unhealthy", "service", status.Name, "error", status.Error)
+		}
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
+
+// checkCatalogServices runs the check for all catalog services in parallel, and returns their
+// states, sorted by name.
+func checkCatalogServices(catalogServices []*models.CatalogService, check func(models.CatalogService) error) []models.CatalogServiceHealth {
+	states := make([]models.CatalogServiceHealth, len(catalogServices))
+
+	var wg sync.WaitGroup
+	for i, catalogService := range catalogServices {
+		wg.Add(1)
+		go func(i int, catalogService models.CatalogService) {
+			defer wg.Done()
+
+			state := models.CatalogServiceHealth{
+				Name:       catalogService.Meta.Name,
+				Repository: catalogService.HelmRepo.URL,
+				Chart:      catalogService.HelmChart,
+				Version:    catalogService.ChartVersion,
+				Healthy:    true,
+			}
+			if err := check(catalogService); err != nil {
+				state.Healthy = false
+				state.Error = err.Error()
+			}
+
+			states[i] = state
+		}(i, *catalogService)
+	}
+	wg.Wait()
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+
+	return states
+}
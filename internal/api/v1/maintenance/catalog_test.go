@@ -0,0 +1,34 @@
+package maintenance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestCheckCatalogServices(t *testing.T) {
+	catalogServices := []*models.CatalogService{
+		{Meta: models.MetaLite{Name: "redis"}, HelmChart: "redis", HelmRepo: models.HelmRepo{URL: "https://broken"}},
+		{Meta: models.MetaLite{Name: "mysql"}, HelmChart: "mysql", ChartVersion: "9.4.1", HelmRepo: models.HelmRepo{URL: "https://charts"}},
+	}
+
+	states := checkCatalogServices(catalogServices, func(catalogService models.CatalogService) error {
+		if catalogService.HelmRepo.URL == "https://broken" {
+			return errors.New("helm repository not reachable")
+		}
+		return nil
+	})
+
+	if len(states) != 2 {
+		t.Fatalf("expected 2 states, got %d", len(states))
+	}
+
+	mysql, redis := states[0], states[1]
+	if mysql.Name != "mysql" || !mysql.Healthy || mysql.Error != "" || mysql.Version != "9.4.1" || mysql.Chart != "mysql" {
+		t.Fatalf("unexpected state for mysql: %+v", mysql)
+	}
+	if redis.Name != "redis" || redis.Healthy || redis.Error != "helm repository not reachable" || redis.Repository != "https://broken" {
+		t.Fatalf("unexpected state for redis: %+v", redis)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/operations"
+	"github.com/gin-gonic/gin"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// stagingPrefix marks the ids of staging operations. They are derived from the stage id, as
+// the builds run in jobs of the cluster, and are not known to the registry of the server.
+const stagingPrefix = operations.TypeStaging + "-"
+
+// Operations handles the API endpoint /maintenance/operations (GET)
+// It returns the long-running operations in progress, i.e. the imports and service
+// provisioning run by the server, and the staging builds run by the cluster.
+func Operations(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	jobs, err := cluster.ListJobs(ctx, helmchart.StagingNamespace(), "app.kubernetes.io/component=staging")
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	running, err := operations.Default.List(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.OperationsResponse{
+		Operations: listOperations(running, jobs.Items),
+	})
+	return nil
+}
+
+// OperationCancel handles the API endpoint /maintenance/operations/cancel (POST)
+// It cancels the operation with the id given in the request. Staging operations are cancelled
+// by deleting their jobs.
+func OperationCancel(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	var cancelRequest models.OperationCancelRequest
+	err := c.BindJSON(&cancelRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+	if cancelRequest.ID == "" {
+		return apierror.NewBadRequestError("operation id is required")
+	}
+
+	err = operations.Default.Cancel(ctx, cancelRequest.ID)
+	if err == nil {
+		response.OK(c)
+		return nil
+	}
+	if !errors.Is(err, operations.ErrNotFound) {
+		return apierror.InternalError(err)
+	}
+
+	stageID, isStaging := strings.CutPrefix(cancelRequest.ID, stagingPrefix)
+	if !isStaging {
+		return apierror.NewNotFoundError("operation", cancelRequest.ID)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	jobs, err := cluster.ListJobs(ctx, helmchart.StagingNamespace(),
+		"app.kubernetes.io/component=staging,"+models.EpinioStageIDLabel+"="+stageID)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	cancelled := false
+	for _, job := range jobs.Items {
+		if jobFinished(job) {
+			continue
+		}
+
+		err := cluster.DeleteJob(ctx, job.Namespace, job.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return apierror.InternalError(err, "deleting the staging job")
+		}
+
+		// And the associated secret holding the job environment
+		err = cluster.DeleteSecret(ctx, job.Namespace, job.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return apierror.InternalError(err, "deleting the staging job environment")
+		}
+		cancelled = true
+	}
+	if !cancelled {
+		return apierror.NewNotFoundError("operation", cancelRequest.ID)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// listOperations merges the operations of the registry and the running staging jobs, oldest
+// first.
+func listOperations(registered []operations.Operation, jobs []batchv1.Job) []models.Operation {
+	result := make([]models.Operation, 0, len(registered)+len(jobs))
+
+	for _, op := range registered {
+		result = append(result, models.Operation{
+			ID:            op.ID,
+			Type:          op.Type,
+			Namespace:     op.Namespace,
+			Resource:      op.Resource,
+			User:          op.User,
+			CorrelationID: op.CorrelationID,
+			StartedAt:     op.StartedAt,
+		})
+	}
+
+	for _, job := range jobs {
+		if jobFinished(job) {
+			continue
+		}
+		result = append(result, models.Operation{
+			ID:        stagingPrefix + job.Labels[models.EpinioStageIDLabel],
+			Type:      operations.TypeStaging,
+			Namespace: job.Labels["app.kubernetes.io/part-of"],
+			Resource:  job.Labels["app.kubernetes.io/name"],
+			User:      job.Annotations[models.EpinioCreatedByAnnotation],
+			StartedAt: job.CreationTimestamp.Time,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result
+}
+
+// jobFinished returns true if the job either completed, or failed for good.
+func jobFinished(job batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
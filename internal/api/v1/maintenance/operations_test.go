@@ -0,0 +1,58 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/epinio/epinio/internal/operations"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListOperations(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	registered := []operations.Operation{
+		{ID: "op-1", Type: operations.TypeImport, Namespace: "workspace", Resource: "sample",
+			User: "alice", CorrelationID: "request-1", StartedAt: start.Add(time.Minute)},
+	}
+
+	stagingJob := func(stageID string, started time.Time, conditions ...batchv1.JobCondition) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(started),
+				Labels: map[string]string{
+					"app.kubernetes.io/name":    "sample",
+					"app.kubernetes.io/part-of": "workspace",
+					models.EpinioStageIDLabel:   stageID,
+				},
+				Annotations: map[string]string{
+					models.EpinioCreatedByAnnotation: "bob",
+				},
+			},
+			Status: batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+
+	jobs := []batchv1.Job{
+		stagingJob("running", start),
+		stagingJob("done", start, batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+		stagingJob("failed", start, batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}),
+	}
+
+	ops := listOperations(registered, jobs)
+	if len(ops) != 2 {
+		t.Fatalf("expected 2 operations, got %+v", ops)
+	}
+
+	staging, imported := ops[0], ops[1]
+	if staging.ID != "staging-running" || staging.Type != operations.TypeStaging ||
+		staging.Namespace != "workspace" || staging.Resource != "sample" || staging.User != "bob" {
+		t.Fatalf("unexpected staging operation: %+v", staging)
+	}
+	if imported.ID != "op-1" || imported.Type != operations.TypeImport || imported.CorrelationID != "request-1" {
+		t.Fatalf("unexpected import operation: %+v", imported)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance contains the handlers for system-wide maintenance operations. They are
+// restricted to admin users.
+package maintenance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+const (
+	// DefaultStaleDays is the minimum age of the images pruned when the request does not
+	// specify it.
+	DefaultStaleDays = 30
+	// MinStaleDays is the smallest accepted age. Younger images may have just been pushed by
+	// a staging job whose application is not deployed yet.
+	MinStaleDays = 1
+)
+
+// PruneRegistry handles the API endpoint /maintenance/registry/prune (POST)
+// It deletes the images of the Epinio registry which are older than `staleDays` days, and
+// neither referenced by an application, nor running in an application pod. With `dryRun` set
+// nothing is deleted, and the response reports what would be.
+func PruneRegistry(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := helpers.Logger.With("component", "PruneRegistry")
+
+	dryRun, staleDays, apiErr := parsePruneParameters(c.Query("dryRun"), c.Query("staleDays"))
+	if apiErr != nil {
+		return apiErr
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	client, err := registryClient(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	references, digests, err := referencedImages(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	images, err := client.Images(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	cutoff := time.Now().Add(-time.Duration(staleDays) * 24 * time.Hour)
+	prune, keep := registry.SelectPrunable(images, func(image registry.Image) bool {
+		return references[image.Reference()] || digests[image.Digest]
+	}, cutoff)
+
+	logger.Infow("pruning registry", "dryRun", dryRun, "staleDays", staleDays,
+		"images", len(images), "prune", len(prune))
+
+	result := models.RegistryPruneResponse{
+		DryRun:    dryRun,
+		StaleDays: staleDays,
+		Pruned:    []models.RegistryPrunedImage{},
+	}
+
+	pruned := []registry.Image{}
+	for _, image := range prune {
+		if !dryRun {
+			err := client.Delete(ctx, image)
+			if err != nil {
+				logger.Errorw("failed to prune image", "image", image.Reference(), "error", err)
+				result.Errors = append(result.Errors, errors.Wrap(err, image.Reference()).Error())
+				keep = append(keep, image)
+				continue
+			}
+		}
+
+		pruned = append(pruned, image)
+		result.Pruned = append(result.Pruned, models.RegistryPrunedImage{
+			Image:   image.Reference(),
+			Digest:  image.Digest,
+			Created: image.Created,
+		})
+	}
+
+	result.Kept = len(keep)
+	result.ReclaimedBytes = registry.ReclaimableBytes(pruned, keep)
+
+	response.OKReturn(c, result)
+	return nil
+}
+
+// parsePruneParameters validates the query parameters of a prune request, and returns them
+// with their defaults applied.
+func parsePruneParameters(dryRunParam, staleDaysParam string) (bool, int, apierror.APIErrors) {
+	dryRun := false
+	if dryRunParam != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			return false, 0, apierror.NewBadRequestErrorf("invalid dryRun: %s", dryRunParam)
+		}
+	}
+
+	staleDays := DefaultStaleDays
+	if staleDaysParam != "" {
+		var err error
+		staleDays, err = strconv.Atoi(staleDaysParam)
+		if err != nil {
+			return false, 0, apierror.NewBadRequestErrorf("invalid staleDays: %s", staleDaysParam)
+		}
+		if staleDays < MinStaleDays {
+			return false, 0, apierror.NewBadRequestErrorf("invalid staleDays: must be >= %d", MinStaleDays)
+		}
+	}
+
+	return dryRun, staleDays, nil
+}
+
+// registryClient returns a client for the Epinio registry. It prefers the public url of the
+// registry, as the localhost url is only usable by the kubelets.
+func registryClient(ctx context.Context, cluster *kubernetes.Cluster) (*registry.Client, error) {
+	details, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting registry connection details")
+	}
+	if len(details.RegistryCredentials) == 0 {
+		return nil, errors.New("no registry credentials found")
+	}
+
+	publicURL, err := details.PublicRegistryURL()
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := details.RegistryCredentials[0]
+	for _, candidate := range details.RegistryCredentials {
+		if candidate.URL == publicURL {
+			credentials = candidate
+			break
+		}
+	}
+
+	return registry.NewClient(credentials, application.RegistryTLSConfig(ctx, cluster, credentials.URL))
+}
+
+// referencedImages returns the images in use. These are the images of all applications, the
+// images recorded in their deploy histories, as a rollback needs them, and the images of all
+// running application pods, which may differ during a rollout. The first result holds them by
+// `repository:tag`, the second by digest.
+func referencedImages(ctx context.Context, cluster *kubernetes.Cluster) (map[string]bool, map[string]bool, error) {
+	references := map[string]bool{}
+	digests := map[string]bool{}
+
+	add := func(imageURL string) {
+		if imageURL == "" {
+			return
+		}
+		repository, tag, err := registry.ImageReference(imageURL)
+		if err != nil {
+			return
+		}
+		if strings.HasPrefix(tag, "sha256:") {
+			digests[tag] = true
+			return
+		}
+		references[repository+":"+tag] = true
+	}
+
+	apps, err := application.ListWithOptions(ctx, cluster, "", application.ListOptions{SkipMetrics: true})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing applications")
+	}
+	for _, app := range apps {
+		add(app.ImageURL)
+	}
+
+	revisions, err := application.HistoryRevisions(ctx, cluster)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing application histories")
+	}
+	for _, revision := range revisions {
+		add(revision.ImageURL)
+		if revision.ImageDigest != "" {
+			digests[revision.ImageDigest] = true
+		}
+	}
+
+	pods, err := cluster.Kubectl.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/component=application",
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing application pods")
+	}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			add(container.Image)
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if _, digest, found := strings.Cut(status.ImageID, "@"); found {
+				digests[digest] = true
+			}
+		}
+	}
+
+	return references, digests, nil
+}
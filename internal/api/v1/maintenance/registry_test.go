@@ -0,0 +1,40 @@
+package maintenance
+
+import (
+	"testing"
+)
+
+func TestParsePruneParametersDefaults(t *testing.T) {
+	dryRun, staleDays, errs := parsePruneParameters("", "")
+	if errs != nil {
+		t.Fatalf("unexpected error: %v", errs)
+	}
+	if dryRun || staleDays != DefaultStaleDays {
+		t.Fatalf("expected defaults, got dryRun %v, staleDays %d", dryRun, staleDays)
+	}
+}
+
+func TestParsePruneParameters(t *testing.T) {
+	dryRun, staleDays, errs := parsePruneParameters("true", "7")
+	if errs != nil {
+		t.Fatalf("unexpected error: %v", errs)
+	}
+	if !dryRun || staleDays != 7 {
+		t.Fatalf("expected dryRun true, staleDays 7, got %v, %d", dryRun, staleDays)
+	}
+}
+
+func TestParsePruneParametersRejectsInvalid(t *testing.T) {
+	for _, params := range [][2]string{
+		{"maybe", ""},
+		{"", "abc"},
+		{"", "30.5"},
+		{"", "0"},
+		{"", "-5"},
+	} {
+		_, _, errs := parsePruneParameters(params[0], params[1])
+		if errs == nil {
+			t.Fatalf("expected an error for dryRun %q, staleDays %q", params[0], params[1])
+		}
+	}
+}
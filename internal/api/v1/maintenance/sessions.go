@@ -0,0 +1,41 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// SessionStats handles the API endpoint /maintenance/sessions (GET)
+// It returns the interactive sessions active on the server, in total and per user, together
+// with the configured limits.
+func SessionStats(c *gin.Context) apierror.APIErrors {
+	response.OKReturn(c, sessionStats(sessions.Default.Stats()))
+	return nil
+}
+
+// sessionStats converts the limiter stats into the response, adding the configured limits.
+func sessionStats(stats sessions.Stats) models.SessionStatsResponse {
+	return models.SessionStatsResponse{
+		Active:    stats.Active,
+		Users:     stats.Users,
+		Rejected:  stats.Rejected,
+		Limit:     viper.GetInt("session-limit"),
+		UserLimit: viper.GetInt("session-user-limit"),
+	}
+}
@@ -45,6 +45,19 @@ func RoleAuthorization(c *gin.Context) {
 	}
 }
 
+// AdminAuthorization middleware restricts the route to admins. It is used for the routes
+// outside of the API, which are not covered by the AdminRoutes.
+func AdminAuthorization(c *gin.Context) {
+	user := requestctx.User(c.Request.Context())
+
+	if !user.IsAdmin() {
+		err := apierrors.NewAPIError("user unauthorized, path restricted", http.StatusForbidden)
+		response.Error(c, err)
+		c.Abort()
+		return
+	}
+}
+
 func NamespaceAuthorization(c *gin.Context) {
 	user := requestctx.User(c.Request.Context())
 	authorization(c, "namespace", user.Namespaces)
@@ -101,4 +101,28 @@ var _ = Describe("Authorization Middleware", func() {
 			})
 		})
 	})
+
+	Context("admin only routes", func() {
+		It("returns status code 403 for a regular user", func() {
+			ctx = requestctx.WithUser(ctx, auth.User{
+				Roles: []auth.Role{{ID: "user"}},
+			})
+			c.Request = c.Request.Clone(ctx)
+
+			middleware.AdminAuthorization(c)
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(c.IsAborted()).To(BeTrue())
+		})
+
+		It("passes an admin", func() {
+			ctx = requestctx.WithUser(ctx, auth.User{
+				Roles: []auth.Role{auth.AdminRole},
+			})
+			c.Request = c.Request.Clone(ctx)
+
+			middleware.AdminAuthorization(c)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(c.IsAborted()).To(BeFalse())
+		})
+	})
 })
@@ -15,6 +15,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -23,6 +24,7 @@ import (
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/dex"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
@@ -52,6 +54,8 @@ func Authentication(ctx *gin.Context) {
 
 	if strings.HasPrefix(authorizationHeader, "Basic ") {
 		user, authError = basicAuthentication(ctx, authService)
+	} else if strings.HasPrefix(authorizationHeader, "Bearer "+auth.APITokenPrefix) {
+		user, authError = apiTokenAuthentication(ctx, authService)
 	} else if strings.HasPrefix(authorizationHeader, "Bearer ") {
 		user, authError = oidcAuthentication(ctx)
 	} else {
@@ -64,8 +68,9 @@ func Authentication(ctx *gin.Context) {
 		return
 	}
 
+	// the users of API tokens are not stored, and have the role of the token only
 	updatedUser, needsUpdate := auth.IsUpdateUserNeeded(user)
-	if needsUpdate {
+	if needsUpdate && user.APITokenName == "" {
 		user, err = authService.UpdateUser(ctx, updatedUser)
 		if err != nil {
 			response.Error(ctx, apierrors.InternalError(err, "updating user"))
@@ -118,6 +123,46 @@ func basicAuthentication(ctx *gin.Context, authService *auth.AuthService) (auth.
 	return user, nil
 }
 
+// apiTokenAuthentication performs the authentication with an API token. Revoked and expired
+// tokens are rejected.
+func apiTokenAuthentication(ctx *gin.Context, authService *auth.AuthService) (auth.User, apierrors.APIErrors) {
+	logger := helpers.Logger.With("component", "apiTokenAuthentication")
+	logger.Debugw("starting API Token Authentication")
+
+	authHeader := ctx.Request.Header.Get("Authorization")
+	value := strings.TrimPrefix(authHeader, "Bearer ")
+
+	token, err := authService.GetAPITokenByValue(ctx, value)
+	if err != nil {
+		if errors.Is(err, auth.ErrAPITokenNotFound) {
+			return auth.User{}, apierrors.NewAPIError("invalid or revoked api token", http.StatusUnauthorized)
+		}
+		return auth.User{}, apierrors.InternalError(err, "getting api token")
+	}
+
+	if token.IsExpired(time.Now()) {
+		return auth.User{}, apierrors.NewAPIError("api token expired", http.StatusUnauthorized)
+	}
+
+	// the token cannot outlive the owner, nor the role of the owner
+	owner, err := authService.GetUserByUsername(ctx, token.Owner)
+	if err != nil {
+		return auth.User{}, apierrors.NewAPIError(errors.Wrap(err, "api token owner").Error(), http.StatusUnauthorized)
+	}
+	if !owner.CanDelegate(token.Role, token.Namespace) {
+		return auth.User{}, apierrors.NewAPIError("api token owner no longer holds the role of the token", http.StatusUnauthorized)
+	}
+
+	user, err := token.User()
+	if err != nil {
+		return auth.User{}, apierrors.NewAPIError(err.Error(), http.StatusUnauthorized)
+	}
+
+	logger.Debugw("api token verified", "user", user.Username, "token", token.Name)
+
+	return user, nil
+}
+
 // oidcAuthentication perform the OIDC authentication with dex
 func oidcAuthentication(ctx *gin.Context) (auth.User, apierrors.APIErrors) {
 	logger := helpers.Logger.With("component", "oidcAuthentication")
@@ -147,7 +192,7 @@ func oidcAuthentication(ctx *gin.Context) (auth.User, apierrors.APIErrors) {
 		return auth.User{}, apierrors.NewAPIError(errors.Wrap(err, "error parsing claims").Error(), http.StatusUnauthorized)
 	}
 
-	roles := getRolesFromProviderGroups(oidcProvider, claims.FederatedClaims.ConnectorID, claims.Groups)
+	roles := getRolesFromProviderGroups(ctx, oidcProvider, claims.FederatedClaims.ConnectorID, claims.Groups)
 
 	user, err := getOrCreateUserByEmail(ctx, claims.Email, roles)
 	if err != nil {
@@ -190,13 +235,15 @@ func getOIDCProvider(ctx context.Context) (*dex.OIDCProvider, error) {
 	return oidcProvider, nil
 }
 
-// getRolesFromProviderGroups returns the user roles, looking for it in the groups defined for the provider.
-func getRolesFromProviderGroups(oidcProvider *dex.OIDCProvider, providerID string, groups []string) auth.Roles {
+// getRolesFromProviderGroups returns the user roles, looking for it in the groups defined for the provider,
+// and in the groups defined for all providers. Roles for a pattern of namespaces, as in
+// "user:team-eng-*", are expanded to the existing namespaces matching the pattern.
+func getRolesFromProviderGroups(ctx context.Context, oidcProvider *dex.OIDCProvider, providerID string, groups []string) auth.Roles {
 	logger := helpers.Logger.With("component", "oidcGroupRoles")
 	roles := auth.Roles{}
 
-	pg, err := oidcProvider.GetProviderGroups(providerID)
-	if err != nil {
+	roleIDs, found := oidcProvider.GetRolesFromProviderGroups(providerID, groups...)
+	if !found {
 		logger.Infow(
 			"error getting provider groups",
 			"provider", providerID,
@@ -205,18 +252,21 @@ func getRolesFromProviderGroups(oidcProvider *dex.OIDCProvider, providerID strin
 		return roles
 	}
 
-	roleIDs := pg.GetRolesFromGroups(groups...)
 	if len(roleIDs) == 0 {
 		logger.Infow(
 			"no matching groups found in provider groups",
 			"provider", providerID,
-			"providerGroups", pg,
 			"groups", strings.Join(groups, ","),
 		)
 
 		return roles
 	}
 
+	roleIDs, err := expandRoleIDs(ctx, roleIDs)
+	if err != nil {
+		logger.Infow("error expanding namespace patterns of roles", "error", err)
+	}
+
 	for _, fullRoleID := range roleIDs {
 		roleID, namespace := auth.ParseRoleID(fullRoleID)
 
@@ -233,6 +283,40 @@ func getRolesFromProviderGroups(oidcProvider *dex.OIDCProvider, providerID strin
 	return roles
 }
 
+// expandRoleIDs expands the roleIDs for a pattern of namespaces to the existing namespaces
+// matching the pattern. The namespaces are only listed when a pattern is used. On error the
+// roleIDs with patterns are dropped.
+func expandRoleIDs(ctx context.Context, roleIDs []string) ([]string, error) {
+	hasPattern := false
+	for _, roleID := range roleIDs {
+		if _, namespace := auth.ParseRoleID(roleID); auth.IsRoleNamespacePattern(namespace) {
+			hasPattern = true
+			break
+		}
+	}
+	if !hasPattern {
+		return roleIDs, nil
+	}
+
+	namespaceNames := []string{}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return auth.ExpandRoleIDs(roleIDs, namespaceNames), err
+	}
+
+	namespaceList, err := namespaces.List(ctx, cluster)
+	if err != nil {
+		return auth.ExpandRoleIDs(roleIDs, namespaceNames), err
+	}
+
+	for _, namespace := range namespaceList {
+		namespaceNames = append(namespaceNames, namespace.Name)
+	}
+
+	return auth.ExpandRoleIDs(roleIDs, namespaceNames), nil
+}
+
 // getOrCreateUserByEmail returns the user with the matching email, or if it not exists, it will create a new user.
 // If some roles are provided then the user will be created or updated with those roles.
 // If no roles are provided then we are going to check if a 'default' role was set. If so the user will be created
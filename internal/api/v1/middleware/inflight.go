@@ -0,0 +1,35 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// inFlight is the number of requests currently served.
+var inFlight atomic.Int64
+
+// InFlight counts the requests while they are served. Long-running requests, like log streams
+// and interactive sessions, count for as long as they last.
+func InFlight(c *gin.Context) {
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+
+	c.Next()
+}
+
+// InFlightRequests returns the number of requests currently served.
+func InFlightRequests() int64 {
+	return inFlight.Load()
+}
@@ -59,6 +59,11 @@ func Delete(c *gin.Context) apierror.APIErrors {
 			return apierror.InternalError(err)
 		}
 
+		err = application.DeleteSharedCachePVC(ctx, cluster, namespace)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
 		err = deleteServices(ctx, cluster, namespace)
 		if err != nil {
 			return apierror.InternalError(err)
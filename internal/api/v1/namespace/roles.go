@@ -0,0 +1,219 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Roles handles the API endpoint GET /namespaces/:namespace/roles
+// It returns the role bindings of all users in the specified namespace
+func Roles(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	users, _, err := auth.NewAuthService(cluster).GetUsers(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, namespaceRoleBindings(users, namespace))
+	return nil
+}
+
+// RoleGrant handles the API endpoint POST /namespaces/:namespace/roles
+// It assigns the requested role to the user, scoped to the specified namespace. The caller can
+// only grant roles they hold themselves in the namespace, unless they are an admin of it.
+func RoleGrant(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var request models.NamespaceRoleGrantRequest
+	err := c.BindJSON(&request)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	if request.User == "" {
+		return apierror.NewBadRequestError("name of user to grant the role to not found")
+	}
+
+	role, found := auth.EpinioRoles.FindByID(request.Role)
+	if !found {
+		return apierror.NewBadRequestErrorf("role '%s' does not exist", request.Role)
+	}
+
+	if apiErr := checkGrantable(requestctx.User(ctx), role.ID, namespace); apiErr != nil {
+		return apiErr
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	authService := auth.NewAuthService(cluster)
+
+	user, apiErr := getUser(ctx, authService, request.User)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if _, found := user.Roles.FindByIDAndNamespace(role.ID, namespace); found {
+		response.OK(c)
+		return nil
+	}
+
+	role.Namespace = namespace
+	user.Roles = append(user.Roles, role)
+	user.AddNamespace(namespace)
+
+	_, err = authService.UpdateUser(ctx, user)
+	if err != nil {
+		errDetail := fmt.Sprintf("error granting role [%s] in namespace [%s] to user [%s]", role.ID, namespace, user.Username)
+		return apierror.InternalError(err, errDetail)
+	}
+
+	response.Created(c)
+	return nil
+}
+
+// RoleRevoke handles the API endpoint DELETE /namespaces/:namespace/roles/:user/:role
+// It removes the role of the user in the specified namespace. The last admin of a namespace
+// cannot be removed.
+func RoleRevoke(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	username := c.Param("user")
+	roleID := c.Param("role")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	authService := auth.NewAuthService(cluster)
+
+	user, apiErr := getUser(ctx, authService, username)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if _, found := user.Roles.FindByIDAndNamespace(roleID, namespace); !found {
+		return apierror.NewNotFoundError("role binding", fmt.Sprintf("%s:%s", roleID, namespace)).
+			WithDetailsf("user '%s'", username)
+	}
+
+	if roleID == auth.AdminRole.ID {
+		users, _, err := authService.GetUsers(ctx)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		if namespaceAdminCount(users, namespace) <= 1 {
+			return apierror.NewAPIError(
+				fmt.Sprintf("cannot remove the last admin of namespace '%s'", namespace),
+				http.StatusConflict)
+		}
+	}
+
+	user.RemoveNamespaceRole(roleID, namespace)
+
+	_, err = authService.UpdateUser(ctx, user)
+	if err != nil {
+		errDetail := fmt.Sprintf("error revoking role [%s] in namespace [%s] from user [%s]", roleID, namespace, username)
+		return apierror.InternalError(err, errDetail)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// checkGrantable returns a forbidden error if the caller is not allowed to hand out the role in
+// the namespace, i.e. neither holds it there nor is an admin of the namespace.
+func checkGrantable(caller auth.User, roleID, namespace string) apierror.APIErrors {
+	if caller.CanDelegate(roleID, namespace) {
+		return nil
+	}
+	return apierror.NewAPIError(
+		fmt.Sprintf("user '%s' cannot grant role '%s' in namespace '%s'", caller.Username, roleID, namespace),
+		http.StatusForbidden)
+}
+
+// getUser returns the named user, or a not found error if there is no such user.
+func getUser(ctx context.Context, authService *auth.AuthService, username string) (auth.User, apierror.APIErrors) {
+	user, err := authService.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return auth.User{}, apierror.NewNotFoundError("user", username)
+		}
+		return auth.User{}, apierror.InternalError(err)
+	}
+	return user, nil
+}
+
+// namespaceRoleBindings returns the role bindings of the users in the namespace, sorted by user
+// and role.
+func namespaceRoleBindings(users []auth.User, namespace string) models.NamespaceRoleBindingList {
+	bindings := models.NamespaceRoleBindingList{}
+
+	for _, user := range users {
+		for _, role := range user.Roles {
+			if role.Namespace != namespace {
+				continue
+			}
+			bindings = append(bindings, models.NamespaceRoleBinding{
+				User:      user.Username,
+				Namespace: namespace,
+				Role:      role.ID,
+			})
+		}
+	}
+
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].User != bindings[j].User {
+			return bindings[i].User < bindings[j].User
+		}
+		return bindings[i].Role < bindings[j].Role
+	})
+
+	return bindings
+}
+
+// namespaceAdminCount returns the number of users holding the admin role in the namespace.
+func namespaceAdminCount(users []auth.User, namespace string) int {
+	count := 0
+	for _, user := range users {
+		if _, found := user.Roles.FindByIDAndNamespace(auth.AdminRole.ID, namespace); found {
+			count++
+		}
+	}
+	return count
+}
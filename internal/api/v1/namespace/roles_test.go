@@ -0,0 +1,89 @@
+package namespace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestNamespaceRoleBindings(t *testing.T) {
+	users := []auth.User{
+		{Username: "zoe", Roles: auth.Roles{{ID: "user", Namespace: "workspace"}}},
+		{Username: "adam", Roles: auth.Roles{
+			{ID: "user", Namespace: "workspace"},
+			{ID: "admin", Namespace: "workspace"},
+			{ID: "admin", Namespace: "other"},
+			{ID: "admin"},
+		}},
+		{Username: "eve", Roles: auth.Roles{{ID: "admin", Namespace: "other"}}},
+	}
+
+	got := namespaceRoleBindings(users, "workspace")
+	want := models.NamespaceRoleBindingList{
+		{User: "adam", Namespace: "workspace", Role: "admin"},
+		{User: "adam", Namespace: "workspace", Role: "user"},
+		{User: "zoe", Namespace: "workspace", Role: "user"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d bindings, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("binding %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	if got := namespaceRoleBindings(users, "unknown"); len(got) != 0 {
+		t.Errorf("expected no bindings, got %v", got)
+	}
+}
+
+func TestNamespaceAdminCount(t *testing.T) {
+	users := []auth.User{
+		{Username: "adam", Roles: auth.Roles{{ID: "admin", Namespace: "workspace"}, {ID: "admin"}}},
+		{Username: "eve", Roles: auth.Roles{{ID: "admin", Namespace: "other"}}},
+		{Username: "zoe", Roles: auth.Roles{{ID: "user", Namespace: "workspace"}}},
+	}
+
+	for namespace, want := range map[string]int{"workspace": 1, "other": 1, "unknown": 0} {
+		if got := namespaceAdminCount(users, namespace); got != want {
+			t.Errorf("namespace %s: expected %d admins, got %d", namespace, want, got)
+		}
+	}
+}
+
+func TestCheckGrantable(t *testing.T) {
+	nsAdmin := auth.User{Username: "adam", Roles: auth.Roles{{ID: "admin", Namespace: "workspace"}}}
+	nsUser := auth.User{Username: "zoe", Roles: auth.Roles{{ID: "user", Namespace: "workspace"}}}
+	admin := auth.User{Username: "root", Roles: auth.Roles{{ID: "admin"}}}
+
+	for _, tc := range []struct {
+		caller    auth.User
+		role      string
+		namespace string
+		allowed   bool
+	}{
+		{admin, "admin", "workspace", true},
+		{nsAdmin, "admin", "workspace", true},
+		{nsAdmin, "user", "workspace", true},
+		{nsAdmin, "admin", "other", false},
+		{nsUser, "user", "workspace", true},
+		{nsUser, "admin", "workspace", false},
+		{nsUser, "user", "other", false},
+	} {
+		apiErr := checkGrantable(tc.caller, tc.role, tc.namespace)
+		if tc.allowed && apiErr != nil {
+			t.Errorf("%s granting %s in %s: unexpected error %v", tc.caller.Username, tc.role, tc.namespace, apiErr)
+		}
+		if !tc.allowed {
+			if apiErr == nil {
+				t.Errorf("%s granting %s in %s: expected forbidden", tc.caller.Username, tc.role, tc.namespace)
+			} else if status := apiErr.FirstStatus(); status != http.StatusForbidden {
+				t.Errorf("%s granting %s in %s: expected status 403, got %d", tc.caller.Username, tc.role, tc.namespace, status)
+			}
+		}
+	}
+}
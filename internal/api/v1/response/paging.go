@@ -0,0 +1,49 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Page returns the page of the items starting at the offset, with at most limit items. A limit
+// of zero places no limit. Offsets past the end result in an empty page. The items are expected
+// to be in the order of the listing.
+func Page[T any](items []T, limit, offset int) []T {
+	total := len(items)
+	if offset >= total {
+		return []T{}
+	}
+
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+
+	return items[offset:end]
+}
+
+// ListNumber parses a limit or offset of a listing. Empty values stand for zero.
+func ListNumber(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a number", value)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%d is negative", n)
+	}
+	return n, nil
+}
@@ -0,0 +1,52 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPage(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+
+	for _, tt := range []struct {
+		limit, offset int
+		want          []string
+	}{
+		{0, 0, []string{"a", "b", "c", "d"}},
+		{2, 0, []string{"a", "b"}},
+		{2, 3, []string{"d"}},
+		{0, 1, []string{"b", "c", "d"}},
+		{2, 4, []string{}},
+		{2, 10, []string{}},
+	} {
+		if got := Page(items, tt.limit, tt.offset); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("limit %d, offset %d: expected %v, got %v", tt.limit, tt.offset, tt.want, got)
+		}
+	}
+}
+
+func TestListNumber(t *testing.T) {
+	if n, err := ListNumber(""); err != nil || n != 0 {
+		t.Fatalf("expected 0 for an empty value, got %d, %v", n, err)
+	}
+	if n, err := ListNumber("25"); err != nil || n != 25 {
+		t.Fatalf("expected 25, got %d, %v", n, err)
+	}
+	if _, err := ListNumber("-1"); err == nil {
+		t.Fatal("expected an error for a negative value")
+	}
+	if _, err := ListNumber("ten"); err == nil {
+		t.Fatal("expected an error for a non-number")
+	}
+}
@@ -15,12 +15,14 @@ package response
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/gin-gonic/gin"
 
 	"github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"sigs.k8s.io/yaml"
 )
 
 // OK reports a generic success
@@ -43,6 +45,16 @@ func OKBytes(c *gin.Context, response []byte) {
 	c.Data(http.StatusOK, "application/octet-stream", response)
 }
 
+// OKMetrics reports a success with metrics in the Prometheus text exposition format
+func OKMetrics(c *gin.Context, response []byte) {
+	helpers.Logger.Infow("OK",
+		"origin", c.Request.URL.String(),
+		"returning", "metrics",
+	)
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", response)
+}
+
 // OKYaml reports a success with some YAML data
 func OKYaml(c *gin.Context, response interface{}) {
 	helpers.Logger.Infow("OK",
@@ -53,6 +65,16 @@ func OKYaml(c *gin.Context, response interface{}) {
 	c.YAML(http.StatusOK, response)
 }
 
+// OKYamlBytes reports a success with some data already rendered as YAML
+func OKYamlBytes(c *gin.Context, response []byte) {
+	helpers.Logger.Infow("OK",
+		"origin", c.Request.URL.String(),
+		"returning", string(response),
+	)
+
+	c.Data(http.StatusOK, "application/x-yaml; charset=utf-8", response)
+}
+
 // OKReturn reports a success with some data
 func OKReturn(c *gin.Context, response interface{}) {
 	// SECURITY: Log only response type/summary to avoid potential secret exposure in logs.
@@ -65,6 +87,48 @@ func OKReturn(c *gin.Context, response interface{}) {
 	c.JSON(http.StatusOK, response)
 }
 
+// OKNegotiated reports a success with some data. The data is returned as YAML when the client
+// asked for it, and as JSON otherwise. See `WantsYAML`.
+func OKNegotiated(c *gin.Context, response interface{}) {
+	if !WantsYAML(c) {
+		OKReturn(c, response)
+		return
+	}
+
+	helpers.Logger.Infow("OK",
+		"origin", c.Request.URL.String(),
+		"response_type", fmt.Sprintf("%T", response),
+		"format", "yaml",
+	)
+
+	// Note: Using the JSON tags of the models, to keep the YAML keys identical to the JSON keys.
+	data, err := yaml.Marshal(response)
+	if err != nil {
+		Error(c, errors.InternalError(err))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/yaml", data)
+}
+
+// WantsYAML returns true if the request asked for a YAML response, either through the `format`
+// query parameter, or the `Accept` header.
+func WantsYAML(c *gin.Context) bool {
+	if format := c.Query("format"); format != "" {
+		return format == "yaml"
+	}
+
+	for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.Split(accepted, ";")[0])
+		switch mediaType {
+		case "application/yaml", "application/x-yaml", "text/yaml":
+			return true
+		}
+	}
+
+	return false
+}
+
 // Created reports successful creation of a resource.
 func Created(c *gin.Context) {
 	helpers.Logger.Infow("CREATED",
@@ -15,14 +15,17 @@ package v1
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
 	"runtime"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/routes"
+	"github.com/epinio/epinio/internal/api/v1/apitoken"
 	"github.com/epinio/epinio/internal/api/v1/appchart"
 	"github.com/epinio/epinio/internal/api/v1/application"
 	"github.com/epinio/epinio/internal/api/v1/configuration"
@@ -31,12 +34,14 @@ import (
 	"github.com/epinio/epinio/internal/api/v1/exportregistry"
 	"github.com/epinio/epinio/internal/api/v1/gitconfig"
 	"github.com/epinio/epinio/internal/api/v1/gitproxy"
+	"github.com/epinio/epinio/internal/api/v1/maintenance"
 	"github.com/epinio/epinio/internal/api/v1/namespace"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/api/v1/service"
 	"github.com/epinio/epinio/internal/api/v1/supportbundle"
 	"github.com/epinio/epinio/internal/auth"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/sessions"
 
 	"github.com/epinio/epinio/pkg/api/core/v1/errors"
 )
@@ -58,6 +63,31 @@ func ErrorHandler(action APIActionFunc) gin.HandlerFunc {
 	return errorHandler(action)
 }
 
+// sessionLimited limits the number of concurrent interactive sessions handled by the handler,
+// globally, and per user, as configured for the server. A session beyond the limits waits for a
+// free slot, for the configured time, and is rejected with status 429 if none becomes free.
+// The check happens before the connection is upgraded.
+func sessionLimited(h gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user := requestctx.User(ctx).Username
+
+		release, err := sessions.Default.Acquire(ctx, user, sessions.Limits{
+			Global:  viper.GetInt("session-limit"),
+			PerUser: viper.GetInt("session-user-limit"),
+			Wait:    viper.GetDuration("session-queue-timeout"),
+		})
+		if err != nil {
+			helpers.Logger.Infow("interactive session rejected", "user", user, "path", c.FullPath())
+			response.Error(c, errors.NewAPIError(err.Error(), http.StatusTooManyRequests))
+			return
+		}
+		defer release()
+
+		h(c)
+	}
+}
+
 func funcName(i interface{}) string {
 	return runtime.FuncForPC(reflect.ValueOf(i).Pointer()).Name()
 }
@@ -104,18 +134,30 @@ func put(path string, h gin.HandlerFunc) routes.Route {
 // AdminRoutes is the list of restricted routes, only accessible by admins
 // The key is the full path as it appears in the request URL (e.g., "/api/v1/support-bundle")
 var AdminRoutes map[string]struct{} = map[string]struct{}{
-	"/api/v1/support-bundle": {},
+	"/api/v1/support-bundle":                {},
+	"/api/v1/maintenance/registry/prune":    {},
+	"/api/v1/maintenance/catalog/health":    {},
+	"/api/v1/maintenance/sessions":          {},
+	"/api/v1/maintenance/operations":        {},
+	"/api/v1/maintenance/operations/cancel": {},
 }
 
 var Routes = routes.NamedRoutes{
 	"AuthToken": get("/authtoken", errorHandler(AuthToken)),
 
+	// API tokens of service accounts - List, create and revoke.
+	"APITokens":      get("/apitokens", errorHandler(apitoken.Index)),
+	"APITokenCreate": post("/apitokens", errorHandler(apitoken.Create)),
+	"APITokenDelete": delete("/apitokens/:name", errorHandler(apitoken.Delete)),
+
 	// app controller files see application/*.go
 
 	"AllApps":         get("/applications", errorHandler(application.FullIndex)),
 	"Apps":            get("/namespaces/:namespace/applications", errorHandler(application.Index)),
 	"AppCreate":       post("/namespaces/:namespace/applications", errorHandler(application.Create)),
 	"AppShow":         get("/namespaces/:namespace/applications/:app", errorHandler(application.Show)),
+	"AppStatuses":     get("/namespaces/:namespace/appstatuses", errorHandler(application.Statuses)),
+	"AppMetrics":      get("/namespaces/:namespace/metrics", errorHandler(application.Metrics)),
 	"StagingComplete": get("/namespaces/:namespace/staging/:stage_id/complete", errorHandler(application.Staged)), // See stage.go
 	"AppDelete":       delete("/namespaces/:namespace/applications/:app", errorHandler(application.Delete)),
 	"AppBatchDelete":  delete("/namespaces/:namespace/applications", errorHandler(application.Delete)),
@@ -123,12 +165,21 @@ var Routes = routes.NamedRoutes{
 	"AppImportGit":    post("/namespaces/:namespace/applications/:app/import-git", errorHandler(application.ImportGit)),
 	"AppPart":         get("/namespaces/:namespace/applications/:app/part/:part", errorHandler(application.GetPart)),
 	"AppRestart":      post("/namespaces/:namespace/applications/:app/restart", errorHandler(application.Restart)),
+	"AppStop":         post("/namespaces/:namespace/applications/:app/stop", errorHandler(application.Stop)),
+	"AppStart":        post("/namespaces/:namespace/applications/:app/start", errorHandler(application.Start)),
 	"AppRunning":      get("/namespaces/:namespace/applications/:app/running", errorHandler(application.Running)),
 	"AppStage":        post("/namespaces/:namespace/applications/:app/stage", errorHandler(application.Stage)), // See stage.go
 	"AppUpdate":       patch("/namespaces/:namespace/applications/:app", errorHandler(application.Update)),
 	"AppUpload":       post("/namespaces/:namespace/applications/:app/store", errorHandler(application.Upload)), // See upload.go
 	"AppValidateCV":   get("/namespaces/:namespace/applications/:app/validate-cv", errorHandler(application.ValidateChartValues)),
 	"AppExport":       post("/namespaces/:namespace/applications/:app/export", errorHandler(application.ExportToRegistry)),
+	"AppExpiry":       post("/namespaces/:namespace/applications/:app/expiry", errorHandler(application.Expiry)),
+	"AppHistory":      get("/namespaces/:namespace/applications/:app/history", errorHandler(application.History)),
+	"AppEvents":       get("/namespaces/:namespace/applications/:app/events", errorHandler(application.Events)),
+
+	"AppHistoryManifest": get("/namespaces/:namespace/applications/:app/history/:revision/manifest", errorHandler(application.HistoryManifest)),
+
+	"AppLogSearch": get("/namespaces/:namespace/applications/:app/logs/search", errorHandler(application.LogSearch)),
 
 	"AppMatch":  get("/namespaces/:namespace/appsmatches/:pattern", errorHandler(application.Match)),
 	"AppMatch0": get("/namespaces/:namespace/appsmatches", errorHandler(application.Match)),
@@ -157,6 +208,11 @@ var Routes = routes.NamedRoutes{
 	"NamespaceBatchDelete": delete("/namespaces", errorHandler(namespace.Delete)),
 	"NamespaceShow":        get("/namespaces/:namespace", errorHandler(namespace.Show)),
 
+	// List, grant and revoke the roles of users in a namespace
+	"NamespaceRoles":      get("/namespaces/:namespace/roles", errorHandler(namespace.Roles)),
+	"NamespaceRoleGrant":  post("/namespaces/:namespace/roles", errorHandler(namespace.RoleGrant)),
+	"NamespaceRoleRevoke": delete("/namespaces/:namespace/roles/:user/:role", errorHandler(namespace.RoleRevoke)),
+
 	// Note, the second registration catches calls with an empty pattern!
 	"NamespacesMatch":  get("/namespacematches/:pattern", errorHandler(namespace.Match)),
 	"NamespacesMatch0": get("/namespacematches", errorHandler(namespace.Match)),
@@ -177,8 +233,9 @@ var Routes = routes.NamedRoutes{
 	"ConfigurationMatch0": get("/namespaces/:namespace/configurationsmatches", errorHandler(configuration.Match)),
 
 	// Service Catalog
-	"ServiceCatalog":     get("/catalogservices", errorHandler(service.Catalog)),
-	"ServiceCatalogShow": get("/catalogservices/:catalogservice", errorHandler(service.CatalogShow)),
+	"ServiceCatalog":         get("/catalogservices", errorHandler(service.Catalog)),
+	"ServiceCatalogShow":     get("/catalogservices/:catalogservice", errorHandler(service.CatalogShow)),
+	"ServiceCatalogValidate": post("/catalogservices/:catalogservice/validate", errorHandler(service.CatalogValidate)),
 
 	// Note, the second registration catches calls with an empty pattern!
 	"ServiceCatalogMatch":  get("catalogservicesmatches/:pattern", errorHandler(service.CatalogMatch)),
@@ -187,14 +244,24 @@ var Routes = routes.NamedRoutes{
 	// Services
 	"ServiceApps": get("/namespaces/:namespace/serviceapps", errorHandler(service.ServiceApps)),
 	//
-	"AllServices":        get("/services", errorHandler(service.FullIndex)),
-	"ServiceCreate":      post("/namespaces/:namespace/services", errorHandler(service.Create)),
-	"ServiceList":        get("/namespaces/:namespace/services", errorHandler(service.List)),
-	"ServiceShow":        get("/namespaces/:namespace/services/:service", errorHandler(service.Show)),
-	"ServiceDelete":      delete("/namespaces/:namespace/services/:service", errorHandler(service.Delete)),
-	"ServiceBatchDelete": delete("/namespaces/:namespace/services", errorHandler(service.Delete)),
-	"ServiceUpdate":      patch("/namespaces/:namespace/services/:service", errorHandler(service.Update)),
-	"ServiceReplace":     put("/namespaces/:namespace/services/:service", errorHandler(service.Replace)),
+	"AllServices":           get("/services", errorHandler(service.FullIndex)),
+	"ServiceCreate":         post("/namespaces/:namespace/services", errorHandler(service.Create)),
+	"ServiceList":           get("/namespaces/:namespace/services", errorHandler(service.List)),
+	"ServiceShow":           get("/namespaces/:namespace/services/:service", errorHandler(service.Show)),
+	"ServiceDelete":         delete("/namespaces/:namespace/services/:service", errorHandler(service.Delete)),
+	"ServiceBatchDelete":    delete("/namespaces/:namespace/services", errorHandler(service.Delete)),
+	"ServiceUpdate":         patch("/namespaces/:namespace/services/:service", errorHandler(service.Update)),
+	"ServiceReplace":        put("/namespaces/:namespace/services/:service", errorHandler(service.Replace)),
+	"ServiceReset":          post("/namespaces/:namespace/services/:service/reset", errorHandler(service.Reset)),
+	"ServiceCatalogService": get("/namespaces/:namespace/services/:service/catalogservice", errorHandler(service.ServiceCatalogService)),
+	"ServiceValues":         get("/namespaces/:namespace/services/:service/values", errorHandler(service.Values)),
+	"ServiceOriginalValues": get("/namespaces/:namespace/services/:service/originalvalues", errorHandler(service.OriginalValues)),
+	"ServiceEvents":         get("/namespaces/:namespace/services/:service/events", errorHandler(service.Events)),
+	"ServiceSuspend":        post("/namespaces/:namespace/services/:service/suspend", errorHandler(service.Suspend)),
+	"ServiceResume":         post("/namespaces/:namespace/services/:service/resume", errorHandler(service.Resume)),
+	"ServiceExpiry":         post("/namespaces/:namespace/services/:service/expiry", errorHandler(service.Expiry)),
+	"ServiceRestart":        post("/namespaces/:namespace/services/:service/restart", errorHandler(service.Restart)),
+	"ServiceClone":          post("/namespaces/:namespace/services/:service/clone", errorHandler(service.Clone)),
 
 	"ServiceMatch":  get("/namespaces/:namespace/servicesmatches/:pattern", errorHandler(service.Match)),
 	"ServiceMatch0": get("/namespaces/:namespace/servicesmatches", errorHandler(service.Match)),
@@ -214,6 +281,11 @@ var Routes = routes.NamedRoutes{
 		"/namespaces/:namespace/applications/:app/servicebindings",
 		errorHandler(service.BatchBind)),
 
+	// Move an application between services, unbinding some and binding others at once
+	"ServiceRebind": post(
+		"/namespaces/:namespace/applications/:app/servicerebind",
+		errorHandler(service.Rebind)),
+
 	// App charts
 	"ChartList":   get("/appcharts", errorHandler(appchart.Index)),
 	"ChartMatch":  get("/appchartsmatch/:pattern", errorHandler(appchart.Match)),
@@ -239,15 +311,27 @@ var Routes = routes.NamedRoutes{
 
 	// Support bundle
 	"SupportBundle": get("/support-bundle", errorHandler(supportbundle.Bundle)),
+
+	// Maintenance
+	"RegistryPrune":   post("/maintenance/registry/prune", errorHandler(maintenance.PruneRegistry)),
+	"CatalogHealth":   get("/maintenance/catalog/health", errorHandler(maintenance.CatalogHealth)),
+	"SessionStats":    get("/maintenance/sessions", errorHandler(maintenance.SessionStats)),
+	"Operations":      get("/maintenance/operations", errorHandler(maintenance.Operations)),
+	"OperationCancel": post("/maintenance/operations/cancel", errorHandler(maintenance.OperationCancel)),
 }
 
 var WsRoutes = routes.NamedRoutes{
-	"AppExec":            get("/namespaces/:namespace/applications/:app/exec", errorHandler(application.Exec)),
-	"AppPortForward":     get("/namespaces/:namespace/applications/:app/portforward", errorHandler(application.PortForward)),
+	"AppExec":            get("/namespaces/:namespace/applications/:app/exec", sessionLimited(errorHandler(application.Exec))),
+	"AppPortForward":     get("/namespaces/:namespace/applications/:app/portforward", sessionLimited(errorHandler(application.PortForward))),
+	"AppDebug":           get("/namespaces/:namespace/applications/:app/debug", sessionLimited(errorHandler(application.Debug))),
 	"AppLogs":            get("/namespaces/:namespace/applications/:app/logs", application.Logs),
-	"ServicePortForward": get("/namespaces/:namespace/services/:service/portforward", errorHandler(service.PortForward)),
+	"AppPushLogs":        get("/namespaces/:namespace/applications/:app/pushlogs/:stage_id", application.PushLogs),
+	"AppStagingLogs":     get("/namespaces/:namespace/applications/:app/staging/logs", application.StagingLogsFollow),
+	"ServicePortForward": get("/namespaces/:namespace/services/:service/portforward", sessionLimited(errorHandler(service.PortForward))),
+	"ServiceProgressWs":  get("/namespaces/:namespace/services/:service/progress", service.ProgressWebsocket),
 	"StagingLogs":        get("/namespaces/:namespace/staging/:stage_id/logs", application.Logs),
 	"StagingCompleteWs":  get("/namespaces/:namespace/staging/:stage_id/complete", application.StagedWebsocket),
+	"StagingCacheWs":     get("/namespaces/:namespace/staging/:stage_id/cache", application.StagingCacheWebsocket),
 }
 
 // Lemon extends the specified router with the methods and urls
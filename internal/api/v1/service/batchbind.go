@@ -12,6 +12,11 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/configurationbinding"
@@ -19,13 +24,17 @@ import (
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/configurations"
 	"github.com/gin-gonic/gin"
+	v1 "k8s.io/api/core/v1"
 
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
 
 // BatchBind handles the API endpoint /namespaces/:namespace/applications/:app/servicebindings (POST)
-// It creates bindings between multiple services and the specified application in a single operation
+// It creates bindings between multiple services and the specified application in a single operation,
+// and returns the configurations each service contributes to the application. By default nothing is
+// bound when any service fails. In partial mode, requested by the body or `partial=true`, the failing
+// services are skipped, and the result of each service is returned.
 func BatchBind(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	logger := helpers.Logger.With("component", "ServiceBatchBind")
@@ -39,10 +48,16 @@ func BatchBind(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequestError(err.Error())
 	}
 
-	if len(bindRequest.ServiceNames) == 0 {
-		return apierror.NewBadRequestError("no services specified for binding")
+	apiErr := validateBatchBindRequest(appName, bindRequest)
+	if apiErr != nil {
+		return apiErr
 	}
 
+	partial := bindRequest.Partial || c.Query("partial") == "true"
+
+	// backward compatibility: if no flag provided then restart the app
+	restart := bindRequest.Restart == nil || *bindRequest.Restart
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -60,27 +75,27 @@ func BatchBind(c *gin.Context) apierror.APIErrors {
 	// Collect all configuration names from all services
 	allConfigurationNames := []string{}
 	servicesToBind := []string{}
+	bindingKeys := []models.ServiceBindingKeys{}
+	results := newBatchBindResults(bindRequest.ServiceNames)
+
+	// Bind in lexicographic order of the services, regardless of the request order. This keeps
+	// the bound configurations, and the response, stable.
+	serviceNames := append([]string{}, bindRequest.ServiceNames...)
+	sort.Strings(serviceNames)
 
 	// Validate all services first before making any changes
-	for _, serviceName := range bindRequest.ServiceNames {
+	for _, serviceName := range serviceNames {
 		logger.Infow("validating service", "service", serviceName)
 
-		service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+		configurationSecrets, apiErr := serviceConfigurationSecrets(ctx, cluster, namespace, serviceName)
 		if apiErr != nil {
-			return apiErr
-		}
+			if !partial {
+				return apiErr
+			}
 
-		apiErr = ValidateService(ctx, cluster, service)
-		if apiErr != nil {
-			return apiErr
-		}
-
-		// Get and label the service secrets to turn them into configurations
-		logger.Infow("looking for secrets to label", "service", serviceName)
-
-		configurationSecrets, err := configurations.LabelServiceSecrets(ctx, cluster, service)
-		if err != nil {
-			return apierror.InternalError(err)
+			logger.Infow("skipping service", "service", serviceName, "error", errorsMessage(apiErr))
+			results.fail([]string{serviceName}, apiErr)
+			continue
 		}
 
 		logger.Infow("configurationSecrets found", "service", serviceName, "secrets", configurationSecrets)
@@ -91,17 +106,43 @@ func BatchBind(c *gin.Context) apierror.APIErrors {
 		}
 
 		servicesToBind = append(servicesToBind, serviceName)
+		bindingKeys = append(bindingKeys, serviceBindingKeys(serviceName, configurationSecrets))
+	}
+
+	if len(servicesToBind) == 0 {
+		// Partial mode only, all services failed
+		response.OKReturn(c, models.ServiceBatchBindResponse{
+			ServiceBindResponse: models.ServiceBindResponse{Response: models.ResponseOK},
+			Results:             results.list(),
+		})
+		return nil
+	}
+
+	if appliesOnDeploy(*app) {
+		logger.Infow("application not deployed, bindings apply on deploy", "app", appName)
 	}
 
-	// Now bind all configurations at once - this triggers a SINGLE deployment
+	// Now bind all configurations at once - this triggers a SINGLE deployment, if the
+	// application is deployed at all
 	logger.Infow("binding all service configurations", "count", len(allConfigurationNames))
 
 	_, errors := configurationbinding.CreateConfigurationBinding(
-		ctx, cluster, namespace, *app, allConfigurationNames,
+		ctx, cluster, namespace, *app, allConfigurationNames, restart,
 	)
 
 	if errors != nil {
-		return apierror.NewMultiError(errors.Errors())
+		if !partial {
+			return apierror.NewMultiError(errors.Errors())
+		}
+
+		// The configurations are bound together, their failure cannot be attributed to a
+		// single service.
+		results.fail(servicesToBind, errors)
+		response.OKReturn(c, models.ServiceBatchBindResponse{
+			ServiceBindResponse: models.ServiceBindResponse{Response: models.ResponseOK},
+			Results:             results.list(),
+		})
+		return nil
 	}
 
 	// Track all service bindings
@@ -115,6 +156,138 @@ func BatchBind(c *gin.Context) apierror.APIErrors {
 
 	logger.Infow("successfully bound services", "count", len(servicesToBind), "services", servicesToBind)
 
-	response.OK(c)
+	bindResponse := models.ServiceBindResponse{
+		Response:       models.ResponseOK,
+		Services:       bindingKeys,
+		ApplyOnDeploy:  appliesOnDeploy(*app),
+		ApplyOnRestart: appliesOnRestart(*app, restart),
+	}
+
+	if !partial {
+		response.OKReturn(c, bindResponse)
+		return nil
+	}
+
+	results.bound(servicesToBind)
+	response.OKReturn(c, models.ServiceBatchBindResponse{
+		ServiceBindResponse: bindResponse,
+		Results:             results.list(),
+	})
+	return nil
+}
+
+// serviceConfigurationSecrets checks that the named service can be bound, and returns its
+// secrets, labeled to turn them into configurations.
+func serviceConfigurationSecrets(
+	ctx context.Context, cluster *kubernetes.Cluster,
+	namespace, serviceName string,
+) ([]v1.Secret, apierror.APIErrors) {
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	apiErr = ValidateService(ctx, cluster, service)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	configurationSecrets, err := configurations.LabelServiceSecrets(ctx, cluster, service)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	return configurationSecrets, nil
+}
+
+// batchBindResults collects the results of a partial batch bind, keeping the order of the
+// requested services.
+type batchBindResults struct {
+	names   []string
+	results map[string]models.ServiceBatchBindResult
+}
+
+func newBatchBindResults(names []string) *batchBindResults {
+	return &batchBindResults{
+		names:   names,
+		results: map[string]models.ServiceBatchBindResult{},
+	}
+}
+
+// fail records the failure of the named services.
+func (r *batchBindResults) fail(services []string, apiErr apierror.APIErrors) {
+	for _, service := range services {
+		r.results[service] = models.ServiceBatchBindResult{
+			Service: service,
+			Status:  models.ServiceBindStatusFailed,
+			Error:   errorsMessage(apiErr),
+		}
+	}
+}
+
+// bound records the success of the named services.
+func (r *batchBindResults) bound(services []string) {
+	for _, service := range services {
+		r.results[service] = models.ServiceBatchBindResult{
+			Service: service,
+			Status:  models.ServiceBindStatusBound,
+		}
+	}
+}
+
+// list returns the recorded results, in request order.
+func (r *batchBindResults) list() []models.ServiceBatchBindResult {
+	list := []models.ServiceBatchBindResult{}
+	for _, name := range r.names {
+		if result, found := r.results[name]; found {
+			list = append(list, result)
+		}
+	}
+	return list
+}
+
+// errorsMessage flattens the errors into a single message.
+func errorsMessage(apiErr apierror.APIErrors) string {
+	messages := []string{}
+	for _, err := range apiErr.Errors() {
+		message := err.Title
+		if err.Details != "" {
+			message = fmt.Sprintf("%s: %s", message, err.Details)
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validateBatchBindRequest checks the request body before any work is done. The services to bind
+// have to be specified, and without duplicates. An application name in the body has to match
+// the application in the path.
+func validateBatchBindRequest(appName string, bindRequest models.ServiceBatchBindRequest) apierror.APIErrors {
+	if bindRequest.AppName != "" && bindRequest.AppName != appName {
+		return apierror.NewBadRequestErrorf("application name in body (%s) does not match the application in the path (%s)",
+			bindRequest.AppName, appName)
+	}
+
+	if len(bindRequest.ServiceNames) == 0 {
+		return apierror.NewBadRequestError("no services specified for binding")
+	}
+
+	seen := map[string]int{}
+	duplicates := []string{}
+	for _, serviceName := range bindRequest.ServiceNames {
+		if serviceName == "" {
+			return apierror.NewBadRequestError("empty service name specified for binding")
+		}
+		seen[serviceName]++
+		if seen[serviceName] == 2 {
+			duplicates = append(duplicates, serviceName)
+		}
+	}
+
+	if len(duplicates) > 0 {
+		return apierror.NewBadRequestErrorf("services specified more than once: %s",
+			strings.Join(duplicates, ", "))
+	}
+
 	return nil
 }
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestValidateBatchBindRequestAccepts(t *testing.T) {
+	for _, appName := range []string{"", "my-app"} {
+		errs := validateBatchBindRequest("my-app", models.ServiceBatchBindRequest{
+			AppName:      appName,
+			ServiceNames: []string{"s1", "s2"},
+		})
+		if errs != nil {
+			t.Fatalf("expected no errors for body app name %q, got %v", appName, errs)
+		}
+	}
+}
+
+func TestValidateBatchBindRequestRejectsAppMismatch(t *testing.T) {
+	errs := validateBatchBindRequest("my-app", models.ServiceBatchBindRequest{
+		AppName:      "other-app",
+		ServiceNames: []string{"s1"},
+	})
+	if errs == nil {
+		t.Fatal("expected an error for mismatched application names")
+	}
+	if errs.FirstStatus() != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", errs.FirstStatus())
+	}
+}
+
+func TestValidateBatchBindRequestRejectsDuplicates(t *testing.T) {
+	errs := validateBatchBindRequest("my-app", models.ServiceBatchBindRequest{
+		ServiceNames: []string{"s1", "s2", "s1", "s3", "s2", "s1"},
+	})
+	if errs == nil {
+		t.Fatal("expected an error for duplicated services")
+	}
+	if errs.FirstStatus() != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", errs.FirstStatus())
+	}
+
+	message := errs.Errors()[0].Title
+	if !strings.HasSuffix(message, "s1, s2") {
+		t.Fatalf("expected duplicates s1, s2 to be listed once each, got %q", message)
+	}
+}
+
+func TestValidateBatchBindRequestRejectsEmpty(t *testing.T) {
+	for _, names := range [][]string{nil, {"s1", ""}} {
+		errs := validateBatchBindRequest("my-app", models.ServiceBatchBindRequest{ServiceNames: names})
+		if errs == nil {
+			t.Fatalf("expected an error for services %v", names)
+		}
+	}
+}
+
+func TestBatchBindResultsKeepRequestOrder(t *testing.T) {
+	results := newBatchBindResults([]string{"db", "cache", "queue"})
+
+	results.fail([]string{"cache"}, apierror.NewNotFoundError("service", "cache"))
+	results.bound([]string{"db", "queue"})
+
+	expected := []models.ServiceBatchBindResult{
+		{Service: "db", Status: models.ServiceBindStatusBound},
+		{Service: "cache", Status: models.ServiceBindStatusFailed, Error: "service 'cache' does not exist"},
+		{Service: "queue", Status: models.ServiceBindStatusBound},
+	}
+	if got := results.list(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestErrorsMessage(t *testing.T) {
+	apiErr := apierror.NewMultiError([]apierror.APIError{
+		apierror.NewBadRequestError("first"),
+		apierror.NewBadRequestError("second").WithDetails("more"),
+	})
+
+	if got := errorsMessage(apiErr); got != "first; second: more" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
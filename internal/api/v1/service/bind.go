@@ -25,7 +25,8 @@ import (
 )
 
 // Bind handles the API endpoint /namespaces/:namespace/services/:service/bind (POST)
-// It creates a binding between the specified service and application
+// It creates a binding between the specified service and application, and returns the
+// configurations the service contributes to the application.
 func Bind(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	logger := helpers.Logger.With("component", "ServiceBind")
@@ -81,10 +82,17 @@ func Bind(c *gin.Context) apierror.APIErrors {
 		configurationNames = append(configurationNames, secret.Name)
 	}
 
+	if appliesOnDeploy(*app) {
+		logger.Infow("application not deployed, binding applies on deploy")
+	}
+
+	// backward compatibility: if no flag provided then restart the app
+	restart := bindRequest.Restart == nil || *bindRequest.Restart
+
 	logger.Infow("binding service configuration")
 
 	_, errors := configurationbinding.CreateConfigurationBinding(
-		ctx, cluster, namespace, *app, configurationNames,
+		ctx, cluster, namespace, *app, configurationNames, restart,
 	)
 
 	if errors != nil {
@@ -104,6 +112,25 @@ func Bind(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	response.OK(c)
+	response.OKReturn(c, models.ServiceBindResponse{
+		Response:       models.ResponseOK,
+		Services:       []models.ServiceBindingKeys{serviceBindingKeys(serviceName, configurationSecrets)},
+		ApplyOnDeploy:  appliesOnDeploy(*app),
+		ApplyOnRestart: appliesOnRestart(*app, restart),
+	})
 	return nil
 }
+
+// appliesOnDeploy returns true when the application has no workload yet, i.e. it was created, but
+// not deployed. Binding to such an application only records the binding, without a deployment.
+// The first deployment of the application then picks it up.
+func appliesOnDeploy(app models.App) bool {
+	return app.Workload == nil
+}
+
+// appliesOnRestart returns true when the restart of the deployed application was declined.
+// Binding then only records the binding, leaving the workload as is. The next restart of the
+// application redeploys it with the binding, as does its next deployment.
+func appliesOnRestart(app models.App, restart bool) bool {
+	return app.Workload != nil && !restart
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestAppliesOnDeploy(t *testing.T) {
+	if !appliesOnDeploy(models.App{}) {
+		t.Fatal("expected an application without workload to apply bindings on deploy")
+	}
+
+	if appliesOnDeploy(models.App{Workload: &models.AppDeployment{}}) {
+		t.Fatal("expected a deployed application to apply bindings immediately")
+	}
+}
+
+func TestAppliesOnRestart(t *testing.T) {
+	deployed := models.App{Workload: &models.AppDeployment{}}
+
+	if appliesOnRestart(deployed, true) {
+		t.Fatal("expected a restarted application to apply bindings immediately")
+	}
+
+	if !appliesOnRestart(deployed, false) {
+		t.Fatal("expected an application not restarted to apply bindings on restart")
+	}
+
+	if appliesOnRestart(models.App{}, false) {
+		t.Fatal("expected an application without workload to apply bindings on deploy instead")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+)
+
+// serviceBindingKeys describes the configurations the service contributes to an application
+// binding it, i.e. the configuration secrets of the service. The mount paths follow the rules
+// used at deployment: The first configuration of the service is mounted under the service name,
+// further configurations get a serial number appended. See [CS-DISAMBI] in deploy.go.
+func serviceBindingKeys(serviceName string, configurationSecrets []v1.Secret) models.ServiceBindingKeys {
+	secrets := append([]v1.Secret{}, configurationSecrets...)
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	result := models.ServiceBindingKeys{
+		Service:        serviceName,
+		Configurations: []models.BoundConfigurationKeys{},
+	}
+
+	for serial, secret := range secrets {
+		path := serviceName
+		if serial > 0 {
+			path = fmt.Sprintf("%s-%d", serviceName, serial+1)
+		}
+
+		keys := []string{}
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		result.Configurations = append(result.Configurations, models.BoundConfigurationKeys{
+			Name:      secret.Name,
+			MountPath: "/configurations/" + path,
+			Keys:      keys,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceBindingKeys(t *testing.T) {
+	secrets := []v1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "xsvc-mydb-extra"},
+			Data:       map[string][]byte{"token": []byte("t")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "xsvc-mydb"},
+			Data: map[string][]byte{
+				"username": []byte("u"),
+				"password": []byte("p"),
+			},
+		},
+	}
+
+	got := serviceBindingKeys("mydb", secrets)
+	want := models.ServiceBindingKeys{
+		Service: "mydb",
+		Configurations: []models.BoundConfigurationKeys{
+			{
+				Name:      "xsvc-mydb",
+				MountPath: "/configurations/mydb",
+				Keys:      []string{"password", "username"},
+			},
+			{
+				Name:      "xsvc-mydb-extra",
+				MountPath: "/configurations/mydb-2",
+				Keys:      []string{"token"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	if secrets[0].Name != "xsvc-mydb-extra" {
+		t.Fatal("expected the input slice to be left unsorted")
+	}
+}
+
+func TestServiceBindingKeysNoConfigurations(t *testing.T) {
+	got := serviceBindingKeys("mydb", nil)
+	if got.Service != "mydb" || len(got.Configurations) != 0 {
+		t.Fatalf("expected no configurations, got %+v", got)
+	}
+}
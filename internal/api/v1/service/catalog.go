@@ -14,15 +14,20 @@ package service
 import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/helm"
 	"github.com/epinio/epinio/internal/services"
 	"github.com/gin-gonic/gin"
 
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
+// Catalog handles the API endpoint GET /catalogservices
+// Deprecated catalog services are listed only with `?includeDeprecated=true`.
 func Catalog(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
+	includeDeprecated := c.Query("includeDeprecated") == "true"
 
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
@@ -39,10 +44,38 @@ func Catalog(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	if !includeDeprecated {
+		serviceList = withoutDeprecated(serviceList)
+	}
+
 	response.OKReturn(c, serviceList)
 	return nil
 }
 
+// withoutDeprecated returns the catalog services which are not deprecated, in order.
+func withoutDeprecated(catalogServices []*models.CatalogService) []*models.CatalogService {
+	result := []*models.CatalogService{}
+	for _, catalogService := range catalogServices {
+		if !catalogService.Deprecated {
+			result = append(result, catalogService)
+		}
+	}
+	return result
+}
+
+// deprecationWarning returns the warning about using the catalog service, if it is deprecated.
+func deprecationWarning(catalogService *models.CatalogService) string {
+	if !catalogService.Deprecated {
+		return ""
+	}
+
+	warning := "catalog service '" + catalogService.Meta.Name + "' is deprecated"
+	if catalogService.DeprecationMessage != "" {
+		warning += ": " + catalogService.DeprecationMessage
+	}
+	return warning
+}
+
 func CatalogShow(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	serviceName := c.Param("catalogservice")
@@ -69,3 +102,93 @@ func CatalogShow(c *gin.Context) apierror.APIErrors {
 	response.OKReturn(c, service)
 	return nil
 }
+
+// ServiceCatalogService handles the API endpoint GET /namespaces/:namespace/services/:service/catalogservice
+// It returns the catalog service the service instance was created from. This links the instance
+// to catalog operations, like listing the versions of its chart.
+func ServiceCatalogService(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, service.CatalogServiceName)
+	if err != nil {
+		if k8sapierrors.IsNotFound(err) {
+			return apierror.NewNotFoundError("catalog service", service.CatalogServiceName).
+				WithDetailsf("service '%s' was created from it", serviceName)
+		}
+
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, catalogService)
+	return nil
+}
+
+// CatalogValidate handles the API endpoint POST /catalogservices/:catalogservice/validate
+// It validates the values of the catalog service, or the proposed values in the request, against
+// the schema of the catalog service's chart. Invalid values are reported in the result, not as
+// an error.
+func CatalogValidate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	serviceName := c.Param("catalogservice")
+
+	var validateRequest models.CatalogServiceValidateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&validateRequest); err != nil {
+			return apierror.NewBadRequestError(err.Error())
+		}
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, serviceName)
+	if err != nil {
+		if k8sapierrors.IsNotFound(err) {
+			return apierror.NewNotFoundError("catalog service", serviceName).WithDetails(err.Error())
+		}
+
+		return apierror.InternalError(err)
+	}
+
+	values := validateRequest.Values
+	if values == "" {
+		values = catalogService.Values
+	}
+
+	chrt, err := helm.CatalogServiceChart(ctx, cluster, *catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result, err := helm.ValidateChartValues(chrt, values)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestWithoutDeprecatedKeepsActiveCatalogServicesInOrder(t *testing.T) {
+	catalog := []*models.CatalogService{
+		{Meta: models.MetaLite{Name: "redis-dev"}},
+		{Meta: models.MetaLite{Name: "mysql-5"}, Deprecated: true},
+		{Meta: models.MetaLite{Name: "postgresql-dev"}},
+	}
+
+	got := withoutDeprecated(catalog)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 catalog services, got %d", len(got))
+	}
+	if got[0].Meta.Name != "redis-dev" || got[1].Meta.Name != "postgresql-dev" {
+		t.Fatalf("expected redis-dev and postgresql-dev, got %s and %s", got[0].Meta.Name, got[1].Meta.Name)
+	}
+}
+
+func TestWithoutDeprecatedReturnsEmptyList(t *testing.T) {
+	got := withoutDeprecated([]*models.CatalogService{
+		{Meta: models.MetaLite{Name: "mysql-5"}, Deprecated: true},
+	})
+	if got == nil || len(got) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %v", got)
+	}
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	tests := []struct {
+		name           string
+		catalogService models.CatalogService
+		want           string
+	}{
+		{
+			name:           "active",
+			catalogService: models.CatalogService{Meta: models.MetaLite{Name: "redis-dev"}},
+			want:           "",
+		},
+		{
+			name:           "deprecated",
+			catalogService: models.CatalogService{Meta: models.MetaLite{Name: "mysql-5"}, Deprecated: true},
+			want:           "catalog service 'mysql-5' is deprecated",
+		},
+		{
+			name: "deprecated with message",
+			catalogService: models.CatalogService{
+				Meta:               models.MetaLite{Name: "mysql-5"},
+				Deprecated:         true,
+				DeprecationMessage: "use mysql-8 instead",
+			},
+			want: "catalog service 'mysql-5' is deprecated: use mysql-8 instead",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deprecationWarning(&tt.catalogService); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
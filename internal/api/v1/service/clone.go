@@ -0,0 +1,131 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Clone handles the API endpoint POST /namespaces/:namespace/services/:service/clone
+// It creates a service in the target namespace from the same catalog service, and with the same
+// user settings, as the named service. The clone keeps the name of the service, unless another
+// is requested.
+func Clone(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+	logger := helpers.Logger.With("component", "ServiceClone")
+
+	var cloneRequest models.ServiceCloneRequest
+	err := c.BindJSON(&cloneRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	targetNamespace := cloneRequest.TargetNamespace
+	if targetNamespace == "" {
+		return apierror.NewBadRequestError("target namespace not specified")
+	}
+	targetName := cloneRequest.Name
+	if targetName == "" {
+		targetName = serviceName
+	}
+	if targetNamespace == namespace && targetName == serviceName {
+		return apierror.NewBadRequestError("cannot clone a service onto itself").
+			WithDetails("specify another target namespace, or name")
+	}
+
+	// The authorization of the request covers the source namespace only. The user also has to
+	// be able to create services in the target namespace.
+	user := requestctx.User(ctx)
+	createPath := strings.TrimSuffix(c.FullPath(), "/:service/clone")
+	if !user.IsAdmin() && (!slices.Contains(user.Namespaces, targetNamespace) ||
+		!user.IsAllowed(http.MethodPost, createPath, map[string]string{"namespace": targetNamespace})) {
+		return apierror.NewAPIError("user unauthorized for namespace "+targetNamespace, http.StatusForbidden)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, targetNamespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.NamespaceIsNotKnown(targetNamespace)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	target, err := kubeServiceClient.Get(ctx, targetNamespace, targetName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if target != nil {
+		return apierror.ServiceAlreadyKnown(targetName).
+			WithDetailsf("service exists in namespace %s", targetNamespace)
+	}
+
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, service.CatalogServiceName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return apierror.NewBadRequestErrorf("catalog service %s not found", service.CatalogServiceName).
+				WithDetailsf("service '%s' was created from it", serviceName)
+		}
+		return apierror.InternalError(err)
+	}
+
+	logger.Infow("cloning service", "namespace", namespace, "service", serviceName,
+		"targetNamespace", targetNamespace, "target", targetName)
+
+	err = kubeServiceClient.Create(ctx, targetNamespace, targetName,
+		cloneRequest.Wait,
+		service.Settings,
+		catalogService,
+		func(ctx context.Context) error {
+			return WhenFullyDeployed(ctx, cluster, targetNamespace, targetName)
+		})
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, models.ServiceCreateResponse{
+		Response: models.ResponseOK,
+		Warning:  deprecationWarning(catalogService),
+	})
+	return nil
+}
@@ -95,7 +95,10 @@ func Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	response.OK(c)
+	response.OKReturn(c, models.ServiceCreateResponse{
+		Response: models.ResponseOK,
+		Warning:  deprecationWarning(catalogService),
+	})
 	return nil
 }
 
@@ -0,0 +1,53 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+)
+
+// Events handles the API endpoint GET /namespaces/:namespace/services/:service/events
+// It returns the kubernetes events of the service's workload, i.e. of the pods, volumes
+// and controllers of its release.
+func Events(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	events, err := kubeServiceClient.Events(ctx, namespace, service.Meta.Name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, events)
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/expiry"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Expiry handles the API endpoint POST /namespaces/:namespace/services/:service/expiry
+// It sets, extends, or removes the time the service is automatically deleted at.
+func Expiry(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	var request models.ExpiryRequest
+	if err := c.BindJSON(&request); err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	ttl, err := expiry.ParseTTL(request.TTL)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	_, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	resp := models.ExpiryResponse{}
+
+	var expiresAt *time.Time
+	if ttl > 0 {
+		at := time.Now().Add(ttl).Truncate(time.Second)
+		expiresAt = &at
+		mat := metav1.NewTime(at)
+		resp.ExpiresAt = &mat
+	}
+
+	err = kubeServiceClient.SetExpiry(ctx, namespace, serviceName, expiresAt)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, resp)
+	return nil
+}
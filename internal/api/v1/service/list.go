@@ -12,19 +12,41 @@
 package service
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/services"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
 	"github.com/gin-gonic/gin"
 )
 
+// List handles the API endpoint GET /namespaces/:namespace/services
+// With any of `?limit=`, `?offset=`, and `?prefix=` the response is a page of the services whose
+// names start with the prefix, sorted by name, together with their total. Without a limit the
+// page extends to the end of the list.
 func List(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
 
+	limitParam, hasLimit := c.GetQuery("limit")
+	offsetParam, hasOffset := c.GetQuery("offset")
+	prefix, hasPrefix := c.GetQuery("prefix")
+	paged := hasLimit || hasOffset || hasPrefix
+
+	limit, err := response.ListNumber(limitParam)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad limit: %s", err.Error())
+	}
+	offset, err := response.ListNumber(offsetParam)
+	if err != nil {
+		return apierror.NewBadRequestErrorf("bad offset: %s", err.Error())
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -45,6 +67,33 @@ func List(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	if paged {
+		page, total := servicePage(serviceList, prefix, limit, offset)
+
+		response.OKReturn(c, models.ServiceListResponse{
+			Items: extendWithBoundApps(page, appsOf),
+			Total: total,
+		})
+		return nil
+	}
+
 	response.OKReturn(c, extendWithBoundApps(serviceList, appsOf))
 	return nil
 }
+
+// servicePage returns the page of the services whose names start with the prefix, sorted by
+// name, and the number of these services. A limit of zero places no limit. Offsets past the end
+// result in an empty page.
+func servicePage(serviceList models.ServiceList, prefix string, limit, offset int) (models.ServiceList, int) {
+	matching := models.ServiceList{}
+	for _, service := range serviceList {
+		if strings.HasPrefix(service.Meta.Name, prefix) {
+			matching = append(matching, service)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Meta.Name < matching[j].Meta.Name
+	})
+
+	return response.Page(matching, limit, offset), len(matching)
+}
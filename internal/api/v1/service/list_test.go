@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func serviceNames(serviceList models.ServiceList) []string {
+	names := []string{}
+	for _, service := range serviceList {
+		names = append(names, service.Meta.Name)
+	}
+	return names
+}
+
+func testServiceList(names ...string) models.ServiceList {
+	serviceList := models.ServiceList{}
+	for _, name := range names {
+		serviceList = append(serviceList, models.Service{Meta: models.Meta{Name: name}})
+	}
+	return serviceList
+}
+
+func TestServicePage(t *testing.T) {
+	serviceList := testServiceList("redis-b", "mysql", "redis-a", "redis-c", "postgres")
+
+	tests := []struct {
+		name   string
+		prefix string
+		limit  int
+		offset int
+		want   []string
+		total  int
+	}{
+		{name: "everything", want: []string{"mysql", "postgres", "redis-a", "redis-b", "redis-c"}, total: 5},
+		{name: "first page", limit: 2, want: []string{"mysql", "postgres"}, total: 5},
+		{name: "last page", limit: 2, offset: 4, want: []string{"redis-c"}, total: 5},
+		{name: "prefix", prefix: "redis", limit: 2, offset: 1, want: []string{"redis-b", "redis-c"}, total: 3},
+		{name: "no match", prefix: "mongo", want: []string{}, total: 0},
+		{name: "past the end", limit: 2, offset: 7, want: []string{}, total: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, total := servicePage(serviceList, tt.prefix, tt.limit, tt.offset)
+			if total != tt.total {
+				t.Fatalf("expected total %d, got %d", tt.total, total)
+			}
+			got := serviceNames(page)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
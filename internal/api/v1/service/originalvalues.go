@@ -0,0 +1,86 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// OriginalValues handles the API endpoint GET /namespaces/:namespace/services/:service/originalvalues
+// It returns the values the service was created with, before any updates. Sensitive values are
+// redacted unless the user is allowed to change the service, as for the effective values.
+func OriginalValues(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	original, err := kubeServiceClient.OriginalValues(ctx, namespace, service.Meta.Name)
+	if err != nil {
+		if errors.Is(err, services.ErrNoOriginalValues) {
+			return apierror.NewAPIError("service '"+serviceName+"' has no recorded original values",
+				http.StatusNotFound)
+		}
+		return apierror.InternalError(err)
+	}
+
+	values, err := original.Values()
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	user := requestctx.User(ctx)
+	params := map[string]string{"namespace": namespace}
+	updatePath := strings.TrimSuffix(c.FullPath(), "/originalvalues")
+	redacted := !user.IsAllowed("PATCH", updatePath, params)
+
+	result := models.ServiceOriginalValuesResponse{
+		CatalogValues: original.CatalogValues,
+		Settings:      original.Settings,
+		Values:        values,
+		Redacted:      redacted,
+	}
+
+	if redacted {
+		result.CatalogValues = ""
+		result.Settings = services.RedactSettings(original.Settings)
+		result.Values = services.RedactValues(values)
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apiapp "github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// progressInterval is the time between two checks of a service's provisioning progress.
+const progressInterval = 2 * time.Second
+
+// ProgressWebsocket handles the API endpoint GET /namespaces/:namespace/services/:service/progress
+// It streams the provisioning progress of the service over a websocket, i.e. the phases of the
+// service's helm release and the readiness of its pods. An event is sent whenever the progress
+// changes, until the service is deployed, or failed.
+func ProgressWebsocket(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	if _, apiErr := GetService(ctx, cluster, namespace, serviceName); apiErr != nil {
+		response.Error(c, apiErr)
+		return
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: apiapp.CheckOriginFunc(viper.GetStringSlice("access-control-allow-origin")),
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		response.Error(c, apierror.InternalError(err))
+		return
+	}
+
+	closeCode, closeText := websocket.CloseNormalClosure, ""
+	defer func() {
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(closeCode, closeText), time.Now().Add(time.Second))
+		_ = conn.Close()
+	}()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Stop streaming when the client goes away.
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	var last *models.ServiceProgressEvent
+	for {
+		progress, err := serviceProgress(streamCtx, kubeServiceClient, namespace, serviceName)
+		if err != nil {
+			if streamCtx.Err() == nil {
+				helpers.Logger.Errorw("service progress check failed", "error", err)
+				closeCode, closeText = websocket.CloseInternalServerErr, err.Error()
+			}
+			return
+		}
+
+		if progressChanged(last, progress) {
+			data, err := json.Marshal(progress)
+			if err != nil {
+				helpers.Logger.Errorw("failed to marshal service progress", "error", err)
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				helpers.Logger.Errorw("failed to write to websockets", "error", err)
+				return
+			}
+			last = &progress
+		}
+
+		if progress.Completed {
+			return
+		}
+
+		select {
+		case <-streamCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// serviceProgress returns the current provisioning progress of the named service.
+func serviceProgress(ctx context.Context, kubeServiceClient *services.ServiceClient,
+	namespace, serviceName string) (models.ServiceProgressEvent, error) {
+
+	service, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+	if err != nil {
+		return models.ServiceProgressEvent{}, err
+	}
+	if service == nil {
+		return models.ServiceProgressEvent{}, errors.Errorf("service '%s' does not exist anymore", serviceName)
+	}
+
+	progress, err := kubeServiceClient.Progress(ctx, service)
+	if err != nil {
+		return progress, err
+	}
+	progress.Completed = progressCompleted(progress)
+
+	return progress, nil
+}
+
+// progressCompleted returns true if the service reached a final state, i.e. it is deployed, or
+// its release failed. A suspended service will not progress anymore either.
+func progressCompleted(progress models.ServiceProgressEvent) bool {
+	return progress.Status == models.ServiceStatusDeployed ||
+		progress.Status == models.ServiceStatusSuspended ||
+		progress.Phase == helmrelease.StatusFailed.String()
+}
+
+// progressChanged returns true if the progress differs from the last one sent, if any.
+func progressChanged(last *models.ServiceProgressEvent, progress models.ServiceProgressEvent) bool {
+	return last == nil || !reflect.DeepEqual(*last, progress)
+}
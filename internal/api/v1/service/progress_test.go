@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestProgressCompleted(t *testing.T) {
+	tests := []struct {
+		name   string
+		phase  string
+		status models.ServiceStatus
+		want   bool
+	}{
+		{name: "pending", phase: "pending", status: models.ServiceStatusNotReady, want: false},
+		{name: "installing", phase: "pending-install", status: models.ServiceStatusNotReady, want: false},
+		{name: "deployed, pods not ready", phase: "deployed", status: models.ServiceStatusNotReady, want: false},
+		{name: "deployed and ready", phase: "deployed", status: models.ServiceStatusDeployed, want: true},
+		{name: "failed", phase: "failed", status: models.ServiceStatusUnknown, want: true},
+		{name: "suspended", phase: "deployed", status: models.ServiceStatusSuspended, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := models.ServiceProgressEvent{Phase: tt.phase, Status: tt.status}
+			if got := progressCompleted(progress); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProgressChanged(t *testing.T) {
+	progress := models.ServiceProgressEvent{
+		Phase:  "deployed",
+		Status: models.ServiceStatusNotReady,
+		Pods:   []models.ServicePodProgress{{Name: "db-0", Phase: "Running", Ready: false}},
+	}
+
+	if !progressChanged(nil, progress) {
+		t.Fatal("expected the first progress to count as a change")
+	}
+
+	same := progress
+	same.Pods = []models.ServicePodProgress{{Name: "db-0", Phase: "Running", Ready: false}}
+	if progressChanged(&progress, same) {
+		t.Fatal("expected identical progress to not count as a change")
+	}
+
+	ready := progress
+	ready.Pods = []models.ServicePodProgress{{Name: "db-0", Phase: "Running", Ready: true}}
+	if !progressChanged(&progress, ready) {
+		t.Fatal("expected a pod readiness transition to count as a change")
+	}
+
+	phase := progress
+	phase.Phase = "failed"
+	if !progressChanged(&progress, phase) {
+		t.Fatal("expected a phase transition to count as a change")
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Rebind handles the API endpoint /namespaces/:namespace/applications/:app/servicerebind (POST)
+// It unbinds the services to remove from the specified application and binds the services to
+// add, as a single change of the application's configurations. A deployed application is
+// restarted once, and never runs with neither set of services bound. The response reports the
+// configurations the added services contribute to the application.
+func Rebind(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := helpers.Logger.With("component", "ServiceRebind")
+	username := requestctx.User(ctx).Username
+
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+
+	var rebindRequest models.ServiceRebindRequest
+	err := c.BindJSON(&rebindRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	apiErr := validateRebindRequest(appName, rebindRequest)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	logger.Infow("looking for application", "app", appName)
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	boundServices, err := application.BoundServiceNames(ctx, cluster, app.Meta)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Validate all services and collect their configurations before making any changes
+
+	removeConfigurations := []string{}
+	for _, serviceName := range rebindRequest.Remove {
+		if !slices.Contains(boundServices, serviceName) {
+			return apierror.NewBadRequestErrorf("service '%s' is not bound to application '%s'",
+				serviceName, appName)
+		}
+
+		service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+		if apiErr != nil {
+			return apiErr
+		}
+
+		serviceConfigurations, err := configurations.ForService(ctx, cluster, service)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		for _, secret := range serviceConfigurations {
+			removeConfigurations = append(removeConfigurations, secret.Name)
+		}
+	}
+
+	addConfigurations := []string{}
+	bindingKeys := []models.ServiceBindingKeys{}
+	for _, serviceName := range rebindRequest.Add {
+		service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+		if apiErr != nil {
+			return apiErr
+		}
+
+		apiErr = ValidateService(ctx, cluster, service)
+		if apiErr != nil {
+			return apiErr
+		}
+
+		// Label the service secrets to turn them into configurations
+		configurationSecrets, err := configurations.LabelServiceSecrets(ctx, cluster, service)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		for _, secret := range configurationSecrets {
+			addConfigurations = append(addConfigurations, secret.Name)
+		}
+		bindingKeys = append(bindingKeys, serviceBindingKeys(serviceName, configurationSecrets))
+	}
+
+	logger.Infow("changing service bindings", "app", appName,
+		"remove", rebindRequest.Remove, "add", rebindRequest.Add,
+		"removeConfigurations", removeConfigurations, "addConfigurations", addConfigurations)
+
+	err = application.BoundConfigurationsChange(ctx, cluster, app.Meta, removeConfigurations, addConfigurations)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.BoundServicesChange(ctx, cluster, app.Meta, rebindRequest.Remove, rebindRequest.Add)
+	if err != nil {
+		// DANGER: This work here is not transactional, the configurations are changed already.
+		return apierror.InternalError(err)
+	}
+
+	// Update the workload, if there is any. This is the single restart of the application.
+	if app.Workload != nil {
+		_, apiErr := deploy.DeployApp(ctx, cluster, app.Meta, username, "")
+		if apiErr != nil {
+			return apiErr
+		}
+	} else {
+		logger.Infow("application not deployed, bindings apply on deploy", "app", appName)
+	}
+
+	response.OKReturn(c, models.ServiceBindResponse{
+		Response:      models.ResponseOK,
+		Services:      bindingKeys,
+		ApplyOnDeploy: appliesOnDeploy(*app),
+	})
+	return nil
+}
+
+// validateRebindRequest checks the request body before any work is done. At least one service
+// has to be removed or added. Names must not be empty, nor appear more than once across both
+// lists. An application name in the body has to match the application in the path.
+func validateRebindRequest(appName string, rebindRequest models.ServiceRebindRequest) apierror.APIErrors {
+	if rebindRequest.AppName != "" && rebindRequest.AppName != appName {
+		return apierror.NewBadRequestErrorf("application name in body (%s) does not match the application in the path (%s)",
+			rebindRequest.AppName, appName)
+	}
+
+	if len(rebindRequest.Remove) == 0 && len(rebindRequest.Add) == 0 {
+		return apierror.NewBadRequestError("no services specified to remove or add")
+	}
+
+	seen := map[string]int{}
+	duplicates := []string{}
+	for _, serviceName := range append(slices.Clone(rebindRequest.Remove), rebindRequest.Add...) {
+		if serviceName == "" {
+			return apierror.NewBadRequestError("empty service name specified for rebinding")
+		}
+		seen[serviceName]++
+		if seen[serviceName] == 2 {
+			duplicates = append(duplicates, serviceName)
+		}
+	}
+
+	if len(duplicates) > 0 {
+		return apierror.NewBadRequestErrorf("services specified more than once: %s",
+			strings.Join(duplicates, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestValidateRebindRequestAccepts(t *testing.T) {
+	for _, request := range []models.ServiceRebindRequest{
+		{Remove: []string{"db-a"}, Add: []string{"db-b"}},
+		{AppName: "my-app", Remove: []string{"db-a"}},
+		{Add: []string{"db-b", "cache"}},
+	} {
+		errs := validateRebindRequest("my-app", request)
+		if errs != nil {
+			t.Fatalf("expected no errors for %+v, got %v", request, errs)
+		}
+	}
+}
+
+func TestValidateRebindRequestRejectsAppMismatch(t *testing.T) {
+	errs := validateRebindRequest("my-app", models.ServiceRebindRequest{
+		AppName: "other-app",
+		Add:     []string{"db-b"},
+	})
+	if errs == nil {
+		t.Fatal("expected an error for mismatched application names")
+	}
+	if errs.FirstStatus() != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", errs.FirstStatus())
+	}
+}
+
+func TestValidateRebindRequestRejectsNothingToDo(t *testing.T) {
+	errs := validateRebindRequest("my-app", models.ServiceRebindRequest{})
+	if errs == nil {
+		t.Fatal("expected an error for a request without services")
+	}
+}
+
+func TestValidateRebindRequestRejectsEmptyNames(t *testing.T) {
+	for _, request := range []models.ServiceRebindRequest{
+		{Remove: []string{""}, Add: []string{"db-b"}},
+		{Remove: []string{"db-a"}, Add: []string{""}},
+	} {
+		errs := validateRebindRequest("my-app", request)
+		if errs == nil {
+			t.Fatalf("expected an error for %+v", request)
+		}
+	}
+}
+
+func TestValidateRebindRequestRejectsDuplicates(t *testing.T) {
+	errs := validateRebindRequest("my-app", models.ServiceRebindRequest{
+		Remove: []string{"db-a", "db-a"},
+		Add:    []string{"db-b", "db-a"},
+	})
+	if errs == nil {
+		t.Fatal("expected an error for duplicated services")
+	}
+
+	message := errs.Errors()[0].Title
+	if !strings.HasSuffix(message, "db-a") {
+		t.Fatalf("expected duplicate db-a to be listed once, got %q", message)
+	}
+}
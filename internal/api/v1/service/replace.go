@@ -42,6 +42,11 @@ func Replace(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplific
 		return apiErr
 	}
 
+	apiErr = ValidateNotSuspended(service)
+	if apiErr != nil {
+		return apiErr
+	}
+
 	var replaceRequest models.ServiceReplaceRequest
 	err = c.BindJSON(&replaceRequest)
 	if err != nil {
@@ -74,7 +79,7 @@ func Replace(c *gin.Context) apierror.APIErrors { // nolint:gocyclo // simplific
 			}
 
 			// Perform restart on the candidates which are actually running
-			apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
+			_, apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
 			if apiErr != nil {
 				x := apiErr.(apierror.APIError)
 				return fmt.Errorf("%s: %s", x.Title, x.Details)
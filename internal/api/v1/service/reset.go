@@ -0,0 +1,114 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apiapp "github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Reset handles the API endpoint POST /namespaces/:namespace/services/:service/reset
+// It reverts the service to the values it was created with, discarding all updates made since,
+// and returns the keys of the settings which changed.
+func Reset(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+	logger := helpers.Logger.With("component", "ServiceReset")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	apiErr = ValidateNotSuspended(service)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	var resetRequest models.ServiceResetRequest
+	err = c.BindJSON(&resetRequest)
+	if err != nil {
+		return apierror.NewBadRequestError(err.Error())
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// backward compatibility with update and replace: if no flag provided then restart the app
+	restart := resetRequest.Restart == nil || *resetRequest.Restart
+
+	var restartCallback func(context.Context) error
+	if restart {
+		restartCallback = func(ctx context.Context) error {
+			err := WhenFullyDeployed(ctx, cluster, namespace, serviceName)
+			if err != nil {
+				return err
+			}
+
+			// Determine bound apps, as candidates for restart.
+			appNames, err := application.ServicesBoundAppsNamesFor(ctx, cluster, namespace, serviceName)
+			if err != nil {
+				return err
+			}
+
+			// Perform restart on the candidates which are actually running
+			_, apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
+			if apiErr != nil {
+				x := apiErr.(apierror.APIError)
+				return fmt.Errorf("%s: %s", x.Title, x.Details)
+			}
+
+			return nil
+		}
+	} else {
+		restartCallback = func(ctx context.Context) error {
+			return WhenFullyDeployed(ctx, cluster, namespace, serviceName)
+		}
+	}
+
+	changed, err := kubeServiceClient.ResetService(ctx, cluster, service, resetRequest.Wait, restartCallback)
+	if err != nil {
+		if errors.Is(err, services.ErrNoOriginalValues) {
+			return apierror.NewAPIError("service '"+serviceName+"' has no recorded original values",
+				http.StatusNotFound)
+		}
+		return apierror.InternalError(err)
+	}
+
+	logger.Infow("reset service", "namespace", namespace, "service", serviceName, "changed", changed)
+
+	response.OKReturn(c, models.ServiceResetResponse{
+		Changed: changed,
+	})
+	return nil
+}
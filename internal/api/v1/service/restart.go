@@ -0,0 +1,60 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	apiapp "github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Restart handles the API endpoint POST /namespaces/:namespace/services/:service/restart
+// It restarts the running applications bound to the service, without changing the service,
+// e.g. to pick up a rotated credential, and returns the names of the restarted applications.
+func Restart(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	_, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	appNames, err := application.ServicesBoundAppsNamesFor(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	sort.Strings(appNames)
+
+	restarted, apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	response.OKReturn(c, models.ServiceRestartResponse{
+		Restarted: restarted,
+	})
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Suspend handles the API endpoint POST /namespaces/:namespace/services/:service/suspend
+// It scales the workload of the service to zero, retaining its data. The response warns
+// about running applications bound to the service.
+func Suspend(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	warnings, err := runningBoundApps(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if service.Status != models.ServiceStatusSuspended {
+		err = kubeServiceClient.Suspend(ctx, namespace, serviceName)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	response.OKReturn(c, models.ServiceSuspendResponse{
+		Warnings: warnings,
+	})
+	return nil
+}
+
+// Resume handles the API endpoint POST /namespaces/:namespace/services/:service/resume
+// It restores the workload of a suspended service.
+func Resume(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if service.Status != models.ServiceStatusSuspended {
+		return apierror.NewBadRequestErrorf("service '%s' is not suspended", serviceName)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = kubeServiceClient.Resume(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// runningBoundApps returns a warning for each application bound to the service which has
+// an active workload.
+func runningBoundApps(ctx context.Context, cluster *kubernetes.Cluster, namespace, serviceName string) ([]string, error) {
+	appNames, err := runningBoundAppNames(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := []string{}
+	for _, appName := range appNames {
+		warnings = append(warnings,
+			fmt.Sprintf("application '%s' is running and bound to the suspended service", appName))
+	}
+
+	return warnings, nil
+}
+
+// runningBoundAppNames returns the names of the applications bound to the service which have
+// an active workload, sorted.
+func runningBoundAppNames(ctx context.Context, cluster *kubernetes.Cluster, namespace, serviceName string) ([]string, error) {
+	appNames, err := application.ServicesBoundAppsNamesFor(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(appNames)
+
+	result := []string{}
+	for _, appName := range appNames {
+		app, err := application.Lookup(ctx, cluster, namespace, appName)
+		if err != nil {
+			return nil, err
+		}
+		if app == nil || app.Workload == nil {
+			continue
+		}
+		result = append(result, appName)
+	}
+
+	return result, nil
+}
@@ -14,12 +14,16 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	apiapp "github.com/epinio/epinio/internal/api/v1/application"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/names"
 	"github.com/epinio/epinio/internal/services"
 	"github.com/gin-gonic/gin"
 
@@ -28,10 +32,16 @@ import (
 )
 
 // Update handles the API endpoint PATCH /namespaces/:namespace/services/:service
+// With `?atomic=true` the endpoint waits for the updated service to become ready, and rolls it
+// back to its previous state if it does not. With `?dryRun=true` nothing is changed, and the
+// endpoint returns the values and resources the update would change instead. Bound apps are not
+// restarted then.
 func Update(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
 	serviceName := c.Param("service")
+	atomic := c.Query("atomic") == "true"
+	dryRun := c.Query("dryRun") == "true"
 	logger := helpers.Logger
 
 	cluster, err := kubernetes.GetCluster(ctx)
@@ -44,6 +54,11 @@ func Update(c *gin.Context) apierror.APIErrors {
 		return apiErr
 	}
 
+	apiErr = ValidateNotSuspended(service)
+	if apiErr != nil {
+		return apiErr
+	}
+
 	// Retrieve and validate update request ...
 
 	var updateRequest models.ServiceUpdateRequest
@@ -54,18 +69,65 @@ func Update(c *gin.Context) apierror.APIErrors {
 
 	logger.Infow("updating service", "namespace", namespace, "service", serviceName, "request", updateRequest)
 
-	// Save changes to resource
-
 	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
+	// Translate the convenience fields into the chart values they map to.
+
+	validate := updateRequest.Validate != nil && *updateRequest.Validate
+
+	var catalogService *models.CatalogService
+	if updateRequest.Replicas != nil || validate {
+		catalogService, err = kubeServiceClient.GetCatalogService(ctx, service.CatalogService)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	if updateRequest.Replicas != nil {
+		apiErr = applyConvenienceFields(catalogService, &updateRequest)
+		if apiErr != nil {
+			return apiErr
+		}
+	}
+
+	if validate {
+		apiErr = validateUpdate(ctx, cluster, kubeServiceClient, service, catalogService, updateRequest)
+		if apiErr != nil {
+			return apiErr
+		}
+	}
+
+	if dryRun {
+		preview, err := kubeServiceClient.PreviewUpdateService(ctx, cluster, service, updateRequest)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		response.OKReturn(c, preview)
+		return nil
+	}
+
+	// Save changes to resource
+
 	// backward compatibility: if no flag provided then restart the app
 	restart := updateRequest.Restart == nil || *updateRequest.Restart
 
+	// The restarted apps are known when waiting for the update. Else the restart happens
+	// asynchronously, and the running bound apps are reported as pending the restart.
+	var restarted, pending []string
+
 	var restartCallback func(context.Context) error
 	if restart {
+		if !updateRequest.Wait && !atomic {
+			pending, err = runningBoundAppNames(ctx, cluster, namespace, serviceName)
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+		}
+
 		restartCallback = func(ctx context.Context) error {
 			err := WhenFullyDeployed(ctx, cluster, namespace, serviceName)
 			if err != nil {
@@ -77,14 +139,18 @@ func Update(c *gin.Context) apierror.APIErrors {
 			if err != nil {
 				return err
 			}
+			sort.Strings(appNames)
 
 			// Perform restart on the candidates which are actually running
 
-			apiErr = apiapp.Redeploy(ctx, cluster, namespace, appNames)
+			appsRestarted, apiErr := apiapp.Redeploy(ctx, cluster, namespace, appNames)
 			if apiErr != nil {
 				x := apiErr.(apierror.APIError)
 				return fmt.Errorf("%s: %s", x.Title, x.Details)
 			}
+			if updateRequest.Wait {
+				restarted = appsRestarted
+			}
 
 			return nil
 		}
@@ -94,11 +160,132 @@ func Update(c *gin.Context) apierror.APIErrors {
 		}
 	}
 
+	var revision int
+	if atomic {
+		// Readiness is needed to decide about the rollback.
+		updateRequest.Wait = true
+
+		release, err := helm.Release(ctx, cluster, namespace, names.ServiceReleaseName(serviceName))
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		revision = release.Version
+	}
+
 	err = kubeServiceClient.UpdateService(ctx, cluster, service, updateRequest, restartCallback)
 	if err != nil {
-		return apierror.InternalError(err)
+		if !atomic {
+			return apierror.InternalError(err)
+		}
+
+		// Failures after the service became ready, i.e. in restarting the bound apps, are
+		// not a reason to roll back.
+		ready, statusErr := serviceReady(ctx, cluster, namespace, serviceName)
+		if statusErr == nil && ready {
+			return apierror.InternalError(err)
+		}
+
+		logger.Infow("rolling back failed service update", "namespace", namespace, "service", serviceName,
+			"revision", revision, "error", err)
+
+		rollbackErr := kubeServiceClient.RollbackService(ctx, cluster, service, revision, service.Settings)
+		if rollbackErr != nil {
+			return apierror.InternalError(err,
+				fmt.Sprintf("rollback to revision %d failed too: %s", revision, rollbackErr.Error()))
+		}
+
+		return apierror.InternalError(err,
+			fmt.Sprintf("service did not become ready, rolled back to revision %d", revision))
+	}
+
+	response.OKReturn(c, models.ServiceUpdateResponse{
+		Response:           models.ResponseOK,
+		RestartedApps:      restarted,
+		PendingRestartApps: pending,
+	})
+	return nil
+}
+
+// applyConvenienceFields translates the convenience fields of the update request into entries of
+// its `Set` map, as per the value keys declared by the catalog service. Explicit `Set` entries for
+// the same key are overridden.
+func applyConvenienceFields(catalogService *models.CatalogService, updateRequest *models.ServiceUpdateRequest) apierror.APIErrors {
+	if updateRequest.Replicas != nil {
+		if *updateRequest.Replicas < 0 {
+			return apierror.NewBadRequestErrorf("replicas must not be negative, got %d", *updateRequest.Replicas)
+		}
+		if catalogService.ReplicasKey == "" {
+			return apierror.NewBadRequestErrorf("catalog service %s does not support setting replicas",
+				catalogService.Meta.Name).
+				WithDetails("use a chart value assignment instead")
+		}
+		if updateRequest.Set == nil {
+			updateRequest.Set = models.ChartValueSettings{}
+		}
+		updateRequest.Set[catalogService.ReplicasKey] = fmt.Sprintf("%d", *updateRequest.Replicas)
 	}
 
-	response.OK(c)
 	return nil
 }
+
+// validateUpdate checks the chart values of the service after the update against the schema of
+// the catalog service's chart. Charts without schema accept all values. The error names the keys
+// of the update causing the violations.
+func validateUpdate(ctx context.Context, cluster *kubernetes.Cluster, kubeServiceClient *services.ServiceClient,
+	service *models.Service, catalogService *models.CatalogService, updateRequest models.ServiceUpdateRequest) apierror.APIErrors {
+
+	chrt, err := helm.CatalogServiceChart(ctx, cluster, *catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if len(chrt.Schema) == 0 {
+		return nil
+	}
+
+	values, err := kubeServiceClient.UpdatedServiceValues(ctx, cluster, service, updateRequest)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result, err := helm.ValidateChartValues(chrt, values)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if result.Valid {
+		return nil
+	}
+
+	keys := []string{}
+	for key := range updateRequest.Set {
+		keys = append(keys, key)
+	}
+
+	violations := []string{}
+	for _, violation := range result.Errors {
+		violations = append(violations, fmt.Sprintf("%s: %s", violation.Path, violation.Message))
+	}
+
+	offending := helm.OffendingKeys(result.Errors, keys)
+	if len(offending) == 0 {
+		return apierror.NewBadRequestError("chart values do not match the chart's values schema").
+			WithDetails(strings.Join(violations, "; "))
+	}
+
+	return apierror.NewBadRequestErrorf("invalid chart values for keys: %s", strings.Join(offending, ", ")).
+		WithDetails(strings.Join(violations, "; "))
+}
+
+// serviceReady returns true if the helm release of the named service is ready.
+func serviceReady(ctx context.Context, cluster *kubernetes.Cluster, namespace, serviceName string) (bool, error) {
+	release, err := helm.Release(ctx, cluster, namespace, names.ServiceReleaseName(serviceName))
+	if err != nil {
+		return false, err
+	}
+
+	status, err := helm.Status(ctx, cluster, release)
+	if err != nil {
+		return false, err
+	}
+
+	return status == helm.StatusReady, nil
+}
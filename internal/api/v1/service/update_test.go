@@ -0,0 +1,71 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+func TestApplyConvenienceFieldsMapsReplicas(t *testing.T) {
+	replicas := int32(3)
+	catalogService := &models.CatalogService{
+		Meta:        models.MetaLite{Name: "postgresql-dev"},
+		ReplicasKey: "readReplicas.replicaCount",
+	}
+	request := models.ServiceUpdateRequest{
+		Set:      models.ChartValueSettings{"auth.username": "epinio"},
+		Replicas: &replicas,
+	}
+
+	if errs := applyConvenienceFields(catalogService, &request); errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	if got := request.Set["readReplicas.replicaCount"]; got != "3" {
+		t.Fatalf("expected replicas to map to 3, got %q", got)
+	}
+	if got := request.Set["auth.username"]; got != "epinio" {
+		t.Fatalf("expected raw setting to be kept, got %q", got)
+	}
+}
+
+func TestApplyConvenienceFieldsRejectsUnmappedReplicas(t *testing.T) {
+	replicas := int32(2)
+	catalogService := &models.CatalogService{Meta: models.MetaLite{Name: "redis-dev"}}
+	request := models.ServiceUpdateRequest{Replicas: &replicas}
+
+	errs := applyConvenienceFields(catalogService, &request)
+	if errs == nil {
+		t.Fatal("expected an error for an unmapped replicas field")
+	}
+	if errs.FirstStatus() != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, errs.FirstStatus())
+	}
+}
+
+func TestApplyConvenienceFieldsRejectsNegativeReplicas(t *testing.T) {
+	replicas := int32(-1)
+	catalogService := &models.CatalogService{ReplicasKey: "replicaCount"}
+	request := models.ServiceUpdateRequest{Replicas: &replicas}
+
+	if errs := applyConvenienceFields(catalogService, &request); errs == nil {
+		t.Fatal("expected an error for negative replicas")
+	}
+}
+
+func TestValidateNotSuspended(t *testing.T) {
+	service := &models.Service{
+		Meta:   models.Meta{Name: "mydb", Namespace: "workspace"},
+		Status: models.ServiceStatusDeployed,
+	}
+	if errs := ValidateNotSuspended(service); errs != nil {
+		t.Fatalf("expected a deployed service to be accepted, got %v", errs)
+	}
+
+	service.Status = models.ServiceStatusSuspended
+	errs := ValidateNotSuspended(service)
+	if errs == nil || errs.FirstStatus() != http.StatusConflict {
+		t.Fatalf("expected a conflict for a suspended service, got %v", errs)
+	}
+}
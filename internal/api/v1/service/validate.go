@@ -13,6 +13,7 @@ package service
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -106,3 +107,15 @@ func ValidateService(
 
 	return nil
 }
+
+// ValidateNotSuspended is used by the service endpoints upgrading the helm release of the service,
+// i.e. update, replace, and reset. The upgrade returns the workloads of a suspended service to the
+// replicas of the chart, so these are refused until the service is resumed.
+func ValidateNotSuspended(service *models.Service) apierror.APIErrors {
+	if service.Status != models.ServiceStatusSuspended {
+		return nil
+	}
+
+	return apierror.NewAPIError("service '"+service.Meta.Name+"' is suspended", http.StatusConflict).
+		WithDetails("resume the service before changing it")
+}
@@ -0,0 +1,65 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Values handles the API endpoint GET /namespaces/:namespace/services/:service/values
+// It returns the effective values the service's release runs with. Sensitive values are redacted
+// unless the user is allowed to change the service, i.e. could set them anyway.
+func Values(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, apiErr := GetService(ctx, cluster, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	values, err := helm.ServiceValues(cluster, models.NewAppRef(service.Meta.Name, namespace))
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	user := requestctx.User(ctx)
+	params := map[string]string{"namespace": namespace}
+	updatePath := strings.TrimSuffix(c.FullPath(), "/values")
+	redacted := !user.IsAllowed("PATCH", updatePath, params)
+
+	if redacted {
+		values = services.RedactValues(values)
+	}
+
+	response.OKReturn(c, models.ServiceValuesResponse{
+		Values:   values,
+		Redacted: redacted,
+	})
+	return nil
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,7 +30,9 @@ import (
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/helpers/kubernetes/tailer"
+	"github.com/epinio/epinio/internal/appchart"
 	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/expiry"
 	"github.com/epinio/epinio/internal/helm"
 	"github.com/epinio/epinio/internal/helmchart"
 	"github.com/epinio/epinio/internal/registry"
@@ -126,6 +130,37 @@ func ValidateCV(
 	return issues
 }
 
+// ValidateConfiguration validates the chart values of the application against the settings
+// declared by its app chart. A failure of the validation itself is reported in the result.
+func ValidateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) *models.ConfigurationValidation {
+	appChart, err := appchart.Lookup(ctx, cluster, app.Configuration.AppChart)
+	if err != nil {
+		return &models.ConfigurationValidation{Error: err.Error()}
+	}
+	if appChart == nil {
+		return &models.ConfigurationValidation{
+			Error: fmt.Sprintf("application chart '%s' does not exist", app.Configuration.AppChart),
+		}
+	}
+
+	return NewConfigurationValidation(ValidateCV(app.Configuration.Settings, appChart.Settings))
+}
+
+// NewConfigurationValidation returns the validation summary for the issues found by ValidateCV.
+// The messages are sorted.
+func NewConfigurationValidation(issues []error) *models.ConfigurationValidation {
+	messages := []string{}
+	for _, issue := range issues {
+		messages = append(messages, issue.Error())
+	}
+	sort.Strings(messages)
+
+	return &models.ConfigurationValidation{
+		Valid:    len(messages) == 0,
+		Messages: messages,
+	}
+}
+
 // Create generates a new kube app resource in the namespace of the namespace.
 // Note that this is the passive resource holding the app's configuration.
 // It is not the active workload.
@@ -260,7 +295,7 @@ func stagingStatus(
 	}
 
 	selector := labels.Set(labelsMap).AsSelector().String()
-	jobList, err := cluster.ListJobs(ctx, helmchart.Namespace(), selector)
+	jobList, err := cluster.ListJobs(ctx, helmchart.StagingNamespace(), selector)
 	if err != nil {
 		return nil, err
 	}
@@ -314,6 +349,23 @@ func Lookup(
 	cluster *kubernetes.Cluster,
 	namespace,
 	appName string,
+) (*models.App, error) {
+	return LookupWithOptions(ctx, cluster, namespace, appName, LookupOptions{})
+}
+
+// LookupOptions tunes the information loaded by LookupWithOptions. The zero value loads
+// everything, like Lookup does.
+type LookupOptions struct {
+	SkipMetrics bool // Do not query the pod metrics. Replica metrics will be missing.
+}
+
+// LookupWithOptions is Lookup, with the loaded information controlled by the options.
+func LookupWithOptions(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	namespace,
+	appName string,
+	options LookupOptions,
 ) (*models.App, error) {
 	meta := models.NewAppRef(appName, namespace)
 
@@ -327,7 +379,7 @@ func Lookup(
 
 	app := meta.App()
 
-	err = fetch(ctx, cluster, app)
+	err = fetchWithOptions(ctx, cluster, app, options)
 	return app, err
 }
 
@@ -361,13 +413,17 @@ func ListAppRefs(
 }
 
 type AppData struct {
-	scaling  *v1.Secret
-	bound    *v1.Secret
-	env      *v1.Secret
-	services *v1.Secret
-	routes   []string
-	pods     []v1.Pod
-	staging  models.ApplicationStagingStatus
+	scaling   *v1.Secret
+	bound     *v1.Secret
+	env       *v1.Secret
+	services  *v1.Secret
+	placement *v1.Secret
+	rollout   *v1.Secret
+	process   *v1.Secret
+	network   *v1.Secret
+	routes    []string
+	pods      []v1.Pod
+	staging   models.ApplicationStagingStatus
 }
 
 /*
@@ -380,6 +436,95 @@ func List(
 	cluster *kubernetes.Cluster,
 	namespace string,
 ) (models.AppList, error) {
+	return ListWithOptions(ctx, cluster, namespace, ListOptions{})
+}
+
+// ListOptions restricts and tunes the listing of applications done by ListWithOptions.
+// The zero value lists everything, like List does.
+type ListOptions struct {
+	Selector    string // Label selector for the application resources to list.
+	SkipMetrics bool   // Do not query the pod metrics. Replica metrics will be missing.
+}
+
+// ListWithOptions is List, with the set of applications, and the loaded information controlled
+// by the options.
+func ListWithOptions(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	namespace string,
+	options ListOptions,
+) (models.AppList, error) {
+	apps, _, err := list(ctx, cluster, namespace, options, false)
+	return apps, err
+}
+
+// ListWithWarnings is ListWithOptions, degrading gracefully. An application which cannot be
+// assembled is listed with an error status, instead of failing the whole listing. This, and
+// other partial failures, like missing metrics, are reported in the returned warnings.
+func ListWithWarnings(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	namespace string,
+	options ListOptions,
+) (models.AppList, []string, error) {
+	apps, warnings, err := list(ctx, cluster, namespace, options, true)
+	return apps, warningMessages(warnings), err
+}
+
+// ListWarning is a warning about a partial failure of a listing. The namespace is set for the
+// warnings about a single application, and empty for the warnings about the whole listing.
+type ListWarning struct {
+	Namespace string
+	Message   string
+}
+
+// ListWithScopedWarnings is ListWithWarnings, with the warnings about single applications
+// scoped to their namespace. This allows the filtering of the warnings by the access of the
+// user to the namespaces, like the applications.
+func ListWithScopedWarnings(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	namespace string,
+	options ListOptions,
+) (models.AppList, []ListWarning, error) {
+	return list(ctx, cluster, namespace, options, true)
+}
+
+// FilterWarnings returns the messages of the warnings for the allowed namespaces, and of the
+// warnings about the whole listing. A nil set of namespaces allows all of them.
+func FilterWarnings(warnings []ListWarning, namespaces []string) []string {
+	if namespaces == nil {
+		return warningMessages(warnings)
+	}
+
+	result := []string{}
+	for _, warning := range warnings {
+		if warning.Namespace == "" || slices.Contains(namespaces, warning.Namespace) {
+			result = append(result, warning.Message)
+		}
+	}
+	return result
+}
+
+// warningMessages returns the messages of the warnings.
+func warningMessages(warnings []ListWarning) []string {
+	result := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		result = append(result, warning.Message)
+	}
+	return result
+}
+
+// list is the core of ListWithOptions and ListWithWarnings. With partial set, failures
+// affecting only some of the applications become warnings.
+func list(
+	ctx context.Context,
+	cluster *kubernetes.Cluster,
+	namespace string,
+	options ListOptions,
+	partial bool,
+) (models.AppList, []ListWarning, error) {
+	warnings := []ListWarning{}
 
 	// Verify namespace, if specified
 	// This is actually handled by `NamespaceMiddleware`.
@@ -391,11 +536,13 @@ func List(
 
 	client, err := cluster.ClientApp()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	appCRList, err := client.Namespace(namespace).List(ctx, metav1.ListOptions{})
+	appCRList, err := client.Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: options.Selector,
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// II. Load the auxiliary application data found in adjacent kube Secret
@@ -408,7 +555,7 @@ func List(
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	appAuxiliary := makeAuxiliaryMap(secrets.Items)
@@ -417,7 +564,7 @@ func List(
 
 	appAuxiliary, err = AddApplicationPods(appAuxiliary, ctx, cluster, namespace)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// IV. Actual application routes from the ingresses
@@ -429,24 +576,35 @@ func List(
 		namespace,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// V. Pod metrics and replica information
 
-	metrics, err := GetPodMetrics(ctx, cluster, namespace)
-	if err != nil {
-		// While the error is ignored, as the server can operate without metrics, and while
-		// the missing metrics will be noted in the data shown to the user, it is logged so
-		// that the operator can see this as well.
-		helpers.Logger.Errorw("metrics not available", "error", err)
+	var metrics map[string]metricsv1beta1.PodMetrics
+	if !options.SkipMetrics {
+		metrics, err = GetPodMetrics(ctx, cluster, namespace)
+		if err != nil {
+			// While the error is ignored, as the server can operate without metrics, and
+			// while the missing metrics will be noted in the data shown to the user, it is
+			// logged so that the operator can see this as well.
+			helpers.Logger.Errorw("metrics not available", "error", err)
+			warnings = append(warnings, ListWarning{
+				Message: fmt.Sprintf("metrics not available: %s", err),
+			})
+		}
 	}
 
 	// VI. load the statuses of all staging jobs
 
 	stagingStatuses, err := StagingStatuses(ctx, cluster, namespace)
 	if err != nil {
-		return nil, err
+		if !partial {
+			return nil, nil, err
+		}
+		warnings = append(warnings, ListWarning{
+			Message: fmt.Sprintf("staging status not available: %s", err),
+		})
 	}
 	appAuxiliary = updateAppDataMapWithStagingJobStatus(
 		appAuxiliary,
@@ -460,14 +618,28 @@ func List(
 	for _, appCR := range appCRList.Items {
 		app, err := aggregate(ctx, cluster, appCR, appAuxiliary, metrics)
 		if err != nil {
-			return result, err
+			if !partial {
+				return result, warnings, err
+			}
+
+			// Keep the application in the list, marked as broken
+			app = models.NewApp(appCR.GetName(), appCR.GetNamespace())
+			app.Meta.CreatedAt = appCR.GetCreationTimestamp()
+			app.Status = models.ApplicationError
+			app.StatusMessage = err.Error()
+
+			warnings = append(warnings, ListWarning{
+				Namespace: appCR.GetNamespace(),
+				Message: fmt.Sprintf("application '%s' in namespace '%s': %s",
+					appCR.GetName(), appCR.GetNamespace(), err),
+			})
 		}
 		if app != nil {
 			result = append(result, *app)
 		}
 	}
 
-	return result, nil
+	return result, warnings, nil
 }
 
 /*
@@ -511,6 +683,16 @@ func Delete(
 		return err
 	}
 
+	// Same for the workloads of the additional process types, if any.
+	processTypes, err := ProcessTypes(ctx, cluster, appRef)
+	if err != nil {
+		return err
+	}
+	err = ProcessTypesRemove(cluster, appRef, DroppedProcessTypes(processTypes, nil))
+	if err != nil {
+		return err
+	}
+
 	// Keep existing code to remove the CRD and everything it owns. Only the
 	// workload resources needed their own removal to ensure that helm information
 	// stays consistent.
@@ -535,7 +717,7 @@ func Delete(
 		}
 	}
 
-	// delete old staging resources in namespace (helmchart.Namespace())
+	// delete old staging resources in namespace (helmchart.StagingNamespace())
 	err = Unstage(ctx, cluster, appRef, "")
 	if err != nil && !apierrors.IsNotFound(err) {
 		return err
@@ -616,6 +798,16 @@ func deleteContainerImage(
 	}
 
 	// Get TLS config to handle self-signed certificates
+	tlsConfig := RegistryTLSConfig(ctx, cluster, imageRegistryURL)
+
+	// Delete the image
+	return registry.DeleteImage(ctx, imageURL, matchingCreds, tlsConfig)
+}
+
+// RegistryTLSConfig returns the TLS configuration for talking to the registry at the given URL.
+// It trusts the certificate from the secret named by REGISTRY_CERTIFICATE_SECRET, and the
+// cluster CA. Verification is skipped for an internal registry without known certificate.
+func RegistryTLSConfig(ctx context.Context, cluster *kubernetes.Cluster, registryURL string) *tls.Config {
 	var tlsConfig *tls.Config
 	rootCAs, err := x509.SystemCertPool()
 	if err != nil {
@@ -670,9 +862,9 @@ func deleteContainerImage(
 	// Build TLS config based on what we have
 	if tlsConfig == nil {
 		// Check if this is an internal registry (cluster.local domain)
-		isInternalRegistry := strings.Contains(imageRegistryURL, ".svc.cluster.local") ||
-			strings.Contains(imageRegistryURL, "127.0.0.1") ||
-			strings.Contains(imageRegistryURL, "localhost")
+		isInternalRegistry := strings.Contains(registryURL, ".svc.cluster.local") ||
+			strings.Contains(registryURL, "127.0.0.1") ||
+			strings.Contains(registryURL, "localhost")
 
 		if certsAdded {
 			// We have certificates in the pool, use them
@@ -690,7 +882,7 @@ func deleteContainerImage(
 			helpers.Logger.Infow(
 				"No registry certificate found, skipping TLS verification for internal registry",
 				"registry",
-				imageRegistryURL,
+				registryURL,
 			)
 		} else {
 			// External registry - use system cert pool (may fail if cert is not trusted)
@@ -701,8 +893,7 @@ func deleteContainerImage(
 		}
 	}
 
-	// Delete the image
-	return registry.DeleteImage(ctx, imageURL, matchingCreds, tlsConfig)
+	return tlsConfig
 }
 
 // deleteCacheStagePVC removes the kube PVC resource which was used to hold the application
@@ -713,10 +904,22 @@ func deleteCacheStagePVC(
 	appRef models.AppRef,
 ) error {
 	return cluster.Kubectl.CoreV1().PersistentVolumeClaims(
-		helmchart.Namespace(),
+		helmchart.StagingNamespace(),
 	).Delete(ctx, appRef.MakeCachePVCName(), metav1.DeleteOptions{})
 }
 
+// DeleteSharedCachePVC removes the kube PVC resource holding the build cache shared by the
+// applications of the namespace, if any.
+func DeleteSharedCachePVC(ctx context.Context, cluster *kubernetes.Cluster, namespace string) error {
+	err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(
+		helmchart.StagingNamespace(),
+	).Delete(ctx, models.MakeSharedCachePVCName(namespace), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func deleteSourceBlobsStagePVC(
 	ctx context.Context,
 	cluster *kubernetes.Cluster,
@@ -724,7 +927,7 @@ func deleteSourceBlobsStagePVC(
 ) error {
 	return cluster.Kubectl.CoreV1().
 		PersistentVolumeClaims(
-			helmchart.Namespace(),
+			helmchart.StagingNamespace(),
 		).Delete(ctx, appRef.MakeSourceBlobsPVCName(), metav1.DeleteOptions{})
 }
 
@@ -801,6 +1004,24 @@ func ImageURL(app *unstructured.Unstructured) (string, error) {
 	return imageURL, nil
 }
 
+// DeployedRevision returns the git revision and the image digest of the deployed application.
+// The revision is only known for applications deployed from git. The digest is taken from the
+// image url if the image was deployed by digest, and else from the running workload, if any.
+func DeployedRevision(app *models.App) (string, string) {
+	var gitRevision string
+	if app.Origin.Kind == models.OriginGit && app.Origin.Git != nil {
+		gitRevision = app.Origin.Git.Revision
+	}
+
+	if _, digest, found := strings.Cut(app.ImageURL, "@"); found {
+		return gitRevision, digest
+	}
+	if app.Workload != nil {
+		return gitRevision, app.Workload.ImageDigest
+	}
+	return gitRevision, ""
+}
+
 /*
 BuilderURL returns the builder url of the currently running build, if one
 exists. It returns an empty string otherwise. The information is pulled out
@@ -843,7 +1064,7 @@ func Unstage(
 		return errors.Wrap(err, "creating an S3 manager")
 	}
 
-	jobs, err := cluster.ListJobs(ctx, helmchart.Namespace(),
+	jobs, err := cluster.ListJobs(ctx, helmchart.StagingNamespace(),
 		fmt.Sprintf("app.kubernetes.io/name=%s,app.kubernetes.io/part-of=%s",
 			appRef.Name, appRef.Namespace))
 
@@ -899,8 +1120,11 @@ type LogParameters struct {
 	Since             *time.Duration
 	SinceTime         *time.Time
 	Follow            bool
+	Timestamps        bool     // Prefix each line with its RFC3339 timestamp
 	IncludeContainers []string // List of container names/patterns to include (regex patterns)
 	ExcludeContainers []string // List of container names/patterns to exclude (regex patterns)
+	Instance          string   // Name of the single pod to restrict the logs to
+	Container         string   // Name of the single container to restrict the logs to
 }
 
 // buildContainerIncludePattern builds the regex pattern for including containers.
@@ -909,7 +1133,16 @@ func buildContainerIncludePattern(logParams *LogParameters) (string, bool, error
 	containerQueryPattern := ".*"
 	hasUserIncludeFilter := false
 
-	if logParams == nil || len(logParams.IncludeContainers) == 0 {
+	if logParams == nil {
+		return containerQueryPattern, hasUserIncludeFilter, nil
+	}
+
+	// A named container takes precedence over the include patterns
+	if logParams.Container != "" {
+		return "^" + regexp.QuoteMeta(logParams.Container) + "$", true, nil
+	}
+
+	if len(logParams.IncludeContainers) == 0 {
 		return containerQueryPattern, hasUserIncludeFilter, nil
 	}
 
@@ -1100,6 +1333,10 @@ func Logs(
 		config.Ordered = true
 	}
 
+	if logParams != nil && logParams.Instance != "" {
+		config.PodQuery = regexp.MustCompile("^" + regexp.QuoteMeta(logParams.Instance) + "$")
+	}
+
 	// Apply log parameters if provided
 	applyLogParameters(config, logParams)
 	if logParams != nil {
@@ -1149,7 +1386,7 @@ func makeAuxiliaryMap(secrets []v1.Secret) map[ConfigurationKey]AppData {
 		as per their area (*). Key the maps by namespace and name of their
 		controlling application for quick access in the	aggregation step.
 
-		(*) Label "epinio.io/area": "environment"|"scaling"|"configuration"
+		(*) Label "epinio.io/area": "environment"|"scaling"|"configuration"|"service"|"placement"|"rollout"|"process"|"network"
 	*/
 
 	result := map[ConfigurationKey]AppData{}
@@ -1182,6 +1419,14 @@ func makeAuxiliaryMap(secrets []v1.Secret) map[ConfigurationKey]AppData {
 			data.env = &secretToAssign
 		case "service":
 			data.services = &secretToAssign
+		case "placement":
+			data.placement = &secretToAssign
+		case "rollout":
+			data.rollout = &secretToAssign
+		case "process":
+			data.process = &secretToAssign
+		case "network":
+			data.network = &secretToAssign
 		default:
 			// ignore secret
 		}
@@ -1230,6 +1475,7 @@ func aggregate(ctx context.Context,
 		// parse errors only, i.e. bad data.
 		return nil, errors.Wrap(err, "finding scaling")
 	}
+	_, stopped := StoppedFromSecret(aux.scaling)
 
 	configurations := BoundConfigurationNamesFromSecret(aux.bound)
 	environment := EnvironmentFromSecret(aux.env)
@@ -1239,6 +1485,41 @@ func aggregate(ctx context.Context,
 	if aux.services != nil {
 		services = BoundServiceNamesFromSecret(aux.services)
 	}
+	var placement *models.ApplicationPlacement
+	if aux.placement != nil {
+		placement, err = PlacementFromSecret(aux.placement)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding placement")
+		}
+	}
+	var rollout *models.ApplicationRollout
+	if aux.rollout != nil {
+		rollout, err = RolloutFromSecret(aux.rollout)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding rollout")
+		}
+	}
+	var process *models.ApplicationProcess
+	var processTypes []models.ApplicationProcessType
+	if aux.process != nil {
+		process, err = ProcessFromSecret(aux.process)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding process")
+		}
+		processTypes, err = ProcessTypesFromSecret(aux.process)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding process types")
+		}
+	}
+	var ingressClass string
+	var dns *models.ApplicationDNS
+	if aux.network != nil {
+		ingressClass = IngressClassFromSecret(aux.network)
+		dns, err = DNSFromSecret(aux.network)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding dns")
+		}
+	}
 
 	// II. Unpack the core application resource
 
@@ -1283,6 +1564,7 @@ func aggregate(ctx context.Context,
 	app := meta.App()
 
 	app.Meta.CreatedAt = appCR.GetCreationTimestamp()
+	app.Stopped = stopped
 
 	app.Configuration.Instances = &instances
 	app.Configuration.Configurations = configurations
@@ -1291,10 +1573,18 @@ func aggregate(ctx context.Context,
 	app.Configuration.Routes = desiredRoutes
 	app.Configuration.AppChart = chartName
 	app.Configuration.Settings = settings
+	app.Configuration.Placement = placement
+	app.Configuration.Rollout = rollout
+	app.Configuration.Process = process
+	app.Configuration.ProcessTypes = processTypes
+	app.Configuration.IngressClass = ingressClass
+	app.Configuration.DNS = dns
+	app.Configuration.PodMetadata = PodMetadataFromAnnotations(appCR.GetAnnotations())
 	app.Origin = origin
 	app.StageID = stageID
 	app.ImageURL = imageURL
 	app.Staging.Builder = builderURL
+	app.ExpiresAt = expiry.FromAnnotations(appCR.GetAnnotations())
 
 	// IV. Assemble the deployment structure for active applications.
 
@@ -1315,6 +1605,8 @@ func aggregate(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	app.ScaledToZero = app.Workload == nil && instances == 0 && app.ImageURL != ""
+	app.GitRevision, app.ImageDigest = DeployedRevision(app)
 
 	// set app status and done ...
 
@@ -1336,6 +1628,11 @@ func aggregate(ctx context.Context,
 
 // fetch is a helper for Lookup. It fetches all information about an application from the cluster.
 func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) error {
+	return fetchWithOptions(ctx, cluster, app, LookupOptions{})
+}
+
+// fetchWithOptions is fetch, with the loaded information controlled by the options.
+func fetchWithOptions(ctx context.Context, cluster *kubernetes.Cluster, app *models.App, options LookupOptions) error {
 	// Consider delayed loading, i.e. on first access, or for transfer (API response).
 	// Consider objects for the information which hide the defered loading.  These
 	// could also have the necessary modifier methods.  See sibling files scale.go,
@@ -1380,13 +1677,21 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 		return err
 	}
 
-	instances, err := Scaling(ctx, cluster, app.Meta)
+	scaleSecret, err := scaleLoad(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding scaling")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+	instances, err := ScalingFromSecret(scaleSecret)
 	if err != nil {
 		err = errors.Wrap(err, "finding scaling")
 		app.StatusMessage = err.Error()
 		app.Status = models.ApplicationError
 		return err
 	}
+	_, stopped := StoppedFromSecret(scaleSecret)
 
 	configurations, err := BoundConfigurationNames(ctx, cluster, app.Meta)
 	if err != nil {
@@ -1444,7 +1749,56 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 		return err
 	}
 
+	placement, err := Placement(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding placement")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	rollout, err := Rollout(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding rollout")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	process, err := Process(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding process")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	processTypes, err := ProcessTypes(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding process types")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	ingressClass, err := IngressClass(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding ingress class")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
+	dns, err := DNS(ctx, cluster, app.Meta)
+	if err != nil {
+		err = errors.Wrap(err, "finding dns")
+		app.StatusMessage = err.Error()
+		app.Status = models.ApplicationError
+		return err
+	}
+
 	app.Meta.CreatedAt = applicationCR.GetCreationTimestamp()
+	app.Stopped = stopped
 
 	app.Configuration.Instances = &instances
 	app.Configuration.Configurations = configurations
@@ -1453,6 +1807,14 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 	app.Configuration.Routes = desiredRoutes
 	app.Configuration.AppChart = chartName
 	app.Configuration.Settings = settings
+	app.Configuration.Placement = placement
+	app.Configuration.Rollout = rollout
+	app.Configuration.Process = process
+	app.Configuration.ProcessTypes = processTypes
+	app.Configuration.IngressClass = ingressClass
+	app.Configuration.DNS = dns
+	app.Configuration.PodMetadata = PodMetadataFromAnnotations(applicationCR.GetAnnotations())
+	app.ExpiresAt = expiry.FromAnnotations(applicationCR.GetAnnotations())
 	app.Origin = origin
 	app.StageID = stageID
 	app.ImageURL = imageURL
@@ -1461,13 +1823,46 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 	// Check if app is active, and if yes, fill the associated parts.  May have to
 	// straighten the workload structure a bit further.
 
-	app.Workload, err = NewWorkload(cluster, app.Meta, instances).Get(ctx)
+	workload := NewWorkload(cluster, app.Meta, instances)
+	if options.SkipMetrics {
+		app.Workload, err = workload.GetWithoutMetrics(ctx)
+	} else {
+		app.Workload, err = workload.Get(ctx)
+	}
 	if err != nil {
 		err = errors.Wrap(err, "workload loading")
 		app.StatusMessage = err.Error()
 		app.Status = models.ApplicationError
 		return err
 	}
+	app.ScaledToZero = app.Workload == nil && instances == 0 && app.ImageURL != ""
+	app.GitRevision, app.ImageDigest = DeployedRevision(app)
+
+	// The workloads of the additional process types, reported separately
+	for _, processType := range processTypes {
+		workload := NewWorkload(cluster, ProcessTypeRef(app.Meta, processType.Name),
+			ProcessTypeInstances(processType))
+
+		var processWorkload *models.AppDeployment
+		if options.SkipMetrics {
+			processWorkload, err = workload.GetWithoutMetrics(ctx)
+		} else {
+			processWorkload, err = workload.Get(ctx)
+		}
+		if err != nil {
+			err = errors.Wrapf(err, "workload loading, process type %s", processType.Name)
+			app.StatusMessage = err.Error()
+			app.Status = models.ApplicationError
+			return err
+		}
+		if processWorkload == nil {
+			continue
+		}
+		if app.Processes == nil {
+			app.Processes = map[string]*models.AppDeployment{}
+		}
+		app.Processes[processType.Name] = processWorkload
+	}
 
 	staging, err := stagingStatus(ctx, cluster, app.Meta.Namespace, app.Meta.Name)
 	if err != nil {
@@ -182,3 +182,23 @@ func makeJob(appName, namespace string, status v1.ConditionStatus, conditionType
 		},
 	}
 }
+
+var _ = Describe("NewConfigurationValidation", func() {
+	It("is valid without issues", func() {
+		validation := application.NewConfigurationValidation(nil)
+		Expect(validation.Valid).To(BeTrue())
+		Expect(validation.Messages).To(BeEmpty())
+	})
+
+	It("reports the issues of the chart values, sorted", func() {
+		issues := application.ValidateCV(
+			models.ChartValueSettings{"unknown": "1", "replicas": "many"},
+			map[string]models.ChartSetting{"replicas": {Type: "integer"}},
+		)
+
+		validation := application.NewConfigurationValidation(issues)
+		Expect(validation.Valid).To(BeFalse())
+		Expect(validation.Messages).To(HaveLen(2))
+		Expect(validation.Messages[1]).To(Equal(`setting "unknown": Not known`))
+	})
+})
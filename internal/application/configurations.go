@@ -219,6 +219,19 @@ func BoundConfigurationsUnset(ctx context.Context, cluster *kubernetes.Cluster,
 	})
 }
 
+// BoundConfigurationsChange removes and adds the specified configuration names for the named
+// application, in a single update. When the function returns the configuration set is changed.
+func BoundConfigurationsChange(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, remove, add []string) error {
+	return configUpdate(ctx, cluster, appRef, func(configSecret *v1.Secret) {
+		for _, c := range remove {
+			delete(configSecret.Data, c)
+		}
+		for _, c := range add {
+			configSecret.Data[c] = nil
+		}
+	})
+}
+
 // configUpdate is a helper for the public functions. It encapsulates the read/modify/write cycle
 // necessary to update the application's kube resource holding the application's configuration
 // names.
@@ -0,0 +1,86 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DeployedConfigurationAnnotation is the annotation of the application resource holding the
+// fingerprint of the configuration the application was last deployed with.
+const DeployedConfigurationAnnotation = "epinio.io/deployed-configuration"
+
+// ConfigurationFingerprint returns the fingerprint of the configuration of the application, i.e.
+// of its bindings, environment, and the other settings rendered into its deployment.
+func ConfigurationFingerprint(configuration models.ApplicationConfiguration) (string, error) {
+	data, err := json.Marshal(configuration)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ConfigurationChanged returns true if the configuration of the application differs from the
+// configuration recorded in the annotations of its resource at its last deployment. This is
+// the case when bindings or environment were changed without a deployment. Without a recorded
+// configuration it is assumed to have changed.
+func ConfigurationChanged(configuration models.ApplicationConfiguration, annotations map[string]string) (bool, error) {
+	recorded, ok := annotations[DeployedConfigurationAnnotation]
+	if !ok || recorded == "" {
+		return true, nil
+	}
+
+	fingerprint, err := ConfigurationFingerprint(configuration)
+	if err != nil {
+		return false, err
+	}
+
+	return fingerprint != recorded, nil
+}
+
+// DeployedConfigurationSet records the configuration the referenced application was deployed
+// with in the annotations of its resource.
+func DeployedConfigurationSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, configuration models.ApplicationConfiguration) error {
+	fingerprint, err := ConfigurationFingerprint(configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				DeployedConfigurationAnnotation: fingerprint,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Namespace(appRef.Namespace).Patch(ctx, appRef.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
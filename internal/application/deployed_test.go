@@ -0,0 +1,66 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigurationChanged", func() {
+	var configuration models.ApplicationConfiguration
+	var annotations map[string]string
+
+	BeforeEach(func() {
+		instances := int32(1)
+		configuration = models.ApplicationConfiguration{
+			Instances:   &instances,
+			Environment: models.EnvVariableMap{"FOO": "bar"},
+		}
+
+		fingerprint, err := application.ConfigurationFingerprint(configuration)
+		Expect(err).ToNot(HaveOccurred())
+		annotations = map[string]string{application.DeployedConfigurationAnnotation: fingerprint}
+	})
+
+	It("is unchanged for the deployed configuration", func() {
+		changed, err := application.ConfigurationChanged(configuration, annotations)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("is changed by a binding recorded without restart", func() {
+		// bind --no-restart records the configuration, without deploying it
+		configuration.Configurations = []string{"mydb"}
+
+		changed, err := application.ConfigurationChanged(configuration, annotations)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+	})
+
+	It("is changed by environment changes", func() {
+		configuration.Environment = models.EnvVariableMap{"FOO": "baz"}
+
+		changed, err := application.ConfigurationChanged(configuration, annotations)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+	})
+
+	It("is changed without a recorded deployment", func() {
+		changed, err := application.ConfigurationChanged(configuration, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+	})
+})
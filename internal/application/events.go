@@ -0,0 +1,111 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	pkgerrors "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Events returns the kubernetes events of the application's workload, newest first.
+func Events(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (models.EventList, error) {
+	return GetAppEvents(ctx, cluster.Kubectl.CoreV1(), cluster.Kubectl.AppsV1(), appRef)
+}
+
+// GetAppEvents returns the kubernetes events of the application's workload, ordered from newest
+// to oldest. The workload are the deployments, replica sets and pods matching the label selector
+// of the application. Scheduling failures, image pull errors, and OOM kills are reported against
+// them. The events of pods already gone, like replaced crashing pods, are found through the name
+// of their replica set, which prefixes the pod names.
+func GetAppEvents(ctx context.Context, coreClient typedcorev1.CoreV1Interface, appsClient typedappsv1.AppsV1Interface, appRef models.AppRef) (models.EventList, error) {
+	selector := metav1.ListOptions{LabelSelector: workloadSelector(appRef)}
+
+	owned := map[types.UID]struct{}{}
+
+	deployments, err := appsClient.Deployments(appRef.Namespace).List(ctx, selector)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetching the deployments")
+	}
+	for _, deployment := range deployments.Items {
+		owned[deployment.UID] = struct{}{}
+	}
+
+	replicaSets, err := appsClient.ReplicaSets(appRef.Namespace).List(ctx, selector)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetching the replica sets")
+	}
+	podPrefixes := []string{}
+	for _, replicaSet := range replicaSets.Items {
+		owned[replicaSet.UID] = struct{}{}
+		podPrefixes = append(podPrefixes, replicaSet.Name+"-")
+	}
+
+	pods, err := coreClient.Pods(appRef.Namespace).List(ctx, selector)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetching the pods")
+	}
+	for _, pod := range pods.Items {
+		owned[pod.UID] = struct{}{}
+	}
+
+	result := models.EventList{}
+	if len(owned) == 0 {
+		return result, nil
+	}
+
+	events, err := coreClient.Events(appRef.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "fetching the events")
+	}
+
+	for _, event := range events.Items {
+		if !isWorkloadEvent(event.InvolvedObject, owned, podPrefixes) {
+			continue
+		}
+		result = append(result, services.ToEvent(event))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[j].LastSeen.Before(&result[i].LastSeen)
+	})
+
+	return result, nil
+}
+
+// isWorkloadEvent returns true if the event object is one of the owned resources, or a pod of one
+// of the replica sets.
+func isWorkloadEvent(object corev1.ObjectReference, owned map[types.UID]struct{}, podPrefixes []string) bool {
+	if _, isOwned := owned[object.UID]; isOwned {
+		return true
+	}
+	if object.Kind != "Pod" {
+		return false
+	}
+	for _, prefix := range podPrefixes {
+		if strings.HasPrefix(object.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetAppEvents", func() {
+	const namespace = "workspace"
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	appRef := models.NewAppRef("myapp", namespace)
+
+	appLabels := map[string]string{
+		"app.kubernetes.io/component": "application",
+		"app.kubernetes.io/name":      "myapp",
+		"app.kubernetes.io/part-of":   namespace,
+	}
+
+	newEvent := func(eventName, kind, object, uid, reason string, count int32, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: eventName, Namespace: namespace},
+			InvolvedObject: corev1.ObjectReference{
+				Kind: kind,
+				Name: object,
+				UID:  k8stypes.UID(uid),
+			},
+			Reason:        reason,
+			Message:       reason + " happened",
+			Count:         count,
+			Type:          corev1.EventTypeWarning,
+			LastTimestamp: metav1.NewTime(lastSeen),
+		}
+	}
+
+	It("returns the events of the app's workload, newest first", func() {
+		objects := []runtime.Object{
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "myapp-deploy", Namespace: namespace, UID: "deploy-uid", Labels: appLabels,
+			}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name: "myapp-deploy-5d8f", Namespace: namespace, UID: "rs-uid", Labels: appLabels,
+			}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "myapp-deploy-5d8f-abcde", Namespace: namespace, UID: "pod-uid", Labels: appLabels,
+			}},
+			newEvent("e1", "Pod", "myapp-deploy-5d8f-abcde", "pod-uid", "FailedScheduling", 3, base),
+			newEvent("e2", "Pod", "myapp-deploy-5d8f-gone1", "gone-uid", "OOMKilling", 1, base.Add(2*time.Minute)),
+			newEvent("e3", "Deployment", "myapp-deploy", "deploy-uid", "ScalingReplicaSet", 1, base.Add(time.Minute)),
+			newEvent("e4", "Pod", "otherapp-7c9b-xyz12", "other-uid", "BackOff", 5, base.Add(3*time.Minute)),
+		}
+
+		client := fake.NewSimpleClientset(objects...)
+
+		events, err := application.GetAppEvents(context.Background(), client.CoreV1(), client.AppsV1(), appRef)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(3))
+
+		Expect(events[0].Reason).To(Equal("OOMKilling"))
+		Expect(events[1].Reason).To(Equal("ScalingReplicaSet"))
+		Expect(events[1].Kind).To(Equal("Deployment"))
+		Expect(events[2].Reason).To(Equal("FailedScheduling"))
+		Expect(events[2].Message).To(Equal("FailedScheduling happened"))
+		Expect(events[2].Count).To(Equal(int32(3)))
+		Expect(events[2].LastSeen.Time).To(Equal(base))
+	})
+
+	It("returns no events for an app without workload", func() {
+		client := fake.NewSimpleClientset(
+			newEvent("e1", "Pod", "otherapp-7c9b-xyz12", "other-uid", "BackOff", 5, base),
+		)
+
+		events, err := application.GetAppEvents(context.Background(), client.CoreV1(), client.AppsV1(), appRef)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(BeEmpty())
+	})
+})
@@ -0,0 +1,172 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	historyKey = "history"
+
+	// DefaultHistoryLimit is the number of revisions retained per application, when not
+	// configured otherwise.
+	DefaultHistoryLimit = 10
+)
+
+// History returns the deploy history of the application, oldest revision first. An application
+// which was never deployed has an empty history.
+func History(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]models.AppRevision, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeHistorySecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []models.AppRevision{}, nil
+		}
+		return nil, err
+	}
+
+	return HistoryFromSecret(secret)
+}
+
+// HistoryFromSecret is the core of History, extracting the revisions from the secret
+// containing them.
+func HistoryFromSecret(secret *v1.Secret) ([]models.AppRevision, error) {
+	revisions := []models.AppRevision{}
+
+	data, ok := secret.Data[historyKey]
+	if !ok || len(data) == 0 {
+		return revisions, nil
+	}
+
+	if err := json.Unmarshal(data, &revisions); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// HistoryRevisions returns the revisions recorded in the deploy histories of all applications,
+// across all namespaces.
+func HistoryRevisions(ctx context.Context, cluster *kubernetes.Cluster) ([]models.AppRevision, error) {
+	secrets, err := cluster.Kubectl.CoreV1().Secrets("").List(ctx, metav1.ListOptions{
+		LabelSelector: EpinioApplicationAreaLabel + "=history",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return HistoryRevisionsFromSecrets(secrets.Items)
+}
+
+// HistoryRevisionsFromSecrets is the core of HistoryRevisions, extracting the revisions from
+// the secrets containing them. A history which cannot be read is an error, not skipped.
+func HistoryRevisionsFromSecrets(secrets []v1.Secret) ([]models.AppRevision, error) {
+	result := []models.AppRevision{}
+	for i := range secrets {
+		revisions, err := HistoryFromSecret(&secrets[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading history %s/%s", secrets[i].Namespace, secrets[i].Name)
+		}
+		result = append(result, revisions...)
+	}
+
+	return result, nil
+}
+
+// HistoryRecord adds the revision to the deploy history of the named application. The revision
+// number is assigned here, one past the last recorded revision. Only the last `limit` revisions
+// are retained. A limit of zero or less disables the recording. When the function returns the
+// history is saved.
+func HistoryRecord(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, revision models.AppRevision, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := historyLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		revisions, err := HistoryFromSecret(secret)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(AppendRevision(revisions, revision, limit))
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[historyKey] = data
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// AppendRevision returns the revisions extended by the new revision, numbered one past the last
+// revision, and capped to the last `limit` entries.
+func AppendRevision(revisions []models.AppRevision, revision models.AppRevision, limit int) []models.AppRevision {
+	revision.Revision = 1
+	if len(revisions) > 0 {
+		revision.Revision = revisions[len(revisions)-1].Revision + 1
+	}
+
+	revisions = append(revisions, revision)
+	if len(revisions) > limit {
+		revisions = revisions[len(revisions)-limit:]
+	}
+
+	return revisions
+}
+
+// NewRevision returns the history entry for a deployment of the application, as it is now.
+func NewRevision(app *models.App, username string) models.AppRevision {
+	revision := models.AppRevision{
+		DeployedAt:    metav1.Now(),
+		DeployedBy:    username,
+		StageID:       app.StageID,
+		ImageURL:      app.ImageURL,
+		Configuration: app.Configuration,
+		Origin:        app.Origin,
+		Staging:       app.Staging,
+	}
+
+	if _, digest, found := strings.Cut(app.ImageURL, "@"); found {
+		revision.ImageDigest = digest
+	}
+
+	return revision
+}
+
+// historyLoad locates and returns the kube secret storing the referenced application's
+// deploy history. If necessary it creates that secret.
+func historyLoad(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*v1.Secret, error) {
+	secretName := appRef.MakeHistorySecretName()
+	return loadOrCreateSecret(ctx, cluster, appRef, secretName, "history")
+}
@@ -0,0 +1,120 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"encoding/json"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("History", func() {
+	numbers := func(revisions []models.AppRevision) []int {
+		result := []int{}
+		for _, revision := range revisions {
+			result = append(result, revision.Revision)
+		}
+		return result
+	}
+
+	Describe("AppendRevision", func() {
+		It("numbers the first revision 1", func() {
+			revisions := application.AppendRevision(nil, models.AppRevision{}, 3)
+			Expect(numbers(revisions)).To(Equal([]int{1}))
+		})
+
+		It("numbers revisions past the last, and caps the history", func() {
+			revisions := []models.AppRevision{}
+			for i := 0; i < 5; i++ {
+				revisions = application.AppendRevision(revisions, models.AppRevision{}, 3)
+			}
+			Expect(numbers(revisions)).To(Equal([]int{3, 4, 5}))
+		})
+	})
+
+	Describe("NewRevision", func() {
+		It("records the deployed image, origin and configuration", func() {
+			instances := int32(2)
+			app := models.NewApp("app", "workspace")
+			app.StageID = "stage"
+			app.ImageURL = "registry/apps/workspace-app@sha256:abc"
+			app.Configuration.Instances = &instances
+			app.Origin = models.ApplicationOrigin{
+				Kind: models.OriginGit,
+				Git:  &models.GitRef{URL: "https://example.com/repo", Revision: "main"},
+			}
+
+			revision := application.NewRevision(app, "admin")
+			Expect(revision.DeployedBy).To(Equal("admin"))
+			Expect(revision.StageID).To(Equal("stage"))
+			Expect(revision.ImageDigest).To(Equal("sha256:abc"))
+			Expect(revision.Origin.Git.Revision).To(Equal("main"))
+			Expect(*revision.Configuration.Instances).To(Equal(int32(2)))
+			Expect(revision.DeployedAt.IsZero()).To(BeFalse())
+
+			manifest := revision.Manifest(app.Meta)
+			Expect(manifest.Name).To(Equal("app"))
+			Expect(manifest.Namespace).To(Equal("workspace"))
+			Expect(manifest.Origin.Git.URL).To(Equal("https://example.com/repo"))
+		})
+	})
+
+	Describe("HistoryFromSecret", func() {
+		It("returns an empty history for an empty secret", func() {
+			revisions, err := application.HistoryFromSecret(&v1.Secret{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revisions).To(BeEmpty())
+		})
+
+		It("returns the stored revisions", func() {
+			data, err := json.Marshal([]models.AppRevision{{Revision: 4, ImageURL: "image"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			revisions, err := application.HistoryFromSecret(&v1.Secret{
+				Data: map[string][]byte{"history": data},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(numbers(revisions)).To(Equal([]int{4}))
+			Expect(revisions[0].ImageURL).To(Equal("image"))
+		})
+	})
+	Describe("HistoryRevisionsFromSecrets", func() {
+		secret := func(revisions ...models.AppRevision) v1.Secret {
+			data, err := json.Marshal(revisions)
+			Expect(err).ToNot(HaveOccurred())
+			return v1.Secret{Data: map[string][]byte{"history": data}}
+		}
+
+		It("returns the revisions of all histories", func() {
+			revisions, err := application.HistoryRevisionsFromSecrets([]v1.Secret{
+				secret(models.AppRevision{Revision: 1, ImageURL: "a:1"}, models.AppRevision{Revision: 2, ImageURL: "a:2"}),
+				{},
+				secret(models.AppRevision{Revision: 7, ImageURL: "b@sha256:abc", ImageDigest: "sha256:abc"}),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(numbers(revisions)).To(Equal([]int{1, 2, 7}))
+			Expect(revisions[2].ImageDigest).To(Equal("sha256:abc"))
+		})
+
+		It("fails on an unreadable history", func() {
+			_, err := application.HistoryRevisionsFromSecrets([]v1.Secret{
+				{Data: map[string][]byte{"history": []byte("garbage")}},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
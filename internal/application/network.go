@@ -0,0 +1,264 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	ingressClassKey = "ingressclass"
+	dnsKey          = "dns"
+
+	// maxNameservers is the kubernetes limit on the nameservers of a pod.
+	maxNameservers = 3
+	// maxSearches is the kubernetes limit on the search domains of a pod.
+	maxSearches = 32
+)
+
+// dnsPolicies are the DNS policies supported by kubernetes
+var dnsPolicies = []string{
+	string(v1.DNSClusterFirst),
+	string(v1.DNSClusterFirstWithHostNet),
+	string(v1.DNSDefault),
+	string(v1.DNSNone),
+}
+
+// IngressClass returns the ingress class set by a user for the application, or the empty
+// string, if there is none. The latter means that the application uses the ingress class
+// configured for the server.
+func IngressClass(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (string, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeNetworkSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return IngressClassFromSecret(secret), nil
+}
+
+// IngressClassFromSecret is the core of IngressClass, extracting the ingress class from the
+// secret containing it.
+func IngressClassFromSecret(secret *v1.Secret) string {
+	return string(secret.Data[ingressClassKey])
+}
+
+// IngressClassSet sets the ingress class for the named application. The empty string returns
+// the application to the ingress class configured for the server. When the function returns
+// the ingress class is saved.
+func IngressClassSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, ingressClass string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := networkLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[ingressClassKey] = []byte(ingressClass)
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// ValidateIngressClass checks that the ingress class can be used by applications. If the
+// operator restricted the ingress classes it has to be in that list. Otherwise it has to exist
+// in the cluster. The empty string, i.e. the server default, is always valid.
+func ValidateIngressClass(ctx context.Context, cluster *kubernetes.Cluster, ingressClass string) error {
+	if ingressClass == "" {
+		return nil
+	}
+
+	allowed := viper.GetStringSlice("ingress-classes")
+	if len(allowed) > 0 {
+		if !slices.Contains(allowed, ingressClass) {
+			return fmt.Errorf("bad ingress class '%s', expected one of '%s'",
+				ingressClass, strings.Join(allowed, "', '"))
+		}
+		return nil
+	}
+
+	_, err := cluster.Kubectl.NetworkingV1().IngressClasses().Get(ctx, ingressClass, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("bad ingress class '%s', not found in the cluster", ingressClass)
+		}
+		return errors.Wrap(err, "checking the ingress class")
+	}
+
+	return nil
+}
+
+// DNS returns the DNS settings set by a user for the application, or nil, if there are none.
+func DNS(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.ApplicationDNS, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeNetworkSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return DNSFromSecret(secret)
+}
+
+// DNSFromSecret is the core of DNS, extracting the DNS settings from the secret containing
+// them.
+func DNSFromSecret(secret *v1.Secret) (*models.ApplicationDNS, error) {
+	data, ok := secret.Data[dnsKey]
+	if !ok || len(data) == 0 {
+		return nil, nil
+	}
+
+	dns := models.ApplicationDNS{}
+	if err := json.Unmarshal(data, &dns); err != nil {
+		return nil, err
+	}
+	if dns.DNSPolicy == "" && dns.DNSConfig == nil {
+		return nil, nil
+	}
+
+	return &dns, nil
+}
+
+// DNSSet sets the DNS settings for the named application. Empty settings return the
+// application to the DNS of the cluster. When the function returns the settings are saved.
+func DNSSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, dns models.ApplicationDNS) error {
+	data, err := json.Marshal(dns)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := networkLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[dnsKey] = data
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// ValidateDNS checks the DNS settings against the rules of kubernetes. The policy has to be
+// known, nameservers have to be IP addresses, and their number, as well as the number of
+// search domains, is limited. Policy "None" requires at least one nameserver.
+func ValidateDNS(dns models.ApplicationDNS) error {
+	if dns.DNSPolicy != "" && !slices.Contains(dnsPolicies, dns.DNSPolicy) {
+		return fmt.Errorf("bad dns policy '%s', expected one of '%s'",
+			dns.DNSPolicy, strings.Join(dnsPolicies, "', '"))
+	}
+
+	config := dns.DNSConfig
+	if config == nil {
+		config = &models.ApplicationDNSConfig{}
+	}
+
+	if dns.DNSPolicy == string(v1.DNSNone) && len(config.Nameservers) == 0 {
+		return fmt.Errorf("bad dns config, dns policy '%s' requires at least one nameserver", v1.DNSNone)
+	}
+
+	if len(config.Nameservers) > maxNameservers {
+		return fmt.Errorf("bad dns config, more than %d nameservers", maxNameservers)
+	}
+	for _, nameserver := range config.Nameservers {
+		if net.ParseIP(nameserver) == nil {
+			return fmt.Errorf("bad nameserver '%s', expected an IP address", nameserver)
+		}
+	}
+
+	if len(config.Searches) > maxSearches {
+		return fmt.Errorf("bad dns config, more than %d search domains", maxSearches)
+	}
+	for index, search := range config.Searches {
+		if strings.TrimSpace(search) == "" {
+			return fmt.Errorf("bad dns config, search domain %d is empty", index)
+		}
+	}
+
+	for index, option := range config.Options {
+		if strings.TrimSpace(option.Name) == "" {
+			return fmt.Errorf("bad dns config, option %d has no name", index)
+		}
+	}
+
+	return nil
+}
+
+// DNSPodConfig computes the DNS policy and configuration of the application's pods from its
+// DNS settings, the configuration in the generic form expected by the chart values. Both are
+// empty when nothing is set.
+func DNSPodConfig(dns *models.ApplicationDNS) (string, map[string]interface{}, error) {
+	if dns == nil {
+		return "", nil, nil
+	}
+	if dns.DNSConfig == nil {
+		return dns.DNSPolicy, nil, nil
+	}
+
+	config := v1.PodDNSConfig{
+		Nameservers: dns.DNSConfig.Nameservers,
+		Searches:    dns.DNSConfig.Searches,
+	}
+	for _, option := range dns.DNSConfig.Options {
+		config.Options = append(config.Options, v1.PodDNSConfigOption{
+			Name:  option.Name,
+			Value: option.Value,
+		})
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", nil, err
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", nil, err
+	}
+
+	return dns.DNSPolicy, result, nil
+}
+
+// networkLoad locates and returns the kube secret storing the referenced application's
+// network settings. If necessary it creates that secret.
+func networkLoad(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*v1.Secret, error) {
+	secretName := appRef.MakeNetworkSecretName()
+	return loadOrCreateSecret(ctx, cluster, appRef, secretName, "network")
+}
@@ -0,0 +1,133 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IngressClassFromSecret", func() {
+	It("returns the stored ingress class", func() {
+		secret := &v1.Secret{Data: map[string][]byte{"ingressclass": []byte("internal")}}
+		Expect(application.IngressClassFromSecret(secret)).To(Equal("internal"))
+	})
+
+	It("returns the empty string when none is stored", func() {
+		Expect(application.IngressClassFromSecret(&v1.Secret{})).To(Equal(""))
+	})
+})
+
+var _ = Describe("ValidateIngressClass", func() {
+	AfterEach(func() {
+		viper.Set("ingress-classes", []string{})
+	})
+
+	It("accepts the server default", func() {
+		Expect(application.ValidateIngressClass(context.Background(), nil, "")).To(Succeed())
+	})
+
+	It("accepts an allowed ingress class", func() {
+		viper.Set("ingress-classes", []string{"internal", "external"})
+		Expect(application.ValidateIngressClass(context.Background(), nil, "external")).To(Succeed())
+	})
+
+	It("rejects an ingress class which is not allowed", func() {
+		viper.Set("ingress-classes", []string{"internal", "external"})
+
+		err := application.ValidateIngressClass(context.Background(), nil, "public")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad ingress class 'public', expected one of 'internal', 'external'"))
+	})
+})
+
+var _ = Describe("ValidateDNS", func() {
+	It("accepts proper settings", func() {
+		Expect(application.ValidateDNS(models.ApplicationDNS{})).To(Succeed())
+		Expect(application.ValidateDNS(models.ApplicationDNS{DNSPolicy: "ClusterFirst"})).To(Succeed())
+		Expect(application.ValidateDNS(models.ApplicationDNS{
+			DNSPolicy: "None",
+			DNSConfig: &models.ApplicationDNSConfig{
+				Nameservers: []string{"10.0.0.10", "fd00::10"},
+				Searches:    []string{"corp.example.com"},
+				Options:     []models.ApplicationDNSOption{{Name: "ndots"}},
+			},
+		})).To(Succeed())
+	})
+
+	It("rejects an unknown policy", func() {
+		err := application.ValidateDNS(models.ApplicationDNS{DNSPolicy: "Custom"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad dns policy 'Custom'"))
+	})
+
+	It("rejects nameservers which are not IP addresses", func() {
+		err := application.ValidateDNS(models.ApplicationDNS{
+			DNSConfig: &models.ApplicationDNSConfig{Nameservers: []string{"dns.example.com"}},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad nameserver 'dns.example.com'"))
+	})
+
+	It("rejects too many nameservers", func() {
+		err := application.ValidateDNS(models.ApplicationDNS{
+			DNSConfig: &models.ApplicationDNSConfig{
+				Nameservers: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("more than 3 nameservers"))
+	})
+
+	It("requires a nameserver for policy None", func() {
+		err := application.ValidateDNS(models.ApplicationDNS{DNSPolicy: "None"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("requires at least one nameserver"))
+	})
+})
+
+var _ = Describe("DNSPodConfig", func() {
+	It("returns nothing without settings", func() {
+		policy, config, err := application.DNSPodConfig(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(BeEmpty())
+		Expect(config).To(BeNil())
+	})
+
+	It("renders the configuration in kubernetes form", func() {
+		two := "2"
+		policy, config, err := application.DNSPodConfig(&models.ApplicationDNS{
+			DNSPolicy: "None",
+			DNSConfig: &models.ApplicationDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Searches:    []string{"corp.example.com"},
+				Options:     []models.ApplicationDNSOption{{Name: "ndots", Value: &two}},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(Equal("None"))
+		Expect(config).To(Equal(map[string]interface{}{
+			"nameservers": []interface{}{"10.0.0.10"},
+			"searches":    []interface{}{"corp.example.com"},
+			"options": []interface{}{
+				map[string]interface{}{"name": "ndots", "value": "2"},
+			},
+		}))
+	})
+})
@@ -0,0 +1,251 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	placementKey = "placement"
+
+	// SpreadNode asks for the application's replicas to be spread across nodes
+	SpreadNode = "node"
+	// SpreadZone asks for the application's replicas to be spread across zones
+	SpreadZone = "zone"
+)
+
+// spreadTopologyKeys maps the spread shorthands to the node labels they spread across
+var spreadTopologyKeys = map[string]string{
+	SpreadNode: "kubernetes.io/hostname",
+	SpreadZone: "topology.kubernetes.io/zone",
+}
+
+// Placement returns the scheduling placement set by a user for the application, or nil, if
+// there is none.
+func Placement(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.ApplicationPlacement, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakePlacementSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return PlacementFromSecret(secret)
+}
+
+// PlacementFromSecret is the core of Placement, extracting the placement from the secret
+// containing it.
+func PlacementFromSecret(secret *v1.Secret) (*models.ApplicationPlacement, error) {
+	data, ok := secret.Data[placementKey]
+	if !ok || len(data) == 0 {
+		return nil, nil
+	}
+
+	placement := models.ApplicationPlacement{}
+	if err := json.Unmarshal(data, &placement); err != nil {
+		return nil, err
+	}
+	if placement.Spread == "" && placement.NodePool == "" && len(placement.Affinity) == 0 {
+		return nil, nil
+	}
+
+	return &placement, nil
+}
+
+// PlacementSet sets the scheduling placement for the named application. An empty placement
+// removes any placement set before. When the function returns the placement is saved.
+func PlacementSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, placement models.ApplicationPlacement) error {
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := placementLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[placementKey] = data
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// ValidatePlacement checks that the spread shorthand is known, that the node pool can be
+// used, and that the raw affinity is a proper kubernetes affinity. A node pool requires a node
+// pool label configured for the server, and has to be in the list of allowed pools, if the
+// operator restricted them.
+func ValidatePlacement(placement models.ApplicationPlacement) error {
+	if placement.Spread != "" {
+		if _, ok := spreadTopologyKeys[placement.Spread]; !ok {
+			return fmt.Errorf("bad spread '%s', expected one of '%s' or '%s'",
+				placement.Spread, SpreadNode, SpreadZone)
+		}
+	}
+
+	if placement.NodePool != "" {
+		if viper.GetString("node-pool-label") == "" {
+			return fmt.Errorf("bad node pool '%s', node pools are not configured for this server",
+				placement.NodePool)
+		}
+
+		pools := viper.GetStringSlice("node-pools")
+		if len(pools) > 0 && !slices.Contains(pools, placement.NodePool) {
+			return fmt.Errorf("bad node pool '%s', expected one of '%s'",
+				placement.NodePool, strings.Join(pools, "', '"))
+		}
+	}
+
+	_, err := rawAffinity(placement.Affinity)
+	return err
+}
+
+// PlacementAffinity computes the kubernetes affinity for the named application from its
+// placement, in the generic form expected by the chart values. The spread shorthand is
+// rendered as a preferred pod anti-affinity, and the node pool as a required node affinity on
+// the configured node pool label. Both are merged with the raw affinity, if any. The result
+// is nil when nothing is placed.
+func PlacementAffinity(appName string, placement *models.ApplicationPlacement) (map[string]interface{}, error) {
+	if placement == nil {
+		return nil, nil
+	}
+
+	affinity, err := rawAffinity(placement.Affinity)
+	if err != nil {
+		return nil, err
+	}
+
+	if topologyKey, ok := spreadTopologyKeys[placement.Spread]; ok {
+		if affinity == nil {
+			affinity = &v1.Affinity{}
+		}
+		if affinity.PodAntiAffinity == nil {
+			affinity.PodAntiAffinity = &v1.PodAntiAffinity{}
+		}
+		affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			v1.WeightedPodAffinityTerm{
+				Weight: 100,
+				PodAffinityTerm: v1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app.kubernetes.io/name": appName,
+						},
+					},
+					TopologyKey: topologyKey,
+				},
+			})
+	}
+
+	if label := viper.GetString("node-pool-label"); placement.NodePool != "" && label != "" {
+		if affinity == nil {
+			affinity = &v1.Affinity{}
+		}
+		requireNodeLabel(affinity, label, placement.NodePool)
+	}
+
+	if affinity == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(affinity)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// requireNodeLabel extends the affinity to require nodes carrying the label with the given
+// value. As node selector terms are ORed the requirement is added to each of the existing
+// terms.
+func requireNodeLabel(affinity *v1.Affinity, label, value string) {
+	requirement := v1.NodeSelectorRequirement{
+		Key:      label,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   []string{value},
+	}
+
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{}
+	}
+
+	selector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []v1.NodeSelectorTerm{{}}
+	}
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(
+			selector.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+}
+
+// rawAffinity converts the generic raw affinity of a placement into a kubernetes affinity,
+// rejecting unknown fields.
+func rawAffinity(raw map[string]interface{}) (*v1.Affinity, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad affinity")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	affinity := v1.Affinity{}
+	if err := decoder.Decode(&affinity); err != nil {
+		return nil, errors.Wrap(err, "bad affinity")
+	}
+
+	return &affinity, nil
+}
+
+// placementLoad locates and returns the kube secret storing the referenced application's
+// placement. If necessary it creates that secret.
+func placementLoad(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*v1.Secret, error) {
+	secretName := appRef.MakePlacementSecretName()
+	return loadOrCreateSecret(ctx, cluster, appRef, secretName, "placement")
+}
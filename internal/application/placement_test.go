@@ -0,0 +1,187 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Placement", func() {
+
+	Describe("ValidatePlacement", func() {
+		It("accepts the known spreads", func() {
+			Expect(application.ValidatePlacement(models.ApplicationPlacement{Spread: "node"})).To(Succeed())
+			Expect(application.ValidatePlacement(models.ApplicationPlacement{Spread: "zone"})).To(Succeed())
+		})
+
+		It("rejects an unknown spread", func() {
+			err := application.ValidatePlacement(models.ApplicationPlacement{Spread: "rack"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bad spread 'rack'"))
+		})
+
+		It("rejects a bad raw affinity", func() {
+			err := application.ValidatePlacement(models.ApplicationPlacement{
+				Affinity: map[string]interface{}{"nodeAffinityX": "bogus"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bad affinity"))
+		})
+	})
+
+	Describe("ValidatePlacement with node pools", func() {
+		AfterEach(func() {
+			viper.Set("node-pool-label", "")
+			viper.Set("node-pools", []string{})
+		})
+
+		It("rejects a node pool when node pools are not configured", func() {
+			err := application.ValidatePlacement(models.ApplicationPlacement{NodePool: "gpu"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("node pools are not configured"))
+		})
+
+		It("accepts any node pool without allowlist", func() {
+			viper.Set("node-pool-label", "cloud.google.com/gke-nodepool")
+			Expect(application.ValidatePlacement(models.ApplicationPlacement{NodePool: "gpu"})).To(Succeed())
+		})
+
+		It("checks the node pool against the allowlist", func() {
+			viper.Set("node-pool-label", "cloud.google.com/gke-nodepool")
+			viper.Set("node-pools", []string{"default", "gpu"})
+
+			Expect(application.ValidatePlacement(models.ApplicationPlacement{NodePool: "gpu"})).To(Succeed())
+
+			err := application.ValidatePlacement(models.ApplicationPlacement{NodePool: "arm"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bad node pool 'arm', expected one of 'default', 'gpu'"))
+		})
+	})
+
+	Describe("PlacementAffinity", func() {
+		It("returns nothing without placement", func() {
+			affinity, err := application.PlacementAffinity("app", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(affinity).To(BeNil())
+		})
+
+		It("renders the spread as preferred pod anti-affinity", func() {
+			affinity, err := application.PlacementAffinity("app", &models.ApplicationPlacement{Spread: "zone"})
+			Expect(err).ToNot(HaveOccurred())
+
+			terms := affinity["podAntiAffinity"].(map[string]interface{})["preferredDuringSchedulingIgnoredDuringExecution"].([]interface{})
+			Expect(terms).To(HaveLen(1))
+
+			term := terms[0].(map[string]interface{})["podAffinityTerm"].(map[string]interface{})
+			Expect(term["topologyKey"]).To(Equal("topology.kubernetes.io/zone"))
+			Expect(term["labelSelector"]).To(Equal(map[string]interface{}{
+				"matchLabels": map[string]interface{}{"app.kubernetes.io/name": "app"},
+			}))
+		})
+
+		It("renders the node pool as required node affinity", func() {
+			viper.Set("node-pool-label", "cloud.google.com/gke-nodepool")
+			defer viper.Set("node-pool-label", "")
+
+			affinity, err := application.PlacementAffinity("app", &models.ApplicationPlacement{NodePool: "gpu"})
+			Expect(err).ToNot(HaveOccurred())
+
+			required := affinity["nodeAffinity"].(map[string]interface{})["requiredDuringSchedulingIgnoredDuringExecution"].(map[string]interface{})
+			Expect(required["nodeSelectorTerms"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"matchExpressions": []interface{}{
+						map[string]interface{}{
+							"key":      "cloud.google.com/gke-nodepool",
+							"operator": string(v1.NodeSelectorOpIn),
+							"values":   []interface{}{"gpu"},
+						},
+					},
+				},
+			}))
+		})
+
+		It("adds the node pool to each raw node selector term", func() {
+			viper.Set("node-pool-label", "pool")
+			defer viper.Set("node-pool-label", "")
+
+			term := func(key string) map[string]interface{} {
+				return map[string]interface{}{
+					"matchExpressions": []interface{}{
+						map[string]interface{}{
+							"key":      key,
+							"operator": string(v1.NodeSelectorOpExists),
+						},
+					},
+				}
+			}
+
+			affinity, err := application.PlacementAffinity("app", &models.ApplicationPlacement{
+				NodePool: "gpu",
+				Affinity: map[string]interface{}{
+					"nodeAffinity": map[string]interface{}{
+						"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+							"nodeSelectorTerms": []interface{}{term("a"), term("b")},
+						},
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			required := affinity["nodeAffinity"].(map[string]interface{})["requiredDuringSchedulingIgnoredDuringExecution"].(map[string]interface{})
+			terms := required["nodeSelectorTerms"].([]interface{})
+			Expect(terms).To(HaveLen(2))
+			for _, term := range terms {
+				expressions := term.(map[string]interface{})["matchExpressions"].([]interface{})
+				Expect(expressions).To(HaveLen(2))
+				Expect(expressions[1]).To(HaveKeyWithValue("key", "pool"))
+			}
+		})
+
+		It("ignores the node pool when node pools are not configured", func() {
+			affinity, err := application.PlacementAffinity("app", &models.ApplicationPlacement{NodePool: "gpu"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(affinity).To(BeNil())
+		})
+
+		It("merges the spread with the raw affinity", func() {
+			affinity, err := application.PlacementAffinity("app", &models.ApplicationPlacement{
+				Spread: "node",
+				Affinity: map[string]interface{}{
+					"nodeAffinity": map[string]interface{}{
+						"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+							"nodeSelectorTerms": []interface{}{
+								map[string]interface{}{
+									"matchExpressions": []interface{}{
+										map[string]interface{}{
+											"key":      "disktype",
+											"operator": string(v1.NodeSelectorOpIn),
+											"values":   []interface{}{"ssd"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(affinity).To(HaveKey("nodeAffinity"))
+			Expect(affinity).To(HaveKey("podAntiAffinity"))
+		})
+	})
+})
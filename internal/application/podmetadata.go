@@ -0,0 +1,129 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// PodMetadataAnnotation is the annotation of the application resource holding the custom
+// annotations and labels of the application's pods, in JSON format.
+const PodMetadataAnnotation = "epinio.io/pod-metadata"
+
+// reservedPodMetadataPrefixes are the prefixes of the annotation and label keys used by Epinio
+// itself. Custom annotations and labels cannot use them.
+var reservedPodMetadataPrefixes = []string{"app.kubernetes.io/", "epinio.io/"}
+
+// PodMetadataFromAnnotations returns the custom pod metadata recorded in the annotations of the
+// application resource, or nil, if there is none, or it is not properly formatted.
+func PodMetadataFromAnnotations(annotations map[string]string) *models.ApplicationPodMetadata {
+	value, ok := annotations[PodMetadataAnnotation]
+	if !ok || value == "" {
+		return nil
+	}
+
+	podMetadata := models.ApplicationPodMetadata{}
+	if err := json.Unmarshal([]byte(value), &podMetadata); err != nil {
+		return nil
+	}
+	if len(podMetadata.Annotations) == 0 && len(podMetadata.Labels) == 0 {
+		return nil
+	}
+
+	return &podMetadata
+}
+
+// PodMetadataSet sets the custom annotations and labels of the referenced application's pods.
+// Empty metadata removes them. They are applied by the next deployment of the application.
+func PodMetadataSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, podMetadata models.ApplicationPodMetadata) error {
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+
+	var value interface{} // nil, removes the annotation
+	if len(podMetadata.Annotations) > 0 || len(podMetadata.Labels) > 0 {
+		data, err := json.Marshal(podMetadata)
+		if err != nil {
+			return err
+		}
+		value = string(data)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				PodMetadataAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Namespace(appRef.Namespace).Patch(ctx, appRef.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// ValidatePodMetadata checks the custom annotations and labels against the rules of kubernetes.
+// Keys reserved for Epinio, i.e. `app.kubernetes.io/*` and `epinio.io/*`, are rejected.
+func ValidatePodMetadata(podMetadata models.ApplicationPodMetadata) error {
+	for _, key := range sortedKeys(podMetadata.Annotations) {
+		if err := validatePodMetadataKey("annotation", key); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range sortedKeys(podMetadata.Labels) {
+		if err := validatePodMetadataKey("label", key); err != nil {
+			return err
+		}
+		if issues := validation.IsValidLabelValue(podMetadata.Labels[key]); len(issues) > 0 {
+			return fmt.Errorf("bad value of label '%s': %s", key, strings.Join(issues, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validatePodMetadataKey checks the key of a custom annotation or label.
+func validatePodMetadataKey(kind, key string) error {
+	for _, prefix := range reservedPodMetadataPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return fmt.Errorf("bad %s '%s', keys '%s*' are reserved", kind, key, prefix)
+		}
+	}
+	if issues := validation.IsQualifiedName(key); len(issues) > 0 {
+		return fmt.Errorf("bad %s '%s': %s", kind, key, strings.Join(issues, ", "))
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of the map, sorted, for deterministic error reporting.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
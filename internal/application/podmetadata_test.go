@@ -0,0 +1,74 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PodMetadataFromAnnotations", func() {
+	It("returns the recorded annotations and labels", func() {
+		podMetadata := application.PodMetadataFromAnnotations(map[string]string{
+			application.PodMetadataAnnotation: `{"annotations":{"cost-center":"42"},"labels":{"team":"web"}}`,
+		})
+		Expect(podMetadata).ToNot(BeNil())
+		Expect(podMetadata.Annotations).To(Equal(map[string]string{"cost-center": "42"}))
+		Expect(podMetadata.Labels).To(Equal(map[string]string{"team": "web"}))
+	})
+
+	It("returns nil for missing, empty, or broken metadata", func() {
+		Expect(application.PodMetadataFromAnnotations(nil)).To(BeNil())
+		Expect(application.PodMetadataFromAnnotations(map[string]string{
+			application.PodMetadataAnnotation: `{}`,
+		})).To(BeNil())
+		Expect(application.PodMetadataFromAnnotations(map[string]string{
+			application.PodMetadataAnnotation: `{`,
+		})).To(BeNil())
+	})
+})
+
+var _ = Describe("ValidatePodMetadata", func() {
+	It("accepts custom annotations and labels", func() {
+		Expect(application.ValidatePodMetadata(models.ApplicationPodMetadata{
+			Annotations: map[string]string{"example.com/cost-center": "team a, project b"},
+			Labels:      map[string]string{"team": "web"},
+		})).To(Succeed())
+	})
+
+	It("rejects the keys reserved for epinio", func() {
+		err := application.ValidatePodMetadata(models.ApplicationPodMetadata{
+			Labels: map[string]string{"app.kubernetes.io/name": "other"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("reserved")))
+
+		err = application.ValidatePodMetadata(models.ApplicationPodMetadata{
+			Annotations: map[string]string{"epinio.io/created-by": "someone"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("reserved")))
+	})
+
+	It("rejects bad keys and label values", func() {
+		err := application.ValidatePodMetadata(models.ApplicationPodMetadata{
+			Annotations: map[string]string{"bad key": "x"},
+		})
+		Expect(err).To(HaveOccurred())
+
+		err = application.ValidatePodMetadata(models.ApplicationPodMetadata{
+			Labels: map[string]string{"team": "web team"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("bad value of label 'team'")))
+	})
+})
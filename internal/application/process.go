@@ -0,0 +1,109 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	processKey = "process"
+)
+
+// Process returns the container command and args overrides set by a user for the application,
+// or nil, if there are none.
+func Process(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.ApplicationProcess, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeProcessSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ProcessFromSecret(secret)
+}
+
+// ProcessFromSecret is the core of Process, extracting the command and args overrides from the
+// secret containing them.
+func ProcessFromSecret(secret *v1.Secret) (*models.ApplicationProcess, error) {
+	data, ok := secret.Data[processKey]
+	if !ok || len(data) == 0 {
+		return nil, nil
+	}
+
+	process := models.ApplicationProcess{}
+	if err := json.Unmarshal(data, &process); err != nil {
+		return nil, err
+	}
+	if len(process.Command) == 0 && len(process.Args) == 0 {
+		return nil, nil
+	}
+
+	return &process, nil
+}
+
+// ProcessSet sets the command and args overrides for the named application. Empty overrides
+// return the application to the entrypoint of its image. When the function returns the
+// overrides are saved.
+func ProcessSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, process models.ApplicationProcess) error {
+	data, err := json.Marshal(process)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := processLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[processKey] = data
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// ValidateProcess checks the command and args overrides. The elements of the command must not
+// be empty, as the first of them names the executable to run. Args are passed as is.
+func ValidateProcess(process models.ApplicationProcess) error {
+	for index, element := range process.Command {
+		if strings.TrimSpace(element) == "" {
+			return fmt.Errorf("bad command, element %d is empty", index)
+		}
+	}
+
+	return nil
+}
+
+// processLoad locates and returns the kube secret storing the referenced application's
+// command and args overrides. If necessary it creates that secret.
+func processLoad(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*v1.Secret, error) {
+	secretName := appRef.MakeProcessSecretName()
+	return loadOrCreateSecret(ctx, cluster, appRef, secretName, "process")
+}
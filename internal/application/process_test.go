@@ -0,0 +1,105 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateProcess", func() {
+	It("accepts commands and args", func() {
+		Expect(application.ValidateProcess(models.ApplicationProcess{
+			Command: []string{"bundle", "exec", "sidekiq"},
+		})).To(Succeed())
+		Expect(application.ValidateProcess(models.ApplicationProcess{
+			Command: []string{"/cnb/process/worker"},
+			Args:    []string{"--queue", ""},
+		})).To(Succeed())
+		Expect(application.ValidateProcess(models.ApplicationProcess{Args: []string{"--verbose"}})).To(Succeed())
+		Expect(application.ValidateProcess(models.ApplicationProcess{})).To(Succeed())
+	})
+
+	It("rejects empty command elements", func() {
+		err := application.ValidateProcess(models.ApplicationProcess{Command: []string{"worker", " "}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad command, element 1 is empty"))
+	})
+})
+
+var _ = Describe("ValidateProcessTypes", func() {
+	It("accepts named process types", func() {
+		Expect(application.ValidateProcessTypes([]models.ApplicationProcessType{
+			{Name: "worker", Instances: ptr.To[int32](2)},
+			{Name: "clock", Command: []string{"bin/clock"}},
+		})).To(Succeed())
+		Expect(application.ValidateProcessTypes(nil)).To(Succeed())
+	})
+
+	It("rejects bad, reserved and duplicate names", func() {
+		err := application.ValidateProcessTypes([]models.ApplicationProcessType{{Name: "Worker_1"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad process type name 'Worker_1'"))
+
+		err = application.ValidateProcessTypes([]models.ApplicationProcessType{{Name: "web"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("this is the application itself"))
+
+		err = application.ValidateProcessTypes([]models.ApplicationProcessType{{Name: "worker"}, {Name: "worker"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("'worker' specified more than once"))
+	})
+
+	It("rejects negative instances and bad commands", func() {
+		err := application.ValidateProcessTypes([]models.ApplicationProcessType{
+			{Name: "worker", Instances: ptr.To[int32](-1)},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad instances for process type 'worker'"))
+
+		err = application.ValidateProcessTypes([]models.ApplicationProcessType{
+			{Name: "worker", Command: []string{""}},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("process type 'worker': bad command"))
+	})
+})
+
+var _ = Describe("ProcessTypeProcess", func() {
+	It("defaults to the buildpacks launcher of the type", func() {
+		process := application.ProcessTypeProcess(models.ApplicationProcessType{Name: "worker"})
+		Expect(process.Command).To(Equal([]string{"/cnb/process/worker"}))
+	})
+
+	It("keeps an explicit command", func() {
+		process := application.ProcessTypeProcess(models.ApplicationProcessType{
+			Name:    "worker",
+			Command: []string{"bundle", "exec", "sidekiq"},
+			Args:    []string{"-q", "default"},
+		})
+		Expect(process.Command).To(Equal([]string{"bundle", "exec", "sidekiq"}))
+		Expect(process.Args).To(Equal([]string{"-q", "default"}))
+	})
+})
+
+var _ = Describe("DroppedProcessTypes", func() {
+	It("returns the current types missing from the desired ones", func() {
+		current := []models.ApplicationProcessType{{Name: "worker"}, {Name: "clock"}}
+		desired := []models.ApplicationProcessType{{Name: "worker"}, {Name: "mailer"}}
+		Expect(application.DroppedProcessTypes(current, desired)).To(Equal([]string{"clock"}))
+		Expect(application.DroppedProcessTypes(current, nil)).To(Equal([]string{"worker", "clock"}))
+	})
+})
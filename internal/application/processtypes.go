@@ -0,0 +1,250 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	processTypesKey = "processtypes"
+
+	// WebProcessType is the process type of the application itself
+	WebProcessType = "web"
+)
+
+// processTypeNamePattern matches the allowed process type names, dns labels, as they become part
+// of the name of the process type's workload.
+var processTypeNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ProcessTypes returns the additional process types of the application, or nil, if there are
+// none.
+func ProcessTypes(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]models.ApplicationProcessType, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeProcessSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ProcessTypesFromSecret(secret)
+}
+
+// ProcessTypesFromSecret is the core of ProcessTypes, extracting the process types from the
+// secret containing them.
+func ProcessTypesFromSecret(secret *v1.Secret) ([]models.ApplicationProcessType, error) {
+	data, ok := secret.Data[processTypesKey]
+	if !ok || len(data) == 0 {
+		return nil, nil
+	}
+
+	processTypes := []models.ApplicationProcessType{}
+	if err := json.Unmarshal(data, &processTypes); err != nil {
+		return nil, err
+	}
+	if len(processTypes) == 0 {
+		return nil, nil
+	}
+
+	return processTypes, nil
+}
+
+// ProcessTypesSet sets the additional process types of the named application. An empty list
+// removes all of them. When the function returns the process types are saved. Removing the
+// workloads of dropped process types is the responsibility of the caller.
+func ProcessTypesSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, processTypes []models.ApplicationProcessType) error {
+	data, err := json.Marshal(processTypes)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := processLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[processTypesKey] = data
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// ValidateProcessTypes checks the process types. Names have to be dns labels, unique, and
+// cannot be the web process type, which is the application itself. Instances cannot be
+// negative, and the command has to be valid, as for the application itself.
+func ValidateProcessTypes(processTypes []models.ApplicationProcessType) error {
+	seen := map[string]struct{}{}
+	for _, processType := range processTypes {
+		if !processTypeNamePattern.MatchString(processType.Name) {
+			return fmt.Errorf("bad process type name '%s', expected a dns label", processType.Name)
+		}
+		if processType.Name == WebProcessType {
+			return fmt.Errorf("bad process type name '%s', this is the application itself", processType.Name)
+		}
+		if _, found := seen[processType.Name]; found {
+			return fmt.Errorf("process type '%s' specified more than once", processType.Name)
+		}
+		seen[processType.Name] = struct{}{}
+
+		if processType.Instances != nil && *processType.Instances < 0 {
+			return fmt.Errorf("bad instances for process type '%s', expected zero or more", processType.Name)
+		}
+
+		err := ValidateProcess(models.ApplicationProcess{
+			Command: processType.Command,
+			Args:    processType.Args,
+		})
+		if err != nil {
+			return fmt.Errorf("process type '%s': %w", processType.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ProcessTypeRef returns the reference of the workload of the named process type of the
+// application. The workload is named after the application and the process type.
+func ProcessTypeRef(appRef models.AppRef, processType string) models.AppRef {
+	return models.NewAppRef(appRef.Name+"-"+processType, appRef.Namespace)
+}
+
+// ProcessTypeConflict returns an error if a workload name of the application's process types
+// collides with another application of the namespace, or with a process type workload of another
+// application, or if the name of the application itself collides with such a workload. Without
+// this check the deployment of one application would overwrite the release of the other, and its
+// removal would uninstall it.
+func ProcessTypeConflict(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, processTypes []models.ApplicationProcessType) error {
+	appRefs, err := ListAppRefs(ctx, cluster, appRef.Namespace)
+	if err != nil {
+		return err
+	}
+
+	others := map[string][]models.ApplicationProcessType{}
+	for _, other := range appRefs {
+		if other.Name == appRef.Name {
+			continue
+		}
+		otherTypes, err := ProcessTypes(ctx, cluster, other)
+		if err != nil {
+			return err
+		}
+		others[other.Name] = otherTypes
+	}
+
+	return processTypeConflict(appRef, processTypes, others)
+}
+
+// processTypeConflict is the core of ProcessTypeConflict, checking against the other
+// applications of the namespace, given by name, with their process types.
+func processTypeConflict(appRef models.AppRef, processTypes []models.ApplicationProcessType, others map[string][]models.ApplicationProcessType) error {
+	names := make([]string, 0, len(others))
+	for name := range others {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// workload name -> description of the owning process type of another application
+	taken := map[string]string{}
+	for _, name := range names {
+		for _, processType := range others[name] {
+			workload := ProcessTypeRef(models.NewAppRef(name, appRef.Namespace), processType.Name).Name
+			taken[workload] = fmt.Sprintf("process type '%s' of application '%s'", processType.Name, name)
+		}
+	}
+
+	if owner, found := taken[appRef.Name]; found {
+		return fmt.Errorf("application name '%s' conflicts with %s", appRef.Name, owner)
+	}
+
+	for _, processType := range processTypes {
+		workload := ProcessTypeRef(appRef, processType.Name).Name
+		if _, found := others[workload]; found {
+			return fmt.Errorf("process type '%s' conflicts with application '%s'", processType.Name, workload)
+		}
+		if owner, found := taken[workload]; found {
+			return fmt.Errorf("process type '%s' conflicts with %s", processType.Name, owner)
+		}
+	}
+
+	return nil
+}
+
+// ProcessTypeInstances returns the number of desired instances of the process type.
+func ProcessTypeInstances(processType models.ApplicationProcessType) int32 {
+	if processType.Instances == nil {
+		return 1
+	}
+	return *processType.Instances
+}
+
+// ProcessTypeProcess returns the command and args of the process type. Without a command the
+// process type is started through the buildpacks launcher for the type.
+func ProcessTypeProcess(processType models.ApplicationProcessType) *models.ApplicationProcess {
+	command := processType.Command
+	if len(command) == 0 {
+		command = []string{"/cnb/process/" + processType.Name}
+	}
+	return &models.ApplicationProcess{
+		Command: command,
+		Args:    processType.Args,
+	}
+}
+
+// DroppedProcessTypes returns the names of the current process types missing from the desired
+// ones.
+func DroppedProcessTypes(current, desired []models.ApplicationProcessType) []string {
+	keep := map[string]struct{}{}
+	for _, processType := range desired {
+		keep[processType.Name] = struct{}{}
+	}
+
+	dropped := []string{}
+	for _, processType := range current {
+		if _, found := keep[processType.Name]; !found {
+			dropped = append(dropped, processType.Name)
+		}
+	}
+	return dropped
+}
+
+// ProcessTypesRemove removes the workloads of the named process types of the application.
+// Process types without workload are ignored.
+func ProcessTypesRemove(cluster *kubernetes.Cluster, appRef models.AppRef, processTypes []string) error {
+	for _, processType := range processTypes {
+		err := helm.Remove(cluster, ProcessTypeRef(appRef, processType))
+		if err != nil && !strings.Contains(err.Error(), "release: not found") {
+			return err
+		}
+	}
+	return nil
+}
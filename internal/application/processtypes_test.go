@@ -0,0 +1,53 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("processTypeConflict", func() {
+	appRef := models.NewAppRef("foo", "workspace")
+	worker := []models.ApplicationProcessType{{Name: "worker"}}
+
+	It("accepts names without collision", func() {
+		others := map[string][]models.ApplicationProcessType{
+			"bar": {{Name: "worker"}},
+		}
+		Expect(processTypeConflict(appRef, worker, others)).To(Succeed())
+	})
+
+	It("rejects a process type named like another application", func() {
+		others := map[string][]models.ApplicationProcessType{"foo-worker": nil}
+		err := processTypeConflict(appRef, worker, others)
+		Expect(err).To(MatchError("process type 'worker' conflicts with application 'foo-worker'"))
+	})
+
+	It("rejects an application named like a process type of another application", func() {
+		others := map[string][]models.ApplicationProcessType{
+			"foo": {{Name: "worker"}},
+		}
+		err := processTypeConflict(models.NewAppRef("foo-worker", "workspace"), nil, others)
+		Expect(err).To(MatchError("application name 'foo-worker' conflicts with process type 'worker' of application 'foo'"))
+	})
+
+	It("rejects process types of two applications sharing a workload name", func() {
+		others := map[string][]models.ApplicationProcessType{
+			"foo-big": {{Name: "worker"}},
+		}
+		err := processTypeConflict(appRef, []models.ApplicationProcessType{{Name: "big-worker"}}, others)
+		Expect(err).To(MatchError("process type 'big-worker' conflicts with process type 'worker' of application 'foo-big'"))
+	})
+})
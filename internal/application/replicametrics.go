@@ -0,0 +1,139 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/panjf2000/ants/v2"
+	"github.com/spf13/viper"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// MetricsOptions bound the gathering of replica metrics done by AddMetrics.
+type MetricsOptions struct {
+	Concurrency int           // Maximum number of applications queried at the same time.
+	Timeout     time.Duration // Maximum time to query the metrics of a single application. Zero is no limit.
+}
+
+// MetricsOptionsFromSettings returns the metrics options configured for the server.
+func MetricsOptionsFromSettings() MetricsOptions {
+	return MetricsOptions{
+		Concurrency: viper.GetInt("metrics-concurrency"),
+		Timeout:     viper.GetDuration("metrics-app-timeout"),
+	}
+}
+
+// metricsFetcher returns the pod metrics of an application.
+type metricsFetcher func(ctx context.Context, app models.App) ([]metricsv1beta1.PodMetrics, error)
+
+// AddMetrics queries the metrics of the replicas of the given applications, one query per
+// application, with bounded concurrency. This is for applications listed without metrics (See
+// ListOptions.SkipMetrics). Applications whose metrics fail, or take longer than the timeout, keep
+// their replicas without metrics. The returned errors are indexed like the applications, nil for
+// the applications whose metrics were added, or which have no replicas.
+func AddMetrics(ctx context.Context, cluster *kubernetes.Cluster, apps models.AppList, options MetricsOptions) []error {
+	return addMetrics(ctx, apps, options, func(ctx context.Context, app models.App) ([]metricsv1beta1.PodMetrics, error) {
+		return NewWorkload(cluster, app.Meta, app.Workload.DesiredReplicas).getPodMetrics(ctx)
+	})
+}
+
+// MetricsWarnings returns the warnings about the applications whose metrics could not be added,
+// given the errors returned by AddMetrics for them.
+func MetricsWarnings(apps models.AppList, failures []error) []string {
+	warnings := []string{}
+	for index, err := range failures {
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("metrics of application '%s' in namespace '%s' not available: %s",
+				apps[index].Meta.Name, apps[index].Meta.Namespace, err))
+		}
+	}
+	return warnings
+}
+
+// addMetrics is the core of AddMetrics, with the query of the metrics given to it.
+func addMetrics(ctx context.Context, apps models.AppList, options MetricsOptions, fetch metricsFetcher) []error {
+	failures := make([]error, len(apps))
+
+	var wg sync.WaitGroup
+
+	pool, err := ants.NewPoolWithFunc(max(options.Concurrency, 1), func(i interface{}) {
+		defer wg.Done()
+
+		index := i.(int)
+		failures[index] = appMetrics(ctx, &apps[index], options.Timeout, fetch)
+	})
+	if err != nil {
+		for index, app := range apps {
+			if app.Workload != nil && len(app.Workload.Replicas) > 0 {
+				failures[index] = err
+			}
+		}
+		return failures
+	}
+	defer pool.Release()
+
+	for index, app := range apps {
+		if app.Workload == nil || len(app.Workload.Replicas) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		if err := pool.Invoke(index); err != nil {
+			wg.Done()
+			failures[index] = err
+		}
+	}
+	wg.Wait()
+
+	return failures
+}
+
+// appMetrics queries the metrics of a single application and adds them to its replicas. The
+// query is abandoned when it does not complete within the timeout.
+func appMetrics(ctx context.Context, app *models.App, timeout time.Duration, fetch metricsFetcher) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		metrics []metricsv1beta1.PodMetrics
+		err     error
+	}
+
+	// Buffered, for the query to complete even after it was abandoned.
+	done := make(chan result, 1)
+	go func() {
+		metrics, err := fetch(ctx, *app)
+		done <- result{metrics: metrics, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		if timeout > 0 && ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		return ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			return r.err
+		}
+		return (&Workload{}).populatePodMetrics(app.Workload.Replicas, r.metrics)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("addMetrics", func() {
+	deployed := func(name string) models.App {
+		app := *models.NewApp(name, "workspace")
+		app.Workload = &models.AppDeployment{
+			Replicas: map[string]*models.PodInfo{name + "-1": {Name: name + "-1"}},
+		}
+		return app
+	}
+
+	podMetrics := func(pod string) []metricsv1beta1.PodMetrics {
+		return []metricsv1beta1.PodMetrics{{
+			ObjectMeta: metav1.ObjectMeta{Name: pod},
+			Containers: []metricsv1beta1.ContainerMetrics{{
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			}},
+		}}
+	}
+
+	It("adds the metrics of all deployed applications", func() {
+		apps := models.AppList{deployed("a"), *models.NewApp("created", "workspace"), deployed("b")}
+
+		var queries int32
+		warnings := MetricsWarnings(apps, addMetrics(context.Background(), apps, MetricsOptions{Concurrency: 2},
+			func(_ context.Context, app models.App) ([]metricsv1beta1.PodMetrics, error) {
+				atomic.AddInt32(&queries, 1)
+				return podMetrics(app.Meta.Name + "-1"), nil
+			}))
+
+		Expect(warnings).To(BeEmpty())
+		Expect(queries).To(Equal(int32(2)))
+		for _, index := range []int{0, 2} {
+			for _, replica := range apps[index].Workload.Replicas {
+				Expect(replica.MetricsOk).To(BeTrue())
+				Expect(replica.MilliCPUs).To(Equal(int64(250)))
+				Expect(replica.MemoryBytes).To(Equal(int64(64 * 1024 * 1024)))
+			}
+		}
+	})
+
+	It("bounds the number of concurrent queries", func() {
+		apps := models.AppList{deployed("a"), deployed("b"), deployed("c"), deployed("d"), deployed("e")}
+
+		var active, peak int32
+		warnings := MetricsWarnings(apps, addMetrics(context.Background(), apps, MetricsOptions{Concurrency: 2},
+			func(_ context.Context, app models.App) ([]metricsv1beta1.PodMetrics, error) {
+				current := atomic.AddInt32(&active, 1)
+				defer atomic.AddInt32(&active, -1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return nil, nil
+			}))
+
+		Expect(warnings).To(BeEmpty())
+		Expect(peak).To(BeNumerically("<=", 2))
+	})
+
+	It("reports slow and failing applications as warnings, keeping the others", func() {
+		apps := models.AppList{deployed("slow"), deployed("broken"), deployed("fast")}
+
+		release := make(chan struct{})
+		defer close(release)
+
+		warnings := MetricsWarnings(apps, addMetrics(context.Background(), apps, MetricsOptions{Concurrency: 3, Timeout: 50 * time.Millisecond},
+			func(_ context.Context, app models.App) ([]metricsv1beta1.PodMetrics, error) {
+				switch app.Meta.Name {
+				case "slow":
+					// Ignores the context, stalling until the test ends
+					<-release
+					return nil, nil
+				case "broken":
+					return nil, errors.New("metrics api unavailable")
+				}
+				return podMetrics("fast-1"), nil
+			}))
+
+		Expect(warnings).To(Equal([]string{
+			"metrics of application 'slow' in namespace 'workspace' not available: timed out after 50ms",
+			"metrics of application 'broken' in namespace 'workspace' not available: metrics api unavailable",
+		}))
+		Expect(apps[0].Workload.Replicas["slow-1"].MetricsOk).To(BeFalse())
+		Expect(apps[2].Workload.Replicas["fast-1"].MetricsOk).To(BeTrue())
+	})
+})
@@ -0,0 +1,131 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	rolloutKey = "rollout"
+)
+
+// rolloutValuePattern matches the allowed rollout values, a number of pods, or a percentage
+var rolloutValuePattern = regexp.MustCompile(`^[0-9]+%?$`)
+
+// Rollout returns the rolling update settings set by a user for the application, or nil, if
+// there are none.
+func Rollout(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.ApplicationRollout, error) {
+	secret, err := cluster.GetSecret(ctx, appRef.Namespace, appRef.MakeRolloutSecretName())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return RolloutFromSecret(secret)
+}
+
+// RolloutFromSecret is the core of Rollout, extracting the rolling update settings from the
+// secret containing them.
+func RolloutFromSecret(secret *v1.Secret) (*models.ApplicationRollout, error) {
+	data, ok := secret.Data[rolloutKey]
+	if !ok || len(data) == 0 {
+		return nil, nil
+	}
+
+	rollout := models.ApplicationRollout{}
+	if err := json.Unmarshal(data, &rollout); err != nil {
+		return nil, err
+	}
+	if rollout.MaxSurge == "" && rollout.MaxUnavailable == "" && rollout.RevisionHistoryLimit == nil {
+		return nil, nil
+	}
+
+	return &rollout, nil
+}
+
+// RolloutSet sets the rolling update settings for the named application. Empty settings
+// return the application to the deployment defaults. When the function returns the settings
+// are saved.
+func RolloutSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, rollout models.ApplicationRollout) error {
+	data, err := json.Marshal(rollout)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := rolloutLoad(ctx, cluster, appRef)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[rolloutKey] = data
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
+			ctx, secret, metav1.UpdateOptions{})
+
+		return err
+	})
+}
+
+// ValidateRollout checks that the rollout values are numbers of pods or percentages, and that
+// they do not prevent the rolling update from making progress, i.e. are not both zero. The
+// revision history limit cannot be negative.
+func ValidateRollout(rollout models.ApplicationRollout) error {
+	for name, value := range map[string]string{
+		"maxSurge":       rollout.MaxSurge,
+		"maxUnavailable": rollout.MaxUnavailable,
+	} {
+		if value != "" && !rolloutValuePattern.MatchString(value) {
+			return fmt.Errorf("bad %s '%s', expected a number, or a percentage", name, value)
+		}
+	}
+
+	if isZeroRolloutValue(rollout.MaxSurge) && isZeroRolloutValue(rollout.MaxUnavailable) {
+		return fmt.Errorf("maxSurge and maxUnavailable cannot both be zero")
+	}
+
+	if rollout.RevisionHistoryLimit != nil && *rollout.RevisionHistoryLimit < 0 {
+		return fmt.Errorf("bad revisionHistoryLimit '%d', expected a number not less than zero",
+			*rollout.RevisionHistoryLimit)
+	}
+
+	return nil
+}
+
+// isZeroRolloutValue returns true if the value is an explicit zero, either as number or as
+// percentage.
+func isZeroRolloutValue(value string) bool {
+	return value == "0" || value == "0%"
+}
+
+// rolloutLoad locates and returns the kube secret storing the referenced application's
+// rolling update settings. If necessary it creates that secret.
+func rolloutLoad(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*v1.Secret, error) {
+	secretName := appRef.MakeRolloutSecretName()
+	return loadOrCreateSecret(ctx, cluster, appRef, secretName, "rollout")
+}
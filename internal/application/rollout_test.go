@@ -0,0 +1,77 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateRollout", func() {
+	It("accepts numbers and percentages", func() {
+		Expect(application.ValidateRollout(models.ApplicationRollout{MaxSurge: "1", MaxUnavailable: "0"})).To(Succeed())
+		Expect(application.ValidateRollout(models.ApplicationRollout{MaxSurge: "25%"})).To(Succeed())
+		Expect(application.ValidateRollout(models.ApplicationRollout{})).To(Succeed())
+	})
+
+	It("rejects bad values", func() {
+		err := application.ValidateRollout(models.ApplicationRollout{MaxSurge: "-1"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad maxSurge '-1'"))
+
+		err = application.ValidateRollout(models.ApplicationRollout{MaxUnavailable: "half"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad maxUnavailable 'half'"))
+	})
+
+	It("rejects a rollout unable to progress", func() {
+		err := application.ValidateRollout(models.ApplicationRollout{MaxSurge: "0", MaxUnavailable: "0%"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cannot both be zero"))
+	})
+
+	It("checks the revision history limit", func() {
+		Expect(application.ValidateRollout(models.ApplicationRollout{RevisionHistoryLimit: ptr.To(int32(0))})).To(Succeed())
+
+		err := application.ValidateRollout(models.ApplicationRollout{RevisionHistoryLimit: ptr.To(int32(-1))})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad revisionHistoryLimit '-1'"))
+	})
+})
+
+var _ = Describe("RolloutFromSecret", func() {
+	It("keeps a rollout setting only the revision history limit", func() {
+		secret := &v1.Secret{Data: map[string][]byte{
+			"rollout": []byte(`{"revisionHistoryLimit":3}`),
+		}}
+
+		rollout, err := application.RolloutFromSecret(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rollout).ToNot(BeNil())
+		Expect(rollout.RevisionHistoryLimit).To(Equal(ptr.To(int32(3))))
+	})
+
+	It("ignores empty settings", func() {
+		secret := &v1.Secret{Data: map[string][]byte{
+			"rollout": []byte(`{}`),
+		}}
+
+		rollout, err := application.RolloutFromSecret(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rollout).To(BeNil())
+	})
+})
@@ -17,6 +17,7 @@ import (
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
@@ -24,6 +25,14 @@ import (
 
 const (
 	instanceKey = "desired"
+	stoppedKey  = "stopped" // desired instances before the application was stopped
+)
+
+var (
+	// ErrAppStopped is returned by Stop for an application which is already stopped.
+	ErrAppStopped = errors.New("application is already stopped")
+	// ErrAppNotStopped is returned by Start for an application which was not stopped.
+	ErrAppNotStopped = errors.New("application is not stopped")
 )
 
 // Scaling returns the number of desired instances set by a user for the application
@@ -53,19 +62,87 @@ func ScalingFromSecret(scaleSecret *v1.Secret) (int32, error) {
 	return result, nil
 }
 
+// StoppedFromSecret extracts from the secret holding the desired number of instances whether the
+// application is stopped, and if so, the number of instances it had before.
+func StoppedFromSecret(scaleSecret *v1.Secret) (int32, bool) {
+	previous, found := scaleSecret.Data[stoppedKey]
+	if !found {
+		return 0, false
+	}
+
+	i, err := strconv.ParseInt(string(previous), 10, 32)
+	if err != nil || i < 1 {
+		// Bad data. Still stopped, restart with a single instance.
+		return 1, true
+	}
+
+	return int32(i), true
+}
+
 // ScalingSet sets the desired number of instances for the named application.
-// When the function returns the number is saved.
+// When the function returns the number is saved. Setting the instances explicitly ends
+// the stopped state of the application, if any.
 func ScalingSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, instances int32) error {
-	return scaleUpdate(ctx, cluster, appRef, func(scaleSecret *v1.Secret) {
+	return scaleUpdate(ctx, cluster, appRef, func(scaleSecret *v1.Secret) error {
 		scaleSecret.Data[instanceKey] = []byte(strconv.Itoa(int(instances)))
+		delete(scaleSecret.Data, stoppedKey)
+		return nil
+	})
+}
+
+// Stop scales the named application to zero instances, remembering the desired number of
+// instances for Start. It returns that number. An application without instances is considered
+// stopped already, and ErrAppStopped is returned for it.
+func Stop(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (int32, error) {
+	var previous int32
+
+	err := scaleUpdate(ctx, cluster, appRef, func(scaleSecret *v1.Secret) error {
+		if _, stopped := StoppedFromSecret(scaleSecret); stopped {
+			return ErrAppStopped
+		}
+
+		instances, err := ScalingFromSecret(scaleSecret)
+		if err != nil {
+			return err
+		}
+		if instances == 0 {
+			return ErrAppStopped
+		}
+
+		previous = instances
+		scaleSecret.Data[stoppedKey] = []byte(strconv.Itoa(int(instances)))
+		scaleSecret.Data[instanceKey] = []byte(`0`)
+		return nil
 	})
+
+	return previous, err
+}
+
+// Start restores the desired number of instances of the named application saved by Stop, and
+// returns it. ErrAppNotStopped is returned for an application which was not stopped.
+func Start(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (int32, error) {
+	var restored int32
+
+	err := scaleUpdate(ctx, cluster, appRef, func(scaleSecret *v1.Secret) error {
+		previous, stopped := StoppedFromSecret(scaleSecret)
+		if !stopped {
+			return ErrAppNotStopped
+		}
+
+		restored = previous
+		scaleSecret.Data[instanceKey] = []byte(strconv.Itoa(int(previous)))
+		delete(scaleSecret.Data, stoppedKey)
+		return nil
+	})
+
+	return restored, err
 }
 
 // scaleUpdate is a helper for the public functions. It encapsulates the read/modify/write cycle
 // necessary to update the application's kube resource holding the application's number of desired
 // instances
 func scaleUpdate(ctx context.Context, cluster *kubernetes.Cluster,
-	appRef models.AppRef, modifyScaling func(*v1.Secret)) error {
+	appRef models.AppRef, modifyScaling func(*v1.Secret) error) error {
 
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		scaleSecret, err := scaleLoad(ctx, cluster, appRef)
@@ -79,7 +156,10 @@ func scaleUpdate(ctx context.Context, cluster *kubernetes.Cluster,
 			}
 		}
 
-		modifyScaling(scaleSecret)
+		err = modifyScaling(scaleSecret)
+		if err != nil {
+			return err
+		}
 
 		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
 			ctx, scaleSecret, metav1.UpdateOptions{})
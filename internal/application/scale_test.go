@@ -0,0 +1,52 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+	v1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoppedFromSecret", func() {
+	It("reports a running application", func() {
+		previous, stopped := application.StoppedFromSecret(&v1.Secret{
+			Data: map[string][]byte{"desired": []byte("3")},
+		})
+		Expect(stopped).To(BeFalse())
+		Expect(previous).To(BeZero())
+	})
+
+	It("reports a stopped application and its previous instances", func() {
+		secret := &v1.Secret{
+			Data: map[string][]byte{"desired": []byte("0"), "stopped": []byte("3")},
+		}
+
+		previous, stopped := application.StoppedFromSecret(secret)
+		Expect(stopped).To(BeTrue())
+		Expect(previous).To(Equal(int32(3)))
+
+		instances, err := application.ScalingFromSecret(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instances).To(BeZero())
+	})
+
+	It("restarts a stopped application with bad data with a single instance", func() {
+		previous, stopped := application.StoppedFromSecret(&v1.Secret{
+			Data: map[string][]byte{"desired": []byte("0"), "stopped": []byte("many")},
+		})
+		Expect(stopped).To(BeTrue())
+		Expect(previous).To(Equal(int32(1)))
+	})
+})
@@ -170,6 +170,19 @@ func BoundServicesUnset(ctx context.Context, cluster *kubernetes.Cluster, appRef
 	})
 }
 
+// BoundServicesChange removes and adds the specified service names for the named application,
+// in a single update. When the function returns the service set is changed.
+func BoundServicesChange(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, remove, add []string) error {
+	return svcUpdate(ctx, cluster, appRef, func(svcSecret *v1.Secret) {
+		for _, serviceName := range remove {
+			delete(svcSecret.Data, serviceName)
+		}
+		for _, serviceName := range add {
+			svcSecret.Data[serviceName] = nil
+		}
+	})
+}
+
 // BoundServiceNames returns the service names bound to the application, sorted for stability.
 func BoundServiceNames(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]string, error) {
 	svcSecret, err := svcLoad(ctx, cluster, appRef)
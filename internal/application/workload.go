@@ -15,6 +15,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/epinio/epinio/helpers"
@@ -23,15 +24,23 @@ import (
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
 	pkgerrors "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/kubectl/pkg/util/podutils"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
+// RestartedAtAnnotation is set on the pod template of the application's deployments by
+// RollingRestart, with the time of the restart. Changing it rolls the pods, like `kubectl
+// rollout restart` does.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
 type AppConfigurationBind struct {
 	configuration string // name of the configuration getting bound
 	resource      string // name of the kube secret to mount as volume to make the configuration params available in the app
@@ -145,16 +154,90 @@ func AddApplicationPods(auxiliary map[ConfigurationKey]AppData, ctx context.Cont
 	return auxiliary, nil
 }
 
+// selector returns the label selector matching the resources of the workload.
+func (a *Workload) selector() string {
+	return workloadSelector(a.app)
+}
+
+// workloadSelector returns the label selector matching the resources of the application's
+// workload, i.e. its deployments, replica sets and pods.
+func workloadSelector(app models.AppRef) string {
+	return labels.Set(map[string]string{
+		"app.kubernetes.io/component": "application",
+		"app.kubernetes.io/name":      app.Name,
+		"app.kubernetes.io/part-of":   app.Namespace,
+	}).String()
+}
+
+// RollingRestart triggers a rolling restart of the pods of the workload, without redeploying
+// the application. See RestartDeployments.
+func (a *Workload) RollingRestart(ctx context.Context) (int64, error) {
+	return RestartDeployments(ctx, a.cluster.Kubectl.AppsV1().Deployments(a.app.Namespace),
+		a.selector(), time.Now())
+}
+
+// DesiredGeneration returns the generation of the deployments of the workload, i.e. the
+// highest of them. It is zero if there are no deployments.
+func (a *Workload) DesiredGeneration(ctx context.Context) (int64, error) {
+	deploymentList, err := a.cluster.Kubectl.AppsV1().Deployments(a.app.Namespace).List(
+		ctx, metav1.ListOptions{LabelSelector: a.selector()})
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "fetching the deployments")
+	}
+
+	return desiredGeneration(deploymentList.Items), nil
+}
+
+// RestartDeployments sets the restart annotation on the pod template of the selected
+// deployments, making kubernetes roll their pods. The replicas are not touched. It returns the
+// new desired generation of the deployments, i.e. the highest of them.
+func RestartDeployments(ctx context.Context, deployments typedappsv1.DeploymentInterface,
+	selector string, restartedAt time.Time) (int64, error) {
+
+	deploymentList, err := deployments.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "fetching the deployments")
+	}
+
+	restarted := []appsv1.Deployment{}
+	for _, item := range deploymentList.Items {
+		var updated *appsv1.Deployment
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			deployment, err := deployments.Get(ctx, item.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = map[string]string{}
+			}
+			deployment.Spec.Template.Annotations[RestartedAtAnnotation] = restartedAt.Format(time.RFC3339)
+
+			updated, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return 0, pkgerrors.Wrapf(err, "restarting deployment %s", item.Name)
+		}
+		restarted = append(restarted, *updated)
+	}
+
+	return desiredGeneration(restarted), nil
+}
+
+func desiredGeneration(deployments []appsv1.Deployment) int64 {
+	generation := int64(0)
+	for _, deployment := range deployments {
+		if deployment.Generation > generation {
+			generation = deployment.Generation
+		}
+	}
+	return generation
+}
+
 // Pods is a helper, it returns the Pods belonging to the Deployment of the workload.
 func (a *Workload) Pods(ctx context.Context) ([]corev1.Pod, error) {
 	podList, err := a.cluster.Kubectl.CoreV1().Pods(a.app.Namespace).List(
-		ctx, metav1.ListOptions{
-			LabelSelector: labels.Set(map[string]string{
-				"app.kubernetes.io/component": "application",
-				"app.kubernetes.io/name":      a.app.Name,
-				"app.kubernetes.io/part-of":   a.app.Namespace,
-			}).String(),
-		})
+		ctx, metav1.ListOptions{LabelSelector: a.selector()})
 	if err != nil {
 		return []corev1.Pod{}, err
 	}
@@ -162,6 +245,50 @@ func (a *Workload) Pods(ctx context.Context) ([]corev1.Pod, error) {
 	return podList.Items, nil
 }
 
+// LastRestart returns the time of the most recent restart of the workload, as determined by
+// LastRestartTime from the workload's pods. The result is nil if there are no pods.
+func (a *Workload) LastRestart(ctx context.Context) (*time.Time, error) {
+	pods, err := a.Pods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return LastRestartTime(pods), nil
+}
+
+// LastRestartTime determines for each pod the most recent start of its containers, falling back
+// to the pod start when no container is running. It returns the earliest of these times, so that
+// the current run of every instance is covered. The result is nil if there are no pods.
+func LastRestartTime(pods []corev1.Pod) *time.Time {
+	var result *time.Time
+
+	for _, pod := range pods {
+		var podStart *time.Time
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Running == nil {
+				continue
+			}
+			started := status.State.Running.StartedAt.Time
+			if podStart == nil || started.After(*podStart) {
+				podStart = &started
+			}
+		}
+		if podStart == nil && pod.Status.StartTime != nil {
+			started := pod.Status.StartTime.Time
+			podStart = &started
+		}
+		if podStart == nil {
+			continue
+		}
+
+		if result == nil || podStart.Before(*result) {
+			result = podStart
+		}
+	}
+
+	return result
+}
+
 func (a *Workload) PodNames(ctx context.Context) ([]string, error) {
 	podList, err := a.Pods(ctx)
 	if err != nil {
@@ -221,6 +348,22 @@ func (a *Workload) Get(ctx context.Context) (*models.AppDeployment, error) {
 	return a.AssembleFromParts(ctx, podList, podMetrics, routes)
 }
 
+// GetWithoutMetrics is Get, without querying the metrics API. The replicas are reported
+// without cpu and memory usage.
+func (a *Workload) GetWithoutMetrics(ctx context.Context) (*models.AppDeployment, error) {
+	podList, err := a.Pods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := ListRoutes(ctx, a.cluster, a.app)
+	if err != nil {
+		routes = []string{err.Error()}
+	}
+
+	return a.AssembleFromParts(ctx, podList, []metricsv1beta1.PodMetrics{}, routes)
+}
+
 // AssembleFromParts is the core of Get constructing the deployment structure from the pods and
 // auxiliary information explicitly given to it.
 func (a *Workload) AssembleFromParts(
@@ -293,9 +436,26 @@ func (a *Workload) AssembleFromParts(
 		Routes:          routes,
 		DesiredReplicas: a.desiredReplicas,
 		ReadyReplicas:   readyReplicas,
+		ImageDigest:     ImageDigest(podList, controllerName),
 	}, nil
 }
 
+// ImageDigest returns the digest of the image run by the named container of the pods, as
+// reported by the container statuses. The result is empty if no pod reports it.
+func ImageDigest(pods []corev1.Pod, containerName string) string {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != containerName {
+				continue
+			}
+			if _, digest, found := strings.Cut(status.ImageID, "@"); found {
+				return digest
+			}
+		}
+	}
+	return ""
+}
+
 // GetPodMetrics is a helper for List. It loads all the pot metrics for epinio controlled pods in
 // the namespace into memory, indexes them by pod name, and returns the resulting map of metrics
 // lists. The user, List, selects the metrics it needs for an application based on the application's
@@ -357,16 +517,46 @@ func (a *Workload) generatePodInfo(pods []corev1.Pod) map[string]*models.PodInfo
 		}
 
 		result[pod.Name] = &models.PodInfo{
-			Name:      pod.Name,
-			Restarts:  restarts,
-			Ready:     podutils.IsPodReady(&pods[i]),
-			CreatedAt: pod.CreationTimestamp.Format(time.RFC3339), // ISO 8601
+			Name:       pod.Name,
+			Restarts:   restarts,
+			Ready:      podutils.IsPodReady(&pods[i]),
+			CreatedAt:  pod.CreationTimestamp.Format(time.RFC3339), // ISO 8601
+			Containers: ContainerResources(pod),
 		}
 	}
 
 	return result
 }
 
+// ContainerResources returns the resource requests and limits of the containers of the pod, as
+// configured in its spec. Requests and limits which are not set are reported as zero.
+func ContainerResources(pod corev1.Pod) []models.ContainerResources {
+	result := []models.ContainerResources{}
+
+	for _, container := range pod.Spec.Containers {
+		result = append(result, models.ContainerResources{
+			Name:     container.Name,
+			Requests: resourceAmounts(container.Resources.Requests),
+			Limits:   resourceAmounts(container.Resources.Limits),
+		})
+	}
+
+	return result
+}
+
+func resourceAmounts(resources corev1.ResourceList) models.ResourceAmounts {
+	amounts := models.ResourceAmounts{}
+
+	if cpu, ok := resources[corev1.ResourceCPU]; ok {
+		amounts.MilliCPUs = cpu.MilliValue()
+	}
+	if memory, ok := resources[corev1.ResourceMemory]; ok {
+		amounts.MemoryBytes = memory.Value()
+	}
+
+	return amounts
+}
+
 func (a *Workload) populatePodMetrics(podInfos map[string]*models.PodInfo, podMetrics []metricsv1beta1.PodMetrics) error {
 	for _, podMetric := range podMetrics {
 		if _, podExists := podInfos[podMetric.Name]; !podExists {
@@ -0,0 +1,214 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package application_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LastRestartTime", func() {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	running := func(started time.Time) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			State: v1.ContainerState{
+				Running: &v1.ContainerStateRunning{StartedAt: metav1.NewTime(started)},
+			},
+		}
+	}
+
+	It("returns nil without pods", func() {
+		Expect(application.LastRestartTime(nil)).To(BeNil())
+	})
+
+	It("returns the earliest of the latest container starts per pod", func() {
+		pods := []v1.Pod{
+			{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+				running(base), running(base.Add(5 * time.Minute)),
+			}}},
+			{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+				running(base.Add(2 * time.Minute)),
+			}}},
+		}
+
+		restart := application.LastRestartTime(pods)
+		Expect(restart).ToNot(BeNil())
+		Expect(*restart).To(Equal(base.Add(2 * time.Minute)))
+	})
+
+	It("falls back to the pod start when no container is running", func() {
+		start := metav1.NewTime(base)
+		pods := []v1.Pod{
+			{Status: v1.PodStatus{StartTime: &start}},
+		}
+
+		restart := application.LastRestartTime(pods)
+		Expect(restart).ToNot(BeNil())
+		Expect(*restart).To(Equal(base))
+	})
+})
+
+var _ = Describe("ContainerResources", func() {
+	It("returns the requests and limits of each container", func() {
+		pod := v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{
+			{
+				Name: "app",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("250m"),
+						v1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+					Limits: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("1"),
+						v1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+			{
+				Name: "sidecar",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceMemory: resource.MustParse("16Mi"),
+					},
+				},
+			},
+		}}}
+
+		Expect(application.ContainerResources(pod)).To(Equal([]models.ContainerResources{
+			{
+				Name:     "app",
+				Requests: models.ResourceAmounts{MilliCPUs: 250, MemoryBytes: 64 * 1024 * 1024},
+				Limits:   models.ResourceAmounts{MilliCPUs: 1000, MemoryBytes: 256 * 1024 * 1024},
+			},
+			{
+				Name:     "sidecar",
+				Requests: models.ResourceAmounts{MemoryBytes: 16 * 1024 * 1024},
+			},
+		}))
+	})
+
+	It("reports zero for containers without requests or limits", func() {
+		pod := v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app"}}}}
+
+		Expect(application.ContainerResources(pod)).To(Equal([]models.ContainerResources{
+			{Name: "app"},
+		}))
+	})
+})
+
+var _ = Describe("RestartDeployments", func() {
+	const namespace = "workspace"
+	restartedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	deployment := func(name, app string, replicas int32, generation int64) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  namespace,
+				Generation: generation,
+				Labels:     map[string]string{"app.kubernetes.io/name": app},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+
+	It("annotates the pod templates of the selected deployments, keeping their replicas", func() {
+		client := fake.NewSimpleClientset(
+			deployment("web", "sample", 3, 4),
+			deployment("web-worker", "sample", 1, 2),
+			deployment("other", "other", 1, 7),
+		)
+		deployments := client.AppsV1().Deployments(namespace)
+
+		generation, err := application.RestartDeployments(context.Background(), deployments,
+			"app.kubernetes.io/name=sample", restartedAt)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(generation).To(Equal(int64(4)))
+
+		for name, replicas := range map[string]int32{"web": 3, "web-worker": 1} {
+			restarted, err := deployments.Get(context.Background(), name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(restarted.Spec.Template.Annotations).To(HaveKeyWithValue(
+				application.RestartedAtAnnotation, "2024-01-01T12:00:00Z"))
+			Expect(*restarted.Spec.Replicas).To(Equal(replicas))
+		}
+
+		other, err := deployments.Get(context.Background(), "other", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(other.Spec.Template.Annotations).ToNot(HaveKey(application.RestartedAtAnnotation))
+	})
+
+	It("returns zero without deployments", func() {
+		deployments := fake.NewSimpleClientset().AppsV1().Deployments(namespace)
+
+		generation, err := application.RestartDeployments(context.Background(), deployments,
+			"app.kubernetes.io/name=sample", restartedAt)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(generation).To(BeZero())
+	})
+})
+
+var _ = Describe("DeployedRevision", func() {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	It("takes the digest from the status of the app container", func() {
+		pods := []v1.Pod{{
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "linkerd-proxy", ImageID: "docker.io/linkerd/proxy@sha256:ffff"},
+					{Name: "sample", ImageID: "registry.example.com/apps/sample@" + digest},
+				},
+			},
+		}}
+		Expect(application.ImageDigest(pods, "sample")).To(Equal(digest))
+		Expect(application.ImageDigest(pods, "other")).To(BeEmpty())
+	})
+
+	It("reports the git revision for apps deployed from git only", func() {
+		app := models.NewApp("sample", "workspace")
+		app.Origin = models.ApplicationOrigin{
+			Kind: models.OriginGit,
+			Git:  &models.GitRef{URL: "https://example.com/sample.git", Revision: "abc123"},
+		}
+		app.ImageURL = "registry.example.com/apps/sample:1"
+		app.Workload = &models.AppDeployment{ImageDigest: digest}
+
+		revision, imageDigest := application.DeployedRevision(app)
+		Expect(revision).To(Equal("abc123"))
+		Expect(imageDigest).To(Equal(digest))
+
+		app.Origin = models.ApplicationOrigin{Kind: models.OriginContainer, Container: "sample:1"}
+		revision, _ = application.DeployedRevision(app)
+		Expect(revision).To(BeEmpty())
+	})
+
+	It("prefers the digest of an image deployed by digest", func() {
+		app := models.NewApp("sample", "workspace")
+		app.ImageURL = "registry.example.com/apps/sample@" + digest
+		app.Workload = &models.AppDeployment{ImageDigest: "sha256:other"}
+
+		_, imageDigest := application.DeployedRevision(app)
+		Expect(imageDigest).To(Equal(digest))
+	})
+})
@@ -0,0 +1,226 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// APITokenPrefix is the prefix of all API tokens. It distinguishes them from the OIDC tokens.
+const APITokenPrefix = "epn_"
+
+var (
+	ErrAPITokenNotFound = errors.New("api token not found")
+	ErrAPITokenConflict = errors.New("api token already exists")
+)
+
+// APIToken is a named token minted by a user, for example for a CI pipeline.
+// It grants a single role, optionally restricted to a namespace. Only the hash of the token
+// value is stored.
+type APIToken struct {
+	Name      string
+	Owner     string
+	Role      string
+	Namespace string
+	CreatedAt time.Time
+	ExpiresAt time.Time // zero if the token never expires
+
+	hash string
+}
+
+// NewAPITokenValue returns a new random token value
+func NewAPITokenValue() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Wrap(err, "generating api token")
+	}
+	return APITokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// IsExpired returns true if the token has an expiry, and it is past
+func (t APIToken) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && now.After(t.ExpiresAt)
+}
+
+// User returns the User impersonated by the token. It has the role of the token only.
+func (t APIToken) User() (User, error) {
+	role, found := EpinioRoles.FindByID(t.Role)
+	if !found {
+		return User{}, errors.Errorf("role '%s' of api token '%s' does not exist", t.Role, t.Name)
+	}
+
+	user := User{
+		Username:     t.Owner,
+		CreatedAt:    t.CreatedAt,
+		Namespaces:   []string{},
+		Gitconfigs:   []string{},
+		APITokenName: t.Name,
+	}
+
+	if t.Namespace != "" {
+		role.Namespace = t.Namespace
+		user.Namespaces = append(user.Namespaces, t.Namespace)
+	}
+	user.Roles = Roles{role}
+
+	return user, nil
+}
+
+// CreateAPIToken saves the token with the hash of its value
+func (s *AuthService) CreateAPIToken(ctx context.Context, token APIToken, value string) (APIToken, error) {
+	logger := helpers.Logger.With("component", "AuthService")
+	logger.Debugw("CreateAPIToken", "owner", token.Owner, "name", token.Name)
+
+	token.hash = hashAPIToken(value)
+
+	secret, err := s.SecretInterface.Create(ctx, newSecretFromAPIToken(token), metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return APIToken{}, ErrAPITokenConflict
+		}
+		return APIToken{}, errors.Wrapf(err, "error creating api token [%s]", token.Name)
+	}
+
+	return newAPITokenFromSecret(*secret), nil
+}
+
+// GetAPITokens returns all the API tokens
+func (s *AuthService) GetAPITokens(ctx context.Context) ([]APIToken, error) {
+	secretSelector := labels.Set(map[string]string{
+		kubernetes.EpinioAPITokenLabelKey: "true",
+	}).AsSelector().String()
+
+	secretList, err := s.SecretInterface.List(ctx, metav1.ListOptions{
+		LabelSelector: secretSelector,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting the list of the api token secrets")
+	}
+
+	tokens := []APIToken{}
+	for _, secret := range secretList.Items {
+		tokens = append(tokens, newAPITokenFromSecret(secret))
+	}
+
+	return tokens, nil
+}
+
+// GetAPITokenByValue returns the token matching the value.
+// It will return an ErrAPITokenNotFound error if there is none, i.e. the token was revoked.
+func (s *AuthService) GetAPITokenByValue(ctx context.Context, value string) (APIToken, error) {
+	tokens, err := s.GetAPITokens(ctx)
+	if err != nil {
+		return APIToken{}, err
+	}
+
+	hash := []byte(hashAPIToken(value))
+	for _, token := range tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(token.hash)) == 1 {
+			return token, nil
+		}
+	}
+
+	return APIToken{}, ErrAPITokenNotFound
+}
+
+// DeleteAPIToken deletes the named token of the owner
+func (s *AuthService) DeleteAPIToken(ctx context.Context, owner, name string) error {
+	logger := helpers.Logger.With("component", "AuthService")
+	logger.Debugw("DeleteAPIToken", "owner", owner, "name", name)
+
+	err := s.SecretInterface.Delete(ctx, apiTokenSecretName(owner, name), metav1.DeleteOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrAPITokenNotFound
+		}
+		return errors.Wrapf(err, "error deleting api token [%s]", name)
+	}
+
+	return nil
+}
+
+func hashAPIToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func apiTokenSecretName(owner, name string) string {
+	return "r" + names.GenerateResourceName("apitoken", owner, name)
+}
+
+// newSecretFromAPIToken create a Secret from an APIToken
+func newSecretFromAPIToken(token APIToken) *corev1.Secret {
+	data := map[string][]byte{
+		"name":      []byte(token.Name),
+		"owner":     []byte(token.Owner),
+		"role":      []byte(token.Role),
+		"namespace": []byte(token.Namespace),
+		"hash":      []byte(token.hash),
+	}
+	if !token.ExpiresAt.IsZero() {
+		data["expiresAt"] = []byte(token.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	return &corev1.Secret{
+		Type: "Opaque",
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiTokenSecretName(token.Owner, token.Name),
+			Namespace: helmchart.Namespace(),
+			Labels: map[string]string{
+				kubernetes.EpinioAPITokenLabelKey: "true",
+			},
+		},
+		Data: data,
+	}
+}
+
+// newAPITokenFromSecret create an APIToken from a Secret
+func newAPITokenFromSecret(secret corev1.Secret) APIToken {
+	token := APIToken{
+		Name:      string(secret.Data["name"]),
+		Owner:     string(secret.Data["owner"]),
+		Role:      string(secret.Data["role"]),
+		Namespace: string(secret.Data["namespace"]),
+		CreatedAt: secret.CreationTimestamp.Time,
+		hash:      string(secret.Data["hash"]),
+	}
+
+	if expiresAt, found := secret.Data["expiresAt"]; found {
+		if t, err := time.Parse(time.RFC3339, string(expiresAt)); err == nil {
+			token.ExpiresAt = t
+		} else {
+			// an unreadable expiry is treated as expired, rather than as never expiring
+			token.ExpiresAt = time.Unix(0, 0)
+		}
+	}
+
+	return token
+}
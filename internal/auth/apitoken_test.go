@@ -0,0 +1,161 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/auth/authfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("API tokens", func() {
+
+	var authService *auth.AuthService
+	var fakeSecret *authfakes.FakeSecretInterface
+
+	BeforeEach(func() {
+		fakeSecret = &authfakes.FakeSecretInterface{}
+		authService = &auth.AuthService{SecretInterface: fakeSecret}
+	})
+
+	Describe("NewAPITokenValue", func() {
+		It("returns distinct prefixed values", func() {
+			value1, err := auth.NewAPITokenValue()
+			Expect(err).ToNot(HaveOccurred())
+			value2, err := auth.NewAPITokenValue()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(strings.HasPrefix(value1, auth.APITokenPrefix)).To(BeTrue())
+			Expect(value1).ToNot(Equal(value2))
+		})
+	})
+
+	Describe("CreateAPIToken", func() {
+		var token auth.APIToken
+
+		BeforeEach(func() {
+			token = auth.APIToken{
+				Name:      "ci",
+				Owner:     "alice",
+				Role:      "user",
+				Namespace: "workspace",
+				ExpiresAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			}
+			fakeSecret.CreateStub = func(_ context.Context, secret *corev1.Secret, _ metav1.CreateOptions) (*corev1.Secret, error) {
+				return secret, nil
+			}
+		})
+
+		It("stores the hash of the token only", func() {
+			created, err := authService.CreateAPIToken(context.Background(), token, "epn_secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created.Name).To(Equal("ci"))
+			Expect(created.ExpiresAt).To(BeTemporally("==", token.ExpiresAt))
+
+			Expect(fakeSecret.CreateCallCount()).To(Equal(1))
+			_, secret, _ := fakeSecret.CreateArgsForCall(0)
+			Expect(secret.Labels).To(HaveKeyWithValue(kubernetes.EpinioAPITokenLabelKey, "true"))
+			Expect(secret.Labels).ToNot(HaveKey(kubernetes.EpinioAPISecretLabelKey))
+			for _, value := range secret.Data {
+				Expect(string(value)).ToNot(ContainSubstring("epn_secret"))
+			}
+		})
+
+		It("returns a conflict when the token already exists", func() {
+			fakeSecret.CreateReturns(nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "secrets"}, "ci"))
+
+			_, err := authService.CreateAPIToken(context.Background(), token, "epn_secret")
+			Expect(err).To(MatchError(auth.ErrAPITokenConflict))
+		})
+	})
+
+	Describe("GetAPITokenByValue", func() {
+
+		BeforeEach(func() {
+			var created []corev1.Secret
+			fakeSecret.CreateStub = func(_ context.Context, secret *corev1.Secret, _ metav1.CreateOptions) (*corev1.Secret, error) {
+				created = append(created, *secret)
+				return secret, nil
+			}
+			fakeSecret.ListStub = func(_ context.Context, _ metav1.ListOptions) (*corev1.SecretList, error) {
+				return &corev1.SecretList{Items: created}, nil
+			}
+
+			_, err := authService.CreateAPIToken(context.Background(), auth.APIToken{Name: "ci", Owner: "alice", Role: "user"}, "epn_one")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = authService.CreateAPIToken(context.Background(), auth.APIToken{Name: "cd", Owner: "bob", Role: "user"}, "epn_two")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("finds the token matching the value", func() {
+			token, err := authService.GetAPITokenByValue(context.Background(), "epn_two")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(token.Name).To(Equal("cd"))
+			Expect(token.Owner).To(Equal("bob"))
+			Expect(token.ExpiresAt.IsZero()).To(BeTrue())
+		})
+
+		It("does not find an unknown value", func() {
+			_, err := authService.GetAPITokenByValue(context.Background(), "epn_three")
+			Expect(err).To(MatchError(auth.ErrAPITokenNotFound))
+		})
+	})
+
+	Describe("DeleteAPIToken", func() {
+		It("returns not found for an unknown token", func() {
+			fakeSecret.DeleteReturns(apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "ci"))
+
+			err := authService.DeleteAPIToken(context.Background(), "alice", "ci")
+			Expect(err).To(MatchError(auth.ErrAPITokenNotFound))
+		})
+	})
+
+	Describe("IsExpired", func() {
+		now := time.Now()
+
+		It("never expires without an expiry", func() {
+			Expect(auth.APIToken{}.IsExpired(now)).To(BeFalse())
+		})
+
+		It("expires after the expiry", func() {
+			Expect(auth.APIToken{ExpiresAt: now.Add(time.Minute)}.IsExpired(now)).To(BeFalse())
+			Expect(auth.APIToken{ExpiresAt: now.Add(-time.Minute)}.IsExpired(now)).To(BeTrue())
+		})
+	})
+
+	Describe("User", func() {
+		It("has the role of the token, in the namespace of the token", func() {
+			user, err := auth.APIToken{Name: "ci", Owner: "alice", Role: "admin", Namespace: "workspace"}.User()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user.Username).To(Equal("alice"))
+			Expect(user.APITokenName).To(Equal("ci"))
+			Expect(user.Roles.IDs()).To(ConsistOf("admin:workspace"))
+			Expect(user.Namespaces).To(ConsistOf("workspace"))
+			Expect(user.IsAdmin()).To(BeFalse())
+		})
+
+		It("fails for an unknown role", func() {
+			_, err := auth.APIToken{Name: "ci", Role: "unknown"}.User()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
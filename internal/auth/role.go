@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -182,3 +183,36 @@ func ParseRoleID(roleID string) (string, string) {
 	}
 	return roleIDAndNamespace[0], ""
 }
+
+// IsRoleNamespacePattern returns true if the namespace of a role is a pattern, as in
+// "team-eng-*", instead of the name of a single namespace.
+func IsRoleNamespacePattern(namespace string) bool {
+	return strings.ContainsAny(namespace, "*?[")
+}
+
+// ExpandRoleIDs replaces the roleIDs whose namespace is a pattern with the roleIDs for each of
+// the namespaces matching the pattern. RoleIDs without a pattern are kept as is. Patterns
+// follow the syntax of path.Match. Invalid patterns match nothing.
+//
+// i.e. with the namespaces "team-eng-a", "team-eng-b", and "sales":
+//
+//	"user:team-eng-*" will return "user:team-eng-a" and "user:team-eng-b"
+func ExpandRoleIDs(roleIDs []string, namespaces []string) []string {
+	expanded := []string{}
+
+	for _, fullRoleID := range roleIDs {
+		roleID, pattern := ParseRoleID(fullRoleID)
+		if !IsRoleNamespacePattern(pattern) {
+			expanded = append(expanded, fullRoleID)
+			continue
+		}
+
+		for _, namespace := range namespaces {
+			if matched, err := path.Match(pattern, namespace); err == nil && matched {
+				expanded = append(expanded, roleID+RoleNamespaceDelimiter+namespace)
+			}
+		}
+	}
+
+	return expanded
+}
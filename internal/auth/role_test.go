@@ -0,0 +1,47 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth collects structures and functions around the
+// generation and processing of credentials.
+package auth_test
+
+import (
+	"github.com/epinio/epinio/internal/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auth roles", func() {
+
+	Describe("ExpandRoleIDs", func() {
+		namespaces := []string{"team-eng-a", "team-eng-b", "sales"}
+
+		It("keeps global and namespaced roles", func() {
+			roleIDs := auth.ExpandRoleIDs([]string{"admin", "user:sales", "user:unknown"}, namespaces)
+			Expect(roleIDs).To(Equal([]string{"admin", "user:sales", "user:unknown"}))
+		})
+
+		It("expands namespace patterns to the matching namespaces", func() {
+			roleIDs := auth.ExpandRoleIDs([]string{"admin:team-eng-*", "user"}, namespaces)
+			Expect(roleIDs).To(Equal([]string{"admin:team-eng-a", "admin:team-eng-b", "user"}))
+		})
+
+		It("drops patterns without matching namespaces", func() {
+			roleIDs := auth.ExpandRoleIDs([]string{"user:team-ops-*"}, namespaces)
+			Expect(roleIDs).To(BeEmpty())
+		})
+
+		It("drops invalid patterns", func() {
+			roleIDs := auth.ExpandRoleIDs([]string{"user:team-[", "user"}, namespaces)
+			Expect(roleIDs).To(Equal([]string{"user"}))
+		})
+	})
+})
@@ -36,6 +36,9 @@ type User struct {
 	Namespaces []string // list of namespaces this user has created (and thus access to)
 	Gitconfigs []string // list of gitconfigs this user has created (and thus access to)
 
+	// APITokenName is the name of the API token the user authenticated with, if any
+	APITokenName string
+
 	roleIDs    []string
 	secretName string
 }
@@ -85,6 +88,30 @@ func (u *User) RemoveNamespace(namespaceToRemove string) bool {
 	return removed
 }
 
+// RemoveNamespaceRole removes the role from the User's roles scoped to the namespace.
+// When no other role is left for the namespace, the namespace is removed as well.
+// It returns false if the role was not there
+func (u *User) RemoveNamespaceRole(roleID, namespace string) bool {
+	removed := false
+	updatedRoles := Roles{}
+
+	for _, role := range u.Roles {
+		if role.ID == roleID && role.Namespace == namespace {
+			removed = true
+		} else {
+			updatedRoles = append(updatedRoles, role)
+		}
+	}
+
+	u.Roles = updatedRoles
+
+	if removed && len(filterRolesByNamespace(u.Roles, namespace)) == 0 {
+		u.RemoveNamespace(namespace)
+	}
+
+	return removed
+}
+
 // AddGitconfig adds the gitconfig to the User's gitconfigs, if it not already exists
 func (u *User) AddGitconfig(gitconfig string) {
 	if gitconfig == "" {
@@ -137,6 +164,37 @@ func (u *User) IsAdmin() bool {
 	return found
 }
 
+// CanDelegate returns true if the user holds the role, or more, in the namespace, and can thus
+// hand it to an API token. An empty namespace stands for the global role.
+func (u *User) CanDelegate(roleID, namespace string) bool {
+	if u.IsAdmin() {
+		return true
+	}
+
+	if namespace == "" {
+		_, found := u.Roles.FindByID(roleID)
+		return found
+	}
+
+	if _, found := u.Roles.FindByIDAndNamespace(AdminRole.ID, namespace); found {
+		return true
+	}
+	if _, found := u.Roles.FindByIDAndNamespace(roleID, namespace); found {
+		return true
+	}
+
+	// a global role applies to the namespaces the user has access to
+	if _, found := u.Roles.FindByID(roleID); found {
+		for _, ns := range u.Namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func filterRolesByNamespace(roles Roles, namespace string) Roles {
 	filteredRoles := Roles{}
 	for _, role := range roles {
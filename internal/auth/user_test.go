@@ -186,4 +186,62 @@ var _ = Describe("Auth users", func() {
 			})
 		})
 	})
+
+	Describe("RemoveNamespaceRole", func() {
+
+		var user auth.User
+
+		BeforeEach(func() {
+			user = auth.User{
+				Roles: auth.Roles{
+					{ID: "admin", Namespace: "workspace"},
+					{ID: "user", Namespace: "workspace"},
+					{ID: "admin", Namespace: "other"},
+				},
+				Namespaces: []string{"workspace", "other"},
+			}
+		})
+
+		It("returns false when the role is not bound to the namespace", func() {
+			Expect(user.RemoveNamespaceRole("user", "other")).To(BeFalse())
+			Expect(user.Roles).To(HaveLen(3))
+			Expect(user.Namespaces).To(ConsistOf("workspace", "other"))
+		})
+
+		It("keeps the namespace while other roles are left for it", func() {
+			Expect(user.RemoveNamespaceRole("admin", "workspace")).To(BeTrue())
+			Expect(user.Roles.IDs()).To(ConsistOf("user:workspace", "admin:other"))
+			Expect(user.Namespaces).To(ConsistOf("workspace", "other"))
+		})
+
+		It("removes the namespace with its last role", func() {
+			Expect(user.RemoveNamespaceRole("admin", "other")).To(BeTrue())
+			Expect(user.Roles.IDs()).To(ConsistOf("admin:workspace", "user:workspace"))
+			Expect(user.Namespaces).To(ConsistOf("workspace"))
+		})
+	})
+
+	Describe("CanDelegate", func() {
+
+		It("allows admins to delegate any role", func() {
+			user := auth.User{Roles: auth.Roles{{ID: "admin"}}}
+			Expect(user.CanDelegate("user", "")).To(BeTrue())
+			Expect(user.CanDelegate("user", "workspace")).To(BeTrue())
+		})
+
+		It("allows namespace admins to delegate any role in their namespace", func() {
+			user := auth.User{Roles: auth.Roles{{ID: "admin", Namespace: "workspace"}}}
+			Expect(user.CanDelegate("user", "workspace")).To(BeTrue())
+			Expect(user.CanDelegate("user", "other")).To(BeFalse())
+			Expect(user.CanDelegate("user", "")).To(BeFalse())
+		})
+
+		It("allows to delegate a global role in the namespaces of the user", func() {
+			user := auth.User{Roles: auth.Roles{{ID: "user"}}, Namespaces: []string{"workspace"}}
+			Expect(user.CanDelegate("user", "")).To(BeTrue())
+			Expect(user.CanDelegate("user", "workspace")).To(BeTrue())
+			Expect(user.CanDelegate("user", "other")).To(BeFalse())
+			Expect(user.CanDelegate("admin", "workspace")).To(BeFalse())
+		})
+	})
 })
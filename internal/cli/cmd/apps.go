@@ -27,12 +27,15 @@ import (
 //counterfeiter:generate -header ../../../LICENSE_HEADER . ApplicationsService
 type ApplicationsService interface {
 	AppCreate(name string, updateRequest models.ApplicationUpdateRequest) error
+	AppDebug(ctx context.Context, name, instance string) error
 	AppDelete(ctx context.Context, appNames []string, all, deleteImage bool) error
-	AppExec(ctx context.Context, name, instance string) error
+	AppEvents(name string) error
+	AppExec(ctx context.Context, name, instance string, command []string) error
 	AppExport(name string, toRegistry bool, exportRequest models.AppExportRequest) error
 	AppLogs(name, stageID string, follow bool, options *client.LogOptions) error
+	AppStagingLogs(name string, follow bool) error
 	AppManifest(name, path string) error
-	AppPortForward(ctx context.Context, name, instance string, address, ports []string) error
+	AppPortForward(ctx context.Context, name, instance, container string, address, ports []string) error
 	AppPush(ctxt context.Context, manifest models.ApplicationManifest) error
 	AppRestage(name string, restart bool) error
 	AppRestart(name string) error
@@ -71,8 +74,10 @@ func NewApplicationsCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.
 	appsCmd.AddCommand(
 		NewAppChartCmd(client), // See appchart.go for implementation
 		NewAppCreateCmd(client),
+		NewAppDebugCmd(client),
 		NewAppDeleteCmd(client),
 		NewAppEnvCmd(client), // See appenv.go for implementation
+		NewAppEventsCmd(client, rootCfg),
 		NewAppExecCmd(client),
 		NewAppExportCmd(client),
 		NewAppListCmd(client, rootCfg),
@@ -175,18 +180,46 @@ type AppExecConfig struct {
 	instance string
 }
 
+// NewAppEventsCmd returns a new `epinio apps events` command
+func NewAppEventsCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "events NAME",
+		Short:             "Show the kubernetes events of the named application",
+		Long:              "Show the kubernetes events of the deployment and pods of the named application, newest first, like scheduling failures, image pull errors, and OOM kills",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppEvents(args[0])
+			return errors.Wrap(err, "error showing app events")
+		},
+	}
+
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
+	bindFlag(cmd, "output")
+	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return cmd
+}
+
 // NewAppExecCmd returns a new `epinio apps exec` command
 func NewAppExecCmd(client ApplicationsService) *cobra.Command {
 	cfg := AppExecConfig{}
 	cmd := &cobra.Command{
-		Use:               "exec NAME",
-		Short:             "creates a shell to the application",
-		Args:              cobra.ExactArgs(1),
+		Use:               "exec NAME [-- COMMAND [ARG...]]",
+		Short:             "creates a shell to the application, or runs a command in it",
+		Long:              "Creates a shell to the application. With a command after `--` that command is run instead, without a terminal.",
+		Args:              cobra.MinimumNArgs(1),
 		ValidArgsFunction: NewAppMatcherFirstFunc(client),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			err := client.AppExec(cmd.Context(), args[0], cfg.instance)
+			if len(args) > 1 && cmd.ArgsLenAtDash() != 1 {
+				return errors.New("the command to run has to follow `--`")
+			}
+
+			err := client.AppExec(cmd.Context(), args[0], cfg.instance, args[1:])
 			// Note: errors.Wrap (nil, "...") == nil
 			return errors.Wrap(err, "error getting a shell to application")
 		},
@@ -198,12 +231,41 @@ func NewAppExecCmd(client ApplicationsService) *cobra.Command {
 	return cmd
 }
 
+type AppDebugConfig struct {
+	instance string
+}
+
+// NewAppDebugCmd returns a new `epinio apps debug` command
+func NewAppDebugCmd(client ApplicationsService) *cobra.Command {
+	cfg := AppDebugConfig{}
+	cmd := &cobra.Command{
+		Use:               "debug NAME",
+		Short:             "attaches to a debug container in the application",
+		Long:              "Starts an ephemeral container with debugging tools in an instance of the application, and attaches to it. This works for images without a shell.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: NewAppMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.AppDebug(cmd.Context(), args[0], cfg.instance)
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error debugging application")
+		},
+	}
+
+	cmd.Flags().StringVarP(&cfg.instance, "instance", "i", "",
+		"The name of the instance to debug")
+
+	return cmd
+}
+
 type AppExportConfig struct {
 	registry     string
 	imageName    string
 	imageTag     string
 	chartName    string
 	chartVersion string
+	pullSecret   string
 }
 
 // NewAppExportCmd return a new `epinio apps export` command
@@ -242,6 +304,7 @@ func NewAppExportCmd(client ApplicationsService) *cobra.Command {
 				ChartName:    cfg.chartName,
 				ImageTag:     cfg.imageTag,
 				ChartVersion: cfg.chartVersion,
+				PullSecret:   cfg.pullSecret,
 			})
 			// Note: errors.Wrap (nil, "...") == nil
 			return errors.Wrap(err, "error exporting app")
@@ -252,6 +315,7 @@ func NewAppExportCmd(client ApplicationsService) *cobra.Command {
 	cmd.Flags().StringVar(&cfg.imageTag, "image-tag", "", "User chosen tag for the image file")
 	cmd.Flags().StringVar(&cfg.chartName, "chart-name", "", "User chosen name for the chart file")
 	cmd.Flags().StringVar(&cfg.chartVersion, "chart-version", "", "User chosen version for the chart file")
+	cmd.Flags().StringVar(&cfg.pullSecret, "pull-secret", "", "Name of the pull secret, in the namespace of the app, to pull the app image with")
 
 	cmd.Flags().StringVarP(&cfg.registry, "registry", "r", "", "The name of the registry to export to")
 	bindFlag(cmd, "registry")
@@ -283,7 +347,7 @@ func NewAppListCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Comma
 
 	cmd.Flags().BoolVar(&cfg.all, "all", false, "list all applications")
 
-	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(cmd, "output")
 	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
@@ -306,23 +370,19 @@ func NewAppLogsCmd(client ApplicationsService) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
-			stageID := ""
 			if cfg.staging {
-				stageIDHere, err := client.AppStageID(args[0])
-				if err != nil {
-					return errors.Wrap(err, "error checking app")
-				}
-				stageID = stageIDHere
+				err := client.AppStagingLogs(args[0], cfg.follow)
+				return errors.Wrap(err, "error streaming staging logs")
 			}
 
-			err := client.AppLogs(args[0], stageID, cfg.follow, nil)
+			err := client.AppLogs(args[0], "", cfg.follow, nil)
 			// Note: errors.Wrap (nil, "...") == nil
 			return errors.Wrap(err, "error streaming application logs")
 		},
 	}
 
 	cmd.Flags().BoolVar(&cfg.follow, "follow", false, "follow the logs of the application")
-	cmd.Flags().BoolVar(&cfg.staging, "staging", false, "show the staging logs of the application")
+	cmd.Flags().BoolVar(&cfg.staging, "staging", false, "show the staging logs of the application, following an active staging until it is done")
 
 	return cmd
 }
@@ -347,8 +407,9 @@ func NewAppManifestCmd(client ApplicationsService) *cobra.Command {
 }
 
 type AppForwardConfig struct {
-	address  []string
-	instance string
+	address   []string
+	instance  string
+	container string
 }
 
 // NewAppPortForwardCmd returns a new `epinio apps port-forward` command
@@ -365,7 +426,7 @@ func NewAppPortForwardCmd(client ApplicationsService) *cobra.Command {
 			appName := args[0]
 			ports := args[1:]
 
-			err := client.AppPortForward(cmd.Context(), appName, cfg.instance, cfg.address, ports)
+			err := client.AppPortForward(cmd.Context(), appName, cfg.instance, cfg.container, cfg.address, ports)
 			// Note: errors.Wrap (nil, "...") == nil
 			return errors.Wrap(err, "error port forwarding to application")
 		},
@@ -375,6 +436,8 @@ func NewAppPortForwardCmd(client ApplicationsService) *cobra.Command {
 		"Addresses to listen on (comma separated). Only accepts IP addresses or localhost as a value. When localhost is supplied, kubectl will try to bind on both 127.0.0.1 and ::1 and will fail if neither of these addresses are available to bind.")
 	cmd.Flags().StringVarP(&cfg.instance, "instance", "i", "",
 		"The name of the instance to shell to")
+	cmd.Flags().StringVarP(&cfg.container, "container", "c", "",
+		"The name of the container to forward to, for instances with more than one container")
 
 	return cmd
 }
@@ -470,6 +533,8 @@ func NewAppPushCmd(client ApplicationsService) *cobra.Command {
 	cmd.Flags().StringP("name", "n", "", "Application name. (mandatory if no manifest is provided)")
 	cmd.Flags().StringP("path", "p", "", "Path to application sources.")
 	cmd.Flags().String("builder-image", "", "Paketo builder image to use for staging")
+	cmd.Flags().StringSlice("build-env", []string{}, "environment variables available only to the staging build")
+	cmd.Flags().String("build-env-secret", "", "secret in the namespace providing environment variables available only to the staging build")
 
 	gitProviderOption(cmd)
 	routeOption(cmd)
@@ -551,7 +616,7 @@ func NewAppShowCmd(client ApplicationsService, rootCfg *RootConfig) *cobra.Comma
 		},
 	}
 
-	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(cmd, "output")
 	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
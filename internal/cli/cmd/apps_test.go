@@ -161,4 +161,87 @@ var _ = Describe("Command 'epinio app'", func() {
 			})
 		})
 	})
+
+	Context("app exec", func() {
+
+		When("called with a command after --", func() {
+			It("runs the command in the app", func() {
+				args = append(args, "myapp", "--instance", "myapp-0", "--", "ls", "-la")
+
+				appCmd := cmd.NewAppExecCmd(mockAppService)
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(mockAppService.AppExecCallCount()).To(Equal(1))
+				_, name, instance, command := mockAppService.AppExecArgsForCall(0)
+				Expect(name).To(Equal("myapp"))
+				Expect(instance).To(Equal("myapp-0"))
+				Expect(command).To(Equal([]string{"ls", "-la"}))
+			})
+		})
+
+		When("called without a command", func() {
+			It("opens a shell", func() {
+				args = append(args, "myapp")
+
+				appCmd := cmd.NewAppExecCmd(mockAppService)
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+
+				_, _, _, command := mockAppService.AppExecArgsForCall(0)
+				Expect(command).To(BeEmpty())
+			})
+		})
+
+		When("called with a command not following --", func() {
+			It("fails", func() {
+				args = append(args, "myapp", "ls")
+
+				appCmd := cmd.NewAppExecCmd(mockAppService)
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("the command to run has to follow `--`"))
+				Expect(mockAppService.AppExecCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("app events", func() {
+
+		When("called with no args", func() {
+			It("fails", func() {
+				appCmd := cmd.NewAppEventsCmd(mockAppService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("accepts 1 arg(s), received 0"))
+			})
+		})
+
+		When("the app events fails", func() {
+			It("returns an error", func() {
+				args = append(args, "myapp")
+				mockAppService.AppEventsReturns(errors.New("something bad happened"))
+
+				appCmd := cmd.NewAppEventsCmd(mockAppService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("error showing app events: something bad happened"))
+			})
+		})
+
+		When("called with the app name", func() {
+			It("shows the events of the app", func() {
+				args = append(args, "myapp", "-o", "json")
+
+				rootCfg := cmd.NewRootConfig()
+				appCmd := cmd.NewAppEventsCmd(mockAppService, rootCfg)
+				_, _, runErr := executeCmd(appCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(mockAppService.AppEventsCallCount()).To(Equal(1))
+				Expect(mockAppService.AppEventsArgsForCall(0)).To(Equal("myapp"))
+				Expect(rootCfg.Output.Value).To(Equal("json"))
+			})
+		})
+	})
 })
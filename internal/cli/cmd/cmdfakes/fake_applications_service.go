@@ -35,6 +35,19 @@ type FakeApplicationsService struct {
 	appCreateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppDebugStub        func(context.Context, string, string) error
+	appDebugMutex       sync.RWMutex
+	appDebugArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	appDebugReturns struct {
+		result1 error
+	}
+	appDebugReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppDeleteStub        func(context.Context, []string, bool, bool) error
 	appDeleteMutex       sync.RWMutex
 	appDeleteArgsForCall []struct {
@@ -49,12 +62,24 @@ type FakeApplicationsService struct {
 	appDeleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AppExecStub        func(context.Context, string, string) error
+	AppEventsStub        func(string) error
+	appEventsMutex       sync.RWMutex
+	appEventsArgsForCall []struct {
+		arg1 string
+	}
+	appEventsReturns struct {
+		result1 error
+	}
+	appEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AppExecStub        func(context.Context, string, string, []string) error
 	appExecMutex       sync.RWMutex
 	appExecArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
+		arg4 []string
 	}
 	appExecReturns struct {
 		result1 error
@@ -101,14 +126,15 @@ type FakeApplicationsService struct {
 	appManifestReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AppPortForwardStub        func(context.Context, string, string, []string, []string) error
+	AppPortForwardStub        func(context.Context, string, string, string, []string, []string) error
 	appPortForwardMutex       sync.RWMutex
 	appPortForwardArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
-		arg4 []string
+		arg4 string
 		arg5 []string
+		arg6 []string
 	}
 	appPortForwardReturns struct {
 		result1 error
@@ -175,6 +201,18 @@ type FakeApplicationsService struct {
 		result1 string
 		result2 error
 	}
+	AppStagingLogsStub        func(string, bool) error
+	appStagingLogsMutex       sync.RWMutex
+	appStagingLogsArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	appStagingLogsReturns struct {
+		result1 error
+	}
+	appStagingLogsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppUpdateStub        func(string, models.ApplicationUpdateRequest) error
 	appUpdateMutex       sync.RWMutex
 	appUpdateArgsForCall []struct {
@@ -429,6 +467,69 @@ func (fake *FakeApplicationsService) AppCreateReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
+func (fake *FakeApplicationsService) AppDebug(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.appDebugMutex.Lock()
+	ret, specificReturn := fake.appDebugReturnsOnCall[len(fake.appDebugArgsForCall)]
+	fake.appDebugArgsForCall = append(fake.appDebugArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AppDebugStub
+	fakeReturns := fake.appDebugReturns
+	fake.recordInvocation("AppDebug", []interface{}{arg1, arg2, arg3})
+	fake.appDebugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppDebugCallCount() int {
+	fake.appDebugMutex.RLock()
+	defer fake.appDebugMutex.RUnlock()
+	return len(fake.appDebugArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppDebugCalls(stub func(context.Context, string, string) error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = stub
+}
+
+func (fake *FakeApplicationsService) AppDebugArgsForCall(i int) (context.Context, string, string) {
+	fake.appDebugMutex.RLock()
+	defer fake.appDebugMutex.RUnlock()
+	argsForCall := fake.appDebugArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeApplicationsService) AppDebugReturns(result1 error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = nil
+	fake.appDebugReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppDebugReturnsOnCall(i int, result1 error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = nil
+	if fake.appDebugReturnsOnCall == nil {
+		fake.appDebugReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appDebugReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppDelete(arg1 context.Context, arg2 []string, arg3 bool, arg4 bool) error {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -498,20 +599,87 @@ func (fake *FakeApplicationsService) AppDeleteReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeApplicationsService) AppExec(arg1 context.Context, arg2 string, arg3 string) error {
+func (fake *FakeApplicationsService) AppEvents(arg1 string) error {
+	fake.appEventsMutex.Lock()
+	ret, specificReturn := fake.appEventsReturnsOnCall[len(fake.appEventsArgsForCall)]
+	fake.appEventsArgsForCall = append(fake.appEventsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AppEventsStub
+	fakeReturns := fake.appEventsReturns
+	fake.recordInvocation("AppEvents", []interface{}{arg1})
+	fake.appEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppEventsCallCount() int {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	return len(fake.appEventsArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppEventsCalls(stub func(string) error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = stub
+}
+
+func (fake *FakeApplicationsService) AppEventsArgsForCall(i int) string {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	argsForCall := fake.appEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApplicationsService) AppEventsReturns(result1 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	fake.appEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppEventsReturnsOnCall(i int, result1 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	if fake.appEventsReturnsOnCall == nil {
+		fake.appEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppExec(arg1 context.Context, arg2 string, arg3 string, arg4 []string) error {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
 	fake.appExecMutex.Lock()
 	ret, specificReturn := fake.appExecReturnsOnCall[len(fake.appExecArgsForCall)]
 	fake.appExecArgsForCall = append(fake.appExecArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 []string
+	}{arg1, arg2, arg3, arg4Copy})
 	stub := fake.AppExecStub
 	fakeReturns := fake.appExecReturns
-	fake.recordInvocation("AppExec", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("AppExec", []interface{}{arg1, arg2, arg3, arg4Copy})
 	fake.appExecMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -525,17 +693,17 @@ func (fake *FakeApplicationsService) AppExecCallCount() int {
 	return len(fake.appExecArgsForCall)
 }
 
-func (fake *FakeApplicationsService) AppExecCalls(stub func(context.Context, string, string) error) {
+func (fake *FakeApplicationsService) AppExecCalls(stub func(context.Context, string, string, []string) error) {
 	fake.appExecMutex.Lock()
 	defer fake.appExecMutex.Unlock()
 	fake.AppExecStub = stub
 }
 
-func (fake *FakeApplicationsService) AppExecArgsForCall(i int) (context.Context, string, string) {
+func (fake *FakeApplicationsService) AppExecArgsForCall(i int) (context.Context, string, string, []string) {
 	fake.appExecMutex.RLock()
 	defer fake.appExecMutex.RUnlock()
 	argsForCall := fake.appExecArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeApplicationsService) AppExecReturns(result1 error) {
@@ -750,32 +918,33 @@ func (fake *FakeApplicationsService) AppManifestReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *FakeApplicationsService) AppPortForward(arg1 context.Context, arg2 string, arg3 string, arg4 []string, arg5 []string) error {
-	var arg4Copy []string
-	if arg4 != nil {
-		arg4Copy = make([]string, len(arg4))
-		copy(arg4Copy, arg4)
-	}
+func (fake *FakeApplicationsService) AppPortForward(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 []string, arg6 []string) error {
 	var arg5Copy []string
 	if arg5 != nil {
 		arg5Copy = make([]string, len(arg5))
 		copy(arg5Copy, arg5)
 	}
+	var arg6Copy []string
+	if arg6 != nil {
+		arg6Copy = make([]string, len(arg6))
+		copy(arg6Copy, arg6)
+	}
 	fake.appPortForwardMutex.Lock()
 	ret, specificReturn := fake.appPortForwardReturnsOnCall[len(fake.appPortForwardArgsForCall)]
 	fake.appPortForwardArgsForCall = append(fake.appPortForwardArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
-		arg4 []string
+		arg4 string
 		arg5 []string
-	}{arg1, arg2, arg3, arg4Copy, arg5Copy})
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5Copy, arg6Copy})
 	stub := fake.AppPortForwardStub
 	fakeReturns := fake.appPortForwardReturns
-	fake.recordInvocation("AppPortForward", []interface{}{arg1, arg2, arg3, arg4Copy, arg5Copy})
+	fake.recordInvocation("AppPortForward", []interface{}{arg1, arg2, arg3, arg4, arg5Copy, arg6Copy})
 	fake.appPortForwardMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -789,17 +958,17 @@ func (fake *FakeApplicationsService) AppPortForwardCallCount() int {
 	return len(fake.appPortForwardArgsForCall)
 }
 
-func (fake *FakeApplicationsService) AppPortForwardCalls(stub func(context.Context, string, string, []string, []string) error) {
+func (fake *FakeApplicationsService) AppPortForwardCalls(stub func(context.Context, string, string, string, []string, []string) error) {
 	fake.appPortForwardMutex.Lock()
 	defer fake.appPortForwardMutex.Unlock()
 	fake.AppPortForwardStub = stub
 }
 
-func (fake *FakeApplicationsService) AppPortForwardArgsForCall(i int) (context.Context, string, string, []string, []string) {
+func (fake *FakeApplicationsService) AppPortForwardArgsForCall(i int) (context.Context, string, string, string, []string, []string) {
 	fake.appPortForwardMutex.RLock()
 	defer fake.appPortForwardMutex.RUnlock()
 	argsForCall := fake.appPortForwardArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeApplicationsService) AppPortForwardReturns(result1 error) {
@@ -1135,6 +1304,68 @@ func (fake *FakeApplicationsService) AppStageIDReturnsOnCall(i int, result1 stri
 	}{result1, result2}
 }
 
+func (fake *FakeApplicationsService) AppStagingLogs(arg1 string, arg2 bool) error {
+	fake.appStagingLogsMutex.Lock()
+	ret, specificReturn := fake.appStagingLogsReturnsOnCall[len(fake.appStagingLogsArgsForCall)]
+	fake.appStagingLogsArgsForCall = append(fake.appStagingLogsArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.AppStagingLogsStub
+	fakeReturns := fake.appStagingLogsReturns
+	fake.recordInvocation("AppStagingLogs", []interface{}{arg1, arg2})
+	fake.appStagingLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApplicationsService) AppStagingLogsCallCount() int {
+	fake.appStagingLogsMutex.RLock()
+	defer fake.appStagingLogsMutex.RUnlock()
+	return len(fake.appStagingLogsArgsForCall)
+}
+
+func (fake *FakeApplicationsService) AppStagingLogsCalls(stub func(string, bool) error) {
+	fake.appStagingLogsMutex.Lock()
+	defer fake.appStagingLogsMutex.Unlock()
+	fake.AppStagingLogsStub = stub
+}
+
+func (fake *FakeApplicationsService) AppStagingLogsArgsForCall(i int) (string, bool) {
+	fake.appStagingLogsMutex.RLock()
+	defer fake.appStagingLogsMutex.RUnlock()
+	argsForCall := fake.appStagingLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApplicationsService) AppStagingLogsReturns(result1 error) {
+	fake.appStagingLogsMutex.Lock()
+	defer fake.appStagingLogsMutex.Unlock()
+	fake.AppStagingLogsStub = nil
+	fake.appStagingLogsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationsService) AppStagingLogsReturnsOnCall(i int, result1 error) {
+	fake.appStagingLogsMutex.Lock()
+	defer fake.appStagingLogsMutex.Unlock()
+	fake.AppStagingLogsStub = nil
+	if fake.appStagingLogsReturnsOnCall == nil {
+		fake.appStagingLogsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appStagingLogsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeApplicationsService) AppUpdate(arg1 string, arg2 models.ApplicationUpdateRequest) error {
 	fake.appUpdateMutex.Lock()
 	ret, specificReturn := fake.appUpdateReturnsOnCall[len(fake.appUpdateArgsForCall)]
@@ -43,6 +43,30 @@ type FakeNamespaceService struct {
 	deleteNamespaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GrantNamespaceRoleStub        func(string, string, string) error
+	grantNamespaceRoleMutex       sync.RWMutex
+	grantNamespaceRoleArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	grantNamespaceRoleReturns struct {
+		result1 error
+	}
+	grantNamespaceRoleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NamespaceRolesStub        func(string) error
+	namespaceRolesMutex       sync.RWMutex
+	namespaceRolesArgsForCall []struct {
+		arg1 string
+	}
+	namespaceRolesReturns struct {
+		result1 error
+	}
+	namespaceRolesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NamespacesStub        func() error
 	namespacesMutex       sync.RWMutex
 	namespacesArgsForCall []struct {
@@ -64,6 +88,19 @@ type FakeNamespaceService struct {
 	namespacesMatchingReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	RevokeNamespaceRoleStub        func(string, string, string) error
+	revokeNamespaceRoleMutex       sync.RWMutex
+	revokeNamespaceRoleArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	revokeNamespaceRoleReturns struct {
+		result1 error
+	}
+	revokeNamespaceRoleReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ShowNamespaceStub        func(string) error
 	showNamespaceMutex       sync.RWMutex
 	showNamespaceArgsForCall []struct {
@@ -208,6 +245,130 @@ func (fake *FakeNamespaceService) DeleteNamespaceReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakeNamespaceService) GrantNamespaceRole(arg1 string, arg2 string, arg3 string) error {
+	fake.grantNamespaceRoleMutex.Lock()
+	ret, specificReturn := fake.grantNamespaceRoleReturnsOnCall[len(fake.grantNamespaceRoleArgsForCall)]
+	fake.grantNamespaceRoleArgsForCall = append(fake.grantNamespaceRoleArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GrantNamespaceRoleStub
+	fakeReturns := fake.grantNamespaceRoleReturns
+	fake.recordInvocation("GrantNamespaceRole", []interface{}{arg1, arg2, arg3})
+	fake.grantNamespaceRoleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) GrantNamespaceRoleCallCount() int {
+	fake.grantNamespaceRoleMutex.RLock()
+	defer fake.grantNamespaceRoleMutex.RUnlock()
+	return len(fake.grantNamespaceRoleArgsForCall)
+}
+
+func (fake *FakeNamespaceService) GrantNamespaceRoleCalls(stub func(string, string, string) error) {
+	fake.grantNamespaceRoleMutex.Lock()
+	defer fake.grantNamespaceRoleMutex.Unlock()
+	fake.GrantNamespaceRoleStub = stub
+}
+
+func (fake *FakeNamespaceService) GrantNamespaceRoleArgsForCall(i int) (string, string, string) {
+	fake.grantNamespaceRoleMutex.RLock()
+	defer fake.grantNamespaceRoleMutex.RUnlock()
+	argsForCall := fake.grantNamespaceRoleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNamespaceService) GrantNamespaceRoleReturns(result1 error) {
+	fake.grantNamespaceRoleMutex.Lock()
+	defer fake.grantNamespaceRoleMutex.Unlock()
+	fake.GrantNamespaceRoleStub = nil
+	fake.grantNamespaceRoleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) GrantNamespaceRoleReturnsOnCall(i int, result1 error) {
+	fake.grantNamespaceRoleMutex.Lock()
+	defer fake.grantNamespaceRoleMutex.Unlock()
+	fake.GrantNamespaceRoleStub = nil
+	if fake.grantNamespaceRoleReturnsOnCall == nil {
+		fake.grantNamespaceRoleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.grantNamespaceRoleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) NamespaceRoles(arg1 string) error {
+	fake.namespaceRolesMutex.Lock()
+	ret, specificReturn := fake.namespaceRolesReturnsOnCall[len(fake.namespaceRolesArgsForCall)]
+	fake.namespaceRolesArgsForCall = append(fake.namespaceRolesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceRolesStub
+	fakeReturns := fake.namespaceRolesReturns
+	fake.recordInvocation("NamespaceRoles", []interface{}{arg1})
+	fake.namespaceRolesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) NamespaceRolesCallCount() int {
+	fake.namespaceRolesMutex.RLock()
+	defer fake.namespaceRolesMutex.RUnlock()
+	return len(fake.namespaceRolesArgsForCall)
+}
+
+func (fake *FakeNamespaceService) NamespaceRolesCalls(stub func(string) error) {
+	fake.namespaceRolesMutex.Lock()
+	defer fake.namespaceRolesMutex.Unlock()
+	fake.NamespaceRolesStub = stub
+}
+
+func (fake *FakeNamespaceService) NamespaceRolesArgsForCall(i int) string {
+	fake.namespaceRolesMutex.RLock()
+	defer fake.namespaceRolesMutex.RUnlock()
+	argsForCall := fake.namespaceRolesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNamespaceService) NamespaceRolesReturns(result1 error) {
+	fake.namespaceRolesMutex.Lock()
+	defer fake.namespaceRolesMutex.Unlock()
+	fake.NamespaceRolesStub = nil
+	fake.namespaceRolesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) NamespaceRolesReturnsOnCall(i int, result1 error) {
+	fake.namespaceRolesMutex.Lock()
+	defer fake.namespaceRolesMutex.Unlock()
+	fake.NamespaceRolesStub = nil
+	if fake.namespaceRolesReturnsOnCall == nil {
+		fake.namespaceRolesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.namespaceRolesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNamespaceService) Namespaces() error {
 	fake.namespacesMutex.Lock()
 	ret, specificReturn := fake.namespacesReturnsOnCall[len(fake.namespacesArgsForCall)]
@@ -322,6 +483,69 @@ func (fake *FakeNamespaceService) NamespacesMatchingReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeNamespaceService) RevokeNamespaceRole(arg1 string, arg2 string, arg3 string) error {
+	fake.revokeNamespaceRoleMutex.Lock()
+	ret, specificReturn := fake.revokeNamespaceRoleReturnsOnCall[len(fake.revokeNamespaceRoleArgsForCall)]
+	fake.revokeNamespaceRoleArgsForCall = append(fake.revokeNamespaceRoleArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeNamespaceRoleStub
+	fakeReturns := fake.revokeNamespaceRoleReturns
+	fake.recordInvocation("RevokeNamespaceRole", []interface{}{arg1, arg2, arg3})
+	fake.revokeNamespaceRoleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNamespaceService) RevokeNamespaceRoleCallCount() int {
+	fake.revokeNamespaceRoleMutex.RLock()
+	defer fake.revokeNamespaceRoleMutex.RUnlock()
+	return len(fake.revokeNamespaceRoleArgsForCall)
+}
+
+func (fake *FakeNamespaceService) RevokeNamespaceRoleCalls(stub func(string, string, string) error) {
+	fake.revokeNamespaceRoleMutex.Lock()
+	defer fake.revokeNamespaceRoleMutex.Unlock()
+	fake.RevokeNamespaceRoleStub = stub
+}
+
+func (fake *FakeNamespaceService) RevokeNamespaceRoleArgsForCall(i int) (string, string, string) {
+	fake.revokeNamespaceRoleMutex.RLock()
+	defer fake.revokeNamespaceRoleMutex.RUnlock()
+	argsForCall := fake.revokeNamespaceRoleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNamespaceService) RevokeNamespaceRoleReturns(result1 error) {
+	fake.revokeNamespaceRoleMutex.Lock()
+	defer fake.revokeNamespaceRoleMutex.Unlock()
+	fake.RevokeNamespaceRoleStub = nil
+	fake.revokeNamespaceRoleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNamespaceService) RevokeNamespaceRoleReturnsOnCall(i int, result1 error) {
+	fake.revokeNamespaceRoleMutex.Lock()
+	defer fake.revokeNamespaceRoleMutex.Unlock()
+	fake.RevokeNamespaceRoleStub = nil
+	if fake.revokeNamespaceRoleReturnsOnCall == nil {
+		fake.revokeNamespaceRoleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeNamespaceRoleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNamespaceService) ShowNamespace(arg1 string) error {
 	fake.showNamespaceMutex.Lock()
 	ret, specificReturn := fake.showNamespaceReturnsOnCall[len(fake.showNamespaceArgsForCall)]
@@ -54,11 +54,13 @@ type FakeServicesService struct {
 	getAPIReturnsOnCall map[int]struct {
 		result1 usercmd.APIClient
 	}
-	ServiceBatchBindStub        func(string, []string) error
+	ServiceBatchBindStub        func(string, []string, bool, bool) error
 	serviceBatchBindMutex       sync.RWMutex
 	serviceBatchBindArgsForCall []struct {
 		arg1 string
 		arg2 []string
+		arg3 bool
+		arg4 bool
 	}
 	serviceBatchBindReturns struct {
 		result1 error
@@ -66,11 +68,12 @@ type FakeServicesService struct {
 	serviceBatchBindReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ServiceBindStub        func(string, string) error
+	ServiceBindStub        func(string, string, bool) error
 	serviceBindMutex       sync.RWMutex
 	serviceBindArgsForCall []struct {
 		arg1 string
 		arg2 string
+		arg3 bool
 	}
 	serviceBindReturns struct {
 		result1 error
@@ -100,6 +103,20 @@ type FakeServicesService struct {
 	serviceCatalogShowReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ServiceCloneStub        func(string, string, string, bool) error
+	serviceCloneMutex       sync.RWMutex
+	serviceCloneArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 bool
+	}
+	serviceCloneReturns struct {
+		result1 error
+	}
+	serviceCloneReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ServiceCreateStub        func(string, string, bool, models.ChartValueSettings) error
 	serviceCreateMutex       sync.RWMutex
 	serviceCreateArgsForCall []struct {
@@ -172,6 +189,19 @@ type FakeServicesService struct {
 	servicePortForwardReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ServiceRebindStub        func(string, []string, []string) error
+	serviceRebindMutex       sync.RWMutex
+	serviceRebindArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+	}
+	serviceRebindReturns struct {
+		result1 error
+	}
+	serviceRebindReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ServiceShowStub        func(string) error
 	serviceShowMutex       sync.RWMutex
 	serviceShowArgsForCall []struct {
@@ -389,7 +419,7 @@ func (fake *FakeServicesService) GetAPIReturnsOnCall(i int, result1 usercmd.APIC
 	}{result1}
 }
 
-func (fake *FakeServicesService) ServiceBatchBind(arg1 string, arg2 []string) error {
+func (fake *FakeServicesService) ServiceBatchBind(arg1 string, arg2 []string, arg3 bool, arg4 bool) error {
 	var arg2Copy []string
 	if arg2 != nil {
 		arg2Copy = make([]string, len(arg2))
@@ -400,13 +430,15 @@ func (fake *FakeServicesService) ServiceBatchBind(arg1 string, arg2 []string) er
 	fake.serviceBatchBindArgsForCall = append(fake.serviceBatchBindArgsForCall, struct {
 		arg1 string
 		arg2 []string
-	}{arg1, arg2Copy})
+		arg3 bool
+		arg4 bool
+	}{arg1, arg2Copy, arg3, arg4})
 	stub := fake.ServiceBatchBindStub
 	fakeReturns := fake.serviceBatchBindReturns
-	fake.recordInvocation("ServiceBatchBind", []interface{}{arg1, arg2Copy})
+	fake.recordInvocation("ServiceBatchBind", []interface{}{arg1, arg2Copy, arg3, arg4})
 	fake.serviceBatchBindMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -420,17 +452,17 @@ func (fake *FakeServicesService) ServiceBatchBindCallCount() int {
 	return len(fake.serviceBatchBindArgsForCall)
 }
 
-func (fake *FakeServicesService) ServiceBatchBindCalls(stub func(string, []string) error) {
+func (fake *FakeServicesService) ServiceBatchBindCalls(stub func(string, []string, bool, bool) error) {
 	fake.serviceBatchBindMutex.Lock()
 	defer fake.serviceBatchBindMutex.Unlock()
 	fake.ServiceBatchBindStub = stub
 }
 
-func (fake *FakeServicesService) ServiceBatchBindArgsForCall(i int) (string, []string) {
+func (fake *FakeServicesService) ServiceBatchBindArgsForCall(i int) (string, []string, bool, bool) {
 	fake.serviceBatchBindMutex.RLock()
 	defer fake.serviceBatchBindMutex.RUnlock()
 	argsForCall := fake.serviceBatchBindArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeServicesService) ServiceBatchBindReturns(result1 error) {
@@ -456,19 +488,20 @@ func (fake *FakeServicesService) ServiceBatchBindReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
-func (fake *FakeServicesService) ServiceBind(arg1 string, arg2 string) error {
+func (fake *FakeServicesService) ServiceBind(arg1 string, arg2 string, arg3 bool) error {
 	fake.serviceBindMutex.Lock()
 	ret, specificReturn := fake.serviceBindReturnsOnCall[len(fake.serviceBindArgsForCall)]
 	fake.serviceBindArgsForCall = append(fake.serviceBindArgsForCall, struct {
 		arg1 string
 		arg2 string
-	}{arg1, arg2})
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.ServiceBindStub
 	fakeReturns := fake.serviceBindReturns
-	fake.recordInvocation("ServiceBind", []interface{}{arg1, arg2})
+	fake.recordInvocation("ServiceBind", []interface{}{arg1, arg2, arg3})
 	fake.serviceBindMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -482,17 +515,17 @@ func (fake *FakeServicesService) ServiceBindCallCount() int {
 	return len(fake.serviceBindArgsForCall)
 }
 
-func (fake *FakeServicesService) ServiceBindCalls(stub func(string, string) error) {
+func (fake *FakeServicesService) ServiceBindCalls(stub func(string, string, bool) error) {
 	fake.serviceBindMutex.Lock()
 	defer fake.serviceBindMutex.Unlock()
 	fake.ServiceBindStub = stub
 }
 
-func (fake *FakeServicesService) ServiceBindArgsForCall(i int) (string, string) {
+func (fake *FakeServicesService) ServiceBindArgsForCall(i int) (string, string, bool) {
 	fake.serviceBindMutex.RLock()
 	defer fake.serviceBindMutex.RUnlock()
 	argsForCall := fake.serviceBindArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeServicesService) ServiceBindReturns(result1 error) {
@@ -633,6 +666,70 @@ func (fake *FakeServicesService) ServiceCatalogShowReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeServicesService) ServiceClone(arg1 string, arg2 string, arg3 string, arg4 bool) error {
+	fake.serviceCloneMutex.Lock()
+	ret, specificReturn := fake.serviceCloneReturnsOnCall[len(fake.serviceCloneArgsForCall)]
+	fake.serviceCloneArgsForCall = append(fake.serviceCloneArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ServiceCloneStub
+	fakeReturns := fake.serviceCloneReturns
+	fake.recordInvocation("ServiceClone", []interface{}{arg1, arg2, arg3, arg4})
+	fake.serviceCloneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeServicesService) ServiceCloneCallCount() int {
+	fake.serviceCloneMutex.RLock()
+	defer fake.serviceCloneMutex.RUnlock()
+	return len(fake.serviceCloneArgsForCall)
+}
+
+func (fake *FakeServicesService) ServiceCloneCalls(stub func(string, string, string, bool) error) {
+	fake.serviceCloneMutex.Lock()
+	defer fake.serviceCloneMutex.Unlock()
+	fake.ServiceCloneStub = stub
+}
+
+func (fake *FakeServicesService) ServiceCloneArgsForCall(i int) (string, string, string, bool) {
+	fake.serviceCloneMutex.RLock()
+	defer fake.serviceCloneMutex.RUnlock()
+	argsForCall := fake.serviceCloneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeServicesService) ServiceCloneReturns(result1 error) {
+	fake.serviceCloneMutex.Lock()
+	defer fake.serviceCloneMutex.Unlock()
+	fake.ServiceCloneStub = nil
+	fake.serviceCloneReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeServicesService) ServiceCloneReturnsOnCall(i int, result1 error) {
+	fake.serviceCloneMutex.Lock()
+	defer fake.serviceCloneMutex.Unlock()
+	fake.ServiceCloneStub = nil
+	if fake.serviceCloneReturnsOnCall == nil {
+		fake.serviceCloneReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceCloneReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeServicesService) ServiceCreate(arg1 string, arg2 string, arg3 bool, arg4 models.ChartValueSettings) error {
 	fake.serviceCreateMutex.Lock()
 	ret, specificReturn := fake.serviceCreateReturnsOnCall[len(fake.serviceCreateArgsForCall)]
@@ -1006,6 +1103,79 @@ func (fake *FakeServicesService) ServicePortForwardReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeServicesService) ServiceRebind(arg1 string, arg2 []string, arg3 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.serviceRebindMutex.Lock()
+	ret, specificReturn := fake.serviceRebindReturnsOnCall[len(fake.serviceRebindArgsForCall)]
+	fake.serviceRebindArgsForCall = append(fake.serviceRebindArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+	}{arg1, arg2Copy, arg3Copy})
+	stub := fake.ServiceRebindStub
+	fakeReturns := fake.serviceRebindReturns
+	fake.recordInvocation("ServiceRebind", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.serviceRebindMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeServicesService) ServiceRebindCallCount() int {
+	fake.serviceRebindMutex.RLock()
+	defer fake.serviceRebindMutex.RUnlock()
+	return len(fake.serviceRebindArgsForCall)
+}
+
+func (fake *FakeServicesService) ServiceRebindCalls(stub func(string, []string, []string) error) {
+	fake.serviceRebindMutex.Lock()
+	defer fake.serviceRebindMutex.Unlock()
+	fake.ServiceRebindStub = stub
+}
+
+func (fake *FakeServicesService) ServiceRebindArgsForCall(i int) (string, []string, []string) {
+	fake.serviceRebindMutex.RLock()
+	defer fake.serviceRebindMutex.RUnlock()
+	argsForCall := fake.serviceRebindArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeServicesService) ServiceRebindReturns(result1 error) {
+	fake.serviceRebindMutex.Lock()
+	defer fake.serviceRebindMutex.Unlock()
+	fake.ServiceRebindStub = nil
+	fake.serviceRebindReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeServicesService) ServiceRebindReturnsOnCall(i int, result1 error) {
+	fake.serviceRebindMutex.Lock()
+	defer fake.serviceRebindMutex.Unlock()
+	fake.ServiceRebindStub = nil
+	if fake.serviceRebindReturnsOnCall == nil {
+		fake.serviceRebindReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceRebindReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeServicesService) ServiceShow(arg1 string) error {
 	fake.serviceShowMutex.Lock()
 	ret, specificReturn := fake.serviceShowReturnsOnCall[len(fake.serviceShowArgsForCall)]
@@ -93,7 +93,7 @@ func NewConfigurationListCmd(client ConfigurationService, rootCfg *RootConfig) *
 	}
 
 	cmd.Flags().BoolVar(&cfg.all, "all", false, "list all configurations")
-	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(cmd, "output")
 	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
@@ -120,7 +120,7 @@ func NewConfigurationShowCmd(client ConfigurationService, rootCfg *RootConfig) *
 		ValidArgsFunction: FirstArgValidator(client.ConfigurationMatching),
 	}
 
-	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(cmd, "output")
 	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
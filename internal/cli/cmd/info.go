@@ -35,7 +35,7 @@ func NewInfoCmd(client *usercmd.EpinioClient, rootCfg *RootConfig) *cobra.Comman
 		},
 	}
 
-	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(cmd, "output")
 	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
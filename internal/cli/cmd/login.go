@@ -21,17 +21,18 @@ import (
 
 //counterfeiter:generate -header ../../../LICENSE_HEADER . LoginService
 type LoginService interface {
-	LoginOIDC(ctx context.Context, address string, trustCA, prompt bool) error
-	Login(ctx context.Context, username, password, address string, trustCA bool) error
+	LoginOIDC(ctx context.Context, address, namespace string, trustCA, prompt bool) error
+	Login(ctx context.Context, username, password, address, namespace string, trustCA bool) error
 	Logout(ctx context.Context) error
 }
 
 type LoginConfig struct {
-	user     string
-	password string
-	trustCA  bool
-	oidc     bool
-	prompt   bool
+	user      string
+	password  string
+	namespace string
+	trustCA   bool
+	oidc      bool
+	prompt    bool
 }
 
 // NewLoginCmd returns a new 'epinio login' command
@@ -59,14 +60,15 @@ func NewLoginCmd(client LoginService) *cobra.Command {
 			}
 
 			if cfg.oidc {
-				return client.LoginOIDC(cmd.Context(), address, cfg.trustCA, cfg.prompt)
+				return client.LoginOIDC(cmd.Context(), address, cfg.namespace, cfg.trustCA, cfg.prompt)
 			}
-			return client.Login(cmd.Context(), cfg.user, cfg.password, address, cfg.trustCA)
+			return client.Login(cmd.Context(), cfg.user, cfg.password, address, cfg.namespace, cfg.trustCA)
 		},
 	}
 
 	loginCmd.Flags().StringVarP(&cfg.user, "user", "u", "", "username that will be used to login")
 	loginCmd.Flags().StringVarP(&cfg.password, "password", "p", "", "password that will be used to login")
+	loginCmd.Flags().StringVar(&cfg.namespace, "namespace", "", "namespace to target after the login, it has to exist and be accessible")
 	loginCmd.Flags().BoolVar(&cfg.trustCA, "trust-ca", false, "automatically trust the unknown CA")
 	loginCmd.Flags().BoolVar(&cfg.oidc, "oidc", false, "perform OIDC authentication (user and password will be ignored)")
 	loginCmd.Flags().BoolVar(&cfg.prompt, "prompt", false, "enable the prompt of the authorization code and disable the local server during OIDC authentication")
@@ -36,7 +36,7 @@ var _ = Describe("Command 'epinio login' and 'epinio logout'", func() {
 			loginCmd := cmd.NewLoginCmd(mockLoginService)
 
 			// https schema
-			mockLoginService.LoginStub = func(_ context.Context, _, _, addr string, _ bool) error {
+			mockLoginService.LoginStub = func(_ context.Context, _, _, addr, _ string, _ bool) error {
 				Expect(addr).To(Equal("https://epinio.io"))
 				return nil
 			}
@@ -49,7 +49,7 @@ var _ = Describe("Command 'epinio login' and 'epinio logout'", func() {
 			Expect(mockLoginService.LoginOIDCCallCount()).To(BeZero())
 
 			// http schema
-			mockLoginService.LoginStub = func(_ context.Context, _, _, addr string, _ bool) error {
+			mockLoginService.LoginStub = func(_ context.Context, _, _, addr, _ string, _ bool) error {
 				Expect(addr).To(Equal("http://epinio.io"))
 				return nil
 			}
@@ -59,7 +59,7 @@ var _ = Describe("Command 'epinio login' and 'epinio logout'", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			// no schema
-			mockLoginService.LoginStub = func(_ context.Context, _, _, addr string, _ bool) error {
+			mockLoginService.LoginStub = func(_ context.Context, _, _, addr, _ string, _ bool) error {
 				Expect(addr).To(Equal("https://epinio.io"))
 				return nil
 			}
@@ -74,7 +74,7 @@ var _ = Describe("Command 'epinio login' and 'epinio logout'", func() {
 
 			username, password, address := "myuser", "mypassword", "https://epinio.io"
 
-			mockLoginService.LoginStub = func(_ context.Context, user, pass, addr string, trustCA bool) error {
+			mockLoginService.LoginStub = func(_ context.Context, user, pass, addr, _ string, trustCA bool) error {
 				Expect(user).To(Equal(username))
 				Expect(pass).To(Equal(password))
 				Expect(addr).To(Equal(address))
@@ -103,7 +103,7 @@ var _ = Describe("Command 'epinio login' and 'epinio logout'", func() {
 
 			address := "https://epinio.io"
 
-			mockLoginService.LoginOIDCStub = func(_ context.Context, addr string, trustCA, prompt bool) error {
+			mockLoginService.LoginOIDCStub = func(_ context.Context, addr, _ string, trustCA, prompt bool) error {
 				Expect(addr).To(Equal(address))
 				Expect(trustCA).To(BeTrue())
 				Expect(prompt).To(BeTrue())
@@ -120,6 +120,30 @@ var _ = Describe("Command 'epinio login' and 'epinio logout'", func() {
 			Expect(mockLoginService.LoginCallCount()).To(BeZero())
 		})
 
+		It("will pass the namespace to target to the Login", func() {
+			loginCmd := cmd.NewLoginCmd(mockLoginService)
+
+			args := []string{"https://epinio.io", "--user", "myuser", "--password", "mypassword", "--namespace", "workspace"}
+			_, _, err := executeCmd(loginCmd, args, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(mockLoginService.LoginCallCount()).To(Equal(1))
+			_, _, _, _, namespace, _ := mockLoginService.LoginArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+		})
+
+		It("will pass the namespace to target to the OIDC Login", func() {
+			loginCmd := cmd.NewLoginCmd(mockLoginService)
+
+			args := []string{"https://epinio.io", "--oidc", "--namespace", "workspace"}
+			_, _, err := executeCmd(loginCmd, args, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(mockLoginService.LoginOIDCCallCount()).To(Equal(1))
+			_, _, namespace, _, _ := mockLoginService.LoginOIDCArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+		})
+
 	})
 
 	When("the logout is called", func() {
@@ -25,6 +25,9 @@ type NamespaceService interface {
 	DeleteNamespace(namespaces []string, force, all bool) error
 	ShowNamespace(namespace string) error
 	NamespacesMatching(toComplete string) []string
+	NamespaceRoles(namespace string) error
+	GrantNamespaceRole(user, namespace, role string) error
+	RevokeNamespaceRole(user, namespace, role string) error
 }
 
 // NewNamespaceCmd returns a new 'epinio namespace' command
@@ -44,6 +47,9 @@ func NewNamespaceCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Comman
 		NewNamespaceListCmd(client, rootCfg),
 		NewNamespaceDeleteCmd(client),
 		NewNamespaceShowCmd(client, rootCfg),
+		NewNamespaceRolesCmd(client, rootCfg),
+		NewNamespaceGrantCmd(client),
+		NewNamespaceRevokeCmd(client),
 	)
 
 	return namespaceCmd
@@ -86,7 +92,7 @@ func NewNamespaceListCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Co
 		},
 	}
 
-	namespaceListCmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	namespaceListCmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(namespaceListCmd, "output")
 	bindFlagCompletionFunc(namespaceListCmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
@@ -147,9 +153,97 @@ func NewNamespaceShowCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Co
 		},
 	}
 
-	namespaceShowCmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	namespaceShowCmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(namespaceShowCmd, "output")
 	bindFlagCompletionFunc(namespaceShowCmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
 	return namespaceShowCmd
 }
+
+// NewNamespaceRolesCmd returns a new 'epinio namespace roles' command
+func NewNamespaceRolesCmd(client NamespaceService, rootCfg *RootConfig) *cobra.Command {
+	namespaceRolesCmd := &cobra.Command{
+		Use:               "roles NAME",
+		Short:             "Lists the roles of the users in an epinio-controlled namespace",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.NamespacesMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.NamespaceRoles(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error listing namespace roles")
+			}
+
+			return nil
+		},
+	}
+
+	namespaceRolesCmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
+	bindFlag(namespaceRolesCmd, "output")
+	bindFlagCompletionFunc(namespaceRolesCmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
+
+	return namespaceRolesCmd
+}
+
+type NamespaceRoleConfig struct {
+	role string
+}
+
+// NewNamespaceGrantCmd returns a new 'epinio namespace grant' command
+func NewNamespaceGrantCmd(client NamespaceService) *cobra.Command {
+	cfg := NamespaceRoleConfig{}
+
+	namespaceGrantCmd := &cobra.Command{
+		Use:   "grant USER NAME",
+		Short: "Grants a role to a user in an epinio-controlled namespace",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if cfg.role == "" {
+				return errors.New("role not specified, use --role")
+			}
+
+			err := client.GrantNamespaceRole(args[0], args[1], cfg.role)
+			if err != nil {
+				return errors.Wrap(err, "error granting namespace role")
+			}
+
+			return nil
+		},
+	}
+
+	namespaceGrantCmd.Flags().StringVar(&cfg.role, "role", "", "role to grant in the namespace")
+
+	return namespaceGrantCmd
+}
+
+// NewNamespaceRevokeCmd returns a new 'epinio namespace revoke' command
+func NewNamespaceRevokeCmd(client NamespaceService) *cobra.Command {
+	cfg := NamespaceRoleConfig{}
+
+	namespaceRevokeCmd := &cobra.Command{
+		Use:   "revoke USER NAME",
+		Short: "Revokes a role of a user in an epinio-controlled namespace",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if cfg.role == "" {
+				return errors.New("role not specified, use --role")
+			}
+
+			err := client.RevokeNamespaceRole(args[0], args[1], cfg.role)
+			if err != nil {
+				return errors.Wrap(err, "error revoking namespace role")
+			}
+
+			return nil
+		},
+	}
+
+	namespaceRevokeCmd.Flags().StringVar(&cfg.role, "role", "", "role to revoke in the namespace")
+
+	return namespaceRevokeCmd
+}
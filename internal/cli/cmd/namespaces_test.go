@@ -153,4 +153,50 @@ var _ = Describe("Command 'epinio namespace'", func() {
 			})
 		})
 	})
+
+	Context("namespace grant", func() {
+
+		When("called without a role", func() {
+			It("fails", func() {
+				args = append(args, "alice", "mynamespace")
+
+				namespaceCmd := cmd.NewNamespaceGrantCmd(mockNamespaceService)
+				_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("role not specified, use --role"))
+				Expect(mockNamespaceService.GrantNamespaceRoleCallCount()).To(Equal(0))
+			})
+		})
+
+		When("called with a user, a namespace and a role", func() {
+			It("grants the role", func() {
+				args = append(args, "alice", "mynamespace", "--role", "admin")
+
+				namespaceCmd := cmd.NewNamespaceGrantCmd(mockNamespaceService)
+				_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(mockNamespaceService.GrantNamespaceRoleCallCount()).To(Equal(1))
+				user, namespace, role := mockNamespaceService.GrantNamespaceRoleArgsForCall(0)
+				Expect(user).To(Equal("alice"))
+				Expect(namespace).To(Equal("mynamespace"))
+				Expect(role).To(Equal("admin"))
+			})
+		})
+	})
+
+	Context("namespace revoke", func() {
+
+		When("the revoke fails", func() {
+			It("returns an error", func() {
+				args = append(args, "alice", "mynamespace", "--role", "admin")
+				mockNamespaceService.RevokeNamespaceRoleReturns(errors.New("cannot remove the last admin"))
+
+				namespaceCmd := cmd.NewNamespaceRevokeCmd(mockNamespaceService)
+				_, _, runErr := executeCmd(namespaceCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("error revoking namespace role: cannot remove the last admin"))
+			})
+		})
+	})
 })
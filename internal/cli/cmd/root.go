@@ -19,6 +19,6 @@ type RootConfig struct {
 
 func NewRootConfig() *RootConfig {
 	return &RootConfig{
-		Output: NewEnumValue([]string{"text", "json"}, "text"),
+		Output: NewEnumValue([]string{"text", "json", "yaml"}, "text"),
 	}
 }
@@ -23,16 +23,18 @@ import (
 
 //counterfeiter:generate -header ../../../LICENSE_HEADER . ServicesService
 type ServicesService interface {
-	ServiceBind(serviceName, appName string) error
-	ServiceBatchBind(appName string, serviceNames []string) error
+	ServiceBind(serviceName, appName string, noRestart bool) error
+	ServiceBatchBind(appName string, serviceNames []string, partial, noRestart bool) error
 	ServiceCatalog() error
 	ServiceCatalogShow(ctx context.Context, serviceName string) error
+	ServiceClone(serviceName, targetName, targetNamespace string, wait bool) error
 	ServiceCreate(catalogName, serviceName string, wait bool, chartValues models.ChartValueSettings) error
 	ServiceDelete(serviceNames []string, unbind, all bool) error
 	ServiceList() error
 	ServiceListAll() error
 	ServicePortForward(ctx context.Context, serviceName string, address, ports []string) error
 	ServiceShow(serviceName string) error
+	ServiceRebind(appName string, remove, add []string) error
 	ServiceUnbind(serviceName, appName string) error
 	ServiceUpdate(serviceName string, wait bool, removed []string, assignments map[string]string, noRestart bool) error
 
@@ -63,10 +65,12 @@ func NewServicesCmd(client ServicesService, rootCfg *RootConfig) *cobra.Command
 	servicesCmd.AddCommand(
 		NewServiceBindCmd(client),
 		NewServiceCatalogCmd(client),
+		NewServiceCloneCmd(client),
 		NewServiceCreateCmd(client),
 		NewServiceDeleteCmd(client),
 		NewServiceListCmd(client, rootCfg),
 		NewServicePortForwardCmd(client),
+		NewServiceRebindCmd(client),
 		NewServiceShowCmd(client, rootCfg),
 		NewServiceUnbindCmd(client),
 		NewServiceUpdateCmd(client),
@@ -144,6 +148,34 @@ func NewServiceCreateCmd(client ServicesService) *cobra.Command {
 	return cmd
 }
 
+type ServiceCloneConfig struct {
+	wait        bool
+	toNamespace string
+}
+
+// NewServiceCloneCmd returns a new `epinio service clone` command
+func NewServiceCloneCmd(client ServicesService) *cobra.Command {
+	cfg := ServiceCloneConfig{}
+	cmd := &cobra.Command{
+		Use:               "clone SERVICENAME TARGETNAME",
+		Short:             "Create a copy TARGETNAME of the service SERVICENAME, with the same settings",
+		Long:              "Create a copy TARGETNAME of the service SERVICENAME, from the same catalog service and with the same settings, in the namespace given by --to-namespace, or the targeted namespace.",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: NewServiceMatcherFirstFunc(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.ServiceClone(args[0], args[1], cfg.toNamespace, cfg.wait)
+			return errors.Wrap(err, "error cloning service")
+		},
+	}
+
+	cmd.Flags().BoolVar(&cfg.wait, "wait", false, "Wait for deployment to complete")
+	cmd.Flags().StringVar(&cfg.toNamespace, "to-namespace", "", "Namespace to create the copy in")
+
+	return cmd
+}
+
 type ServiceUpdateConfig struct {
 	wait      bool
 	noRestart bool
@@ -203,7 +235,7 @@ func NewServiceShowCmd(client ServicesService, rootCfg *RootConfig) *cobra.Comma
 		},
 	}
 
-	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(cmd, "output")
 	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
@@ -243,8 +275,14 @@ func NewServiceDeleteCmd(client ServicesService) *cobra.Command {
 	return cmd
 }
 
+type ServiceBindConfig struct {
+	partial   bool
+	noRestart bool
+}
+
 // NewServiceBindCmd returns a new `epinio service bind` command
 func NewServiceBindCmd(client ServicesService) *cobra.Command {
+	cfg := ServiceBindConfig{}
 	cmd := &cobra.Command{
 		Use:   "bind SERVICENAME APPNAME [SERVICENAME...]",
 		Short: "Bind one or more services to an Epinio app",
@@ -258,7 +296,13 @@ Usage:
     epinio service bind APPNAME SERVICENAME1 SERVICENAME2 [SERVICENAME3...]
     
 When providing 3 or more arguments, the first is treated as APPNAME and the rest as service names.
-This allows binding multiple services in a single operation with only one pod restart.`,
+This allows binding multiple services in a single operation with only one pod restart.
+
+By default a batch binds nothing when any service fails. With --partial the services which can be
+bound are, and the result of each service is shown.
+
+With --no-restart a deployed application is not restarted. The bindings are recorded, and apply
+on its next restart (epinio app restart) or deployment (epinio push).`,
 		Args:              cobra.MinimumNArgs(2),
 		ValidArgsFunction: NewServiceAppMatcherFunc(client),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -271,18 +315,21 @@ This allows binding multiple services in a single operation with only one pod re
 				// Backward compatible: single service bind
 				serviceName := args[0]
 				appName := args[1]
-				err := client.ServiceBind(serviceName, appName)
+				err := client.ServiceBind(serviceName, appName, cfg.noRestart)
 				return errors.Wrap(err, "error binding service")
 			}
 
 			// New batch binding format (3+ args)
 			appName := args[0]
 			serviceNames := args[1:]
-			err := client.ServiceBatchBind(appName, serviceNames)
+			err := client.ServiceBatchBind(appName, serviceNames, cfg.partial, cfg.noRestart)
 			return errors.Wrap(err, "error binding services")
 		},
 	}
 
+	cmd.Flags().BoolVar(&cfg.partial, "partial", false, "Bind the services which can be bound, and show the result of each (batch binding only)")
+	cmd.Flags().BoolVar(&cfg.noRestart, "no-restart", false, "Prevent restarting the application after binding")
+
 	return cmd
 }
 
@@ -307,6 +354,41 @@ func NewServiceUnbindCmd(client ServicesService) *cobra.Command {
 	return cmd
 }
 
+type ServiceRebindConfig struct {
+	remove []string
+	add    []string
+}
+
+// NewServiceRebindCmd returns a new `epinio service rebind` command
+func NewServiceRebindCmd(client ServicesService) *cobra.Command {
+	cfg := ServiceRebindConfig{}
+	cmd := &cobra.Command{
+		Use:   "rebind APPNAME",
+		Short: "Move an Epinio app APPNAME between services",
+		Long: `Unbind the services given by --remove from the application, and bind the services given by --add.
+
+Both happen in a single operation, with only one pod restart. The application is never left
+without either set of services bound, as it would be with an unbind followed by a bind.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: FirstArgValidator(client.AppsMatching),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if len(cfg.remove) == 0 && len(cfg.add) == 0 {
+				return errors.New("no services to remove or add, use --remove and/or --add")
+			}
+
+			err := client.ServiceRebind(args[0], cfg.remove, cfg.add)
+			return errors.Wrap(err, "error rebinding services")
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&cfg.remove, "remove", []string{}, "services to unbind from the application")
+	cmd.Flags().StringSliceVar(&cfg.add, "add", []string{}, "services to bind to the application")
+
+	return cmd
+}
+
 type ServiceListConfig struct {
 	all bool
 }
@@ -334,7 +416,7 @@ func NewServiceListCmd(client ServicesService, rootCfg *RootConfig) *cobra.Comma
 
 	cmd.Flags().BoolVar(&cfg.all, "all", false, "List all services")
 
-	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json]")
+	cmd.Flags().VarP(rootCfg.Output, "output", "o", "sets output format [text|json|yaml]")
 	bindFlag(cmd, "output")
 	bindFlagCompletionFunc(cmd, "output", NewStaticFlagsCompletionFunc(rootCfg.Output.Allowed))
 
@@ -100,6 +100,50 @@ var _ = Describe("Command 'epinio service'", func() {
 		})
 	})
 
+	Context("service clone", func() {
+
+		When("called with one arg", func() {
+			It("fails", func() {
+				args = append(args, "myservice")
+
+				serviceCmd := cmd.NewServiceCloneCmd(mockServiceService)
+				_, _, runErr := executeCmd(serviceCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("accepts 2 arg(s), received 1"))
+			})
+		})
+
+		When("the service clone fails", func() {
+			It("returns an error", func() {
+				args = append(args, "myservice", "copy")
+
+				mockServiceService.ServiceCloneReturns(errors.New("something bad happened"))
+
+				serviceCmd := cmd.NewServiceCloneCmd(mockServiceService)
+				_, _, runErr := executeCmd(serviceCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(Equal("error cloning service: something bad happened"))
+			})
+		})
+
+		When("the service clone succeeds", func() {
+			It("passes the target namespace", func() {
+				args = append(args, "myservice", "copy", "--to-namespace", "production", "--wait")
+
+				serviceCmd := cmd.NewServiceCloneCmd(mockServiceService)
+				_, _, runErr := executeCmd(serviceCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(mockServiceService.ServiceCloneCallCount()).To(Equal(1))
+				serviceName, targetName, targetNamespace, wait := mockServiceService.ServiceCloneArgsForCall(0)
+				Expect(serviceName).To(Equal("myservice"))
+				Expect(targetName).To(Equal("copy"))
+				Expect(targetNamespace).To(Equal("production"))
+				Expect(wait).To(BeTrue())
+			})
+		})
+	})
+
 	Context("service list", func() {
 
 		When("called with one or more args", func() {
@@ -162,5 +206,29 @@ var _ = Describe("Command 'epinio service'", func() {
 				Expect(rootCfg.Output.Value).To(Equal("json"))
 			})
 		})
+
+		When("called with the output flag to yaml", func() {
+			It("shows that the output is 'yaml'", func() {
+				args = append(args, "-o", "yaml")
+				mockServiceService.ServiceListReturns(nil)
+
+				rootCfg := cmd.NewRootConfig()
+				serviceCmd := cmd.NewServiceListCmd(mockServiceService, rootCfg)
+				_, _, runErr := executeCmd(serviceCmd, args, output, outputErr)
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(rootCfg.Output.Value).To(Equal("yaml"))
+			})
+		})
+
+		When("called with an unknown output format", func() {
+			It("fails", func() {
+				args = append(args, "-o", "xml")
+
+				serviceCmd := cmd.NewServiceListCmd(mockServiceService, cmd.NewRootConfig())
+				_, _, runErr := executeCmd(serviceCmd, args, output, outputErr)
+				Expect(runErr).To(HaveOccurred())
+				Expect(mockServiceService.ServiceListCallCount()).To(Equal(0))
+			})
+		})
 	})
 })
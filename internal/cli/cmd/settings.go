@@ -34,6 +34,10 @@ func NewSettingsCmd(client *usercmd.EpinioClient) *cobra.Command {
 		NewSettingsColorsCmd(client),
 		NewSettingsShowCmd(client),
 		NewSettingsUpdateCACmd(client),
+		NewSettingsValidateCmd(client),
+		NewSettingsListContextsCmd(client),
+		NewSettingsUseContextCmd(client),
+		NewSettingsTokenCmd(client),
 	)
 
 	return settingsCmd
@@ -66,31 +70,37 @@ func NewSettingsColorsCmd(client *usercmd.EpinioClient) *cobra.Command {
 type SettingsShowConfig struct {
 	showPassword bool
 	showToken    bool
+	output       *EnumValue
 }
 
 // NewSettingsShowCmd returns a new 'epinio settings show' command
 func NewSettingsShowCmd(client *usercmd.EpinioClient) *cobra.Command {
-	cfg := &SettingsShowConfig{}
+	cfg := &SettingsShowConfig{
+		output: NewEnumValue([]string{"text", "json", "yaml"}, "text"),
+	}
 
 	settingsShowCmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show the current settings",
 		Args:  cobra.NoArgs,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
 			showPassword := cfg.showPassword
 			showToken := cfg.showPassword
 
-			client.SettingsShow(showPassword, showToken)
+			return client.SettingsShow(showPassword, showToken, cfg.output.String())
 		},
 	}
 
 	settingsShowCmd.Flags().BoolVar(&cfg.showPassword, "show-password", false, "Show hidden password")
 	settingsShowCmd.Flags().BoolVar(&cfg.showToken, "show-token", false, "Show access token")
 
+	settingsShowCmd.Flags().VarP(cfg.output, "output", "o", "sets output format [text|json|yaml]")
+
 	bindFlag(settingsShowCmd, "show-password")
 	bindFlag(settingsShowCmd, "show-token")
+	bindFlagCompletionFunc(settingsShowCmd, "output", NewStaticFlagsCompletionFunc(cfg.output.Allowed))
 
 	return settingsShowCmd
 }
@@ -113,3 +123,169 @@ func NewSettingsUpdateCACmd(client *usercmd.EpinioClient) *cobra.Command {
 		},
 	}
 }
+
+// NewSettingsValidateCmd returns a new 'epinio settings validate' command
+func NewSettingsValidateCmd(client *usercmd.EpinioClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the current settings",
+		Long:  "Check that the server of the current settings is reachable and trusted, and that the credentials are accepted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.SettingsValidate(cmd.Context())
+			if err != nil {
+				return errors.Wrap(err, "invalid settings")
+			}
+			return nil
+		},
+	}
+}
+
+// NewSettingsListContextsCmd returns a new 'epinio settings list-contexts' command
+func NewSettingsListContextsCmd(client *usercmd.EpinioClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-contexts",
+		Short: "List the contexts of the settings",
+		Long:  "List the named contexts of the settings, each holding the location and credentials of an Epinio server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			return client.SettingsListContexts()
+		},
+	}
+}
+
+// NewSettingsUseContextCmd returns a new 'epinio settings use-context' command
+func NewSettingsUseContextCmd(client *usercmd.EpinioClient) *cobra.Command {
+	var create bool
+
+	settingsUseContextCmd := &cobra.Command{
+		Use:   "use-context NAME",
+		Short: "Switch to another context",
+		Long:  "Make the named context the current one. Use --create and then `epinio login` to add a context for a new server.",
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return client.ContextsMatching(toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.SettingsUseContext(cmd.Context(), args[0], create)
+			if err != nil {
+				return errors.Wrap(err, "error switching context")
+			}
+			return nil
+		},
+	}
+
+	settingsUseContextCmd.Flags().BoolVar(&create, "create", false, "Create the context if it does not exist")
+
+	return settingsUseContextCmd
+}
+
+// NewSettingsTokenCmd returns a new 'epinio settings token' command
+func NewSettingsTokenCmd(client *usercmd.EpinioClient) *cobra.Command {
+	settingsTokenCmd := &cobra.Command{
+		Use:   "token",
+		Short: "API token management",
+		Long:  "Manage the API tokens used by service accounts, i.e. CI pipelines",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Usage()
+		},
+	}
+
+	settingsTokenCmd.AddCommand(
+		NewSettingsTokenCreateCmd(client),
+		NewSettingsTokenListCmd(client),
+		NewSettingsTokenRevokeCmd(client),
+	)
+
+	return settingsTokenCmd
+}
+
+type SettingsTokenCreateConfig struct {
+	name      string
+	namespace string
+	role      string
+	expiresIn string
+}
+
+// NewSettingsTokenCreateCmd returns a new 'epinio settings token create' command
+func NewSettingsTokenCreateCmd(client *usercmd.EpinioClient) *cobra.Command {
+	cfg := SettingsTokenCreateConfig{}
+
+	settingsTokenCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API token",
+		Long:  "Create an API token with a restricted role. The token is printed only once.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if cfg.name == "" {
+				return errors.New("name not specified, use --name")
+			}
+
+			err := client.APITokenCreate(cfg.name, cfg.namespace, cfg.role, cfg.expiresIn)
+			if err != nil {
+				return errors.Wrap(err, "error creating api token")
+			}
+			return nil
+		},
+	}
+
+	settingsTokenCreateCmd.Flags().StringVar(&cfg.name, "name", "", "name of the token")
+	settingsTokenCreateCmd.Flags().StringVar(&cfg.namespace, "namespace", "", "namespace the token is restricted to")
+	settingsTokenCreateCmd.Flags().StringVar(&cfg.role, "role", "", "role of the token (default: the default role)")
+	settingsTokenCreateCmd.Flags().StringVar(&cfg.expiresIn, "expires-in", "", "lifetime of the token, i.e. 720h (default: never expires)")
+
+	return settingsTokenCreateCmd
+}
+
+// NewSettingsTokenListCmd returns a new 'epinio settings token list' command
+func NewSettingsTokenListCmd(client *usercmd.EpinioClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the API tokens",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.APITokens()
+			if err != nil {
+				return errors.Wrap(err, "error listing api tokens")
+			}
+			return nil
+		},
+	}
+}
+
+// NewSettingsTokenRevokeCmd returns a new 'epinio settings token revoke' command
+func NewSettingsTokenRevokeCmd(client *usercmd.EpinioClient) *cobra.Command {
+	var owner string
+
+	settingsTokenRevokeCmd := &cobra.Command{
+		Use:   "revoke NAME",
+		Short: "Revoke an API token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := client.APITokenRevoke(args[0], owner)
+			if err != nil {
+				return errors.Wrap(err, "error revoking api token")
+			}
+			return nil
+		},
+	}
+
+	settingsTokenRevokeCmd.Flags().StringVar(&owner, "owner", "", "owner of the token (admins only)")
+
+	return settingsTokenRevokeCmd
+}
@@ -13,14 +13,25 @@ package cmd_test
 
 import (
 	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/epinio/epinio/internal/cli/cmd"
 	"github.com/epinio/epinio/internal/cli/settings"
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/client"
+	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
 	. "github.com/epinio/epinio/acceptance/helpers/matchers"
 	. "github.com/onsi/ginkgo/v2"
@@ -78,17 +89,18 @@ var _ = Describe("Command 'epinio settings'", func() {
 					Token: settings.TokenSetting{
 						AccessToken: "mytoken",
 					},
-					API:    "https://epinio.io",
-					WSS:    "wss://epinio.io",
-					Certs:  "-- CERT --",
-					Colors: true,
+					API:     "https://epinio.io",
+					WSS:     "wss://epinio.io",
+					Certs:   "-- CERT --",
+					Colors:  true,
+					Context: "dev",
 				}
 
 				args := []string{"show"}
 				stdout, _, _ := executeCmd(settingsCmd, args, output, nil)
 
 				lines := strings.Split(stdout, "\n")
-				Expect(lines).To(HaveLen(15), stdout)
+				Expect(lines).To(HaveLen(16), stdout)
 
 				Expect(lines[0]).To(Equal("🚢  Show Settings"))
 				Expect(lines[1]).To(Equal("Settings: /my/local/settings"))
@@ -97,6 +109,7 @@ var _ = Describe("Command 'epinio settings'", func() {
 				Expect(stdout).To(
 					HaveATable(
 						WithHeaders("KEY", "VALUE"),
+						WithRow("Current Context", "dev"),
 						WithRow("Colorized Output", "true"),
 						WithRow("Current Namespace", "mynamespace"),
 						WithRow("Default App Chart", "default-app-chart"),
@@ -121,7 +134,7 @@ var _ = Describe("Command 'epinio settings'", func() {
 				stdout, _, _ := executeCmd(settingsCmd, args, output, nil)
 
 				lines := strings.Split(stdout, "\n")
-				Expect(lines).To(HaveLen(15), stdout)
+				Expect(lines).To(HaveLen(16), stdout)
 
 				Expect(lines[0]).To(Equal("🚢  Show Settings"))
 				Expect(lines[2]).To(Equal("✔️  Ok"))
@@ -147,7 +160,7 @@ var _ = Describe("Command 'epinio settings'", func() {
 				stdout, _, _ := executeCmd(settingsCmd, args, output, nil)
 
 				lines := strings.Split(stdout, "\n")
-				Expect(lines).To(HaveLen(15), stdout)
+				Expect(lines).To(HaveLen(16), stdout)
 
 				Expect(lines[0]).To(Equal("🚢  Show Settings"))
 				Expect(lines[2]).To(Equal("✔️  Ok"))
@@ -160,5 +173,229 @@ var _ = Describe("Command 'epinio settings'", func() {
 				)
 			})
 		})
+
+		When("the output flag is set to json", func() {
+			It("will show the masked settings as pure json", func() {
+				epinioClient.Settings = &settings.Settings{
+					Namespace: "mynamespace",
+					User:      "myuser",
+					Password:  "mypassword",
+					Token: settings.TokenSetting{
+						AccessToken: "mytoken",
+					},
+					Certs: "-- CERT --",
+				}
+
+				args := []string{"show", "-o", "json"}
+				stdout, _, err := executeCmd(settingsCmd, args, output, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				shown := usercmd.SettingsShowOutput{}
+				Expect(json.Unmarshal([]byte(stdout), &shown)).To(Succeed(), stdout)
+				Expect(shown.Namespace).To(Equal("mynamespace"))
+				Expect(shown.User).To(Equal("myuser"))
+				Expect(shown.Password).To(MatchRegexp("^[*]+$"))
+				Expect(shown.Token).To(MatchRegexp("^[*]+$"))
+				Expect(shown.Certificates).To(Equal("Present"))
+			})
+		})
+
+		When("the output flag is set to yaml", func() {
+			It("will show the settings as yaml", func() {
+				epinioClient.Settings = &settings.Settings{
+					User: "myuser",
+				}
+
+				args := []string{"show", "--output", "yaml"}
+				stdout, _, err := executeCmd(settingsCmd, args, output, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stdout).ToNot(ContainSubstring("Show Settings"))
+
+				shown := usercmd.SettingsShowOutput{}
+				Expect(yaml.Unmarshal([]byte(stdout), &shown)).To(Succeed(), stdout)
+				Expect(shown.User).To(Equal("myuser"))
+				Expect(shown.Password).To(BeEmpty())
+				Expect(shown.Token).To(BeEmpty())
+				Expect(shown.Certificates).To(Equal("None defined"))
+			})
+		})
+
+		When("the output flag is set to an unknown format", func() {
+			It("fails", func() {
+				args := []string{"show", "-o", "xml"}
+				_, _, err := executeCmd(settingsCmd, args, output, nil)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("contexts", func() {
+		var file string
+
+		BeforeEach(func() {
+			file = filepath.Join(GinkgoT().TempDir(), "settings.yaml")
+
+			var err error
+			epinioClient.Settings, err = settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+
+			epinioClient.Settings.API = "https://epinio.dev.example.com"
+			epinioClient.Settings.User = "dev-user"
+			Expect(epinioClient.Settings.UseContext("prod", true)).To(Succeed())
+			epinioClient.Settings.API = "https://epinio.prod.example.com"
+			Expect(epinioClient.Settings.Save()).To(Succeed())
+		})
+
+		It("lists the contexts, marking the current one", func() {
+			stdout, _, err := executeCmd(settingsCmd, []string{"list-contexts"}, output, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stdout).To(
+				HaveATable(
+					WithHeaders("CURRENT", "NAME", "API URL", "USER", "NAMESPACE"),
+					WithRow("", "default", "https://epinio.dev.example.com", "dev-user", "workspace"),
+					WithRow("\\*", "prod", "https://epinio.prod.example.com", "", "workspace"),
+				),
+			)
+		})
+
+		It("switches to an existing context", func() {
+			_, _, err := executeCmd(settingsCmd, []string{"use-context", "default"}, output, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(epinioClient.Settings.Context).To(Equal("default"))
+			Expect(epinioClient.Settings.API).To(Equal("https://epinio.dev.example.com"))
+
+			reloaded, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reloaded.Context).To(Equal("default"))
+			Expect(reloaded.User).To(Equal("dev-user"))
+		})
+
+		It("fails to switch to a missing context", func() {
+			_, _, err := executeCmd(settingsCmd, []string{"use-context", "stage"}, output, nil)
+			Expect(err).To(MatchError("error switching context: context 'stage' does not exist"))
+		})
+
+		It("creates a missing context with --create", func() {
+			_, _, err := executeCmd(settingsCmd, []string{"use-context", "stage", "--create"}, output, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(epinioClient.Settings.Context).To(Equal("stage"))
+			Expect(epinioClient.Settings.API).To(BeEmpty())
+			Expect(epinioClient.Settings.ContextNames()).To(Equal([]string{"default", "prod", "stage"}))
+		})
+	})
+
+	Describe("validate", func() {
+		var mockAPIClient *usercmdfakes.FakeAPIClient
+
+		BeforeEach(func() {
+			mockAPIClient = &usercmdfakes.FakeAPIClient{}
+			epinioClient.API = mockAPIClient
+
+			epinioClient.Settings = &settings.Settings{
+				Namespace: "workspace",
+				User:      "myuser",
+				Password:  "mypassword",
+				API:       "https://epinio.example.com",
+			}
+
+			mockAPIClient.MeReturns(models.MeResponse{
+				User:       "myuser",
+				Namespaces: []string{"workspace"},
+			}, nil)
+		})
+
+		When("the settings are valid", func() {
+			It("reports the user, namespace and certificates", func() {
+				stdout, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mockAPIClient.ReadyCallCount()).To(Equal(1))
+
+				Expect(stdout).To(
+					HaveATable(
+						WithHeaders("CHECK", "STATUS"),
+						WithRow("API Url", "https://epinio.example.com"),
+						WithRow("User", "myuser"),
+						WithRow("Namespace", "workspace"),
+						WithRow("Certificates", "System trust store"),
+					),
+				)
+			})
+
+			It("flags a namespace the user cannot access", func() {
+				epinioClient.Settings.Namespace = "other"
+
+				stdout, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stdout).To(ContainSubstring("other (not accessible)"))
+			})
+		})
+
+		When("there is no API server in the settings", func() {
+			It("fails", func() {
+				epinioClient.Settings.API = ""
+
+				_, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no API server in the settings"))
+				Expect(mockAPIClient.ReadyCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the server is unreachable", func() {
+			It("fails", func() {
+				mockAPIClient.ReadyReturns(&url.Error{Op: "Get", URL: "https://epinio.example.com/ready", Err: errors.New("connection refused")})
+
+				_, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("server 'https://epinio.example.com' unreachable"))
+				Expect(mockAPIClient.MeCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the server certificate is not trusted", func() {
+			It("fails", func() {
+				mockAPIClient.ReadyReturns(&url.Error{Op: "Get", URL: "https://epinio.example.com/ready", Err: x509.UnknownAuthorityError{}})
+
+				_, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("server certificate not trusted"))
+			})
+		})
+
+		When("the server is not ready", func() {
+			It("fails", func() {
+				mockAPIClient.ReadyReturns(&client.APIError{
+					StatusCode: 500,
+					Err: &apierrors.ErrorResponse{
+						Errors: []apierrors.APIError{{Status: 500, Title: "Roles are not yet initialized"}},
+					},
+				})
+
+				_, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("is not ready: Roles are not yet initialized"))
+			})
+		})
+
+		When("the access token is expired", func() {
+			It("fails", func() {
+				mockAPIClient.MeReturns(models.MeResponse{}, fmt.Errorf("%w: refresh failed", client.ErrSessionExpired))
+
+				_, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("access token expired"))
+			})
+		})
+
+		When("the credentials are rejected", func() {
+			It("fails", func() {
+				mockAPIClient.MeReturns(models.MeResponse{}, &client.APIError{StatusCode: 401})
+
+				_, _, err := executeCmd(settingsCmd, []string{"validate"}, output, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("credentials rejected by the server"))
+			})
+		})
 	})
 })
@@ -72,9 +72,13 @@ func NewRootCmd() (*cobra.Command, error) {
 				return errors.Wrap(err, "initializing client")
 			}
 
-			if cfg.Output.String() == "json" {
+			switch cfg.Output.String() {
+			case "json":
 				client.UI().EnableJSON()
 				client.API.DisableVersionWarning()
+			case "yaml":
+				client.UI().EnableYAML()
+				client.API.DisableVersionWarning()
 			}
 
 			for _, header := range flagHeaders {
@@ -13,6 +13,7 @@ package cli
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -24,7 +25,12 @@ import (
 	"time"
 
 	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/cli/server"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/operations"
+	"github.com/epinio/epinio/internal/reaper"
 	"github.com/epinio/epinio/internal/upgraderesponder"
 	"github.com/epinio/epinio/internal/version"
 	"github.com/gin-gonic/gin"
@@ -45,6 +51,12 @@ func init() {
 	err = viper.BindEnv("namespace", "NAMESPACE")
 	checkErr(err)
 
+	flags.String("staging-namespace", "", "(STAGING_NAMESPACE) The namespace of the staging jobs and their volumes. It has to provide the staging scripts, and the registry and S3 secrets used by the jobs. Defaults to the namespace of Epinio.")
+	err = viper.BindPFlag("staging-namespace", flags.Lookup("staging-namespace"))
+	checkErr(err)
+	err = viper.BindEnv("staging-namespace", "STAGING_NAMESPACE")
+	checkErr(err)
+
 	flags.Int("port", 0, "(PORT) The port to listen on. Leave empty to auto-assign a random port")
 	err = viper.BindPFlag("port", flags.Lookup("port"))
 	checkErr(err)
@@ -57,6 +69,30 @@ func init() {
 	err = viper.BindEnv("tls-issuer", "TLS_ISSUER")
 	checkErr(err)
 
+	flags.String("tls-cert-file", "", "(TLS_CERT_FILE) Certificate file for serving the API over HTTPS. Without it the API is served over HTTP, with TLS terminated by the ingress.")
+	err = viper.BindPFlag("tls-cert-file", flags.Lookup("tls-cert-file"))
+	checkErr(err)
+	err = viper.BindEnv("tls-cert-file", "TLS_CERT_FILE")
+	checkErr(err)
+
+	flags.String("tls-key-file", "", "(TLS_KEY_FILE) Private key file for serving the API over HTTPS.")
+	err = viper.BindPFlag("tls-key-file", flags.Lookup("tls-key-file"))
+	checkErr(err)
+	err = viper.BindEnv("tls-key-file", "TLS_KEY_FILE")
+	checkErr(err)
+
+	flags.String("tls-min-version", server.DefaultTLSMinVersion, "(TLS_MIN_VERSION) Minimum TLS version accepted over HTTPS, 1.2 or 1.3.")
+	err = viper.BindPFlag("tls-min-version", flags.Lookup("tls-min-version"))
+	checkErr(err)
+	err = viper.BindEnv("tls-min-version", "TLS_MIN_VERSION")
+	checkErr(err)
+
+	flags.StringSlice("tls-cipher-suites", server.DefaultTLSCipherSuites, "(TLS_CIPHER_SUITES) TLS 1.2 cipher suites accepted over HTTPS (comma separated). Insecure cipher suites are rejected.")
+	err = viper.BindPFlag("tls-cipher-suites", flags.Lookup("tls-cipher-suites"))
+	checkErr(err)
+	err = viper.BindEnv("tls-cipher-suites", "TLS_CIPHER_SUITES")
+	checkErr(err)
+
 	flags.String("access-control-allow-origin", "", "(ACCESS_CONTROL_ALLOW_ORIGIN) Domains allowed to use the API")
 	err = viper.BindPFlag("access-control-allow-origin", flags.Lookup("access-control-allow-origin"))
 	checkErr(err)
@@ -81,18 +117,138 @@ func init() {
 	err = viper.BindEnv("ingress-class-name", "INGRESS_CLASS_NAME")
 	checkErr(err)
 
+	flags.StringSlice("ingress-classes", []string{}, "(INGRESS_CLASSES) Ingress classes applications are allowed to choose (comma separated). Leave empty to allow any ingress class of the cluster.")
+	err = viper.BindPFlag("ingress-classes", flags.Lookup("ingress-classes"))
+	checkErr(err)
+	err = viper.BindEnv("ingress-classes", "INGRESS_CLASSES")
+	checkErr(err)
+
+	flags.Int("revision-history-limit", 10, "(REVISION_HISTORY_LIMIT) Number of old ReplicaSets kept for rollbacks of applications which do not set their own. A negative number keeps the default of the application chart.")
+	err = viper.BindPFlag("revision-history-limit", flags.Lookup("revision-history-limit"))
+	checkErr(err)
+	err = viper.BindEnv("revision-history-limit", "REVISION_HISTORY_LIMIT")
+	checkErr(err)
+
+	flags.Int("saturation-requests", 0, "(SATURATION_REQUESTS) Number of concurrently served requests at which the server reports itself as saturated. Zero disables the check. Does not affect readiness.")
+	err = viper.BindPFlag("saturation-requests", flags.Lookup("saturation-requests"))
+	checkErr(err)
+	err = viper.BindEnv("saturation-requests", "SATURATION_REQUESTS")
+	checkErr(err)
+
+	flags.String("debug-image", "busybox:stable", "(DEBUG_IMAGE) Container image of the ephemeral containers used to debug applications. Leave empty to disable debugging.")
+	err = viper.BindPFlag("debug-image", flags.Lookup("debug-image"))
+	checkErr(err)
+	err = viper.BindEnv("debug-image", "DEBUG_IMAGE")
+	checkErr(err)
+
 	flags.String("app-image-exporter", "", "(APP_IMAGE_EXPORTER) Name of the container image used to download the application image from the 'export' API.")
 	err = viper.BindPFlag("app-image-exporter", flags.Lookup("app-image-exporter"))
 	checkErr(err)
 	err = viper.BindEnv("app-image-exporter", "APP_IMAGE_EXPORTER")
 	checkErr(err)
 
+	flags.String("image-copy-format", "", "(IMAGE_COPY_FORMAT) Manifest format of images exported to a registry, one of oci, v2s2, or v2s1. Default is to keep the format of the source.")
+	err = viper.BindPFlag("image-copy-format", flags.Lookup("image-copy-format"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-format", "IMAGE_COPY_FORMAT")
+	checkErr(err)
+
+	flags.String("image-copy-compress-format", "", "(IMAGE_COPY_COMPRESS_FORMAT) Layer compression of images exported to a registry, one of gzip, zstd, or zstd:chunked. Default is to keep the compression of the source.")
+	err = viper.BindPFlag("image-copy-compress-format", flags.Lookup("image-copy-compress-format"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-compress-format", "IMAGE_COPY_COMPRESS_FORMAT")
+	checkErr(err)
+
+	flags.Int("image-copy-compress-level", 0, "(IMAGE_COPY_COMPRESS_LEVEL) Layer compression level of images exported to a registry. Zero uses the default level of the compression format.")
+	err = viper.BindPFlag("image-copy-compress-level", flags.Lookup("image-copy-compress-level"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-compress-level", "IMAGE_COPY_COMPRESS_LEVEL")
+	checkErr(err)
+
+	flags.Duration("image-copy-timeout", 10*time.Minute, "(IMAGE_COPY_TIMEOUT) Maximum run time of the jobs copying application images, for download and export. Jobs running longer are failed. Zero is no limit.")
+	err = viper.BindPFlag("image-copy-timeout", flags.Lookup("image-copy-timeout"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-timeout", "IMAGE_COPY_TIMEOUT")
+	checkErr(err)
+
+	flags.String("image-copy-affinity", "required", "(IMAGE_COPY_AFFINITY) Placement of the jobs copying application images on the node of the Epinio server, one of required, or preferred. Use preferred only with an export volume accessible from all nodes.")
+	err = viper.BindPFlag("image-copy-affinity", flags.Lookup("image-copy-affinity"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-affinity", "IMAGE_COPY_AFFINITY")
+	checkErr(err)
+
+	flags.String("image-copy-cpu-request", "100m", "(IMAGE_COPY_CPU_REQUEST) CPU requested by the jobs copying application images. Empty requests nothing.")
+	err = viper.BindPFlag("image-copy-cpu-request", flags.Lookup("image-copy-cpu-request"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-cpu-request", "IMAGE_COPY_CPU_REQUEST")
+	checkErr(err)
+
+	flags.String("image-copy-memory-request", "128Mi", "(IMAGE_COPY_MEMORY_REQUEST) Memory requested by the jobs copying application images. Empty requests nothing.")
+	err = viper.BindPFlag("image-copy-memory-request", flags.Lookup("image-copy-memory-request"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-memory-request", "IMAGE_COPY_MEMORY_REQUEST")
+	checkErr(err)
+
+	flags.String("image-copy-cpu-limit", "1", "(IMAGE_COPY_CPU_LIMIT) CPU limit of the jobs copying application images. Empty is no limit.")
+	err = viper.BindPFlag("image-copy-cpu-limit", flags.Lookup("image-copy-cpu-limit"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-cpu-limit", "IMAGE_COPY_CPU_LIMIT")
+	checkErr(err)
+
+	flags.String("image-copy-memory-limit", "1Gi", "(IMAGE_COPY_MEMORY_LIMIT) Memory limit of the jobs copying application images. Empty is no limit.")
+	err = viper.BindPFlag("image-copy-memory-limit", flags.Lookup("image-copy-memory-limit"))
+	checkErr(err)
+	err = viper.BindEnv("image-copy-memory-limit", "IMAGE_COPY_MEMORY_LIMIT")
+	checkErr(err)
+
+	flags.String("node-pool-label", "", "(NODE_POOL_LABEL) Node label identifying the node pool of a node, as in 'cloud.google.com/gke-nodepool'. Leave empty to disable the node pool placement of applications.")
+	err = viper.BindPFlag("node-pool-label", flags.Lookup("node-pool-label"))
+	checkErr(err)
+	err = viper.BindEnv("node-pool-label", "NODE_POOL_LABEL")
+	checkErr(err)
+
+	flags.StringSlice("node-pools", []string{}, "(NODE_POOLS) Node pools applications are allowed to be placed in (comma separated). Leave empty to allow any pool.")
+	err = viper.BindPFlag("node-pools", flags.Lookup("node-pools"))
+	checkErr(err)
+	err = viper.BindEnv("node-pools", "NODE_POOLS")
+	checkErr(err)
+
+	flags.Int("staging-retries", 0, "(STAGING_RETRIES) Number of automatic re-attempts of a failed staging build before the staging is reported as failed. Each attempt is a pod of the staging job.")
+	err = viper.BindPFlag("staging-retries", flags.Lookup("staging-retries"))
+	checkErr(err)
+	err = viper.BindEnv("staging-retries", "STAGING_RETRIES")
+	checkErr(err)
+
+	flags.Int("session-limit", 0, "(SESSION_LIMIT) Maximum number of concurrent interactive sessions, i.e. exec and port-forward, across all users. Zero means unlimited.")
+	err = viper.BindPFlag("session-limit", flags.Lookup("session-limit"))
+	checkErr(err)
+	err = viper.BindEnv("session-limit", "SESSION_LIMIT")
+	checkErr(err)
+
+	flags.Int("session-user-limit", 0, "(SESSION_USER_LIMIT) Maximum number of concurrent interactive sessions of a single user. Zero means unlimited.")
+	err = viper.BindPFlag("session-user-limit", flags.Lookup("session-user-limit"))
+	checkErr(err)
+	err = viper.BindEnv("session-user-limit", "SESSION_USER_LIMIT")
+	checkErr(err)
+
+	flags.Duration("session-queue-timeout", 0, "(SESSION_QUEUE_TIMEOUT) Time an interactive session beyond the limits waits for a free slot before it is rejected. Zero rejects it immediately.")
+	err = viper.BindPFlag("session-queue-timeout", flags.Lookup("session-queue-timeout"))
+	checkErr(err)
+	err = viper.BindEnv("session-queue-timeout", "SESSION_QUEUE_TIMEOUT")
+	checkErr(err)
+
 	flags.String("default-builder-image", "", "(DEFAULT_BUILDER_IMAGE) Name of the container image used to build images from staged sources.")
 	err = viper.BindPFlag("default-builder-image", flags.Lookup("default-builder-image"))
 	checkErr(err)
 	err = viper.BindEnv("default-builder-image", "DEFAULT_BUILDER_IMAGE")
 	checkErr(err)
 
+	flags.StringSlice("language-builders", []string{}, "(LANGUAGE_BUILDERS) Builder images to use for sources containing a file, as `file=image` (comma separated, first match wins), e.g. `go.mod=paketobuildpacks/builder-jammy-tiny`. Used when neither the app nor the request names a builder. Falls back to the default builder image.")
+	err = viper.BindPFlag("language-builders", flags.Lookup("language-builders"))
+	checkErr(err)
+	err = viper.BindEnv("language-builders", "LANGUAGE_BUILDERS")
+	checkErr(err)
+
 	flags.Bool("disable-tracking", false, "(DISABLE_TRACKING) Disable tracking of the running Epinio and Kubernetes versions")
 	err = viper.BindPFlag("disable-tracking", flags.Lookup("disable-tracking"))
 	checkErr(err)
@@ -117,6 +273,54 @@ func init() {
 	err = viper.BindEnv("kube-api-burst", "KUBE_API_BURST")
 	checkErr(err)
 
+	flags.Int("metrics-concurrency", 8, "(METRICS_CONCURRENCY) Maximum number of applications whose replica metrics are queried at the same time by the bulk status and list endpoints.")
+	err = viper.BindPFlag("metrics-concurrency", flags.Lookup("metrics-concurrency"))
+	checkErr(err)
+	err = viper.BindEnv("metrics-concurrency", "METRICS_CONCURRENCY")
+	checkErr(err)
+
+	flags.Duration("metrics-app-timeout", 5*time.Second, "(METRICS_APP_TIMEOUT) Maximum time to query the replica metrics of a single application in the bulk status and list endpoints. Slower applications are reported without metrics. Zero is no limit.")
+	err = viper.BindPFlag("metrics-app-timeout", flags.Lookup("metrics-app-timeout"))
+	checkErr(err)
+	err = viper.BindEnv("metrics-app-timeout", "METRICS_APP_TIMEOUT")
+	checkErr(err)
+
+	flags.Duration("logs-ping-interval", 30*time.Second, "(LOGS_PING_INTERVAL) Interval between websocket pings on log streams, keeping idle connections alive. Clients not answering within two intervals are disconnected. Zero disables the pings.")
+	err = viper.BindPFlag("logs-ping-interval", flags.Lookup("logs-ping-interval"))
+	checkErr(err)
+	err = viper.BindEnv("logs-ping-interval", "LOGS_PING_INTERVAL")
+	checkErr(err)
+
+	flags.Int("logs-flush-lines", 100, "(LOGS_FLUSH_LINES) Number of buffered log lines triggering their sending to the client.")
+	err = viper.BindPFlag("logs-flush-lines", flags.Lookup("logs-flush-lines"))
+	checkErr(err)
+	err = viper.BindEnv("logs-flush-lines", "LOGS_FLUSH_LINES")
+	checkErr(err)
+
+	flags.Duration("logs-flush-interval", 100*time.Millisecond, "(LOGS_FLUSH_INTERVAL) Maximum time log lines are buffered before they are sent to the client.")
+	err = viper.BindPFlag("logs-flush-interval", flags.Lookup("logs-flush-interval"))
+	checkErr(err)
+	err = viper.BindEnv("logs-flush-interval", "LOGS_FLUSH_INTERVAL")
+	checkErr(err)
+
+	flags.Int("logs-buffer-lines", 10000, "(LOGS_BUFFER_LINES) Maximum number of log lines buffered for a slow client. Further lines are skipped, and the client is told about it.")
+	err = viper.BindPFlag("logs-buffer-lines", flags.Lookup("logs-buffer-lines"))
+	checkErr(err)
+	err = viper.BindEnv("logs-buffer-lines", "LOGS_BUFFER_LINES")
+	checkErr(err)
+
+	flags.Int("app-history-limit", application.DefaultHistoryLimit, "(APP_HISTORY_LIMIT) Number of deploy revisions retained per application. Zero disables the deploy history.")
+	err = viper.BindPFlag("app-history-limit", flags.Lookup("app-history-limit"))
+	checkErr(err)
+	err = viper.BindEnv("app-history-limit", "APP_HISTORY_LIMIT")
+	checkErr(err)
+
+	flags.Duration("expiry-reaper-interval", 5*time.Minute, "(EXPIRY_REAPER_INTERVAL) Interval between checks for expired applications and services. Zero disables the automatic expiry. With several replicas only the holder of the epinio-expiry-reaper Lease checks.")
+	err = viper.BindPFlag("expiry-reaper-interval", flags.Lookup("expiry-reaper-interval"))
+	checkErr(err)
+	err = viper.BindEnv("expiry-reaper-interval", "EXPIRY_REAPER_INTERVAL")
+	checkErr(err)
+
 	version.ChartVersion = os.Getenv("CHART_VERSION")
 	if !strings.HasPrefix(version.ChartVersion, "v") {
 		version.ChartVersion = "v" + version.ChartVersion
@@ -137,6 +341,11 @@ var CmdServer = &cobra.Command{
 			}
 		}
 
+		err := checkStagingNamespace(cmd.Context())
+		if err != nil {
+			return err
+		}
+
 		handler, err := server.NewHandler()
 		if err != nil {
 			return errors.Wrap(err, "error creating handler")
@@ -150,6 +359,26 @@ var CmdServer = &cobra.Command{
 
 		helpers.Logger.Infow("Epinio version", "version", version.Version)
 		listeningPort := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+
+		certFile := viper.GetString("tls-cert-file")
+		if certFile != "" {
+			tlsConfig, err := server.NewTLSConfig(
+				certFile,
+				viper.GetString("tls-key-file"),
+				viper.GetString("tls-min-version"),
+				viper.GetStringSlice("tls-cipher-suites"),
+			)
+			if err != nil {
+				return errors.Wrap(err, "error configuring TLS")
+			}
+
+			listener = tls.NewListener(listener, tlsConfig)
+			helpers.Logger.Infow("serving HTTPS",
+				"min_version", viper.GetString("tls-min-version"),
+				"cipher_suites", viper.GetStringSlice("tls-cipher-suites"),
+			)
+		}
+
 		helpers.Logger.Infow("listening on localhost", "port", listeningPort)
 
 		trackingDisabled := viper.GetBool("disable-tracking")
@@ -177,10 +406,71 @@ var CmdServer = &cobra.Command{
 			defer checker.Stop()
 		}
 
+		stopOperations, err := startOperationsStore(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer stopOperations()
+
+		reaperInterval := viper.GetDuration("expiry-reaper-interval")
+		helpers.Logger.Infow("expiry reaper", "interval", reaperInterval)
+
+		if reaperInterval > 0 {
+			expiryReaper := reaper.New(reaperInterval)
+			expiryReaper.Start()
+			defer expiryReaper.Stop()
+		}
+
 		return startServerGracefully(listener, handler)
 	},
 }
 
+// operationsSyncInterval is the interval at which the server checks for its operations
+// cancelled through another replica.
+const operationsSyncInterval = 5 * time.Second
+
+// startOperationsStore records the long-running operations of the server in the cluster, where
+// all replicas list and cancel them. The returned function stops the syncing of the operations.
+func startOperationsStore(ctx context.Context) (func(), error) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting access to the cluster")
+	}
+
+	store, err := operations.NewSecretStore(ctx, cluster.Kubectl, helmchart.Namespace())
+	if err != nil {
+		return nil, errors.Wrap(err, "setting up the operations store")
+	}
+
+	operations.Default.UseStore(store)
+	return operations.Default.Sync(operationsSyncInterval), nil
+}
+
+// checkStagingNamespace ensures that the namespace configured for the staging jobs exists. The
+// namespace of Epinio itself is not checked, the server runs in it.
+func checkStagingNamespace(ctx context.Context) error {
+	namespace := helmchart.StagingNamespace()
+	if namespace == helmchart.Namespace() {
+		return nil
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting access to the cluster")
+	}
+
+	exists, err := cluster.NamespaceExists(ctx, namespace)
+	if err != nil {
+		return errors.Wrapf(err, "checking staging namespace %s", namespace)
+	}
+	if !exists {
+		return fmt.Errorf("staging namespace %s does not exist", namespace)
+	}
+
+	helpers.Logger.Infow("staging namespace", "namespace", namespace)
+	return nil
+}
+
 // startServerGracefully will start the server and will wait for a graceful shutdown
 func startServerGracefully(listener net.Listener, handler http.Handler) error {
 	srv := &http.Server{
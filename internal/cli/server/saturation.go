@@ -0,0 +1,46 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/epinio/epinio/internal/api/v1/middleware"
+	"github.com/epinio/epinio/internal/sessions"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// saturationHandler reports the load of the server. It always responds with status 200. It is
+// an indicator for operators and autoscalers, not a probe. Load balancers have to use `/ready`,
+// which does not depend on load. Access requires admin credentials, e.g. an API token. The
+// request for the report counts as in flight itself.
+func saturationHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, Saturation(
+		middleware.InFlightRequests(), viper.GetInt("saturation-requests"),
+		sessions.Default.Stats(), viper.GetInt("session-limit")))
+}
+
+// Saturation computes the load of the server from the requests currently served and the
+// active interactive sessions. The server is saturated when either reaches its limit. A zero
+// limit never saturates.
+func Saturation(inFlight int64, requestLimit int, stats sessions.Stats, sessionLimit int) models.ServerSaturation {
+	return models.ServerSaturation{
+		InFlightRequests: inFlight,
+		RequestLimit:     requestLimit,
+		ActiveSessions:   stats.Active,
+		SessionLimit:     sessionLimit,
+		Saturated: (requestLimit > 0 && inFlight >= int64(requestLimit)) ||
+			(sessionLimit > 0 && stats.Active >= sessionLimit),
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"github.com/epinio/epinio/internal/cli/server"
+	"github.com/epinio/epinio/internal/sessions"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Saturation", func() {
+	It("is not saturated without limits", func() {
+		saturation := server.Saturation(100, 0, sessions.Stats{Active: 50}, 0)
+		Expect(saturation.Saturated).To(BeFalse())
+		Expect(saturation.InFlightRequests).To(Equal(int64(100)))
+		Expect(saturation.ActiveSessions).To(Equal(50))
+	})
+
+	It("is saturated when the requests reach their limit", func() {
+		Expect(server.Saturation(9, 10, sessions.Stats{}, 0).Saturated).To(BeFalse())
+		Expect(server.Saturation(10, 10, sessions.Stats{}, 0).Saturated).To(BeTrue())
+	})
+
+	It("is saturated when the sessions reach their limit", func() {
+		Expect(server.Saturation(0, 0, sessions.Stats{Active: 4}, 5).Saturated).To(BeFalse())
+		Expect(server.Saturation(0, 0, sessions.Stats{Active: 5}, 5).Saturated).To(BeTrue())
+	})
+})
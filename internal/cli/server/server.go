@@ -46,6 +46,7 @@ func NewHandler() (*gin.Engine, error) {
 	// | ---               | ---        | ----
 	// | <Root>/...        | API        | Via "<Root>" Group
 	// | /ready            | L/R Probes |
+	// | /saturation       | Load, admin | Yes
 	// | /namespaces/target/:namespace | ditto      | ditto
 
 	// Use gin.New() instead of gin.Default() to avoid gin's default logger
@@ -78,7 +79,9 @@ func NewHandler() (*gin.Engine, error) {
 	}
 
 	// Register routes - No authentication, no logging, no session.
-	// This is the healthcheck.
+	// This is the healthcheck. It reflects only whether the server is able to serve requests,
+	// never its load. Heavy staging or import activity must not take the server out of the
+	// rotation of load balancers.
 	router.GET("/ready", func(c *gin.Context) {
 		if rolesInitialized {
 			c.JSON(http.StatusOK, gin.H{})
@@ -96,6 +99,15 @@ func NewHandler() (*gin.Engine, error) {
 		middleware.GinLogger(),
 		middleware.Recovery,
 		middleware.InitContext(),
+		middleware.InFlight,
+	)
+
+	// The load indicator, separate from the healthcheck. It reveals the activity on the
+	// server, and is thus restricted to admins.
+	router.GET("/saturation",
+		middleware.Authentication,
+		middleware.AdminAuthorization,
+		saturationHandler,
 	)
 
 	// No authentication, no session. This is epinio's version and auth information.
@@ -0,0 +1,105 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultTLSMinVersion is the minimum TLS version accepted by the server, if not configured
+const DefaultTLSMinVersion = "1.2"
+
+// DefaultTLSCipherSuites are the cipher suites accepted by the server for TLS 1.2, if not
+// configured. These provide forward secrecy and authenticated encryption. The cipher suites of
+// TLS 1.3 are not configurable.
+var DefaultTLSCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// NewTLSConfig returns the TLS configuration of the server for the certificate and key files,
+// the minimum TLS version, and the names of the accepted cipher suites. Versions older than 1.2
+// and insecure cipher suites are rejected.
+func NewTLSConfig(certFile, keyFile, minVersion string, cipherSuites []string) (*tls.Config, error) {
+	version, ok := tlsVersions[minVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported minimum TLS version '%s', expected one of 1.2, 1.3", minVersion)
+	}
+
+	suites, err := ParseCipherSuites(cipherSuites)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading TLS certificate")
+	}
+
+	return &tls.Config{
+		MinVersion:   version,
+		CipherSuites: suites,
+		Certificates: []tls.Certificate{certificate},
+	}, nil
+}
+
+// ParseCipherSuites converts the names of cipher suites into their ids. Only the secure cipher
+// suites known to Go are accepted.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	known := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	insecure := map[string]bool{}
+	for _, suite := range tls.InsecureCipherSuites() {
+		insecure[suite.Name] = true
+	}
+
+	result := []uint16{}
+	for _, entry := range names {
+		// Values from the environment arrive as a single comma separated string
+		for _, name := range strings.Split(entry, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if insecure[name] {
+				return nil, fmt.Errorf("insecure TLS cipher suite '%s' not allowed", name)
+			}
+			id, ok := known[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown TLS cipher suite '%s'", name)
+			}
+			result = append(result, id)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no TLS cipher suites specified")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/epinio/epinio/internal/cli/server"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// writeCertificate creates a self-signed certificate and key for localhost, and returns the
+// paths of the files holding them.
+func writeCertificate(dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)).To(Succeed())
+
+	return certFile, keyFile
+}
+
+// handshake connects to the listener with the client configuration, and returns the result of
+// the TLS handshake.
+func handshake(listener net.Listener, config *tls.Config) error {
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), config)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+var _ = Describe("TLS configuration", func() {
+	Describe("ParseCipherSuites", func() {
+		It("accepts the default cipher suites", func() {
+			suites, err := server.ParseCipherSuites(server.DefaultTLSCipherSuites)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(suites).To(HaveLen(len(server.DefaultTLSCipherSuites)))
+		})
+
+		It("accepts a comma separated list", func() {
+			suites, err := server.ParseCipherSuites([]string{
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(suites).To(Equal([]uint16{
+				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			}))
+		})
+
+		It("rejects insecure, unknown, and missing cipher suites", func() {
+			_, err := server.ParseCipherSuites([]string{"TLS_RSA_WITH_RC4_128_SHA"})
+			Expect(err).To(MatchError(ContainSubstring("insecure")))
+
+			_, err = server.ParseCipherSuites([]string{"TLS_BOGUS"})
+			Expect(err).To(MatchError(ContainSubstring("unknown")))
+
+			_, err = server.ParseCipherSuites([]string{""})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("NewTLSConfig", func() {
+		var certFile, keyFile string
+
+		BeforeEach(func() {
+			certFile, keyFile = writeCertificate(GinkgoT().TempDir())
+		})
+
+		It("rejects versions before 1.2", func() {
+			_, err := server.NewTLSConfig(certFile, keyFile, "1.1", server.DefaultTLSCipherSuites)
+			Expect(err).To(MatchError(ContainSubstring("unsupported minimum TLS version")))
+		})
+
+		It("rejects clients below the minimum version", func() {
+			config, err := server.NewTLSConfig(certFile, keyFile, "1.3", server.DefaultTLSCipherSuites)
+			Expect(err).ToNot(HaveOccurred())
+
+			listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+
+			// #nosec G402 -- the test certificate is self-signed
+			err = handshake(listener, &tls.Config{InsecureSkipVerify: true, MaxVersion: tls.VersionTLS12})
+			Expect(err).To(HaveOccurred())
+
+			// #nosec G402 -- the test certificate is self-signed
+			err = handshake(listener, &tls.Config{InsecureSkipVerify: true})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("rejects clients without an accepted cipher suite", func() {
+			config, err := server.NewTLSConfig(certFile, keyFile, "1.2",
+				[]string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"})
+			Expect(err).ToNot(HaveOccurred())
+
+			listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+
+			// #nosec G402 -- the test certificate is self-signed
+			err = handshake(listener, &tls.Config{
+				InsecureSkipVerify: true,
+				MaxVersion:         tls.VersionTLS12,
+				CipherSuites:       []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+			})
+			Expect(err).To(HaveOccurred())
+
+			// #nosec G402 -- the test certificate is self-signed
+			err = handshake(listener, &tls.Config{
+				InsecureSkipVerify: true,
+				MaxVersion:         tls.VersionTLS12,
+				CipherSuites:       []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -18,6 +18,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -34,8 +36,16 @@ import (
 
 var (
 	defaultSettingsFilePath = "epinio/settings.yaml"
+
+	// contextNameRegex restricts context names to lowercase DNS labels, as viper keys are
+	// case-insensitive and split at dots
+	contextNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
 )
 
+// DefaultContext is the name of the context holding the settings of files written before named
+// contexts existed
+const DefaultContext = "default"
+
 // Settings represents a epinio settings
 type Settings struct {
 	Namespace string       `mapstructure:"namespace"` // Currently targeted namespace
@@ -48,6 +58,10 @@ type Settings struct {
 	Colors    bool         `mapstructure:"colors"`
 	AppChart  string       `mapstructure:"appchart"` // Current default app chart (name)
 
+	// Context is the name of the active context. The fields above hold its values.
+	Context  string                    `mapstructure:"current-context"`
+	Contexts map[string]ContextSetting `mapstructure:"contexts"`
+
 	Location string // Origin of data, file which was loaded
 
 	v   *viper.Viper
@@ -61,6 +75,19 @@ type TokenSetting struct {
 	Expiry       time.Time `json:"expiry,omitempty" mapstructure:"expiry,omitempty"`
 }
 
+// ContextSetting holds the server location and credentials of a named context, as stored in
+// the settings file
+type ContextSetting struct {
+	Namespace string       `mapstructure:"namespace"`
+	User      string       `mapstructure:"user"`
+	Password  string       `mapstructure:"pass"` // base64 encoded
+	Token     TokenSetting `mapstructure:"token"`
+	API       string       `mapstructure:"api"`
+	WSS       string       `mapstructure:"wss"`
+	Certs     string       `mapstructure:"certs"`
+	AppChart  string       `mapstructure:"appchart"`
+}
+
 // DefaultLocation returns the standard location for the settings file
 func DefaultLocation() (string, error) {
 	return xdg.ConfigFile(defaultSettingsFilePath)
@@ -95,6 +122,7 @@ func LoadFrom(file string) (*Settings, error) {
 	v.SetDefault("wss", "")
 	v.SetDefault("certs", "")
 	v.SetDefault("colors", true)
+	v.SetDefault("current-context", DefaultContext)
 
 	settingsExists, err := fileExists(file)
 	if err != nil {
@@ -106,6 +134,17 @@ func LoadFrom(file string) (*Settings, error) {
 		if err := v.ReadInConfig(); err != nil {
 			return nil, errors.Wrapf(err, "failed to read settings file '%s'", file)
 		}
+
+		// Files with named contexts store the per-context keys under the current
+		// context. Make its values the defaults of the top-level keys, so that
+		// environment variables still override them.
+		if contexts, ok := v.Get("contexts").(map[string]interface{}); ok {
+			if current, ok := contexts[v.GetString("current-context")].(map[string]interface{}); ok {
+				for key, value := range current {
+					v.SetDefault(key, value)
+				}
+			}
+		}
 	}
 	v.AutomaticEnv()
 
@@ -114,6 +153,10 @@ func LoadFrom(file string) (*Settings, error) {
 		return nil, errors.Wrap(err, "failed to unmarshal settings file")
 	}
 
+	if cfg.Contexts == nil {
+		cfg.Contexts = map[string]ContextSetting{}
+	}
+
 	cfg.v = v
 
 	if cfg.Certs != "" {
@@ -177,32 +220,125 @@ func LoadFrom(file string) (*Settings, error) {
 // String generates a string representation of the settings (for debugging)
 func (c *Settings) String() string {
 	return fmt.Sprintf(
-		"namespace=(%s), user=(%s), pass=(%s), access_token=(%v), api=(%s), wss=(%s), color=(%v), appchart=(%v), @(%s)",
-		c.Namespace, c.User, c.Password, c.Token, c.API, c.WSS, c.Colors, c.AppChart, c.Location)
+		"context=(%s), namespace=(%s), user=(%s), pass=(%s), access_token=(%v), api=(%s), wss=(%s), color=(%v), appchart=(%v), @(%s)",
+		c.Context, c.Namespace, c.User, c.Password, c.Token, c.API, c.WSS, c.Colors, c.AppChart, c.Location)
+}
+
+// ContextNames returns the sorted names of all contexts, including the active one
+func (c *Settings) ContextNames() []string {
+	names := []string{}
+	found := false
+	for name := range c.Contexts {
+		names = append(names, name)
+		found = found || name == c.Context
+	}
+	if !found {
+		names = append(names, c.Context)
+	}
+
+	sort.Strings(names)
+	return names
 }
 
-// Save saves the Epinio settings
+// UseContext makes the named context the active one. The values of the previously active
+// context are kept in memory, and stored with the next Save. With create set a missing
+// context is created empty, otherwise it is an error.
+func (c *Settings) UseContext(name string, create bool) error {
+	if !contextNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid context name '%s', it must consist of lower case alphanumeric characters or '-', and start and end with an alphanumeric character", name)
+	}
+
+	next, found := c.Contexts[name]
+	if !found && name != c.Context && !create {
+		return fmt.Errorf("context '%s' does not exist", name)
+	}
+	if name == c.Context {
+		return nil
+	}
+
+	if c.Contexts == nil {
+		c.Contexts = map[string]ContextSetting{}
+	}
+	c.Contexts[c.Context] = c.currentContext()
+
+	if next.Namespace == "" {
+		next.Namespace = "workspace"
+	}
+	password, err := base64.StdEncoding.DecodeString(next.Password)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode the password of context '%s'", name)
+	}
+
+	c.Context = name
+	c.Namespace = next.Namespace
+	c.User = next.User
+	c.Password = string(password)
+	c.Token = next.Token
+	c.API = next.API
+	c.WSS = next.WSS
+	c.Certs = next.Certs
+	c.AppChart = next.AppChart
+
+	return nil
+}
+
+// currentContext returns the values of the active context, in their stored form
+func (c *Settings) currentContext() ContextSetting {
+	return ContextSetting{
+		Namespace: c.Namespace,
+		User:      c.User,
+		Password:  base64.StdEncoding.EncodeToString([]byte(c.Password)),
+		Token:     c.Token,
+		API:       c.API,
+		WSS:       c.WSS,
+		Certs:     c.Certs,
+		AppChart:  c.AppChart,
+	}
+}
+
+// Save saves the Epinio settings. The values of the active context are stored under its name.
+// Files written before named contexts existed are migrated to the context format, with their
+// values in the "default" context.
 func (c *Settings) Save() error {
-	c.v.Set("namespace", c.Namespace)
-	c.v.Set("appchart", c.AppChart)
-	c.v.Set("user", c.User)
-	c.v.Set("pass", base64.StdEncoding.EncodeToString([]byte(c.Password)))
-	c.v.Set("token", c.Token)
-	c.v.Set("api", c.API)
-	c.v.Set("wss", c.WSS)
-	c.v.Set("certs", c.Certs)
-	c.v.Set("colors", c.Colors)
-
-	c.log.Info("Saving", "to", c.v.ConfigFileUsed())
-
-	err := os.MkdirAll(filepath.Dir(c.v.ConfigFileUsed()), 0700)
+	if c.Contexts == nil {
+		c.Contexts = map[string]ContextSetting{}
+	}
+	c.Contexts[c.Context] = c.currentContext()
+
+	contexts := map[string]interface{}{}
+	for name, context := range c.Contexts {
+		contexts[name] = map[string]interface{}{
+			"namespace": context.Namespace,
+			"user":      context.User,
+			"pass":      context.Password,
+			"token":     context.Token,
+			"api":       context.API,
+			"wss":       context.WSS,
+			"certs":     context.Certs,
+			"appchart":  context.AppChart,
+		}
+	}
+
+	// A fresh viper instance drops the top-level context keys of the old format, and the
+	// defaults of the loading instance
+	file := c.v.ConfigFileUsed()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetConfigFile(file)
+	v.Set("colors", c.Colors)
+	v.Set("current-context", c.Context)
+	v.Set("contexts", contexts)
+
+	c.log.Info("Saving", "to", file)
+
+	err := os.MkdirAll(filepath.Dir(file), 0700)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create settings dir '%s'", filepath.Dir(c.v.ConfigFileUsed()))
+		return errors.Wrapf(err, "failed to create settings dir '%s'", filepath.Dir(file))
 	}
 
-	err = c.v.WriteConfig()
+	err = v.WriteConfig()
 	if err != nil {
-		return errors.Wrapf(err, "failed to write settings file '%s'", c.v.ConfigFileUsed())
+		return errors.Wrapf(err, "failed to write settings file '%s'", file)
 	}
 
 	c.log.Info("Saved", "value", c.String())
@@ -0,0 +1,152 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/epinio/epinio/internal/cli/settings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Settings", func() {
+	var file string
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "settings.yaml")
+	})
+
+	readFile := func() map[string]interface{} {
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+
+		content := map[string]interface{}{}
+		Expect(yaml.Unmarshal(data, &content)).To(Succeed())
+		return content
+	}
+
+	When("the file has no contexts", func() {
+		BeforeEach(func() {
+			Expect(os.WriteFile(file, []byte(`
+api: https://epinio.dev.example.com
+wss: wss://epinio.dev.example.com
+user: admin
+pass: cGFzc3dvcmQ=
+namespace: myspace
+colors: false
+`), 0600)).To(Succeed())
+		})
+
+		It("loads the values into the default context", func() {
+			cfg, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.Context).To(Equal(settings.DefaultContext))
+			Expect(cfg.API).To(Equal("https://epinio.dev.example.com"))
+			Expect(cfg.Password).To(Equal("password"))
+			Expect(cfg.Namespace).To(Equal("myspace"))
+			Expect(cfg.ContextNames()).To(Equal([]string{settings.DefaultContext}))
+		})
+
+		It("migrates the file to contexts on save", func() {
+			cfg, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.Save()).To(Succeed())
+
+			content := readFile()
+			Expect(content).ToNot(HaveKey("api"))
+			Expect(content).ToNot(HaveKey("namespace"))
+			Expect(content).To(HaveKeyWithValue("current-context", settings.DefaultContext))
+			Expect(content).To(HaveKeyWithValue("colors", false))
+			Expect(content["contexts"]).To(HaveKey(settings.DefaultContext))
+
+			cfg, err = settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.API).To(Equal("https://epinio.dev.example.com"))
+			Expect(cfg.User).To(Equal("admin"))
+			Expect(cfg.Password).To(Equal("password"))
+			Expect(cfg.Namespace).To(Equal("myspace"))
+			Expect(cfg.Colors).To(BeFalse())
+		})
+	})
+
+	When("the file has several contexts", func() {
+		BeforeEach(func() {
+			cfg, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+
+			cfg.API = "https://epinio.dev.example.com"
+			cfg.User = "dev-user"
+			cfg.Password = "dev-password"
+			Expect(cfg.Save()).To(Succeed())
+
+			Expect(cfg.UseContext("prod", true)).To(Succeed())
+			cfg.API = "https://epinio.prod.example.com"
+			cfg.Token = settings.TokenSetting{
+				AccessToken: "prod-token",
+				Expiry:      time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+			}
+			Expect(cfg.Save()).To(Succeed())
+		})
+
+		It("loads the values of the current context", func() {
+			cfg, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.Context).To(Equal("prod"))
+			Expect(cfg.ContextNames()).To(Equal([]string{settings.DefaultContext, "prod"}))
+			Expect(cfg.API).To(Equal("https://epinio.prod.example.com"))
+			Expect(cfg.User).To(BeEmpty())
+			Expect(cfg.Namespace).To(Equal("workspace"))
+			Expect(cfg.Token.AccessToken).To(Equal("prod-token"))
+			Expect(cfg.Token.Expiry.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC))).To(BeTrue())
+		})
+
+		It("switches to another context", func() {
+			cfg, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cfg.UseContext(settings.DefaultContext, false)).To(Succeed())
+			Expect(cfg.API).To(Equal("https://epinio.dev.example.com"))
+			Expect(cfg.Password).To(Equal("dev-password"))
+			Expect(cfg.Token.AccessToken).To(BeEmpty())
+			Expect(cfg.Save()).To(Succeed())
+
+			cfg, err = settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.Context).To(Equal(settings.DefaultContext))
+			Expect(cfg.User).To(Equal("dev-user"))
+			Expect(cfg.Contexts["prod"].Token.AccessToken).To(Equal("prod-token"))
+		})
+
+		It("fails to switch to a missing context", func() {
+			cfg, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cfg.UseContext("stage", false)
+			Expect(err).To(MatchError("context 'stage' does not exist"))
+			Expect(cfg.Context).To(Equal("prod"))
+		})
+
+		It("rejects invalid context names", func() {
+			cfg, err := settings.LoadFrom(file)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cfg.UseContext("My.Context", true)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid context name 'My.Context'"))
+		})
+	})
+})
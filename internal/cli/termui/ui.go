@@ -25,6 +25,7 @@ import (
 	"github.com/kyokomi/emoji"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
 )
 
 type msgType int
@@ -58,6 +59,7 @@ type UI struct {
 	output      io.Writer
 	verbosity   int // Verbosity level for user messages.
 	jsonEnabled bool
+	yamlEnabled bool // Structured output as yaml instead of json. Implies jsonEnabled.
 }
 
 // Message represents a piece of information we want displayed to the user
@@ -104,18 +106,36 @@ func (u *UI) EnableJSON() {
 	u.jsonEnabled = true
 }
 
+// EnableYAML enables the structured output, like EnableJSON, but as yaml
+func (u *UI) EnableYAML() {
+	u.EnableJSON()
+	u.yamlEnabled = true
+}
+
 func (u *UI) DisableJSON() {
 	u.verbosity = verbosity()
 	u.jsonEnabled = false
+	u.yamlEnabled = false
 }
 
+// JSON writes the value in the enabled structured output format, json or yaml. It does nothing
+// when no structured output is enabled.
 func (u *UI) JSON(value any) error {
+	if u.yamlEnabled {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = u.output.Write(data)
+		return err
+	}
 	if u.jsonEnabled {
 		return json.NewEncoder(u.output).Encode(value)
 	}
 	return nil
 }
 
+// JSONEnabled returns true if a structured output, json or yaml, is enabled
 func (u *UI) JSONEnabled() bool {
 	return u.jsonEnabled
 }
@@ -0,0 +1,118 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/fatih/color"
+)
+
+// APITokenCreate creates an API token and shows its value. The value is shown only once.
+func (c *EpinioClient) APITokenCreate(name, namespace, role, expiresIn string) error {
+	log := c.Log.WithName("APITokenCreate").WithValues("Name", name, "Namespace", namespace, "Role", role)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Role", role).
+		Msg("Creating API token...")
+
+	token, err := c.API.APITokenCreate(models.APITokenCreateRequest{
+		Name:      name,
+		Role:      role,
+		Namespace: namespace,
+		ExpiresIn: expiresIn,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(token)
+	}
+
+	c.ui.Success().
+		WithTable("Key", "Value").
+		WithTableRow("Name", token.Name).
+		WithTableRow("Role", token.Role).
+		WithTableRow("Namespace", token.Namespace).
+		WithTableRow("Expires", expiry(token.APIToken)).
+		WithTableRow("Token", color.BlueString(token.Token)).
+		Msg("API token created.")
+
+	c.ui.Exclamation().Msg("Copy the token now. It is not shown again.")
+
+	return nil
+}
+
+// APITokens lists the API tokens of the user
+func (c *EpinioClient) APITokens() error {
+	log := c.Log.WithName("APITokens")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().Msg("Listing API tokens")
+
+	tokens, err := c.API.APITokens()
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(tokens)
+	}
+
+	msg := c.ui.Success().WithTable("Name", "Owner", "Role", "Namespace", "Created", "Expires")
+
+	for _, token := range tokens {
+		msg = msg.WithTableRow(
+			token.Name,
+			token.Owner,
+			token.Role,
+			token.Namespace,
+			token.CreatedAt.String(),
+			expiry(token))
+	}
+
+	msg.Msg("API tokens:")
+
+	return nil
+}
+
+// APITokenRevoke revokes the named API token
+func (c *EpinioClient) APITokenRevoke(name, owner string) error {
+	log := c.Log.WithName("APITokenRevoke").WithValues("Name", name, "Owner", owner)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		Msg("Revoking API token...")
+
+	_, err := c.API.APITokenDelete(name, owner)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("API token revoked.")
+
+	return nil
+}
+
+func expiry(token models.APIToken) string {
+	if token.ExpiresAt == nil {
+		return "never"
+	}
+	return token.ExpiresAt.String()
+}
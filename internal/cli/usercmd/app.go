@@ -15,6 +15,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
@@ -118,18 +119,19 @@ func (c *EpinioClient) Apps(all bool) error {
 
 	details.Info("list applications")
 
-	var apps models.AppList
+	var listing models.AppListResponse
 	var err error
 
 	if all {
-		apps, err = c.API.AllApps()
+		listing, err = c.API.AllAppsWithWarnings()
 	} else {
-		apps, err = c.API.Apps(c.Settings.Namespace)
+		listing, err = c.API.AppsWithWarnings(c.Settings.Namespace)
 	}
 	if err != nil {
 		return err
 	}
 
+	apps := listing.Apps
 	sort.Sort(apps)
 
 	if c.ui.JSONEnabled() {
@@ -157,6 +159,9 @@ func (c *EpinioClient) Apps(all bool) error {
 		if app.Workload == nil {
 			status = "n/a"
 			routes = "n/a"
+			if app.ScaledToZero {
+				status = "0/0"
+			}
 
 			switch app.StagingStatus {
 			case models.ApplicationStagingActive:
@@ -171,6 +176,9 @@ func (c *EpinioClient) Apps(all bool) error {
 			case models.ApplicationStagingFailed:
 				statusDetails = "staging failed"
 			}
+			if app.Status == models.ApplicationError {
+				statusDetails = app.StatusMessage
+			}
 		} else {
 			status = app.Workload.Status
 			routes = formatRoutes(app.Workload.Routes)
@@ -210,6 +218,10 @@ func (c *EpinioClient) Apps(all bool) error {
 
 	msg.Msg("Epinio Applications:")
 
+	for _, warning := range listing.Warnings {
+		c.ui.Exclamation().Msg(warning)
+	}
+
 	return nil
 }
 
@@ -244,7 +256,56 @@ func (c *EpinioClient) AppShow(appName string) error {
 		return err
 	}
 
-	return c.printReplicaDetails(app)
+	if err := c.printReplicaDetails(app); err != nil {
+		return err
+	}
+
+	return c.printProcessDetails(app)
+}
+
+// AppEvents shows the kubernetes events of the workload of the named app, newest first
+func (c *EpinioClient) AppEvents(appName string) error {
+	log := c.Log.WithName("AppEvents").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Show application events")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	events, err := c.API.AppEvents(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(events)
+	}
+
+	if len(events) == 0 {
+		c.ui.Normal().Msg("No events found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Last Seen", "Type", "Reason", "Object", "Count", "Message")
+	for _, event := range events {
+		msg = msg.WithTableRow(
+			event.LastSeen.String(),
+			event.Type,
+			event.Reason,
+			fmt.Sprintf("%s/%s", strings.ToLower(event.Kind), event.Object),
+			strconv.Itoa(int(event.Count)),
+			event.Message,
+		)
+	}
+	msg.Msg("Events:")
+
+	return nil
 }
 
 // AppExport saves the named app, in the targeted namespace, to the directory.
@@ -306,9 +367,21 @@ func (c *EpinioClient) AppExport(appName string, toRegistry bool, param models.A
 		return err
 	}
 
-	err = c.getPartAndWriteFile(appName, "image", filepath.Join(directory, "app-image.tar"))
-	if err != nil {
-		return err
+	imagePath := filepath.Join(directory, "app-image.tar")
+	if param.PullSecret != "" {
+		partResponse, err := c.API.AppGetImagePart(c.Settings.Namespace, appName, param.PullSecret)
+		if err != nil {
+			return err
+		}
+		err = c.writePartFile(partResponse, "image", imagePath)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = c.getPartAndWriteFile(appName, "image", imagePath)
+		if err != nil {
+			return err
+		}
 	}
 
 	c.ui.Success().Msg("Ok")
@@ -321,6 +394,11 @@ func (c *EpinioClient) getPartAndWriteFile(appName, part, destinationPath string
 		return err
 	}
 
+	return c.writePartFile(partResponse, part, destinationPath)
+}
+
+// writePartFile saves the retrieved part of an app into the destination file.
+func (c *EpinioClient) writePartFile(partResponse models.AppPartResponse, part, destinationPath string) error {
 	defer func() {
 		if err := partResponse.Data.Close(); err != nil {
 			c.Log.Error(err, "failed to close part response")
@@ -395,8 +473,15 @@ func (c *EpinioClient) AppRestart(appName string) error {
 
 	log.V(1).Info("restarting application")
 
-	_, err := c.API.AppRestart(c.Settings.Namespace, appName)
-	return err
+	restart, err := c.API.AppRestart(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().
+		WithStringValue("Generation", strconv.FormatInt(restart.Generation, 10)).
+		Msg("Application restarting")
+	return nil
 }
 
 // AppStageID returns the last stage id of the named app, in the targeted namespace
@@ -493,7 +578,65 @@ func (c *EpinioClient) AppLogs(appName, stageID string, follow bool, options *cl
 	return nil
 }
 
-func (c *EpinioClient) AppExec(ctx context.Context, appName, instance string) error {
+// AppStagingLogs streams the logs of the active staging of the named application, until the
+// staging is done. Without an active staging the logs of the last staging are shown instead,
+// following them as requested.
+func (c *EpinioClient) AppStagingLogs(appName string, follow bool) error {
+	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+	details := log.V(1) // NOTE: Increment of level, not absolute.
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Streaming staging logs")
+
+	failed := false
+	printer := logprinter.LogPrinter{Tmpl: logprinter.DefaultSingleNamespaceTemplate()}
+	callback := func(logLine tailer.ContainerLogLine) {
+		if logLine.Message == models.PushLogsFailedMarker {
+			failed = true
+			return
+		}
+		printer.Print(logprinter.Log{
+			Message:       logLine.Message,
+			Namespace:     logLine.Namespace,
+			PodName:       logLine.PodName,
+			ContainerName: logLine.ContainerName,
+		}, c.ui.ProgressNote().Compact())
+	}
+
+	err := c.API.AppStagingLogs(c.Settings.Namespace, appName, nil, callback)
+	if err == nil {
+		if failed {
+			return errors.New("staging failed")
+		}
+		return nil
+	}
+
+	epinioAPIError := &client.APIError{}
+	if !errors.As(err, &epinioAPIError) || epinioAPIError.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	details.Info("not staging, showing logs of last staging")
+
+	stageID, err := c.AppStageID(appName)
+	if err != nil {
+		return errors.Wrap(err, "error checking app")
+	}
+
+	return c.API.AppLogs(c.Settings.Namespace, appName, stageID, follow, nil, callback)
+}
+
+// AppExec runs a shell in an instance of the named application, attached to the terminal. With
+// a command that command is run instead, without a terminal.
+func (c *EpinioClient) AppExec(ctx context.Context, appName, instance string, command []string) error {
 	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
 	log.Info("start")
 	defer log.Info("return")
@@ -506,7 +649,41 @@ func (c *EpinioClient) AppExec(ctx context.Context, appName, instance string) er
 		msg = msg.WithStringValue("Instance", instance)
 	}
 
-	msg.Msg("Executing a shell")
+	if len(command) > 0 {
+		msg.WithStringValue("Command", strings.Join(command, " ")).Msg("Executing a command")
+	} else {
+		msg.Msg("Executing a shell")
+	}
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	tty := kubectlterm.TTY{
+		In:     os.Stdin,
+		Out:    os.Stdout,
+		Raw:    len(command) == 0,
+		TryDev: true,
+	}
+
+	return c.API.AppExec(ctx, c.Settings.Namespace, appName, instance, command, tty, os.Stderr)
+}
+
+// AppDebug attaches to a new debug container in an instance of the named application
+func (c *EpinioClient) AppDebug(ctx context.Context, appName, instance string) error {
+	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName)
+
+	if instance != "" {
+		msg = msg.WithStringValue("Instance", instance)
+	}
+
+	msg.Msg("Starting a debug container")
 
 	if err := c.TargetOk(); err != nil {
 		return err
@@ -519,10 +696,10 @@ func (c *EpinioClient) AppExec(ctx context.Context, appName, instance string) er
 		TryDev: true,
 	}
 
-	return c.API.AppExec(ctx, c.Settings.Namespace, appName, instance, tty)
+	return c.API.AppDebug(ctx, c.Settings.Namespace, appName, instance, tty)
 }
 
-func (c *EpinioClient) AppPortForward(ctx context.Context, appName, instance string, address, ports []string) error {
+func (c *EpinioClient) AppPortForward(ctx context.Context, appName, instance, container string, address, ports []string) error {
 	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
 	log.Info("start")
 	defer log.Info("return")
@@ -535,6 +712,10 @@ func (c *EpinioClient) AppPortForward(ctx context.Context, appName, instance str
 		msg = msg.WithStringValue("Instance", instance)
 	}
 
+	if container != "" {
+		msg = msg.WithStringValue("Container", container)
+	}
+
 	msg.Msg("Executing port forwarding")
 
 	if err := c.TargetOk(); err != nil {
@@ -542,6 +723,7 @@ func (c *EpinioClient) AppPortForward(ctx context.Context, appName, instance str
 	}
 
 	opts := client.NewPortForwardOpts(address, ports)
+	opts.Container = container
 	return c.API.AppPortForward(c.Settings.Namespace, appName, instance, opts)
 }
 
@@ -638,10 +820,39 @@ func (c *EpinioClient) AppDelete(ctx context.Context, appNames []string, all, de
 	return nil
 }
 
+// runningRevision returns the git revision and the image digest the app runs, e.g.
+// `sha abc1234 / registry/app@sha256:...`. Unknown parts are left out.
+func runningRevision(app models.App) string {
+	parts := []string{}
+
+	if app.GitRevision != "" {
+		revision := app.GitRevision
+		if len(revision) == 40 {
+			revision = revision[:7]
+		}
+		parts = append(parts, "sha "+revision)
+	}
+
+	if app.ImageDigest != "" {
+		image, _, _ := strings.Cut(app.ImageURL, "@")
+		if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+			image = image[:i]
+		}
+		parts = append(parts, image+"@"+app.ImageDigest)
+	}
+
+	return strings.Join(parts, " / ")
+}
+
 func (c *EpinioClient) printAppDetails(app models.App) error {
 	msg := c.ui.Success().WithTable("Key", "Value").
-		WithTableRow("Origin", app.Origin.String()).
-		WithTableRow("Created", app.Meta.CreatedAt.String())
+		WithTableRow("Origin", app.Origin.String())
+
+	if running := runningRevision(app); running != "" {
+		msg = msg.WithTableRow("Running", running)
+	}
+
+	msg = msg.WithTableRow("Created", app.Meta.CreatedAt.String())
 
 	var createdAt time.Time
 	var err error
@@ -665,7 +876,12 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 			}
 		}
 	} else {
-		if app.StageID == "" {
+		if app.ScaledToZero {
+			msg = msg.WithTableRow("Status", "deployed, scaled to zero")
+			if app.StageID != "" {
+				msg = msg.WithTableRow("Last StageId", app.StageID)
+			}
+		} else if app.StageID == "" {
 			msg = msg.WithTableRow("Status", "not deployed")
 		} else {
 			switch app.StagingStatus {
@@ -725,6 +941,20 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 		}
 	}
 
+	if validation := app.ConfigurationValidation; validation != nil {
+		switch {
+		case validation.Error != "":
+			msg = msg.WithTableRow("Chart Values Valid", "unknown: "+validation.Error)
+		case validation.Valid:
+			msg = msg.WithTableRow("Chart Values Valid", "yes")
+		default:
+			msg = msg.WithTableRow("Chart Values Valid", "no")
+			for _, message := range validation.Messages {
+				msg = msg.WithTableRow(" - ", message)
+			}
+		}
+	}
+
 	msg.Msg("Details:")
 
 	if len(app.Configuration.Configurations) > 0 {
@@ -754,7 +984,8 @@ func (c *EpinioClient) printReplicaDetails(app models.App) error {
 	}
 
 	if len(app.Workload.Replicas) > 0 {
-		msg := c.ui.Success().WithTable("Name", "Ready", "Memory", "MilliCPUs", "Restarts", "Age")
+		msg := c.ui.Success().WithTable("Name", "Ready", "Memory", "MilliCPUs",
+			"Requests (Memory/MilliCPUs)", "Limits (Memory/MilliCPUs)", "Restarts", "Age")
 		for _, r := range app.Workload.Replicas {
 			createdAt, err := time.Parse(time.RFC3339, r.CreatedAt)
 			if err != nil {
@@ -767,12 +998,15 @@ func (c *EpinioClient) printReplicaDetails(app models.App) error {
 				millis = strconv.Itoa(int(r.MilliCPUs))
 				memory = bytes.ByteCountIEC(r.MemoryBytes)
 			}
+			requests, limits := podResources(r.Containers)
 
 			msg = msg.WithTableRow(
 				r.Name,
 				strconv.FormatBool(r.Ready),
 				memory,
 				millis,
+				formatResourceAmounts(requests),
+				formatResourceAmounts(limits),
 				strconv.Itoa(int(r.Restarts)),
 				time.Since(createdAt).Round(time.Second).String(),
 			)
@@ -783,6 +1017,74 @@ func (c *EpinioClient) printReplicaDetails(app models.App) error {
 	return nil
 }
 
+// podResources returns the requests and limits of a pod, summed over its containers. A limit
+// is only reported when all the containers have it, as the pod is not bounded otherwise.
+func podResources(containers []models.ContainerResources) (models.ResourceAmounts, models.ResourceAmounts) {
+	requests := models.ResourceAmounts{}
+	limits := models.ResourceAmounts{}
+	cpuLimited, memoryLimited := len(containers) > 0, len(containers) > 0
+
+	for _, container := range containers {
+		requests.MilliCPUs += container.Requests.MilliCPUs
+		requests.MemoryBytes += container.Requests.MemoryBytes
+		limits.MilliCPUs += container.Limits.MilliCPUs
+		limits.MemoryBytes += container.Limits.MemoryBytes
+		cpuLimited = cpuLimited && container.Limits.MilliCPUs > 0
+		memoryLimited = memoryLimited && container.Limits.MemoryBytes > 0
+	}
+
+	if !cpuLimited {
+		limits.MilliCPUs = 0
+	}
+	if !memoryLimited {
+		limits.MemoryBytes = 0
+	}
+
+	return requests, limits
+}
+
+// formatResourceAmounts renders the memory and cpu amounts, with `-` for those not set.
+func formatResourceAmounts(amounts models.ResourceAmounts) string {
+	memory := "-"
+	if amounts.MemoryBytes > 0 {
+		memory = bytes.ByteCountIEC(amounts.MemoryBytes)
+	}
+	millis := "-"
+	if amounts.MilliCPUs > 0 {
+		millis = strconv.FormatInt(amounts.MilliCPUs, 10)
+	}
+
+	return memory + "/" + millis
+}
+
+// printProcessDetails shows the replica status of each of the additional process types of the
+// application, if any.
+func (c *EpinioClient) printProcessDetails(app models.App) error {
+	if len(app.Configuration.ProcessTypes) == 0 {
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Process Type", "Status", "Desired", "Ready")
+	for _, processType := range app.Configuration.ProcessTypes {
+		desired := int32(1)
+		if processType.Instances != nil {
+			desired = *processType.Instances
+		}
+
+		status := "not deployed"
+		ready := "0"
+		if workload, found := app.Processes[processType.Name]; found {
+			status = workload.Status
+			ready = strconv.Itoa(int(workload.ReadyReplicas))
+		}
+
+		msg = msg.WithTableRow(processType.Name, status, strconv.Itoa(int(desired)), ready)
+	}
+	msg.Msg("Process Types: ")
+
+	return nil
+}
+
 // AppRestage restage an application
 func (c *EpinioClient) AppRestage(appName string, restart bool) error {
 	log := c.Log.WithName("AppRestage").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
@@ -903,7 +1205,8 @@ func stagingWait(logger logr.Logger, apiClient APIClient, namespace, stageID str
 	defer cancel()
 
 	wsErr := apiClient.StagingCompleteStream(ctx, namespace, stageID, func(event models.StageCompleteEvent) error {
-		logger.Info("staging status", "stageID", stageID, "status", event.Status, "message", event.Message)
+		logger.Info("staging status", "stageID", stageID, "status", event.Status, "message", event.Message,
+			"attempts", event.Attempts, "retries", event.Retries)
 
 		if !event.Completed {
 			return nil
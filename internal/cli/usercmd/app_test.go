@@ -12,12 +12,18 @@
 package usercmd_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
 	"github.com/epinio/epinio/helpers/kubernetes/tailer"
 	"github.com/epinio/epinio/internal/cli/settings"
 	"github.com/epinio/epinio/internal/cli/usercmd"
 	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
 	"github.com/epinio/epinio/pkg/api/core/v1/client"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/epinio/epinio/acceptance/helpers/matchers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -44,8 +50,8 @@ var _ = Describe("Client Apps unit tests", Label("wip"), func() {
 					return nil
 				}
 
-				fake.StagingCompleteStub = func(namespace, id string) (models.Response, error) {
-					return models.Response{Status: "ok"}, nil
+				fake.StagingCompleteStub = func(namespace, id string) (models.StagingCompleteResponse, error) {
+					return models.StagingCompleteResponse{Response: models.Response{Status: "ok"}}, nil
 				}
 			})
 
@@ -89,4 +95,207 @@ var _ = Describe("Client Apps unit tests", Label("wip"), func() {
 			})
 		})
 	})
+
+	Describe("AppStagingLogs", func() {
+		var epinioClient *usercmd.EpinioClient
+
+		BeforeEach(func() {
+			fake = &usercmdfakes.FakeAPIClient{}
+
+			var err error
+			epinioClient, err = usercmd.New()
+			Expect(err).ToNot(HaveOccurred())
+
+			epinioClient.Settings = &settings.Settings{Namespace: "workspace"}
+			epinioClient.API = fake
+		})
+
+		It("follows the active staging", func() {
+			err := epinioClient.AppStagingLogs("appname", false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fake.AppStagingLogsCallCount()).To(Equal(1))
+			namespace, appName, _, _ := fake.AppStagingLogsArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+			Expect(appName).To(Equal("appname"))
+			Expect(fake.AppLogsCallCount()).To(Equal(0))
+		})
+
+		It("reports a failed staging", func() {
+			fake.AppStagingLogsStub = func(namespace, appName string, options *client.LogOptions, callback func(tailer.ContainerLogLine)) error {
+				callback(tailer.ContainerLogLine{Message: models.PushLogsFailedMarker})
+				return nil
+			}
+
+			err := epinioClient.AppStagingLogs("appname", false)
+			Expect(err).To(MatchError("staging failed"))
+		})
+
+		It("shows the logs of the last staging when not staging", func() {
+			fake.AppStagingLogsReturns(&client.APIError{StatusCode: http.StatusNotFound})
+			fake.AppShowStub = func(namespace, appName string) (models.App, error) {
+				app := models.NewApp(appName, namespace)
+				app.StageID = "last"
+				return *app, nil
+			}
+
+			err := epinioClient.AppStagingLogs("appname", true)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fake.AppLogsCallCount()).To(Equal(1))
+			_, _, stageID, follow, _, _ := fake.AppLogsArgsForCall(0)
+			Expect(stageID).To(Equal("last"))
+			Expect(follow).To(BeTrue())
+		})
+	})
+
+	Describe("AppShow", func() {
+		var (
+			epinioClient *usercmd.EpinioClient
+			output       *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			fake = &usercmdfakes.FakeAPIClient{}
+			fake.AppShowStub = func(namespace, appName string) (models.App, error) {
+				app := models.NewApp(appName, namespace)
+				app.Workload = &models.AppDeployment{
+					Name:            appName,
+					DesiredReplicas: 2,
+					ReadyReplicas:   1,
+					Replicas: map[string]*models.PodInfo{
+						"busy": {Name: "busy", MetricsOk: true, MilliCPUs: 250, MemoryBytes: 1024, Restarts: 3},
+						"idle": {Name: "idle", MetricsOk: true},
+					},
+				}
+				return *app, nil
+			}
+
+			var err error
+			epinioClient, err = usercmd.New()
+			Expect(err).ToNot(HaveOccurred())
+
+			epinioClient.Settings = &settings.Settings{Namespace: "workspace"}
+			epinioClient.API = fake
+
+			output = &bytes.Buffer{}
+			epinioClient.UI().SetOutput(output)
+			epinioClient.UI().EnableJSON()
+		})
+
+		It("writes the app with its workload metrics as pure json", func() {
+			err := epinioClient.AppShow("appname")
+			Expect(err).ToNot(HaveOccurred())
+
+			app := models.App{}
+			Expect(json.Unmarshal(output.Bytes(), &app)).To(Succeed(), output.String())
+			Expect(app.Meta.Name).To(Equal("appname"))
+			Expect(app.Workload).ToNot(BeNil())
+			Expect(app.Workload.Replicas).To(HaveLen(2))
+			Expect(*app.Workload.Replicas["busy"]).To(Equal(models.PodInfo{
+				Name: "busy", MetricsOk: true, MilliCPUs: 250, MemoryBytes: 1024, Restarts: 3,
+			}))
+		})
+
+		It("keeps the zero metrics", func() {
+			err := epinioClient.AppShow("appname")
+			Expect(err).ToNot(HaveOccurred())
+
+			app := map[string]interface{}{}
+			Expect(json.Unmarshal(output.Bytes(), &app)).To(Succeed(), output.String())
+
+			idle := app["deployment"].(map[string]interface{})["replicas"].(map[string]interface{})["idle"]
+			Expect(idle).To(HaveKeyWithValue("millicpus", BeNumerically("==", 0)))
+			Expect(idle).To(HaveKeyWithValue("memoryBytes", BeNumerically("==", 0)))
+			Expect(idle).To(HaveKeyWithValue("restarts", BeNumerically("==", 0)))
+		})
+	})
+
+	Describe("AppShow, scaled to zero", func() {
+		It("reports the deployed app without workload as scaled to zero", func() {
+			fake = &usercmdfakes.FakeAPIClient{}
+			fake.AppShowStub = func(namespace, appName string) (models.App, error) {
+				app := models.NewApp(appName, namespace)
+				instances := int32(0)
+				app.Configuration.Instances = &instances
+				app.ImageURL = "registry/appname:1"
+				app.ScaledToZero = true
+				return *app, nil
+			}
+
+			epinioClient, err := usercmd.New()
+			Expect(err).ToNot(HaveOccurred())
+			epinioClient.Settings = &settings.Settings{Namespace: "workspace"}
+			epinioClient.API = fake
+
+			output := &bytes.Buffer{}
+			epinioClient.UI().SetOutput(output)
+
+			Expect(epinioClient.AppShow("appname")).To(Succeed())
+			Expect(output.String()).To(MatchRegexp(`Status\s+\|\s+deployed, scaled to zero`))
+			Expect(output.String()).To(MatchRegexp(`Desired Instances\s+\|\s+0`))
+		})
+	})
+
+	Describe("AppEvents", func() {
+		var (
+			epinioClient *usercmd.EpinioClient
+			output       *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			fake = &usercmdfakes.FakeAPIClient{}
+			fake.AppEventsReturns(models.EventList{
+				{Type: "Warning", Reason: "BackOff", Message: "Back-off restarting", Kind: "Pod", Object: "myapp-5d8f-abcde", Count: 4},
+				{Type: "Normal", Reason: "ScalingReplicaSet", Message: "Scaled up", Kind: "Deployment", Object: "myapp", Count: 1},
+			}, nil)
+
+			var err error
+			epinioClient, err = usercmd.New()
+			Expect(err).ToNot(HaveOccurred())
+
+			epinioClient.Settings = &settings.Settings{Namespace: "workspace"}
+			epinioClient.API = fake
+
+			output = &bytes.Buffer{}
+			epinioClient.UI().SetOutput(output)
+		})
+
+		It("shows the events as a table", func() {
+			err := epinioClient.AppEvents("myapp")
+			Expect(err).ToNot(HaveOccurred())
+
+			namespace, appName := fake.AppEventsArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+			Expect(appName).To(Equal("myapp"))
+
+			Expect(output.String()).To(
+				HaveATable(
+					WithHeaders("LAST SEEN", "TYPE", "REASON", "OBJECT", "COUNT", "MESSAGE"),
+					WithRow(".*", "Warning", "BackOff", "pod/myapp-5d8f-abcde", "4", "Back-off restarting"),
+					WithRow(".*", "Normal", "ScalingReplicaSet", "deployment/myapp", "1", "Scaled up"),
+				),
+			)
+		})
+
+		It("writes the events as pure json", func() {
+			epinioClient.UI().EnableJSON()
+
+			err := epinioClient.AppEvents("myapp")
+			Expect(err).ToNot(HaveOccurred())
+
+			events := models.EventList{}
+			Expect(json.Unmarshal(output.Bytes(), &events)).To(Succeed(), output.String())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Reason).To(Equal("BackOff"))
+		})
+
+		It("reports an app without events", func() {
+			fake.AppEventsReturns(models.EventList{}, nil)
+
+			err := epinioClient.AppEvents("myapp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output.String()).To(ContainSubstring("No events found"))
+		})
+	})
 })
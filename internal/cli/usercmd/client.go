@@ -16,6 +16,7 @@ package usercmd
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"runtime"
 
@@ -46,11 +47,19 @@ type EpinioClient struct {
 type APIClient interface {
 	AuthToken() (models.AuthTokenResponse, error)
 	Me() (models.MeResponse, error)
+	Ready() error
+
+	// api tokens
+	APITokens() (models.APITokenList, error)
+	APITokenCreate(req models.APITokenCreateRequest) (models.APITokenCreateResponse, error)
+	APITokenDelete(name, owner string) (models.Response, error)
 
 	// app
 	AppCreate(req models.ApplicationCreateRequest, namespace string) (models.Response, error)
 	Apps(namespace string) (models.AppList, error)
 	AllApps() (models.AppList, error)
+	AppsWithWarnings(namespace string) (models.AppListResponse, error)
+	AllAppsWithWarnings() (models.AppListResponse, error)
 	AppShow(namespace string, appName string) (models.App, error)
 	AppUpdate(req models.ApplicationUpdateRequest, namespace string, appName string) (models.Response, error)
 	AppDelete(namespace string, names []string, deleteImage bool) (models.ApplicationDeleteResponse, error)
@@ -59,13 +68,17 @@ type APIClient interface {
 	AppStage(req models.StageRequest) (*models.StageResponse, error)
 	AppDeploy(req models.DeployRequest) (*models.DeployResponse, error)
 	AppLogs(namespace, appName, stageID string, follow bool, options *client.LogOptions, callback func(tailer.ContainerLogLine)) error
-	StagingComplete(namespace string, id string) (models.Response, error)
+	AppStagingLogs(namespace, appName string, options *client.LogOptions, callback func(tailer.ContainerLogLine)) error
+	StagingComplete(namespace string, id string) (models.StagingCompleteResponse, error)
 	StagingCompleteStream(ctx context.Context, namespace, id string, callback func(models.StageCompleteEvent) error) error
 	AppRunning(app models.AppRef) (models.Response, error)
-	AppExec(ctx context.Context, namespace string, appName, instance string, tty kubectlterm.TTY) error
+	AppExec(ctx context.Context, namespace string, appName, instance string, command []string, tty kubectlterm.TTY, errOut io.Writer) error
+	AppDebug(ctx context.Context, namespace string, appName, instance string, tty kubectlterm.TTY) error
 	AppPortForward(namespace string, appName, instance string, opts *client.PortForwardOpts) error
-	AppRestart(namespace string, appName string) (models.Response, error)
+	AppRestart(namespace string, appName string) (models.AppRestartResponse, error)
+	AppEvents(namespace string, appName string) (models.EventList, error)
 	AppGetPart(namespace, appName, part string) (models.AppPartResponse, error)
+	AppGetImagePart(namespace, appName, pullSecret string) (models.AppPartResponse, error)
 	AppMatch(namespace, prefix string) (models.AppMatchResponse, error)
 	AppValidateCV(namespace string, name string) (models.Response, error)
 	AppExport(namespace, appName string, param models.AppExportRequest) (models.Response, error)
@@ -87,6 +100,9 @@ type APIClient interface {
 	NamespaceShow(namespace string) (models.Namespace, error)
 	NamespacesMatch(prefix string) (models.NamespacesMatchResponse, error)
 	Namespaces() (models.NamespaceList, error)
+	NamespaceRoles(namespace string) (models.NamespaceRoleBindingList, error)
+	NamespaceRoleGrant(namespace string, req models.NamespaceRoleGrantRequest) (models.Response, error)
+	NamespaceRoleRevoke(namespace, user, role string) (models.Response, error)
 
 	// configurations
 	Configurations(namespace string) (models.ConfigurationResponseList, error)
@@ -108,15 +124,17 @@ type APIClient interface {
 
 	AllServices() (models.ServiceList, error)
 	ServiceShow(namespace, name string) (*models.Service, error)
-	ServiceCreate(req models.ServiceCreateRequest, namespace string) (models.Response, error)
-	ServiceBind(req models.ServiceBindRequest, namespace, name string) (models.Response, error)
-	ServiceBatchBind(req models.ServiceBatchBindRequest, namespace, appName string) (models.Response, error)
+	ServiceCreate(req models.ServiceCreateRequest, namespace string) (models.ServiceCreateResponse, error)
+	ServiceClone(req models.ServiceCloneRequest, namespace, name string) (models.ServiceCreateResponse, error)
+	ServiceBind(req models.ServiceBindRequest, namespace, name string) (models.ServiceBindResponse, error)
+	ServiceBatchBind(req models.ServiceBatchBindRequest, namespace, appName string) (models.ServiceBatchBindResponse, error)
+	ServiceRebind(req models.ServiceRebindRequest, namespace, appName string) (models.ServiceBindResponse, error)
 	ServiceUnbind(req models.ServiceUnbindRequest, namespace, name string) (models.Response, error)
 	ServiceDelete(req models.ServiceDeleteRequest, namespace string, names []string) (models.ServiceDeleteResponse, error)
 	ServiceList(namespace string) (models.ServiceList, error)
 	ServiceMatch(namespace, prefix string) (models.ServiceMatchResponse, error)
 	ServicePortForward(namespace string, serviceName string, opts *client.PortForwardOpts) error
-	ServiceUpdate(req models.ServiceUpdateRequest, namespace, name string) (models.Response, error)
+	ServiceUpdate(req models.ServiceUpdateRequest, namespace, name string) (models.ServiceUpdateResponse, error)
 	// note: The replace endpoint is not used by the cli.
 
 	// application charts
@@ -32,7 +32,8 @@ import (
 )
 
 // Login will ask the user for a username and password, and then it will update the settings file accordingly
-func (c *EpinioClient) Login(ctx context.Context, username, password, address string, trustCA bool) error {
+// When a namespace is given it has to be accessible to the user, and it becomes the current namespace.
+func (c *EpinioClient) Login(ctx context.Context, username, password, address, namespace string, trustCA bool) error {
 	var err error
 
 	log := c.Log.WithName("Login")
@@ -76,6 +77,14 @@ func (c *EpinioClient) Login(ctx context.Context, username, password, address st
 		return errors.Wrap(err, "error verifying credentials")
 	}
 
+	if namespace != "" {
+		err = verifyNamespace(ctx, updatedSettings, customHeaders, namespace)
+		if err != nil {
+			return err
+		}
+		updatedSettings.Namespace = namespace
+	}
+
 	c.ui.Success().Msg("Login successful")
 
 	err = updatedSettings.Save()
@@ -83,10 +92,11 @@ func (c *EpinioClient) Login(ctx context.Context, username, password, address st
 		return errors.Wrap(err, "error saving new settings")
 	}
 
-	// Verify that the targeted namespace (if any) exists in the targeted cluster.
-	// If not report the issue, clear the information, and ask the user to chose a proper namespace.
+	// Verify that the targeted namespace (if any, and not just verified) exists in the targeted
+	// cluster. If not report the issue, clear the information, and ask the user to chose a proper
+	// namespace.
 
-	if updatedSettings.Namespace != "" {
+	if namespace == "" && updatedSettings.Namespace != "" {
 		// Note: Create a new client for this. `c` cannot be assumed to be properly initialized,
 		// as it was created before the login was performed and saved.
 
@@ -367,3 +377,33 @@ func verifyCredentials(ctx context.Context, epinioSettings *settings.Settings, c
 	}
 	return nil
 }
+
+// verifyNamespace checks that the namespace exists and that the user of the settings can access it
+func verifyNamespace(ctx context.Context, epinioSettings *settings.Settings, customHeaders http.Header, namespace string) error {
+	epinioSettings.Location = "fake" // See verifyCredentials
+	apiClient := client.New(ctx, epinioSettings)
+	apiClient.DisableVersionWarning()
+
+	for k, values := range customHeaders {
+		for _, v := range values {
+			apiClient.SetHeader(k, v)
+		}
+	}
+
+	_, err := apiClient.NamespaceShow(namespace)
+	if err == nil {
+		return nil
+	}
+
+	epinioAPIError := &client.APIError{}
+	if errors.As(err, &epinioAPIError) {
+		switch epinioAPIError.StatusCode {
+		case http.StatusNotFound:
+			return errors.Errorf("namespace '%s' does not exist", namespace)
+		case http.StatusForbidden:
+			return errors.Errorf("user is not authorized to access namespace '%s'", namespace)
+		}
+	}
+
+	return errors.Wrapf(err, "error verifying namespace '%s'", namespace)
+}
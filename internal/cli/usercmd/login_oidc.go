@@ -31,7 +31,8 @@ import (
 
 // LoginOIDC implements the "public client" flow of dex:
 // https://dexidp.io/docs/custom-scopes-claims-clients/#public-clients
-func (c *EpinioClient) LoginOIDC(ctx context.Context, address string, trustCA, prompt bool) error {
+// When a namespace is given it has to be accessible to the user, and it becomes the current namespace.
+func (c *EpinioClient) LoginOIDC(ctx context.Context, address, namespace string, trustCA, prompt bool) error {
 	var err error
 
 	log := c.Log.WithName("Login")
@@ -91,6 +92,14 @@ func (c *EpinioClient) LoginOIDC(ctx context.Context, address string, trustCA, p
 		return errors.Wrap(err, "error verifying credentials")
 	}
 
+	if namespace != "" {
+		err = verifyNamespace(ctx, updatedSettings, customHeaders, namespace)
+		if err != nil {
+			return err
+		}
+		updatedSettings.Namespace = namespace
+	}
+
 	c.ui.Success().Msg("Login successful")
 
 	err = updatedSettings.Save()
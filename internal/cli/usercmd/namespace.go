@@ -266,6 +266,83 @@ func (c *EpinioClient) ShowNamespace(namespace string) error {
 	return nil
 }
 
+// NamespaceRoles lists the roles of the users in a Namespace
+func (c *EpinioClient) NamespaceRoles(namespace string) error {
+	log := c.Log.WithName("NamespaceRoles").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Listing namespace roles...")
+
+	bindings, err := c.API.NamespaceRoles(namespace)
+	if err != nil {
+		return err
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(bindings)
+	}
+
+	msg := c.ui.Success().WithTable("User", "Role")
+
+	for _, binding := range bindings {
+		msg = msg.WithTableRow(binding.User, binding.Role)
+	}
+
+	msg.Msg("Roles:")
+
+	return nil
+}
+
+// GrantNamespaceRole assigns the role to the user in the Namespace
+func (c *EpinioClient) GrantNamespaceRole(user, namespace, role string) error {
+	log := c.Log.WithName("GrantNamespaceRole").WithValues("User", user, "Namespace", namespace, "Role", role)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("User", user).
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Role", role).
+		Msg("Granting namespace role...")
+
+	_, err := c.API.NamespaceRoleGrant(namespace, models.NamespaceRoleGrantRequest{
+		User: user,
+		Role: role,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Role granted.")
+
+	return nil
+}
+
+// RevokeNamespaceRole removes the role of the user in the Namespace
+func (c *EpinioClient) RevokeNamespaceRole(user, namespace, role string) error {
+	log := c.Log.WithName("RevokeNamespaceRole").WithValues("User", user, "Namespace", namespace, "Role", role)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("User", user).
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Role", role).
+		Msg("Revoking namespace role...")
+
+	_, err := c.API.NamespaceRoleRevoke(namespace, user, role)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Role revoked.")
+
+	return nil
+}
+
 // askConfirmation is a helper for CmdNamespaceDelete to confirm a deletion request
 func (c *EpinioClient) askConfirmation(m string) bool {
 	c.ui.Note().Msg(m)
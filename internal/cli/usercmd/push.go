@@ -203,9 +203,13 @@ func (c *EpinioClient) AppPush(ctx context.Context, manifest models.ApplicationM
 		c.ui.ProgressNote().Msg("Running staging")
 
 		req := models.StageRequest{
-			App:          appRef,
-			BlobUID:      blobUID,
-			BuilderImage: manifest.Staging.Builder,
+			App:                    appRef,
+			BlobUID:                blobUID,
+			BuilderImage:           manifest.Staging.Builder,
+			BuildEnvironment:       manifest.Staging.Environment,
+			BuildEnvironmentSecret: manifest.Staging.EnvironmentSecret,
+			EmbedBuildEnvironment:  manifest.Staging.EmbedEnvironment,
+			Storage:                manifest.Staging.Storage,
 		}
 		details.Info("staging code", "Blob", blobUID)
 		stageResponse, err = c.API.AppStage(req)
@@ -215,6 +219,10 @@ func (c *EpinioClient) AppPush(ctx context.Context, manifest models.ApplicationM
 		stageID = stageResponse.Stage.ID
 		log.V(3).Info("stage response", "response", stageResponse)
 
+		if stageResponse.BuilderSignal != "" {
+			c.ui.Note().Msgf("Using builder %s, selected by %s", stageResponse.BuilderImage, stageResponse.BuilderSignal)
+		}
+
 		details.Info("start tailing logs", "StageID", stageResponse.Stage.ID)
 		c.stageLogs(appRef, stageResponse.Stage.ID)
 
@@ -236,6 +244,10 @@ func (c *EpinioClient) AppPush(ctx context.Context, manifest models.ApplicationM
 		App:    appRef,
 		Origin: manifest.Origin,
 	}
+	if podMetadata := manifest.Configuration.PodMetadata; podMetadata != nil {
+		deployRequest.Annotations = podMetadata.Annotations
+		deployRequest.Labels = podMetadata.Labels
+	}
 	// If container param is specified, then we just take it into ImageURL
 	// If not, we take the one from the staging response
 	if manifest.Origin.Kind == models.OriginContainer {
@@ -261,7 +273,12 @@ func (c *EpinioClient) AppPush(ctx context.Context, manifest models.ApplicationM
 		routes = append(routes, fmt.Sprintf("https://%s", d))
 	}
 
-	c.reportOK(appRef, manifest.Staging.Builder, routes)
+	builder := manifest.Staging.Builder
+	if stageResponse != nil && stageResponse.BuilderImage != "" {
+		builder = stageResponse.BuilderImage
+	}
+
+	c.reportOK(appRef, builder, routes)
 	return nil
 }
 
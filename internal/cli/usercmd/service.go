@@ -13,6 +13,7 @@ package usercmd
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"sort"
 	"strings"
@@ -101,9 +102,62 @@ func (c *EpinioClient) ServiceCreate(catalogServiceName, serviceName string, wai
 		Settings:       chartValues,
 	}
 
-	_, err := c.API.ServiceCreate(request, c.Settings.Namespace)
-	// Note: errors.Wrap (nil, "...") == nil
-	return errors.Wrap(err, "service create failed")
+	resp, err := c.API.ServiceCreate(request, c.Settings.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "service create failed")
+	}
+
+	if resp.Warning != "" {
+		c.ui.Exclamation().Msg(resp.Warning)
+	}
+
+	return nil
+}
+
+// ServiceClone creates a copy of a service in the target namespace, the targeted namespace if
+// none is given
+func (c *EpinioClient) ServiceClone(serviceName, targetName, targetNamespace string, wait bool) error {
+	log := c.Log.WithName("ServiceClone")
+	log.Info("start")
+	defer log.Info("return")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	if targetNamespace == "" {
+		targetNamespace = c.Settings.Namespace
+	}
+
+	c.ui.Note().
+		WithStringValue("Service", serviceName).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Copy", targetName).
+		WithStringValue("Target Namespace", targetNamespace).
+		WithBoolValue("Wait For Completion", wait).
+		Msg("Cloning Service...")
+
+	request := models.ServiceCloneRequest{
+		TargetNamespace: targetNamespace,
+		Name:            targetName,
+		Wait:            wait,
+	}
+
+	resp, err := c.API.ServiceClone(request, c.Settings.Namespace, serviceName)
+	if err != nil {
+		return err
+	}
+
+	if resp.Warning != "" {
+		c.ui.Exclamation().Msg(resp.Warning)
+	}
+
+	c.ui.Success().
+		WithStringValue("Name", targetName).
+		WithStringValue("Namespace", targetNamespace).
+		Msg("Service Cloned.")
+
+	return nil
 }
 
 // UpdateService updates a service specified by name and information about removed keys and changed assignments.
@@ -127,15 +181,21 @@ func (c *EpinioClient) ServiceUpdate(name string, wait bool, removedKeys []strin
 		Restart: &restart,
 	}
 
-	_, err := c.API.ServiceUpdate(request, c.Settings.Namespace, name)
+	resp, err := c.API.ServiceUpdate(request, c.Settings.Namespace, name)
 	if err != nil {
 		return err
 	}
 
-	c.ui.Success().
+	msg := c.ui.Success().
 		WithStringValue("Name", name).
-		WithStringValue("Namespace", c.Settings.Namespace).
-		Msg("Service Changes Saved.")
+		WithStringValue("Namespace", c.Settings.Namespace)
+	if len(resp.RestartedApps) > 0 {
+		msg = msg.WithStringValue("Restarted", strings.Join(resp.RestartedApps, ", "))
+	}
+	if len(resp.PendingRestartApps) > 0 {
+		msg = msg.WithStringValue("Restarting", strings.Join(resp.PendingRestartApps, ", "))
+	}
+	msg.Msg("Service Changes Saved.")
 
 	return nil
 }
@@ -167,11 +227,17 @@ func (c *EpinioClient) ServiceShow(serviceName string) error {
 	internalRoutes := service.InternalRoutes
 	sort.Strings(internalRoutes)
 
+	chart := ""
+	if service.Chart != nil {
+		chart = fmt.Sprintf("%s:%s (%s)", service.Chart.Chart, service.Chart.Version, service.Chart.Repository)
+	}
+
 	c.ui.Success().WithTable("Key", "Value").
 		WithTableRow("Name", service.Meta.Name).
 		WithTableRow("Created", service.Meta.CreatedAt.String()).
 		WithTableRow("Catalog Service", service.CatalogService).
 		WithTableRow("Version", service.CatalogServiceVersion).
+		WithTableRow("Chart", chart).
 		WithTableRow("Status", service.Status.String()).
 		WithTableRow("Used-By", strings.Join(boundApps, ", ")).
 		WithTableRow("Internal Routes", strings.Join(internalRoutes, ", ")).
@@ -323,27 +389,37 @@ func (c *EpinioClient) ServiceDelete(serviceNames []string, unbind, all bool) er
 	return nil
 }
 
-// ServiceBind binds a service to an application
-func (c *EpinioClient) ServiceBind(name, appName string) error {
+// ServiceBind binds a service to an application. With noRestart a deployed application is not
+// restarted, and the binding applies on its next restart or deployment.
+func (c *EpinioClient) ServiceBind(name, appName string, noRestart bool) error {
 	log := c.Log.WithName("ServiceBind")
 	log.Info("start")
 	defer log.Info("return")
 
 	c.ui.Note().Msg("Binding Service...")
 
+	restart := !noRestart
 	request := models.ServiceBindRequest{
 		AppName: appName,
+		Restart: &restart,
 	}
 
-	_, err := c.API.ServiceBind(request, c.Settings.Namespace, name)
-	// Note: errors.Wrap (nil, "...") == nil
-	return errors.Wrap(err, "service bind failed")
+	resp, err := c.API.ServiceBind(request, c.Settings.Namespace, name)
+	if err != nil {
+		return errors.Wrap(err, "service bind failed")
+	}
+
+	c.printServiceBindResponse(appName, resp)
+	return nil
 }
 
-// ServiceBatchBind binds multiple services to an application at once
-func (c *EpinioClient) ServiceBatchBind(appName string, serviceNames []string) error {
+// ServiceBatchBind binds multiple services to an application at once. With partial set the
+// services which can be bound are, and the result of each service is shown. With noRestart a
+// deployed application is not restarted, and the bindings apply on its next restart or
+// deployment.
+func (c *EpinioClient) ServiceBatchBind(appName string, serviceNames []string, partial, noRestart bool) error {
 	log := c.Log.WithName("ServiceBatchBind")
-	log.Info("start", "services", serviceNames)
+	log.Info("start", "services", serviceNames, "partial", partial, "noRestart", noRestart)
 	defer log.Info("return")
 
 	c.ui.Note().
@@ -351,25 +427,139 @@ func (c *EpinioClient) ServiceBatchBind(appName string, serviceNames []string) e
 		WithStringValue("Services", strings.Join(serviceNames, ", ")).
 		Msg("Binding Services...")
 
+	restart := !noRestart
 	request := models.ServiceBatchBindRequest{
 		AppName:      appName,
 		ServiceNames: serviceNames,
+		Partial:      partial,
+		Restart:      &restart,
 	}
 
-	_, err := c.API.ServiceBatchBind(request, c.Settings.Namespace, appName)
+	resp, err := c.API.ServiceBatchBind(request, c.Settings.Namespace, appName)
 	if err != nil {
 		return errors.Wrap(err, "service batch bind failed")
 	}
 
+	if partial {
+		return c.printServiceBatchBindResults(appName, resp)
+	}
+
 	c.ui.Success().
 		WithStringValue("Application", appName).
 		WithStringValue("Services", strings.Join(serviceNames, ", ")).
 		WithStringValue("Namespace", c.Settings.Namespace).
 		Msg("Services Bound Successfully.")
 
+	c.printServiceBindResponse(appName, resp.ServiceBindResponse)
+	return nil
+}
+
+// printServiceBatchBindResults shows the result of each service of a partial batch bind, and
+// the configuration keys of the bound services. It is an error when no service was bound.
+func (c *EpinioClient) printServiceBatchBindResults(appName string, resp models.ServiceBatchBindResponse) error {
+	bound := []string{}
+	msg := c.ui.Note().WithTable("Service", "Status", "Error")
+	for _, result := range resp.Results {
+		msg = msg.WithTableRow(result.Service, result.Status, result.Error)
+		if result.Status == models.ServiceBindStatusBound {
+			bound = append(bound, result.Service)
+		}
+	}
+	msg.Msg("Service Bind Results")
+
+	if len(bound) == 0 {
+		return errors.New("no service was bound")
+	}
+
+	if len(bound) < len(resp.Results) {
+		c.ui.Exclamation().
+			WithStringValue("Application", appName).
+			Msg("Some services could not be bound.")
+	}
+
+	c.ui.Success().
+		WithStringValue("Application", appName).
+		WithStringValue("Services", strings.Join(bound, ", ")).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Services Bound.")
+
+	c.printServiceBindResponse(appName, resp.ServiceBindResponse)
 	return nil
 }
 
+// ServiceRebind moves an application between services, unbinding and binding them at once
+func (c *EpinioClient) ServiceRebind(appName string, remove, add []string) error {
+	log := c.Log.WithName("ServiceRebind")
+	log.Info("start", "remove", remove, "add", add)
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Application", appName).
+		WithStringValue("Remove", strings.Join(remove, ", ")).
+		WithStringValue("Add", strings.Join(add, ", ")).
+		Msg("Rebinding Services...")
+
+	request := models.ServiceRebindRequest{
+		AppName: appName,
+		Remove:  remove,
+		Add:     add,
+	}
+
+	resp, err := c.API.ServiceRebind(request, c.Settings.Namespace, appName)
+	if err != nil {
+		return errors.Wrap(err, "service rebind failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Application", appName).
+		WithStringValue("Removed", strings.Join(remove, ", ")).
+		WithStringValue("Added", strings.Join(add, ", ")).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Services Rebound Successfully.")
+
+	c.printServiceBindResponse(appName, resp)
+	return nil
+}
+
+// printServiceBindResponse shows the configuration keys the bound services contribute to the
+// application, and where the application finds them. It further notes when the bindings only
+// apply on the first deployment of the application.
+func (c *EpinioClient) printServiceBindResponse(appName string, resp models.ServiceBindResponse) {
+	if resp.ApplyOnDeploy {
+		c.ui.Note().
+			WithStringValue("Application", appName).
+			Msg("Application is not deployed yet. The bindings apply on its first deployment.")
+	}
+	if resp.ApplyOnRestart {
+		c.ui.Note().
+			WithStringValue("Application", appName).
+			Msg("Application was not restarted. The bindings apply on its next restart (`epinio app restart`) or deployment.")
+	}
+
+	services := resp.Services
+	if len(services) == 0 {
+		return
+	}
+
+	msg := c.ui.Note().WithTable("Service", "Configuration", "Key", "Access Path")
+	for _, service := range services {
+		for _, configuration := range service.Configurations {
+			if len(configuration.Keys) == 0 {
+				msg = msg.WithTableRow(service.Service, configuration.Name, "", configuration.MountPath)
+				continue
+			}
+			for _, key := range configuration.Keys {
+				msg = msg.WithTableRow(service.Service, configuration.Name, key,
+					fmt.Sprintf("%s/%s", configuration.MountPath, key))
+			}
+		}
+	}
+	msg.Msg("Bound Configuration Keys")
+
+	c.ui.Exclamation().
+		Msg("Beware, the shown access paths are only available in the application's container")
+}
+
 // ServiceUnbind unbinds a service from an application
 func (c *EpinioClient) ServiceUnbind(name, appName string) error {
 	log := c.Log.WithName("ServiceUnbind")
@@ -0,0 +1,130 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usercmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/epinio/epinio/internal/cli/settings"
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/internal/cli/usercmd/usercmdfakes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Client Services unit tests", func() {
+	var (
+		fake         *usercmdfakes.FakeAPIClient
+		epinioClient *usercmd.EpinioClient
+		output       *bytes.Buffer
+		service      models.Service
+	)
+
+	BeforeEach(func() {
+		fake = &usercmdfakes.FakeAPIClient{}
+
+		var err error
+		epinioClient, err = usercmd.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		epinioClient.Settings = &settings.Settings{Namespace: "workspace"}
+		epinioClient.API = fake
+
+		output = &bytes.Buffer{}
+		epinioClient.UI().SetOutput(output)
+
+		service = models.Service{
+			Meta:                  models.Meta{Name: "mydb", Namespace: "workspace"},
+			CatalogService:        "postgresql-dev",
+			CatalogServiceVersion: "15.1.0",
+			Status:                models.ServiceStatusDeployed,
+			BoundApps:             []string{"myapp"},
+			Settings:              models.ChartValueSettings{"auth.database": "shop"},
+		}
+		fake.ServiceShowReturns(&service, nil)
+		fake.ServiceListReturns(models.ServiceList{service}, nil)
+	})
+
+	Describe("ServiceShow", func() {
+		It("writes the service as pure json", func() {
+			epinioClient.UI().EnableJSON()
+
+			err := epinioClient.ServiceShow("mydb")
+			Expect(err).ToNot(HaveOccurred())
+
+			shown := models.Service{}
+			Expect(json.Unmarshal(output.Bytes(), &shown)).To(Succeed(), output.String())
+			Expect(shown).To(Equal(service))
+		})
+
+		It("writes the service as pure yaml", func() {
+			epinioClient.UI().EnableYAML()
+
+			err := epinioClient.ServiceShow("mydb")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output.String()).To(ContainSubstring("catalog_service: postgresql-dev"))
+
+			shown := models.Service{}
+			Expect(yaml.Unmarshal(output.Bytes(), &shown)).To(Succeed(), output.String())
+			Expect(shown).To(Equal(service))
+		})
+	})
+
+	Describe("ServiceList", func() {
+		It("writes the services as pure yaml", func() {
+			epinioClient.UI().EnableYAML()
+
+			err := epinioClient.ServiceList()
+			Expect(err).ToNot(HaveOccurred())
+
+			listed := models.ServiceList{}
+			Expect(yaml.Unmarshal(output.Bytes(), &listed)).To(Succeed(), output.String())
+			Expect(listed).To(Equal(models.ServiceList{service}))
+		})
+	})
+	Describe("ServiceUpdate", func() {
+		It("reports the restarted apps", func() {
+			fake.ServiceUpdateReturns(models.ServiceUpdateResponse{
+				Response:      models.ResponseOK,
+				RestartedApps: []string{"myapp"},
+			}, nil)
+
+			err := epinioClient.ServiceUpdate("mydb", true, nil, map[string]string{"auth.database": "shop"}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			request, namespace, name := fake.ServiceUpdateArgsForCall(0)
+			Expect(namespace).To(Equal("workspace"))
+			Expect(name).To(Equal("mydb"))
+			Expect(request.Wait).To(BeTrue())
+			Expect(*request.Restart).To(BeTrue())
+
+			Expect(output.String()).To(ContainSubstring("Restarted: myapp"))
+			Expect(output.String()).ToNot(ContainSubstring("Restarting"))
+		})
+
+		It("reports the apps pending the restart", func() {
+			fake.ServiceUpdateReturns(models.ServiceUpdateResponse{
+				Response:           models.ResponseOK,
+				PendingRestartApps: []string{"myapp"},
+			}, nil)
+
+			err := epinioClient.ServiceUpdate("mydb", false, nil, map[string]string{"auth.database": "shop"}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(output.String()).To(ContainSubstring("Restarting: myapp"))
+			Expect(output.String()).ToNot(ContainSubstring("Restarted"))
+		})
+	})
+})
@@ -15,13 +15,20 @@ package usercmd
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
+	"net/http"
 	"strings"
 
 	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/pkg/api/core/v1/client"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/fatih/color"
 
 	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
 )
 
 // SettingsColors will update the settings colors configuration
@@ -39,15 +46,28 @@ func (c *EpinioClient) SettingsColors(ctx context.Context, colors bool) error {
 	return nil
 }
 
-// SettingsShow display the current settings configuration
-func (c *EpinioClient) SettingsShow(showPassword, showToken bool) {
-	c.ui.Note().
-		WithStringValue("Settings", helpers.AbsPath(c.Settings.Location)).
-		Msg("Show Settings")
+// SettingsShowOutput is the structured form of the settings shown by `settings show`, for the
+// json and yaml outputs. Secrets are masked as in the table.
+type SettingsShowOutput struct {
+	Settings     string `json:"settings"`
+	Context      string `json:"context"`
+	Colors       bool   `json:"colors"`
+	Namespace    string `json:"namespace"`
+	AppChart     string `json:"appChart"`
+	User         string `json:"user"`
+	Password     string `json:"password"`
+	Token        string `json:"token"`
+	API          string `json:"api"`
+	WSS          string `json:"wss"`
+	Certificates string `json:"certificates"`
+}
 
-	certInfo := color.CyanString("None defined")
+// SettingsShow display the current settings configuration, as a table, or in the json or yaml
+// output format
+func (c *EpinioClient) SettingsShow(showPassword, showToken bool, output string) error {
+	certInfo := "None defined"
 	if c.Settings.Certs != "" {
-		certInfo = color.BlueString("Present")
+		certInfo = "Present"
 	}
 
 	var password string
@@ -66,8 +86,52 @@ func (c *EpinioClient) SettingsShow(showPassword, showToken bool) {
 		}
 	}
 
+	switch output {
+	case "json", "yaml":
+		// pure data, no banner
+		settingsOutput := SettingsShowOutput{
+			Settings:     helpers.AbsPath(c.Settings.Location),
+			Context:      c.Settings.Context,
+			Colors:       c.Settings.Colors,
+			Namespace:    c.Settings.Namespace,
+			AppChart:     c.Settings.AppChart,
+			User:         c.Settings.User,
+			Password:     password,
+			Token:        token,
+			API:          c.Settings.API,
+			WSS:          c.Settings.WSS,
+			Certificates: certInfo,
+		}
+
+		var data []byte
+		var err error
+		if output == "json" {
+			data, err = json.MarshalIndent(settingsOutput, "", "  ")
+			data = append(data, '\n')
+		} else {
+			data, err = yaml.Marshal(settingsOutput)
+		}
+		if err != nil {
+			return errors.Wrap(err, "encoding settings")
+		}
+
+		c.ui.Raw(string(data))
+		return nil
+	}
+
+	c.ui.Note().
+		WithStringValue("Settings", helpers.AbsPath(c.Settings.Location)).
+		Msg("Show Settings")
+
+	if c.Settings.Certs != "" {
+		certInfo = color.BlueString(certInfo)
+	} else {
+		certInfo = color.CyanString(certInfo)
+	}
+
 	c.ui.Success().
 		WithTable("Key", "Value").
+		WithTableRow("Current Context", color.CyanString(c.Settings.Context)).
 		WithTableRow("Colorized Output", color.MagentaString("%t", c.Settings.Colors)).
 		WithTableRow("Current Namespace", color.CyanString(c.Settings.Namespace)).
 		WithTableRow("Default App Chart", color.CyanString(c.Settings.AppChart)).
@@ -78,6 +142,202 @@ func (c *EpinioClient) SettingsShow(showPassword, showToken bool) {
 		WithTableRow("WSS Url", color.BlueString(c.Settings.WSS)).
 		WithTableRow("Certificates", certInfo).
 		Msg("Ok")
+
+	return nil
+}
+
+// SettingsContext is the structured form of a context listed by `settings list-contexts`
+type SettingsContext struct {
+	Name      string `json:"name"`
+	Current   bool   `json:"current"`
+	API       string `json:"api"`
+	User      string `json:"user"`
+	Namespace string `json:"namespace"`
+}
+
+// SettingsListContexts lists the named contexts of the settings, marking the active one
+func (c *EpinioClient) SettingsListContexts() error {
+	log := c.Log.WithName("SettingsListContexts")
+	log.Info("start")
+	defer log.Info("return")
+
+	contexts := []SettingsContext{}
+	for _, name := range c.Settings.ContextNames() {
+		context := SettingsContext{Name: name}
+		if name == c.Settings.Context {
+			context.Current = true
+			context.API = c.Settings.API
+			context.User = c.Settings.User
+			context.Namespace = c.Settings.Namespace
+		} else {
+			stored := c.Settings.Contexts[name]
+			context.API = stored.API
+			context.User = stored.User
+			context.Namespace = stored.Namespace
+		}
+		contexts = append(contexts, context)
+	}
+
+	if c.ui.JSONEnabled() {
+		return c.ui.JSON(contexts)
+	}
+
+	c.ui.Note().
+		WithStringValue("Settings", helpers.AbsPath(c.Settings.Location)).
+		Msg("Listing contexts")
+
+	msg := c.ui.Success().WithTable("Current", "Name", "API Url", "User", "Namespace")
+	for _, context := range contexts {
+		current := ""
+		if context.Current {
+			current = "*"
+		}
+		msg = msg.WithTableRow(current, context.Name, context.API, context.User, context.Namespace)
+	}
+	msg.Msg("Epinio Contexts:")
+
+	return nil
+}
+
+// SettingsUseContext makes the named context the active one, creating it empty if asked to
+func (c *EpinioClient) SettingsUseContext(ctx context.Context, name string, create bool) error {
+	log := c.Log.WithName("SettingsUseContext").WithValues("Context", name)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Settings", helpers.AbsPath(c.Settings.Location)).
+		WithStringValue("Context", name).
+		Msg("Switching context")
+
+	if err := c.Settings.UseContext(name, create); err != nil {
+		return err
+	}
+	if err := c.Settings.Save(); err != nil {
+		return errors.Wrap(err, "failed to save settings")
+	}
+
+	msg := c.ui.Success().
+		WithStringValue("Context", c.Settings.Context).
+		WithStringValue("API Url", c.Settings.API)
+	if c.Settings.API == "" {
+		msg = msg.WithStringValue("Hint", "login to a server with `epinio login`")
+	}
+	msg.Msg("Ok")
+
+	return nil
+}
+
+// ContextsMatching returns the names of all contexts starting with the prefix
+func (c *EpinioClient) ContextsMatching(prefix string) []string {
+	result := []string{}
+	for _, name := range c.Settings.ContextNames() {
+		if strings.HasPrefix(name, prefix) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// SettingsValidate checks the current settings against the server they point to. It checks that
+// the server is reachable, trusted and ready, and that the stored credentials are accepted. Each
+// class of failure is returned as its own error, with a hint about how to fix it.
+func (c *EpinioClient) SettingsValidate(ctx context.Context) error {
+	log := c.Log.WithName("SettingsValidate")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Settings", helpers.AbsPath(c.Settings.Location)).
+		Msg("Validating Settings")
+
+	if c.Settings.API == "" {
+		return errors.New("no API server in the settings, please login with `epinio login`")
+	}
+
+	if err := c.API.Ready(); err != nil {
+		apiErr := &client.APIError{}
+		switch {
+		case errors.As(err, &apiErr):
+			return errors.Wrapf(err, "server '%s' is not ready", c.Settings.API)
+		case isCertificateError(err):
+			return errors.Wrapf(err, "server certificate not trusted, update it with `epinio settings update-ca`")
+		default:
+			return errors.Wrapf(err, "server '%s' unreachable", c.Settings.API)
+		}
+	}
+
+	if c.Settings.Token.AccessToken == "" && c.Settings.User == "" {
+		return errors.New("no credentials in the settings, please login with `epinio login`")
+	}
+
+	me, err := c.API.Me()
+	if err != nil {
+		apiErr := &client.APIError{}
+		switch {
+		case errors.Is(err, client.ErrSessionExpired):
+			return errors.Wrap(err, "access token expired")
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+			return errors.New("credentials rejected by the server, please login again with `epinio login`")
+		default:
+			return errors.Wrap(err, "verifying credentials")
+		}
+	}
+
+	namespace := "None targeted"
+	if c.Settings.Namespace != "" {
+		namespace = c.Settings.Namespace
+		if !canAccessNamespace(me, c.Settings.Namespace) {
+			namespace += " (not accessible)"
+		}
+	}
+
+	certInfo := "System trust store"
+	if !strings.HasPrefix(c.Settings.API, "https://") {
+		certInfo = "Not used (plain http)"
+	} else if c.Settings.Certs != "" {
+		certInfo = "Trusted, from the settings"
+	}
+
+	c.ui.Success().
+		WithTable("Check", "Status").
+		WithTableRow("API Url", color.BlueString(c.Settings.API)).
+		WithTableRow("User", color.BlueString(me.User)).
+		WithTableRow("Namespace", color.CyanString(namespace)).
+		WithTableRow("Certificates", color.CyanString(certInfo)).
+		Msg("Settings are valid")
+
+	return nil
+}
+
+// isCertificateError returns true if the error comes from the verification of the server
+// certificate
+func isCertificateError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var verificationErr *tls.CertificateVerificationError
+
+	return errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &verificationErr)
+}
+
+// canAccessNamespace returns true if the user described by the `me` response has access to the
+// namespace, either as an admin or through one of its namespaces or namespaced roles
+func canAccessNamespace(me models.MeResponse, namespace string) bool {
+	for _, role := range me.Roles {
+		if role.ID == "admin" || role.Namespace == namespace {
+			return true
+		}
+	}
+	for _, ns := range me.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
 }
 
 // SettingsUpdateCA updates the CA credentials stored in the settings
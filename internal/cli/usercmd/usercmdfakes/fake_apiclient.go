@@ -14,6 +14,7 @@ package usercmdfakes
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"sync"
 
@@ -25,6 +26,45 @@ import (
 )
 
 type FakeAPIClient struct {
+	APITokenCreateStub        func(models.APITokenCreateRequest) (models.APITokenCreateResponse, error)
+	aPITokenCreateMutex       sync.RWMutex
+	aPITokenCreateArgsForCall []struct {
+		arg1 models.APITokenCreateRequest
+	}
+	aPITokenCreateReturns struct {
+		result1 models.APITokenCreateResponse
+		result2 error
+	}
+	aPITokenCreateReturnsOnCall map[int]struct {
+		result1 models.APITokenCreateResponse
+		result2 error
+	}
+	APITokenDeleteStub        func(string, string) (models.Response, error)
+	aPITokenDeleteMutex       sync.RWMutex
+	aPITokenDeleteArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	aPITokenDeleteReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	aPITokenDeleteReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	APITokensStub        func() (models.APITokenList, error)
+	aPITokensMutex       sync.RWMutex
+	aPITokensArgsForCall []struct {
+	}
+	aPITokensReturns struct {
+		result1 models.APITokenList
+		result2 error
+	}
+	aPITokensReturnsOnCall map[int]struct {
+		result1 models.APITokenList
+		result2 error
+	}
 	AllAppsStub        func() (models.AppList, error)
 	allAppsMutex       sync.RWMutex
 	allAppsArgsForCall []struct {
@@ -37,6 +77,18 @@ type FakeAPIClient struct {
 		result1 models.AppList
 		result2 error
 	}
+	AllAppsWithWarningsStub        func() (models.AppListResponse, error)
+	allAppsWithWarningsMutex       sync.RWMutex
+	allAppsWithWarningsArgsForCall []struct {
+	}
+	allAppsWithWarningsReturns struct {
+		result1 models.AppListResponse
+		result2 error
+	}
+	allAppsWithWarningsReturnsOnCall map[int]struct {
+		result1 models.AppListResponse
+		result2 error
+	}
 	AllConfigurationsStub        func() (models.ConfigurationResponseList, error)
 	allConfigurationsMutex       sync.RWMutex
 	allConfigurationsArgsForCall []struct {
@@ -75,6 +127,21 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	AppDebugStub        func(context.Context, string, string, string, term.TTY) error
+	appDebugMutex       sync.RWMutex
+	appDebugArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 term.TTY
+	}
+	appDebugReturns struct {
+		result1 error
+	}
+	appDebugReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppDeleteStub        func(string, []string, bool) (models.ApplicationDeleteResponse, error)
 	appDeleteMutex       sync.RWMutex
 	appDeleteArgsForCall []struct {
@@ -103,14 +170,30 @@ type FakeAPIClient struct {
 		result1 *models.DeployResponse
 		result2 error
 	}
-	AppExecStub        func(context.Context, string, string, string, term.TTY) error
+	AppEventsStub        func(string, string) (models.EventList, error)
+	appEventsMutex       sync.RWMutex
+	appEventsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appEventsReturns struct {
+		result1 models.EventList
+		result2 error
+	}
+	appEventsReturnsOnCall map[int]struct {
+		result1 models.EventList
+		result2 error
+	}
+	AppExecStub        func(context.Context, string, string, string, []string, term.TTY, io.Writer) error
 	appExecMutex       sync.RWMutex
 	appExecArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
 		arg4 string
-		arg5 term.TTY
+		arg5 []string
+		arg6 term.TTY
+		arg7 io.Writer
 	}
 	appExecReturns struct {
 		result1 error
@@ -133,6 +216,21 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	AppGetImagePartStub        func(string, string, string) (models.AppPartResponse, error)
+	appGetImagePartMutex       sync.RWMutex
+	appGetImagePartArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	appGetImagePartReturns struct {
+		result1 models.AppPartResponse
+		result2 error
+	}
+	appGetImagePartReturnsOnCall map[int]struct {
+		result1 models.AppPartResponse
+		result2 error
+	}
 	AppGetPartStub        func(string, string, string) (models.AppPartResponse, error)
 	appGetPartMutex       sync.RWMutex
 	appGetPartArgsForCall []struct {
@@ -207,18 +305,18 @@ type FakeAPIClient struct {
 	appPortForwardReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AppRestartStub        func(string, string) (models.Response, error)
+	AppRestartStub        func(string, string) (models.AppRestartResponse, error)
 	appRestartMutex       sync.RWMutex
 	appRestartArgsForCall []struct {
 		arg1 string
 		arg2 string
 	}
 	appRestartReturns struct {
-		result1 models.Response
+		result1 models.AppRestartResponse
 		result2 error
 	}
 	appRestartReturnsOnCall map[int]struct {
-		result1 models.Response
+		result1 models.AppRestartResponse
 		result2 error
 	}
 	AppRunningStub        func(models.AppRef) (models.Response, error)
@@ -261,6 +359,20 @@ type FakeAPIClient struct {
 		result1 *models.StageResponse
 		result2 error
 	}
+	AppStagingLogsStub        func(string, string, *client.LogOptions, func(tailer.ContainerLogLine)) error
+	appStagingLogsMutex       sync.RWMutex
+	appStagingLogsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *client.LogOptions
+		arg4 func(tailer.ContainerLogLine)
+	}
+	appStagingLogsReturns struct {
+		result1 error
+	}
+	appStagingLogsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AppUpdateStub        func(models.ApplicationUpdateRequest, string, string) (models.Response, error)
 	appUpdateMutex       sync.RWMutex
 	appUpdateArgsForCall []struct {
@@ -318,6 +430,19 @@ type FakeAPIClient struct {
 		result1 models.AppList
 		result2 error
 	}
+	AppsWithWarningsStub        func(string) (models.AppListResponse, error)
+	appsWithWarningsMutex       sync.RWMutex
+	appsWithWarningsArgsForCall []struct {
+		arg1 string
+	}
+	appsWithWarningsReturns struct {
+		result1 models.AppListResponse
+		result2 error
+	}
+	appsWithWarningsReturnsOnCall map[int]struct {
+		result1 models.AppListResponse
+		result2 error
+	}
 	AuthTokenStub        func() (models.AuthTokenResponse, error)
 	authTokenMutex       sync.RWMutex
 	authTokenArgsForCall []struct {
@@ -737,6 +862,48 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	NamespaceRoleGrantStub        func(string, models.NamespaceRoleGrantRequest) (models.Response, error)
+	namespaceRoleGrantMutex       sync.RWMutex
+	namespaceRoleGrantArgsForCall []struct {
+		arg1 string
+		arg2 models.NamespaceRoleGrantRequest
+	}
+	namespaceRoleGrantReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceRoleGrantReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceRoleRevokeStub        func(string, string, string) (models.Response, error)
+	namespaceRoleRevokeMutex       sync.RWMutex
+	namespaceRoleRevokeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	namespaceRoleRevokeReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceRoleRevokeReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceRolesStub        func(string) (models.NamespaceRoleBindingList, error)
+	namespaceRolesMutex       sync.RWMutex
+	namespaceRolesArgsForCall []struct {
+		arg1 string
+	}
+	namespaceRolesReturns struct {
+		result1 models.NamespaceRoleBindingList
+		result2 error
+	}
+	namespaceRolesReturnsOnCall map[int]struct {
+		result1 models.NamespaceRoleBindingList
+		result2 error
+	}
 	NamespaceShowStub        func(string) (models.Namespace, error)
 	namespaceShowMutex       sync.RWMutex
 	namespaceShowArgsForCall []struct {
@@ -775,7 +942,17 @@ type FakeAPIClient struct {
 		result1 models.NamespacesMatchResponse
 		result2 error
 	}
-	ServiceBatchBindStub        func(models.ServiceBatchBindRequest, string, string) (models.Response, error)
+	ReadyStub        func() error
+	readyMutex       sync.RWMutex
+	readyArgsForCall []struct {
+	}
+	readyReturns struct {
+		result1 error
+	}
+	readyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ServiceBatchBindStub        func(models.ServiceBatchBindRequest, string, string) (models.ServiceBatchBindResponse, error)
 	serviceBatchBindMutex       sync.RWMutex
 	serviceBatchBindArgsForCall []struct {
 		arg1 models.ServiceBatchBindRequest
@@ -783,14 +960,14 @@ type FakeAPIClient struct {
 		arg3 string
 	}
 	serviceBatchBindReturns struct {
-		result1 models.Response
+		result1 models.ServiceBatchBindResponse
 		result2 error
 	}
 	serviceBatchBindReturnsOnCall map[int]struct {
-		result1 models.Response
+		result1 models.ServiceBatchBindResponse
 		result2 error
 	}
-	ServiceBindStub        func(models.ServiceBindRequest, string, string) (models.Response, error)
+	ServiceBindStub        func(models.ServiceBindRequest, string, string) (models.ServiceBindResponse, error)
 	serviceBindMutex       sync.RWMutex
 	serviceBindArgsForCall []struct {
 		arg1 models.ServiceBindRequest
@@ -798,11 +975,11 @@ type FakeAPIClient struct {
 		arg3 string
 	}
 	serviceBindReturns struct {
-		result1 models.Response
+		result1 models.ServiceBindResponse
 		result2 error
 	}
 	serviceBindReturnsOnCall map[int]struct {
-		result1 models.Response
+		result1 models.ServiceBindResponse
 		result2 error
 	}
 	ServiceCatalogStub        func() (models.CatalogServices, error)
@@ -843,18 +1020,33 @@ type FakeAPIClient struct {
 		result1 *models.CatalogService
 		result2 error
 	}
-	ServiceCreateStub        func(models.ServiceCreateRequest, string) (models.Response, error)
+	ServiceCloneStub        func(models.ServiceCloneRequest, string, string) (models.ServiceCreateResponse, error)
+	serviceCloneMutex       sync.RWMutex
+	serviceCloneArgsForCall []struct {
+		arg1 models.ServiceCloneRequest
+		arg2 string
+		arg3 string
+	}
+	serviceCloneReturns struct {
+		result1 models.ServiceCreateResponse
+		result2 error
+	}
+	serviceCloneReturnsOnCall map[int]struct {
+		result1 models.ServiceCreateResponse
+		result2 error
+	}
+	ServiceCreateStub        func(models.ServiceCreateRequest, string) (models.ServiceCreateResponse, error)
 	serviceCreateMutex       sync.RWMutex
 	serviceCreateArgsForCall []struct {
 		arg1 models.ServiceCreateRequest
 		arg2 string
 	}
 	serviceCreateReturns struct {
-		result1 models.Response
+		result1 models.ServiceCreateResponse
 		result2 error
 	}
 	serviceCreateReturnsOnCall map[int]struct {
-		result1 models.Response
+		result1 models.ServiceCreateResponse
 		result2 error
 	}
 	ServiceDeleteStub        func(models.ServiceDeleteRequest, string, []string) (models.ServiceDeleteResponse, error)
@@ -912,6 +1104,21 @@ type FakeAPIClient struct {
 	servicePortForwardReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ServiceRebindStub        func(models.ServiceRebindRequest, string, string) (models.ServiceBindResponse, error)
+	serviceRebindMutex       sync.RWMutex
+	serviceRebindArgsForCall []struct {
+		arg1 models.ServiceRebindRequest
+		arg2 string
+		arg3 string
+	}
+	serviceRebindReturns struct {
+		result1 models.ServiceBindResponse
+		result2 error
+	}
+	serviceRebindReturnsOnCall map[int]struct {
+		result1 models.ServiceBindResponse
+		result2 error
+	}
 	ServiceShowStub        func(string, string) (*models.Service, error)
 	serviceShowMutex       sync.RWMutex
 	serviceShowArgsForCall []struct {
@@ -941,7 +1148,7 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
-	ServiceUpdateStub        func(models.ServiceUpdateRequest, string, string) (models.Response, error)
+	ServiceUpdateStub        func(models.ServiceUpdateRequest, string, string) (models.ServiceUpdateResponse, error)
 	serviceUpdateMutex       sync.RWMutex
 	serviceUpdateArgsForCall []struct {
 		arg1 models.ServiceUpdateRequest
@@ -949,11 +1156,11 @@ type FakeAPIClient struct {
 		arg3 string
 	}
 	serviceUpdateReturns struct {
-		result1 models.Response
+		result1 models.ServiceUpdateResponse
 		result2 error
 	}
 	serviceUpdateReturnsOnCall map[int]struct {
-		result1 models.Response
+		result1 models.ServiceUpdateResponse
 		result2 error
 	}
 	SetHeaderStub        func(string, string)
@@ -962,18 +1169,18 @@ type FakeAPIClient struct {
 		arg1 string
 		arg2 string
 	}
-	StagingCompleteStub        func(string, string) (models.Response, error)
+	StagingCompleteStub        func(string, string) (models.StagingCompleteResponse, error)
 	stagingCompleteMutex       sync.RWMutex
 	stagingCompleteArgsForCall []struct {
 		arg1 string
 		arg2 string
 	}
 	stagingCompleteReturns struct {
-		result1 models.Response
+		result1 models.StagingCompleteResponse
 		result2 error
 	}
 	stagingCompleteReturnsOnCall map[int]struct {
-		result1 models.Response
+		result1 models.StagingCompleteResponse
 		result2 error
 	}
 	StagingCompleteStreamStub        func(context.Context, string, string, func(models.StageCompleteEvent) error) error
@@ -1004,6 +1211,191 @@ type FakeAPIClient struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeAPIClient) APITokenCreate(arg1 models.APITokenCreateRequest) (models.APITokenCreateResponse, error) {
+	fake.aPITokenCreateMutex.Lock()
+	ret, specificReturn := fake.aPITokenCreateReturnsOnCall[len(fake.aPITokenCreateArgsForCall)]
+	fake.aPITokenCreateArgsForCall = append(fake.aPITokenCreateArgsForCall, struct {
+		arg1 models.APITokenCreateRequest
+	}{arg1})
+	stub := fake.APITokenCreateStub
+	fakeReturns := fake.aPITokenCreateReturns
+	fake.recordInvocation("APITokenCreate", []interface{}{arg1})
+	fake.aPITokenCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) APITokenCreateCallCount() int {
+	fake.aPITokenCreateMutex.RLock()
+	defer fake.aPITokenCreateMutex.RUnlock()
+	return len(fake.aPITokenCreateArgsForCall)
+}
+
+func (fake *FakeAPIClient) APITokenCreateCalls(stub func(models.APITokenCreateRequest) (models.APITokenCreateResponse, error)) {
+	fake.aPITokenCreateMutex.Lock()
+	defer fake.aPITokenCreateMutex.Unlock()
+	fake.APITokenCreateStub = stub
+}
+
+func (fake *FakeAPIClient) APITokenCreateArgsForCall(i int) models.APITokenCreateRequest {
+	fake.aPITokenCreateMutex.RLock()
+	defer fake.aPITokenCreateMutex.RUnlock()
+	argsForCall := fake.aPITokenCreateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) APITokenCreateReturns(result1 models.APITokenCreateResponse, result2 error) {
+	fake.aPITokenCreateMutex.Lock()
+	defer fake.aPITokenCreateMutex.Unlock()
+	fake.APITokenCreateStub = nil
+	fake.aPITokenCreateReturns = struct {
+		result1 models.APITokenCreateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) APITokenCreateReturnsOnCall(i int, result1 models.APITokenCreateResponse, result2 error) {
+	fake.aPITokenCreateMutex.Lock()
+	defer fake.aPITokenCreateMutex.Unlock()
+	fake.APITokenCreateStub = nil
+	if fake.aPITokenCreateReturnsOnCall == nil {
+		fake.aPITokenCreateReturnsOnCall = make(map[int]struct {
+			result1 models.APITokenCreateResponse
+			result2 error
+		})
+	}
+	fake.aPITokenCreateReturnsOnCall[i] = struct {
+		result1 models.APITokenCreateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) APITokenDelete(arg1 string, arg2 string) (models.Response, error) {
+	fake.aPITokenDeleteMutex.Lock()
+	ret, specificReturn := fake.aPITokenDeleteReturnsOnCall[len(fake.aPITokenDeleteArgsForCall)]
+	fake.aPITokenDeleteArgsForCall = append(fake.aPITokenDeleteArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.APITokenDeleteStub
+	fakeReturns := fake.aPITokenDeleteReturns
+	fake.recordInvocation("APITokenDelete", []interface{}{arg1, arg2})
+	fake.aPITokenDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) APITokenDeleteCallCount() int {
+	fake.aPITokenDeleteMutex.RLock()
+	defer fake.aPITokenDeleteMutex.RUnlock()
+	return len(fake.aPITokenDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) APITokenDeleteCalls(stub func(string, string) (models.Response, error)) {
+	fake.aPITokenDeleteMutex.Lock()
+	defer fake.aPITokenDeleteMutex.Unlock()
+	fake.APITokenDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) APITokenDeleteArgsForCall(i int) (string, string) {
+	fake.aPITokenDeleteMutex.RLock()
+	defer fake.aPITokenDeleteMutex.RUnlock()
+	argsForCall := fake.aPITokenDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) APITokenDeleteReturns(result1 models.Response, result2 error) {
+	fake.aPITokenDeleteMutex.Lock()
+	defer fake.aPITokenDeleteMutex.Unlock()
+	fake.APITokenDeleteStub = nil
+	fake.aPITokenDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) APITokenDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.aPITokenDeleteMutex.Lock()
+	defer fake.aPITokenDeleteMutex.Unlock()
+	fake.APITokenDeleteStub = nil
+	if fake.aPITokenDeleteReturnsOnCall == nil {
+		fake.aPITokenDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.aPITokenDeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) APITokens() (models.APITokenList, error) {
+	fake.aPITokensMutex.Lock()
+	ret, specificReturn := fake.aPITokensReturnsOnCall[len(fake.aPITokensArgsForCall)]
+	fake.aPITokensArgsForCall = append(fake.aPITokensArgsForCall, struct {
+	}{})
+	stub := fake.APITokensStub
+	fakeReturns := fake.aPITokensReturns
+	fake.recordInvocation("APITokens", []interface{}{})
+	fake.aPITokensMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) APITokensCallCount() int {
+	fake.aPITokensMutex.RLock()
+	defer fake.aPITokensMutex.RUnlock()
+	return len(fake.aPITokensArgsForCall)
+}
+
+func (fake *FakeAPIClient) APITokensCalls(stub func() (models.APITokenList, error)) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = stub
+}
+
+func (fake *FakeAPIClient) APITokensReturns(result1 models.APITokenList, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	fake.aPITokensReturns = struct {
+		result1 models.APITokenList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) APITokensReturnsOnCall(i int, result1 models.APITokenList, result2 error) {
+	fake.aPITokensMutex.Lock()
+	defer fake.aPITokensMutex.Unlock()
+	fake.APITokensStub = nil
+	if fake.aPITokensReturnsOnCall == nil {
+		fake.aPITokensReturnsOnCall = make(map[int]struct {
+			result1 models.APITokenList
+			result2 error
+		})
+	}
+	fake.aPITokensReturnsOnCall[i] = struct {
+		result1 models.APITokenList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AllApps() (models.AppList, error) {
 	fake.allAppsMutex.Lock()
 	ret, specificReturn := fake.allAppsReturnsOnCall[len(fake.allAppsArgsForCall)]
@@ -1060,6 +1452,62 @@ func (fake *FakeAPIClient) AllAppsReturnsOnCall(i int, result1 models.AppList, r
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AllAppsWithWarnings() (models.AppListResponse, error) {
+	fake.allAppsWithWarningsMutex.Lock()
+	ret, specificReturn := fake.allAppsWithWarningsReturnsOnCall[len(fake.allAppsWithWarningsArgsForCall)]
+	fake.allAppsWithWarningsArgsForCall = append(fake.allAppsWithWarningsArgsForCall, struct {
+	}{})
+	stub := fake.AllAppsWithWarningsStub
+	fakeReturns := fake.allAppsWithWarningsReturns
+	fake.recordInvocation("AllAppsWithWarnings", []interface{}{})
+	fake.allAppsWithWarningsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AllAppsWithWarningsCallCount() int {
+	fake.allAppsWithWarningsMutex.RLock()
+	defer fake.allAppsWithWarningsMutex.RUnlock()
+	return len(fake.allAppsWithWarningsArgsForCall)
+}
+
+func (fake *FakeAPIClient) AllAppsWithWarningsCalls(stub func() (models.AppListResponse, error)) {
+	fake.allAppsWithWarningsMutex.Lock()
+	defer fake.allAppsWithWarningsMutex.Unlock()
+	fake.AllAppsWithWarningsStub = stub
+}
+
+func (fake *FakeAPIClient) AllAppsWithWarningsReturns(result1 models.AppListResponse, result2 error) {
+	fake.allAppsWithWarningsMutex.Lock()
+	defer fake.allAppsWithWarningsMutex.Unlock()
+	fake.AllAppsWithWarningsStub = nil
+	fake.allAppsWithWarningsReturns = struct {
+		result1 models.AppListResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AllAppsWithWarningsReturnsOnCall(i int, result1 models.AppListResponse, result2 error) {
+	fake.allAppsWithWarningsMutex.Lock()
+	defer fake.allAppsWithWarningsMutex.Unlock()
+	fake.AllAppsWithWarningsStub = nil
+	if fake.allAppsWithWarningsReturnsOnCall == nil {
+		fake.allAppsWithWarningsReturnsOnCall = make(map[int]struct {
+			result1 models.AppListResponse
+			result2 error
+		})
+	}
+	fake.allAppsWithWarningsReturnsOnCall[i] = struct {
+		result1 models.AppListResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AllConfigurations() (models.ConfigurationResponseList, error) {
 	fake.allConfigurationsMutex.Lock()
 	ret, specificReturn := fake.allConfigurationsReturnsOnCall[len(fake.allConfigurationsArgsForCall)]
@@ -1237,6 +1685,71 @@ func (fake *FakeAPIClient) AppCreateReturnsOnCall(i int, result1 models.Response
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppDebug(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 term.TTY) error {
+	fake.appDebugMutex.Lock()
+	ret, specificReturn := fake.appDebugReturnsOnCall[len(fake.appDebugArgsForCall)]
+	fake.appDebugArgsForCall = append(fake.appDebugArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 term.TTY
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.AppDebugStub
+	fakeReturns := fake.appDebugReturns
+	fake.recordInvocation("AppDebug", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.appDebugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) AppDebugCallCount() int {
+	fake.appDebugMutex.RLock()
+	defer fake.appDebugMutex.RUnlock()
+	return len(fake.appDebugArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppDebugCalls(stub func(context.Context, string, string, string, term.TTY) error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = stub
+}
+
+func (fake *FakeAPIClient) AppDebugArgsForCall(i int) (context.Context, string, string, string, term.TTY) {
+	fake.appDebugMutex.RLock()
+	defer fake.appDebugMutex.RUnlock()
+	argsForCall := fake.appDebugArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeAPIClient) AppDebugReturns(result1 error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = nil
+	fake.appDebugReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) AppDebugReturnsOnCall(i int, result1 error) {
+	fake.appDebugMutex.Lock()
+	defer fake.appDebugMutex.Unlock()
+	fake.AppDebugStub = nil
+	if fake.appDebugReturnsOnCall == nil {
+		fake.appDebugReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appDebugReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeAPIClient) AppDelete(arg1 string, arg2 []string, arg3 bool) (models.ApplicationDeleteResponse, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -1372,7 +1885,77 @@ func (fake *FakeAPIClient) AppDeployReturnsOnCall(i int, result1 *models.DeployR
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppExec(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 term.TTY) error {
+func (fake *FakeAPIClient) AppEvents(arg1 string, arg2 string) (models.EventList, error) {
+	fake.appEventsMutex.Lock()
+	ret, specificReturn := fake.appEventsReturnsOnCall[len(fake.appEventsArgsForCall)]
+	fake.appEventsArgsForCall = append(fake.appEventsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppEventsStub
+	fakeReturns := fake.appEventsReturns
+	fake.recordInvocation("AppEvents", []interface{}{arg1, arg2})
+	fake.appEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppEventsCallCount() int {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	return len(fake.appEventsArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppEventsCalls(stub func(string, string) (models.EventList, error)) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = stub
+}
+
+func (fake *FakeAPIClient) AppEventsArgsForCall(i int) (string, string) {
+	fake.appEventsMutex.RLock()
+	defer fake.appEventsMutex.RUnlock()
+	argsForCall := fake.appEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppEventsReturns(result1 models.EventList, result2 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	fake.appEventsReturns = struct {
+		result1 models.EventList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppEventsReturnsOnCall(i int, result1 models.EventList, result2 error) {
+	fake.appEventsMutex.Lock()
+	defer fake.appEventsMutex.Unlock()
+	fake.AppEventsStub = nil
+	if fake.appEventsReturnsOnCall == nil {
+		fake.appEventsReturnsOnCall = make(map[int]struct {
+			result1 models.EventList
+			result2 error
+		})
+	}
+	fake.appEventsReturnsOnCall[i] = struct {
+		result1 models.EventList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppExec(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 []string, arg6 term.TTY, arg7 io.Writer) error {
+	var arg5Copy []string
+	if arg5 != nil {
+		arg5Copy = make([]string, len(arg5))
+		copy(arg5Copy, arg5)
+	}
 	fake.appExecMutex.Lock()
 	ret, specificReturn := fake.appExecReturnsOnCall[len(fake.appExecArgsForCall)]
 	fake.appExecArgsForCall = append(fake.appExecArgsForCall, struct {
@@ -1380,14 +1963,16 @@ func (fake *FakeAPIClient) AppExec(arg1 context.Context, arg2 string, arg3 strin
 		arg2 string
 		arg3 string
 		arg4 string
-		arg5 term.TTY
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg5 []string
+		arg6 term.TTY
+		arg7 io.Writer
+	}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7})
 	stub := fake.AppExecStub
 	fakeReturns := fake.appExecReturns
-	fake.recordInvocation("AppExec", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AppExec", []interface{}{arg1, arg2, arg3, arg4, arg5Copy, arg6, arg7})
 	fake.appExecMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1401,17 +1986,17 @@ func (fake *FakeAPIClient) AppExecCallCount() int {
 	return len(fake.appExecArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppExecCalls(stub func(context.Context, string, string, string, term.TTY) error) {
+func (fake *FakeAPIClient) AppExecCalls(stub func(context.Context, string, string, string, []string, term.TTY, io.Writer) error) {
 	fake.appExecMutex.Lock()
 	defer fake.appExecMutex.Unlock()
 	fake.AppExecStub = stub
 }
 
-func (fake *FakeAPIClient) AppExecArgsForCall(i int) (context.Context, string, string, string, term.TTY) {
+func (fake *FakeAPIClient) AppExecArgsForCall(i int) (context.Context, string, string, string, []string, term.TTY, io.Writer) {
 	fake.appExecMutex.RLock()
 	defer fake.appExecMutex.RUnlock()
 	argsForCall := fake.appExecArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
 }
 
 func (fake *FakeAPIClient) AppExecReturns(result1 error) {
@@ -1503,6 +2088,72 @@ func (fake *FakeAPIClient) AppExportReturnsOnCall(i int, result1 models.Response
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppGetImagePart(arg1 string, arg2 string, arg3 string) (models.AppPartResponse, error) {
+	fake.appGetImagePartMutex.Lock()
+	ret, specificReturn := fake.appGetImagePartReturnsOnCall[len(fake.appGetImagePartArgsForCall)]
+	fake.appGetImagePartArgsForCall = append(fake.appGetImagePartArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AppGetImagePartStub
+	fakeReturns := fake.appGetImagePartReturns
+	fake.recordInvocation("AppGetImagePart", []interface{}{arg1, arg2, arg3})
+	fake.appGetImagePartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppGetImagePartCallCount() int {
+	fake.appGetImagePartMutex.RLock()
+	defer fake.appGetImagePartMutex.RUnlock()
+	return len(fake.appGetImagePartArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppGetImagePartCalls(stub func(string, string, string) (models.AppPartResponse, error)) {
+	fake.appGetImagePartMutex.Lock()
+	defer fake.appGetImagePartMutex.Unlock()
+	fake.AppGetImagePartStub = stub
+}
+
+func (fake *FakeAPIClient) AppGetImagePartArgsForCall(i int) (string, string, string) {
+	fake.appGetImagePartMutex.RLock()
+	defer fake.appGetImagePartMutex.RUnlock()
+	argsForCall := fake.appGetImagePartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppGetImagePartReturns(result1 models.AppPartResponse, result2 error) {
+	fake.appGetImagePartMutex.Lock()
+	defer fake.appGetImagePartMutex.Unlock()
+	fake.AppGetImagePartStub = nil
+	fake.appGetImagePartReturns = struct {
+		result1 models.AppPartResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppGetImagePartReturnsOnCall(i int, result1 models.AppPartResponse, result2 error) {
+	fake.appGetImagePartMutex.Lock()
+	defer fake.appGetImagePartMutex.Unlock()
+	fake.AppGetImagePartStub = nil
+	if fake.appGetImagePartReturnsOnCall == nil {
+		fake.appGetImagePartReturnsOnCall = make(map[int]struct {
+			result1 models.AppPartResponse
+			result2 error
+		})
+	}
+	fake.appGetImagePartReturnsOnCall[i] = struct {
+		result1 models.AppPartResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AppGetPart(arg1 string, arg2 string, arg3 string) (models.AppPartResponse, error) {
 	fake.appGetPartMutex.Lock()
 	ret, specificReturn := fake.appGetPartReturnsOnCall[len(fake.appGetPartArgsForCall)]
@@ -1830,7 +2481,7 @@ func (fake *FakeAPIClient) AppPortForwardReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeAPIClient) AppRestart(arg1 string, arg2 string) (models.Response, error) {
+func (fake *FakeAPIClient) AppRestart(arg1 string, arg2 string) (models.AppRestartResponse, error) {
 	fake.appRestartMutex.Lock()
 	ret, specificReturn := fake.appRestartReturnsOnCall[len(fake.appRestartArgsForCall)]
 	fake.appRestartArgsForCall = append(fake.appRestartArgsForCall, struct {
@@ -1856,7 +2507,7 @@ func (fake *FakeAPIClient) AppRestartCallCount() int {
 	return len(fake.appRestartArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppRestartCalls(stub func(string, string) (models.Response, error)) {
+func (fake *FakeAPIClient) AppRestartCalls(stub func(string, string) (models.AppRestartResponse, error)) {
 	fake.appRestartMutex.Lock()
 	defer fake.appRestartMutex.Unlock()
 	fake.AppRestartStub = stub
@@ -1869,28 +2520,28 @@ func (fake *FakeAPIClient) AppRestartArgsForCall(i int) (string, string) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) AppRestartReturns(result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) AppRestartReturns(result1 models.AppRestartResponse, result2 error) {
 	fake.appRestartMutex.Lock()
 	defer fake.appRestartMutex.Unlock()
 	fake.AppRestartStub = nil
 	fake.appRestartReturns = struct {
-		result1 models.Response
+		result1 models.AppRestartResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) AppRestartReturnsOnCall(i int, result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) AppRestartReturnsOnCall(i int, result1 models.AppRestartResponse, result2 error) {
 	fake.appRestartMutex.Lock()
 	defer fake.appRestartMutex.Unlock()
 	fake.AppRestartStub = nil
 	if fake.appRestartReturnsOnCall == nil {
 		fake.appRestartReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+			result1 models.AppRestartResponse
 			result2 error
 		})
 	}
 	fake.appRestartReturnsOnCall[i] = struct {
-		result1 models.Response
+		result1 models.AppRestartResponse
 		result2 error
 	}{result1, result2}
 }
@@ -2088,6 +2739,70 @@ func (fake *FakeAPIClient) AppStageReturnsOnCall(i int, result1 *models.StageRes
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppStagingLogs(arg1 string, arg2 string, arg3 *client.LogOptions, arg4 func(tailer.ContainerLogLine)) error {
+	fake.appStagingLogsMutex.Lock()
+	ret, specificReturn := fake.appStagingLogsReturnsOnCall[len(fake.appStagingLogsArgsForCall)]
+	fake.appStagingLogsArgsForCall = append(fake.appStagingLogsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *client.LogOptions
+		arg4 func(tailer.ContainerLogLine)
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AppStagingLogsStub
+	fakeReturns := fake.appStagingLogsReturns
+	fake.recordInvocation("AppStagingLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.appStagingLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) AppStagingLogsCallCount() int {
+	fake.appStagingLogsMutex.RLock()
+	defer fake.appStagingLogsMutex.RUnlock()
+	return len(fake.appStagingLogsArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppStagingLogsCalls(stub func(string, string, *client.LogOptions, func(tailer.ContainerLogLine)) error) {
+	fake.appStagingLogsMutex.Lock()
+	defer fake.appStagingLogsMutex.Unlock()
+	fake.AppStagingLogsStub = stub
+}
+
+func (fake *FakeAPIClient) AppStagingLogsArgsForCall(i int) (string, string, *client.LogOptions, func(tailer.ContainerLogLine)) {
+	fake.appStagingLogsMutex.RLock()
+	defer fake.appStagingLogsMutex.RUnlock()
+	argsForCall := fake.appStagingLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeAPIClient) AppStagingLogsReturns(result1 error) {
+	fake.appStagingLogsMutex.Lock()
+	defer fake.appStagingLogsMutex.Unlock()
+	fake.AppStagingLogsStub = nil
+	fake.appStagingLogsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) AppStagingLogsReturnsOnCall(i int, result1 error) {
+	fake.appStagingLogsMutex.Lock()
+	defer fake.appStagingLogsMutex.Unlock()
+	fake.AppStagingLogsStub = nil
+	if fake.appStagingLogsReturnsOnCall == nil {
+		fake.appStagingLogsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appStagingLogsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeAPIClient) AppUpdate(arg1 models.ApplicationUpdateRequest, arg2 string, arg3 string) (models.Response, error) {
 	fake.appUpdateMutex.Lock()
 	ret, specificReturn := fake.appUpdateReturnsOnCall[len(fake.appUpdateArgsForCall)]
@@ -2349,6 +3064,70 @@ func (fake *FakeAPIClient) AppsReturnsOnCall(i int, result1 models.AppList, resu
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppsWithWarnings(arg1 string) (models.AppListResponse, error) {
+	fake.appsWithWarningsMutex.Lock()
+	ret, specificReturn := fake.appsWithWarningsReturnsOnCall[len(fake.appsWithWarningsArgsForCall)]
+	fake.appsWithWarningsArgsForCall = append(fake.appsWithWarningsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AppsWithWarningsStub
+	fakeReturns := fake.appsWithWarningsReturns
+	fake.recordInvocation("AppsWithWarnings", []interface{}{arg1})
+	fake.appsWithWarningsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppsWithWarningsCallCount() int {
+	fake.appsWithWarningsMutex.RLock()
+	defer fake.appsWithWarningsMutex.RUnlock()
+	return len(fake.appsWithWarningsArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppsWithWarningsCalls(stub func(string) (models.AppListResponse, error)) {
+	fake.appsWithWarningsMutex.Lock()
+	defer fake.appsWithWarningsMutex.Unlock()
+	fake.AppsWithWarningsStub = stub
+}
+
+func (fake *FakeAPIClient) AppsWithWarningsArgsForCall(i int) string {
+	fake.appsWithWarningsMutex.RLock()
+	defer fake.appsWithWarningsMutex.RUnlock()
+	argsForCall := fake.appsWithWarningsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) AppsWithWarningsReturns(result1 models.AppListResponse, result2 error) {
+	fake.appsWithWarningsMutex.Lock()
+	defer fake.appsWithWarningsMutex.Unlock()
+	fake.AppsWithWarningsStub = nil
+	fake.appsWithWarningsReturns = struct {
+		result1 models.AppListResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppsWithWarningsReturnsOnCall(i int, result1 models.AppListResponse, result2 error) {
+	fake.appsWithWarningsMutex.Lock()
+	defer fake.appsWithWarningsMutex.Unlock()
+	fake.AppsWithWarningsStub = nil
+	if fake.appsWithWarningsReturnsOnCall == nil {
+		fake.appsWithWarningsReturnsOnCall = make(map[int]struct {
+			result1 models.AppListResponse
+			result2 error
+		})
+	}
+	fake.appsWithWarningsReturnsOnCall[i] = struct {
+		result1 models.AppListResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AuthToken() (models.AuthTokenResponse, error) {
 	fake.authTokenMutex.Lock()
 	ret, specificReturn := fake.authTokenReturnsOnCall[len(fake.authTokenArgsForCall)]
@@ -4334,6 +5113,201 @@ func (fake *FakeAPIClient) NamespaceDeleteReturnsOnCall(i int, result1 models.Re
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) NamespaceRoleGrant(arg1 string, arg2 models.NamespaceRoleGrantRequest) (models.Response, error) {
+	fake.namespaceRoleGrantMutex.Lock()
+	ret, specificReturn := fake.namespaceRoleGrantReturnsOnCall[len(fake.namespaceRoleGrantArgsForCall)]
+	fake.namespaceRoleGrantArgsForCall = append(fake.namespaceRoleGrantArgsForCall, struct {
+		arg1 string
+		arg2 models.NamespaceRoleGrantRequest
+	}{arg1, arg2})
+	stub := fake.NamespaceRoleGrantStub
+	fakeReturns := fake.namespaceRoleGrantReturns
+	fake.recordInvocation("NamespaceRoleGrant", []interface{}{arg1, arg2})
+	fake.namespaceRoleGrantMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceRoleGrantCallCount() int {
+	fake.namespaceRoleGrantMutex.RLock()
+	defer fake.namespaceRoleGrantMutex.RUnlock()
+	return len(fake.namespaceRoleGrantArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceRoleGrantCalls(stub func(string, models.NamespaceRoleGrantRequest) (models.Response, error)) {
+	fake.namespaceRoleGrantMutex.Lock()
+	defer fake.namespaceRoleGrantMutex.Unlock()
+	fake.NamespaceRoleGrantStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceRoleGrantArgsForCall(i int) (string, models.NamespaceRoleGrantRequest) {
+	fake.namespaceRoleGrantMutex.RLock()
+	defer fake.namespaceRoleGrantMutex.RUnlock()
+	argsForCall := fake.namespaceRoleGrantArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceRoleGrantReturns(result1 models.Response, result2 error) {
+	fake.namespaceRoleGrantMutex.Lock()
+	defer fake.namespaceRoleGrantMutex.Unlock()
+	fake.NamespaceRoleGrantStub = nil
+	fake.namespaceRoleGrantReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceRoleGrantReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceRoleGrantMutex.Lock()
+	defer fake.namespaceRoleGrantMutex.Unlock()
+	fake.NamespaceRoleGrantStub = nil
+	if fake.namespaceRoleGrantReturnsOnCall == nil {
+		fake.namespaceRoleGrantReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceRoleGrantReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceRoleRevoke(arg1 string, arg2 string, arg3 string) (models.Response, error) {
+	fake.namespaceRoleRevokeMutex.Lock()
+	ret, specificReturn := fake.namespaceRoleRevokeReturnsOnCall[len(fake.namespaceRoleRevokeArgsForCall)]
+	fake.namespaceRoleRevokeArgsForCall = append(fake.namespaceRoleRevokeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.NamespaceRoleRevokeStub
+	fakeReturns := fake.namespaceRoleRevokeReturns
+	fake.recordInvocation("NamespaceRoleRevoke", []interface{}{arg1, arg2, arg3})
+	fake.namespaceRoleRevokeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceRoleRevokeCallCount() int {
+	fake.namespaceRoleRevokeMutex.RLock()
+	defer fake.namespaceRoleRevokeMutex.RUnlock()
+	return len(fake.namespaceRoleRevokeArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceRoleRevokeCalls(stub func(string, string, string) (models.Response, error)) {
+	fake.namespaceRoleRevokeMutex.Lock()
+	defer fake.namespaceRoleRevokeMutex.Unlock()
+	fake.NamespaceRoleRevokeStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceRoleRevokeArgsForCall(i int) (string, string, string) {
+	fake.namespaceRoleRevokeMutex.RLock()
+	defer fake.namespaceRoleRevokeMutex.RUnlock()
+	argsForCall := fake.namespaceRoleRevokeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) NamespaceRoleRevokeReturns(result1 models.Response, result2 error) {
+	fake.namespaceRoleRevokeMutex.Lock()
+	defer fake.namespaceRoleRevokeMutex.Unlock()
+	fake.NamespaceRoleRevokeStub = nil
+	fake.namespaceRoleRevokeReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceRoleRevokeReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceRoleRevokeMutex.Lock()
+	defer fake.namespaceRoleRevokeMutex.Unlock()
+	fake.NamespaceRoleRevokeStub = nil
+	if fake.namespaceRoleRevokeReturnsOnCall == nil {
+		fake.namespaceRoleRevokeReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceRoleRevokeReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceRoles(arg1 string) (models.NamespaceRoleBindingList, error) {
+	fake.namespaceRolesMutex.Lock()
+	ret, specificReturn := fake.namespaceRolesReturnsOnCall[len(fake.namespaceRolesArgsForCall)]
+	fake.namespaceRolesArgsForCall = append(fake.namespaceRolesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceRolesStub
+	fakeReturns := fake.namespaceRolesReturns
+	fake.recordInvocation("NamespaceRoles", []interface{}{arg1})
+	fake.namespaceRolesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceRolesCallCount() int {
+	fake.namespaceRolesMutex.RLock()
+	defer fake.namespaceRolesMutex.RUnlock()
+	return len(fake.namespaceRolesArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceRolesCalls(stub func(string) (models.NamespaceRoleBindingList, error)) {
+	fake.namespaceRolesMutex.Lock()
+	defer fake.namespaceRolesMutex.Unlock()
+	fake.NamespaceRolesStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceRolesArgsForCall(i int) string {
+	fake.namespaceRolesMutex.RLock()
+	defer fake.namespaceRolesMutex.RUnlock()
+	argsForCall := fake.namespaceRolesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceRolesReturns(result1 models.NamespaceRoleBindingList, result2 error) {
+	fake.namespaceRolesMutex.Lock()
+	defer fake.namespaceRolesMutex.Unlock()
+	fake.NamespaceRolesStub = nil
+	fake.namespaceRolesReturns = struct {
+		result1 models.NamespaceRoleBindingList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceRolesReturnsOnCall(i int, result1 models.NamespaceRoleBindingList, result2 error) {
+	fake.namespaceRolesMutex.Lock()
+	defer fake.namespaceRolesMutex.Unlock()
+	fake.NamespaceRolesStub = nil
+	if fake.namespaceRolesReturnsOnCall == nil {
+		fake.namespaceRolesReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceRoleBindingList
+			result2 error
+		})
+	}
+	fake.namespaceRolesReturnsOnCall[i] = struct {
+		result1 models.NamespaceRoleBindingList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) NamespaceShow(arg1 string) (models.Namespace, error) {
 	fake.namespaceShowMutex.Lock()
 	ret, specificReturn := fake.namespaceShowReturnsOnCall[len(fake.namespaceShowArgsForCall)]
@@ -4518,7 +5492,60 @@ func (fake *FakeAPIClient) NamespacesMatchReturnsOnCall(i int, result1 models.Na
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceBatchBind(arg1 models.ServiceBatchBindRequest, arg2 string, arg3 string) (models.Response, error) {
+func (fake *FakeAPIClient) Ready() error {
+	fake.readyMutex.Lock()
+	ret, specificReturn := fake.readyReturnsOnCall[len(fake.readyArgsForCall)]
+	fake.readyArgsForCall = append(fake.readyArgsForCall, struct {
+	}{})
+	stub := fake.ReadyStub
+	fakeReturns := fake.readyReturns
+	fake.recordInvocation("Ready", []interface{}{})
+	fake.readyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ReadyCallCount() int {
+	fake.readyMutex.RLock()
+	defer fake.readyMutex.RUnlock()
+	return len(fake.readyArgsForCall)
+}
+
+func (fake *FakeAPIClient) ReadyCalls(stub func() error) {
+	fake.readyMutex.Lock()
+	defer fake.readyMutex.Unlock()
+	fake.ReadyStub = stub
+}
+
+func (fake *FakeAPIClient) ReadyReturns(result1 error) {
+	fake.readyMutex.Lock()
+	defer fake.readyMutex.Unlock()
+	fake.ReadyStub = nil
+	fake.readyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ReadyReturnsOnCall(i int, result1 error) {
+	fake.readyMutex.Lock()
+	defer fake.readyMutex.Unlock()
+	fake.ReadyStub = nil
+	if fake.readyReturnsOnCall == nil {
+		fake.readyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.readyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceBatchBind(arg1 models.ServiceBatchBindRequest, arg2 string, arg3 string) (models.ServiceBatchBindResponse, error) {
 	fake.serviceBatchBindMutex.Lock()
 	ret, specificReturn := fake.serviceBatchBindReturnsOnCall[len(fake.serviceBatchBindArgsForCall)]
 	fake.serviceBatchBindArgsForCall = append(fake.serviceBatchBindArgsForCall, struct {
@@ -4545,7 +5572,7 @@ func (fake *FakeAPIClient) ServiceBatchBindCallCount() int {
 	return len(fake.serviceBatchBindArgsForCall)
 }
 
-func (fake *FakeAPIClient) ServiceBatchBindCalls(stub func(models.ServiceBatchBindRequest, string, string) (models.Response, error)) {
+func (fake *FakeAPIClient) ServiceBatchBindCalls(stub func(models.ServiceBatchBindRequest, string, string) (models.ServiceBatchBindResponse, error)) {
 	fake.serviceBatchBindMutex.Lock()
 	defer fake.serviceBatchBindMutex.Unlock()
 	fake.ServiceBatchBindStub = stub
@@ -4558,33 +5585,33 @@ func (fake *FakeAPIClient) ServiceBatchBindArgsForCall(i int) (models.ServiceBat
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) ServiceBatchBindReturns(result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceBatchBindReturns(result1 models.ServiceBatchBindResponse, result2 error) {
 	fake.serviceBatchBindMutex.Lock()
 	defer fake.serviceBatchBindMutex.Unlock()
 	fake.ServiceBatchBindStub = nil
 	fake.serviceBatchBindReturns = struct {
-		result1 models.Response
+		result1 models.ServiceBatchBindResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceBatchBindReturnsOnCall(i int, result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceBatchBindReturnsOnCall(i int, result1 models.ServiceBatchBindResponse, result2 error) {
 	fake.serviceBatchBindMutex.Lock()
 	defer fake.serviceBatchBindMutex.Unlock()
 	fake.ServiceBatchBindStub = nil
 	if fake.serviceBatchBindReturnsOnCall == nil {
 		fake.serviceBatchBindReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+			result1 models.ServiceBatchBindResponse
 			result2 error
 		})
 	}
 	fake.serviceBatchBindReturnsOnCall[i] = struct {
-		result1 models.Response
+		result1 models.ServiceBatchBindResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceBind(arg1 models.ServiceBindRequest, arg2 string, arg3 string) (models.Response, error) {
+func (fake *FakeAPIClient) ServiceBind(arg1 models.ServiceBindRequest, arg2 string, arg3 string) (models.ServiceBindResponse, error) {
 	fake.serviceBindMutex.Lock()
 	ret, specificReturn := fake.serviceBindReturnsOnCall[len(fake.serviceBindArgsForCall)]
 	fake.serviceBindArgsForCall = append(fake.serviceBindArgsForCall, struct {
@@ -4611,7 +5638,7 @@ func (fake *FakeAPIClient) ServiceBindCallCount() int {
 	return len(fake.serviceBindArgsForCall)
 }
 
-func (fake *FakeAPIClient) ServiceBindCalls(stub func(models.ServiceBindRequest, string, string) (models.Response, error)) {
+func (fake *FakeAPIClient) ServiceBindCalls(stub func(models.ServiceBindRequest, string, string) (models.ServiceBindResponse, error)) {
 	fake.serviceBindMutex.Lock()
 	defer fake.serviceBindMutex.Unlock()
 	fake.ServiceBindStub = stub
@@ -4624,28 +5651,28 @@ func (fake *FakeAPIClient) ServiceBindArgsForCall(i int) (models.ServiceBindRequ
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) ServiceBindReturns(result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceBindReturns(result1 models.ServiceBindResponse, result2 error) {
 	fake.serviceBindMutex.Lock()
 	defer fake.serviceBindMutex.Unlock()
 	fake.ServiceBindStub = nil
 	fake.serviceBindReturns = struct {
-		result1 models.Response
+		result1 models.ServiceBindResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceBindReturnsOnCall(i int, result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceBindReturnsOnCall(i int, result1 models.ServiceBindResponse, result2 error) {
 	fake.serviceBindMutex.Lock()
 	defer fake.serviceBindMutex.Unlock()
 	fake.ServiceBindStub = nil
 	if fake.serviceBindReturnsOnCall == nil {
 		fake.serviceBindReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+			result1 models.ServiceBindResponse
 			result2 error
 		})
 	}
 	fake.serviceBindReturnsOnCall[i] = struct {
-		result1 models.Response
+		result1 models.ServiceBindResponse
 		result2 error
 	}{result1, result2}
 }
@@ -4834,7 +5861,73 @@ func (fake *FakeAPIClient) ServiceCatalogShowReturnsOnCall(i int, result1 *model
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceCreate(arg1 models.ServiceCreateRequest, arg2 string) (models.Response, error) {
+func (fake *FakeAPIClient) ServiceClone(arg1 models.ServiceCloneRequest, arg2 string, arg3 string) (models.ServiceCreateResponse, error) {
+	fake.serviceCloneMutex.Lock()
+	ret, specificReturn := fake.serviceCloneReturnsOnCall[len(fake.serviceCloneArgsForCall)]
+	fake.serviceCloneArgsForCall = append(fake.serviceCloneArgsForCall, struct {
+		arg1 models.ServiceCloneRequest
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceCloneStub
+	fakeReturns := fake.serviceCloneReturns
+	fake.recordInvocation("ServiceClone", []interface{}{arg1, arg2, arg3})
+	fake.serviceCloneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceCloneCallCount() int {
+	fake.serviceCloneMutex.RLock()
+	defer fake.serviceCloneMutex.RUnlock()
+	return len(fake.serviceCloneArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceCloneCalls(stub func(models.ServiceCloneRequest, string, string) (models.ServiceCreateResponse, error)) {
+	fake.serviceCloneMutex.Lock()
+	defer fake.serviceCloneMutex.Unlock()
+	fake.ServiceCloneStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceCloneArgsForCall(i int) (models.ServiceCloneRequest, string, string) {
+	fake.serviceCloneMutex.RLock()
+	defer fake.serviceCloneMutex.RUnlock()
+	argsForCall := fake.serviceCloneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceCloneReturns(result1 models.ServiceCreateResponse, result2 error) {
+	fake.serviceCloneMutex.Lock()
+	defer fake.serviceCloneMutex.Unlock()
+	fake.ServiceCloneStub = nil
+	fake.serviceCloneReturns = struct {
+		result1 models.ServiceCreateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceCloneReturnsOnCall(i int, result1 models.ServiceCreateResponse, result2 error) {
+	fake.serviceCloneMutex.Lock()
+	defer fake.serviceCloneMutex.Unlock()
+	fake.ServiceCloneStub = nil
+	if fake.serviceCloneReturnsOnCall == nil {
+		fake.serviceCloneReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceCreateResponse
+			result2 error
+		})
+	}
+	fake.serviceCloneReturnsOnCall[i] = struct {
+		result1 models.ServiceCreateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceCreate(arg1 models.ServiceCreateRequest, arg2 string) (models.ServiceCreateResponse, error) {
 	fake.serviceCreateMutex.Lock()
 	ret, specificReturn := fake.serviceCreateReturnsOnCall[len(fake.serviceCreateArgsForCall)]
 	fake.serviceCreateArgsForCall = append(fake.serviceCreateArgsForCall, struct {
@@ -4860,7 +5953,7 @@ func (fake *FakeAPIClient) ServiceCreateCallCount() int {
 	return len(fake.serviceCreateArgsForCall)
 }
 
-func (fake *FakeAPIClient) ServiceCreateCalls(stub func(models.ServiceCreateRequest, string) (models.Response, error)) {
+func (fake *FakeAPIClient) ServiceCreateCalls(stub func(models.ServiceCreateRequest, string) (models.ServiceCreateResponse, error)) {
 	fake.serviceCreateMutex.Lock()
 	defer fake.serviceCreateMutex.Unlock()
 	fake.ServiceCreateStub = stub
@@ -4873,28 +5966,28 @@ func (fake *FakeAPIClient) ServiceCreateArgsForCall(i int) (models.ServiceCreate
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) ServiceCreateReturns(result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceCreateReturns(result1 models.ServiceCreateResponse, result2 error) {
 	fake.serviceCreateMutex.Lock()
 	defer fake.serviceCreateMutex.Unlock()
 	fake.ServiceCreateStub = nil
 	fake.serviceCreateReturns = struct {
-		result1 models.Response
+		result1 models.ServiceCreateResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceCreateReturnsOnCall(i int, result1 models.ServiceCreateResponse, result2 error) {
 	fake.serviceCreateMutex.Lock()
 	defer fake.serviceCreateMutex.Unlock()
 	fake.ServiceCreateStub = nil
 	if fake.serviceCreateReturnsOnCall == nil {
 		fake.serviceCreateReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+			result1 models.ServiceCreateResponse
 			result2 error
 		})
 	}
 	fake.serviceCreateReturnsOnCall[i] = struct {
-		result1 models.Response
+		result1 models.ServiceCreateResponse
 		result2 error
 	}{result1, result2}
 }
@@ -5162,6 +6255,72 @@ func (fake *FakeAPIClient) ServicePortForwardReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeAPIClient) ServiceRebind(arg1 models.ServiceRebindRequest, arg2 string, arg3 string) (models.ServiceBindResponse, error) {
+	fake.serviceRebindMutex.Lock()
+	ret, specificReturn := fake.serviceRebindReturnsOnCall[len(fake.serviceRebindArgsForCall)]
+	fake.serviceRebindArgsForCall = append(fake.serviceRebindArgsForCall, struct {
+		arg1 models.ServiceRebindRequest
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceRebindStub
+	fakeReturns := fake.serviceRebindReturns
+	fake.recordInvocation("ServiceRebind", []interface{}{arg1, arg2, arg3})
+	fake.serviceRebindMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceRebindCallCount() int {
+	fake.serviceRebindMutex.RLock()
+	defer fake.serviceRebindMutex.RUnlock()
+	return len(fake.serviceRebindArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceRebindCalls(stub func(models.ServiceRebindRequest, string, string) (models.ServiceBindResponse, error)) {
+	fake.serviceRebindMutex.Lock()
+	defer fake.serviceRebindMutex.Unlock()
+	fake.ServiceRebindStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceRebindArgsForCall(i int) (models.ServiceRebindRequest, string, string) {
+	fake.serviceRebindMutex.RLock()
+	defer fake.serviceRebindMutex.RUnlock()
+	argsForCall := fake.serviceRebindArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceRebindReturns(result1 models.ServiceBindResponse, result2 error) {
+	fake.serviceRebindMutex.Lock()
+	defer fake.serviceRebindMutex.Unlock()
+	fake.ServiceRebindStub = nil
+	fake.serviceRebindReturns = struct {
+		result1 models.ServiceBindResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceRebindReturnsOnCall(i int, result1 models.ServiceBindResponse, result2 error) {
+	fake.serviceRebindMutex.Lock()
+	defer fake.serviceRebindMutex.Unlock()
+	fake.ServiceRebindStub = nil
+	if fake.serviceRebindReturnsOnCall == nil {
+		fake.serviceRebindReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceBindResponse
+			result2 error
+		})
+	}
+	fake.serviceRebindReturnsOnCall[i] = struct {
+		result1 models.ServiceBindResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) ServiceShow(arg1 string, arg2 string) (*models.Service, error) {
 	fake.serviceShowMutex.Lock()
 	ret, specificReturn := fake.serviceShowReturnsOnCall[len(fake.serviceShowArgsForCall)]
@@ -5293,7 +6452,7 @@ func (fake *FakeAPIClient) ServiceUnbindReturnsOnCall(i int, result1 models.Resp
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceUpdate(arg1 models.ServiceUpdateRequest, arg2 string, arg3 string) (models.Response, error) {
+func (fake *FakeAPIClient) ServiceUpdate(arg1 models.ServiceUpdateRequest, arg2 string, arg3 string) (models.ServiceUpdateResponse, error) {
 	fake.serviceUpdateMutex.Lock()
 	ret, specificReturn := fake.serviceUpdateReturnsOnCall[len(fake.serviceUpdateArgsForCall)]
 	fake.serviceUpdateArgsForCall = append(fake.serviceUpdateArgsForCall, struct {
@@ -5320,7 +6479,7 @@ func (fake *FakeAPIClient) ServiceUpdateCallCount() int {
 	return len(fake.serviceUpdateArgsForCall)
 }
 
-func (fake *FakeAPIClient) ServiceUpdateCalls(stub func(models.ServiceUpdateRequest, string, string) (models.Response, error)) {
+func (fake *FakeAPIClient) ServiceUpdateCalls(stub func(models.ServiceUpdateRequest, string, string) (models.ServiceUpdateResponse, error)) {
 	fake.serviceUpdateMutex.Lock()
 	defer fake.serviceUpdateMutex.Unlock()
 	fake.ServiceUpdateStub = stub
@@ -5333,28 +6492,28 @@ func (fake *FakeAPIClient) ServiceUpdateArgsForCall(i int) (models.ServiceUpdate
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) ServiceUpdateReturns(result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceUpdateReturns(result1 models.ServiceUpdateResponse, result2 error) {
 	fake.serviceUpdateMutex.Lock()
 	defer fake.serviceUpdateMutex.Unlock()
 	fake.ServiceUpdateStub = nil
 	fake.serviceUpdateReturns = struct {
-		result1 models.Response
+		result1 models.ServiceUpdateResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ServiceUpdateReturnsOnCall(i int, result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) ServiceUpdateReturnsOnCall(i int, result1 models.ServiceUpdateResponse, result2 error) {
 	fake.serviceUpdateMutex.Lock()
 	defer fake.serviceUpdateMutex.Unlock()
 	fake.ServiceUpdateStub = nil
 	if fake.serviceUpdateReturnsOnCall == nil {
 		fake.serviceUpdateReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+			result1 models.ServiceUpdateResponse
 			result2 error
 		})
 	}
 	fake.serviceUpdateReturnsOnCall[i] = struct {
-		result1 models.Response
+		result1 models.ServiceUpdateResponse
 		result2 error
 	}{result1, result2}
 }
@@ -5392,7 +6551,7 @@ func (fake *FakeAPIClient) SetHeaderArgsForCall(i int) (string, string) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) StagingComplete(arg1 string, arg2 string) (models.Response, error) {
+func (fake *FakeAPIClient) StagingComplete(arg1 string, arg2 string) (models.StagingCompleteResponse, error) {
 	fake.stagingCompleteMutex.Lock()
 	ret, specificReturn := fake.stagingCompleteReturnsOnCall[len(fake.stagingCompleteArgsForCall)]
 	fake.stagingCompleteArgsForCall = append(fake.stagingCompleteArgsForCall, struct {
@@ -5418,7 +6577,7 @@ func (fake *FakeAPIClient) StagingCompleteCallCount() int {
 	return len(fake.stagingCompleteArgsForCall)
 }
 
-func (fake *FakeAPIClient) StagingCompleteCalls(stub func(string, string) (models.Response, error)) {
+func (fake *FakeAPIClient) StagingCompleteCalls(stub func(string, string) (models.StagingCompleteResponse, error)) {
 	fake.stagingCompleteMutex.Lock()
 	defer fake.stagingCompleteMutex.Unlock()
 	fake.StagingCompleteStub = stub
@@ -5431,28 +6590,28 @@ func (fake *FakeAPIClient) StagingCompleteArgsForCall(i int) (string, string) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) StagingCompleteReturns(result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) StagingCompleteReturns(result1 models.StagingCompleteResponse, result2 error) {
 	fake.stagingCompleteMutex.Lock()
 	defer fake.stagingCompleteMutex.Unlock()
 	fake.StagingCompleteStub = nil
 	fake.stagingCompleteReturns = struct {
-		result1 models.Response
+		result1 models.StagingCompleteResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) StagingCompleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+func (fake *FakeAPIClient) StagingCompleteReturnsOnCall(i int, result1 models.StagingCompleteResponse, result2 error) {
 	fake.stagingCompleteMutex.Lock()
 	defer fake.stagingCompleteMutex.Unlock()
 	fake.StagingCompleteStub = nil
 	if fake.stagingCompleteReturnsOnCall == nil {
 		fake.stagingCompleteReturnsOnCall = make(map[int]struct {
-			result1 models.Response
+			result1 models.StagingCompleteResponse
 			result2 error
 		})
 	}
 	fake.stagingCompleteReturnsOnCall[i] = struct {
-		result1 models.Response
+		result1 models.StagingCompleteResponse
 		result2 error
 	}{result1, result2}
 }
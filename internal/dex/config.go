@@ -28,6 +28,10 @@ type Config struct {
 	Oauth2 *oauth2.Config
 }
 
+// AllConnectors is the connectorId of the provider groups applying to the users of all
+// connectors, in addition to the groups of their own connector.
+const AllConnectors = "*"
+
 type ProviderGroups struct {
 	ConnectorID string  `yaml:"connectorId"`
 	Groups      []Group `yaml:"groups"`
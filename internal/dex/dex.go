@@ -14,6 +14,7 @@ package dex
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/dchest/uniuri"
@@ -132,6 +133,25 @@ func (pc *OIDCProvider) GetProviderGroups(providerID string) (*ProviderGroups, e
 	return nil, errors.Errorf("provider '%s' not found", providerID)
 }
 
+// GetRolesFromProviderGroups returns the roles matching the provided groups, in the groups
+// of the specified provider, and in the groups applying to all providers. It returns false
+// if neither are defined.
+func (pc *OIDCProvider) GetRolesFromProviderGroups(providerID string, groupIDs ...string) ([]string, bool) {
+	roles := []string{}
+	found := false
+
+	for _, id := range []string{AllConnectors, providerID} {
+		pg, err := pc.GetProviderGroups(id)
+		if err != nil {
+			continue
+		}
+		found = true
+		roles = append(roles, pg.GetRolesFromGroups(groupIDs...)...)
+	}
+
+	return roles, found
+}
+
 // GetRoleFromGroups returns the roles matching the provided groups
 func (pg *ProviderGroups) GetRolesFromGroups(groupIDs ...string) []string {
 	roles := []string{}
@@ -144,3 +164,41 @@ func (pg *ProviderGroups) GetRolesFromGroups(groupIDs ...string) []string {
 
 	return roles
 }
+
+// TokenSource returns a TokenSource returning the provided token until it is about to expire.
+// The token is then refreshed earlyExpiry before its expiry, using the latest refresh token.
+func (pc *OIDCProvider) TokenSource(ctx context.Context, token *oauth2.Token, earlyExpiry time.Duration) oauth2.TokenSource {
+	refresher := &tokenRefresher{
+		ctx:          ctx,
+		config:       pc.Config.Oauth2,
+		refreshToken: token.RefreshToken,
+	}
+	return oauth2.ReuseTokenSourceWithExpiry(token, refresher, earlyExpiry)
+}
+
+// tokenRefresher is a TokenSource asking for a new token with the refresh token on every call.
+// The oauth2.Config.TokenSource is not used since it would only refresh an already expired token.
+type tokenRefresher struct {
+	ctx          context.Context
+	config       *oauth2.Config
+	refreshToken string
+}
+
+// Token returns a new token, obtained with the refresh token
+func (tr *tokenRefresher) Token() (*oauth2.Token, error) {
+	if tr.refreshToken == "" {
+		return nil, errors.New("no refresh token available")
+	}
+
+	// a token without an access token is not valid, forcing the refresh
+	token, err := tr.config.TokenSource(tr.ctx, &oauth2.Token{RefreshToken: tr.refreshToken}).Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "refreshing token")
+	}
+
+	if token.RefreshToken != "" {
+		tr.refreshToken = token.RefreshToken
+	}
+
+	return token, nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expiry provides the helpers for the opt-in automatic expiry of applications and
+// services. Resources are marked for expiry with an annotation holding the expiry time. The
+// reaper (see package reaper) deletes marked resources when their time has come.
+package expiry
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotation is the annotation holding the expiry time of a resource, in RFC3339 format
+const Annotation = "epinio.io/expires-at"
+
+// FromAnnotations returns the expiry time recorded in the annotations, or nil, if there is
+// none, or it is not a proper time.
+func FromAnnotations(annotations map[string]string) *metav1.Time {
+	value, ok := annotations[Annotation]
+	if !ok {
+		return nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	result := metav1.NewTime(expiresAt)
+	return &result
+}
+
+// Format returns the annotation value for the expiry time
+func Format(expiresAt time.Time) string {
+	return expiresAt.UTC().Format(time.RFC3339)
+}
+
+// ParseTTL parses a time to live into a duration. The empty string is accepted, and returns
+// zero, i.e. no expiry.
+func ParseTTL(ttl string) (time.Duration, error) {
+	if ttl == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("bad ttl '%s': %w", ttl, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("bad ttl '%s', expected a positive duration", ttl)
+	}
+
+	return duration, nil
+}
+
+// Expired returns true if the expiry time is set and not after now.
+func Expired(expiresAt *metav1.Time, now time.Time) bool {
+	return expiresAt != nil && !expiresAt.After(now)
+}
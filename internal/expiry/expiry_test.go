@@ -0,0 +1,77 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry_test
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/internal/expiry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Expiry", func() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	Describe("ParseTTL", func() {
+		It("accepts the empty string as no expiry", func() {
+			ttl, err := expiry.ParseTTL("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ttl).To(BeZero())
+		})
+
+		It("accepts a positive duration", func() {
+			ttl, err := expiry.ParseTTL("36h")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ttl).To(Equal(36 * time.Hour))
+		})
+
+		It("rejects bad and non-positive durations", func() {
+			_, err := expiry.ParseTTL("tomorrow")
+			Expect(err).To(HaveOccurred())
+			_, err = expiry.ParseTTL("-1h")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("FromAnnotations", func() {
+		It("round-trips the formatted expiry time", func() {
+			expiresAt := expiry.FromAnnotations(map[string]string{
+				expiry.Annotation: expiry.Format(now),
+			})
+			Expect(expiresAt).ToNot(BeNil())
+			Expect(expiresAt.Time.Equal(now)).To(BeTrue())
+		})
+
+		It("ignores missing and malformed annotations", func() {
+			Expect(expiry.FromAnnotations(nil)).To(BeNil())
+			Expect(expiry.FromAnnotations(map[string]string{expiry.Annotation: "soon"})).To(BeNil())
+		})
+	})
+
+	Describe("Expired", func() {
+		It("is false without an expiry time", func() {
+			Expect(expiry.Expired(nil, now)).To(BeFalse())
+		})
+
+		It("compares the expiry time against now", func() {
+			past := metav1.NewTime(now.Add(-time.Minute))
+			future := metav1.NewTime(now.Add(time.Minute))
+			exact := metav1.NewTime(now)
+
+			Expect(expiry.Expired(&past, now)).To(BeTrue())
+			Expect(expiry.Expired(&exact, now)).To(BeTrue())
+			Expect(expiry.Expired(&future, now)).To(BeFalse())
+		})
+	})
+})
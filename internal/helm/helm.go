@@ -30,6 +30,7 @@ import (
 	"github.com/epinio/epinio/internal/domain"
 	"github.com/epinio/epinio/internal/duration"
 	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/operations"
 	"github.com/epinio/epinio/internal/routes"
 	"github.com/epinio/epinio/internal/urlcache"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -47,6 +48,7 @@ import (
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 )
 
 type PostDeployFunction func(ctx context.Context) error
@@ -80,6 +82,14 @@ type ChartParameters struct {
 	Domains        domain.DomainMap      // Map of domains with secrets covering them
 	Start          *int64                // Nano-epoch of deployment. Optional. Used to force a restart, even when nothing else has changed.
 	Settings       models.ChartValueSettings
+	Affinity       map[string]interface{}     // Pod affinity computed from the app placement. Optional.
+	Rollout        *models.ApplicationRollout // Rolling update settings. Optional.
+	Process        *models.ApplicationProcess // Container command and args overrides. Optional.
+	IngressClass   string                     // Ingress class of the app routes. Optional, overrides the server default.
+	DNSPolicy      string                     // DNS policy of the pods. Optional.
+	DNSConfig      map[string]interface{}     // DNS configuration of the pods. Optional.
+	PodAnnotations map[string]string          // Custom annotations of the pods. Optional.
+	PodLabels      map[string]string          // Custom labels of the pods. Optional.
 }
 
 func Values(
@@ -106,6 +116,25 @@ func Values(
 	return yaml, nil
 }
 
+// ServiceValues returns the effective values of the named service's release, i.e. the chart
+// defaults merged with all the user supplied values.
+func ServiceValues(
+	cluster *kubernetes.Cluster,
+	service models.AppRef,
+) (map[string]interface{}, error) {
+	client, err := GetHelmClient(cluster.RestConfig, service.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "create a helm client")
+	}
+
+	values, err := client.GetReleaseValues(names.ServiceReleaseName(service.Name), true)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting release values")
+	}
+
+	return values, nil
+}
+
 func Remove(
 	cluster *kubernetes.Cluster,
 	app models.AppRef,
@@ -131,6 +160,26 @@ func RemoveService(
 	return errors.Wrap(err, "deleting release")
 }
 
+// RollbackService rolls the release of the named service back to its previous revision, and waits
+// for the rollback to complete.
+func RollbackService(
+	cluster *kubernetes.Cluster,
+	service models.AppRef,
+) error {
+	client, err := GetHelmClient(cluster.RestConfig, service.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "create a helm client")
+	}
+
+	err = client.RollbackRelease(&hc.ChartSpec{
+		ReleaseName: names.ServiceReleaseName(service.Name),
+		Namespace:   service.Namespace,
+		Wait:        true,
+		Timeout:     duration.ToDeployment(),
+	})
+	return errors.Wrap(err, "rolling back release")
+}
+
 func DeployService(ctx context.Context, parameters ServiceParameters) error {
 	logger := helpers.Logger.With("component", "helm-service")
 	logger.Infow("service helm setup", "parameters", parameters)
@@ -150,33 +199,22 @@ func DeployService(ctx context.Context, parameters ServiceParameters) error {
 		return errors.Wrap(err, "cleaning up release")
 	}
 
-	catalogService := parameters.CatalogService
-
-	// helmChart is the full helmChart name
-	// i.e.: epinio/mychart, or oci://registry/chart for OCI charts
-	// This will also login into the OCI registry or add/update the helm repository
-	helmChart, err := initHelmOCIRegistryOrRepository(client, catalogService)
+	chartSpec, err := serviceChartSpec(client, parameters)
 	if err != nil {
-		return errors.Wrap(err, "initializing Helm repository or OCI registry")
-	}
-
-	chartSpec := hc.ChartSpec{
-		ReleaseName: releaseName,
-		ChartName:   helmChart,
-		Version:     catalogService.ChartVersion,
-		Namespace:   parameters.Namespace,
-		Wait:        parameters.Wait,
-		ValuesYaml:  string(parameters.Values),
-		Timeout:     duration.ToDeployment(),
-		ReuseValues: true,
+		return err
 	}
 
 	if !parameters.Wait {
 		// Note: We are backgrounding the action. The incoming context cannot be used, as it
 		// is linked to the request. We will get a `context canceled` error. To avoid this a
-		// background context is used instead.
+		// context detached from the request is used instead, cancelled only when the
+		// operation is.
+		opCtx, done := operations.Default.Start(context.WithoutCancel(ctx),
+			operations.TypeServiceProvision, parameters.Namespace, parameters.Name)
 		go func() {
-			err = installOrUpgradeChartWithRetry(context.Background(), client, &chartSpec)
+			defer done()
+
+			err := installOrUpgradeChartWithRetry(opCtx, client, &chartSpec)
 			if err != nil {
 				logger.Errorw("installing or upgrading service ASYNC", "error", err)
 				return
@@ -185,7 +223,7 @@ func DeployService(ctx context.Context, parameters ServiceParameters) error {
 			if parameters.PostDeployHook != nil {
 				// MAYBE : `wait for the release to be in a ready state` here too, see below.
 				// So far, local, things were fast enough to not need it for labeling
-				err := parameters.PostDeployHook(context.Background())
+				err := parameters.PostDeployHook(opCtx)
 				if err != nil {
 					logger.Errorw("service post deployment ASYNC", "error", err)
 					return
@@ -197,6 +235,10 @@ func DeployService(ctx context.Context, parameters ServiceParameters) error {
 		return nil
 	}
 
+	ctx, done := operations.Default.Start(ctx,
+		operations.TypeServiceProvision, parameters.Namespace, parameters.Name)
+	defer done()
+
 	// Note: Steps 1: Retry only once!
 	err = installOrUpgradeChartWithRetry(ctx, client, &chartSpec)
 	if err != nil {
@@ -235,6 +277,57 @@ func DeployService(ctx context.Context, parameters ServiceParameters) error {
 	return nil
 }
 
+// PreviewService runs a dry-run upgrade of the helm release of the service with the given
+// parameters, and returns the manifest rendered for it. Nothing is applied.
+func PreviewService(ctx context.Context, parameters ServiceParameters) (string, error) {
+	client, err := GetHelmClient(
+		parameters.Cluster.RestConfig,
+		parameters.Namespace,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "create a helm client")
+	}
+
+	chartSpec, err := serviceChartSpec(client, parameters)
+	if err != nil {
+		return "", err
+	}
+	chartSpec.DryRun = true
+	chartSpec.Wait = false
+
+	release, err := client.UpgradeChart(ctx, &chartSpec, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "dry-run upgrade of service")
+	}
+
+	return release.Manifest, nil
+}
+
+// serviceChartSpec returns the specification of the helm release for the service with the given
+// parameters.
+func serviceChartSpec(client *SynchronizedClient, parameters ServiceParameters) (hc.ChartSpec, error) {
+	catalogService := parameters.CatalogService
+
+	// helmChart is the full helmChart name
+	// i.e.: epinio/mychart, or oci://registry/chart for OCI charts
+	// This will also login into the OCI registry or add/update the helm repository
+	helmChart, err := initHelmOCIRegistryOrRepository(client, catalogService)
+	if err != nil {
+		return hc.ChartSpec{}, errors.Wrap(err, "initializing Helm repository or OCI registry")
+	}
+
+	return hc.ChartSpec{
+		ReleaseName: names.ServiceReleaseName(parameters.Name),
+		ChartName:   helmChart,
+		Version:     catalogService.ChartVersion,
+		Namespace:   parameters.Namespace,
+		Wait:        parameters.Wait,
+		ValuesYaml:  string(parameters.Values),
+		Timeout:     duration.ToDeployment(),
+		ReuseValues: true,
+	}, nil
+}
+
 // initHelmOCIRegistryOrRepository will perform the initial setup for the helm Client and the specified Service,
 // and return the final helm chart name. If the service is from an OCI registry it will perform a login, if needed,
 // and return a 'oci://repoURL/chart' value. Else if a standard repoURL is provided it will add the repo, caching the index,
@@ -325,18 +418,31 @@ type RouteParam struct {
 	Secret string `yaml:"secret,omitempty"`
 }
 type EpinioParam struct {
-	AppName        string               `yaml:"appName"`
-	Configurations []string             `yaml:"configurations"`
-	ConfigPaths    []ConfigParameter    `yaml:"configpaths"`
-	Env            []models.EnvVariable `yaml:"env"`
-	ImageUrl       string               `yaml:"imageURL"`
-	Ingress        string               `yaml:"ingress,omitempty"`
-	ReplicaCount   int32                `yaml:"replicaCount"`
-	Routes         []RouteParam         `yaml:"routes"`
-	StageID        string               `yaml:"stageID"`
-	Start          string               `yaml:"start,omitempty"`
-	TlsIssuer      string               `yaml:"tlsIssuer"`
-	Username       string               `yaml:"username"`
+	Affinity             map[string]interface{} `yaml:"affinity,omitempty"`
+	AppName              string                 `yaml:"appName"`
+	Args                 []string               `yaml:"args,omitempty"`
+	Command              []string               `yaml:"command,omitempty"`
+	Configurations       []string               `yaml:"configurations"`
+	ConfigPaths          []ConfigParameter      `yaml:"configpaths"`
+	DNSConfig            map[string]interface{} `yaml:"dnsConfig,omitempty"`
+	DNSPolicy            string                 `yaml:"dnsPolicy,omitempty"`
+	Env                  []models.EnvVariable   `yaml:"env"`
+	ImageUrl             string                 `yaml:"imageURL"`
+	Ingress              string                 `yaml:"ingress,omitempty"`
+	PodAnnotations       map[string]string      `yaml:"podAnnotations,omitempty"`
+	PodLabels            map[string]string      `yaml:"podLabels,omitempty"`
+	ReplicaCount         int32                  `yaml:"replicaCount"`
+	RevisionHistoryLimit *int32                 `yaml:"revisionHistoryLimit,omitempty"`
+	RollingUpdate        *RollingUpdateParam    `yaml:"rollingUpdate,omitempty"`
+	Routes               []RouteParam           `yaml:"routes"`
+	StageID              string                 `yaml:"stageID"`
+	Start                string                 `yaml:"start,omitempty"`
+	TlsIssuer            string                 `yaml:"tlsIssuer"`
+	Username             string                 `yaml:"username"`
+}
+type RollingUpdateParam struct {
+	MaxSurge       interface{} `yaml:"maxSurge,omitempty"`
+	MaxUnavailable interface{} `yaml:"maxUnavailable,omitempty"`
 }
 type ChartParam struct {
 	Epinio EpinioParam            `yaml:"epinio"`
@@ -749,7 +855,7 @@ func getValuesYAML(appChart *models.AppChartFull, parameters ChartParameters) (s
 	params := ChartParam{
 		Epinio: EpinioParam{
 			AppName:        parameters.Name,
-			Env:            parameters.Environment.List(),
+			Env:            parameters.Environment.DependencyList(),
 			ImageUrl:       parameters.ImageURL,
 			ReplicaCount:   parameters.Instances,
 			Configurations: configurationNames,
@@ -757,16 +863,40 @@ func getValuesYAML(appChart *models.AppChartFull, parameters ChartParameters) (s
 			StageID:        parameters.StageID,
 			TlsIssuer:      viper.GetString("tls-issuer"),
 			Username:       parameters.Username,
+			Affinity:       parameters.Affinity,
+			DNSPolicy:      parameters.DNSPolicy,
+			DNSConfig:      parameters.DNSConfig,
+			PodAnnotations: parameters.PodAnnotations,
+			PodLabels:      parameters.PodLabels,
 			// Ingress, Start, Routes: see below
 		},
 		// Chart, User: see below
 	}
 
 	name := viper.GetString("ingress-class-name")
+	if parameters.IngressClass != "" {
+		name = parameters.IngressClass
+	}
 	if name != "" {
 		params.Epinio.Ingress = name
 		logger.Infow("deploy app", "ingress-class", name)
 	}
+	if parameters.Rollout != nil && (parameters.Rollout.MaxSurge != "" || parameters.Rollout.MaxUnavailable != "") {
+		params.Epinio.RollingUpdate = &RollingUpdateParam{
+			MaxSurge:       rolloutValue(parameters.Rollout.MaxSurge),
+			MaxUnavailable: rolloutValue(parameters.Rollout.MaxUnavailable),
+		}
+		logger.Infow("deploy app", "rollout", parameters.Rollout)
+	}
+	params.Epinio.RevisionHistoryLimit = revisionHistoryLimit(parameters.Rollout)
+	if params.Epinio.RevisionHistoryLimit != nil {
+		logger.Infow("deploy app", "revision-history-limit", *params.Epinio.RevisionHistoryLimit)
+	}
+	if parameters.Process != nil {
+		params.Epinio.Command = parameters.Process.Command
+		params.Epinio.Args = parameters.Process.Args
+		logger.Infow("deploy app", "process", parameters.Process)
+	}
 	if parameters.Start != nil {
 		params.Epinio.Start = fmt.Sprintf(`%d`, *parameters.Start)
 		logger.Infow("deploy app", "start", params.Epinio.Start)
@@ -854,3 +984,30 @@ func getValuesYAML(appChart *models.AppChartFull, parameters ChartParameters) (s
 	logger.Infow("deploy app, return values.yaml")
 	return yamlString, nil
 }
+
+// revisionHistoryLimit returns the number of old ReplicaSets to keep for the application, as
+// set by the user, or else as configured for the server. The result is nil when neither is
+// set, keeping the chart default.
+func revisionHistoryLimit(rollout *models.ApplicationRollout) *int32 {
+	if rollout != nil && rollout.RevisionHistoryLimit != nil {
+		return rollout.RevisionHistoryLimit
+	}
+	limit := viper.GetInt("revision-history-limit")
+	if limit < 0 {
+		return nil
+	}
+	return ptr.To(int32(limit))
+}
+
+// rolloutValue converts a rollout value into the form expected by the chart, i.e. an integer
+// for a number of pods, and the string itself for a percentage. The result is nil for an empty
+// value, keeping the chart default.
+func rolloutValue(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err == nil {
+		return n
+	}
+	return value
+}
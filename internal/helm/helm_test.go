@@ -13,6 +13,8 @@ package helm
 
 import (
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/spf13/viper"
+	"k8s.io/utils/ptr"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -220,3 +222,26 @@ var _ = Describe("ValidateField()", func() {
 		Expect(err.Error()).To(Equal(`setting "field": Expected boolean, got "hound"`))
 	})
 })
+
+var _ = Describe("revisionHistoryLimit()", func() {
+	AfterEach(func() {
+		viper.Set("revision-history-limit", nil)
+	})
+
+	It("prefers the limit of the application", func() {
+		viper.Set("revision-history-limit", 10)
+		limit := revisionHistoryLimit(&models.ApplicationRollout{RevisionHistoryLimit: ptr.To(int32(2))})
+		Expect(limit).To(Equal(ptr.To(int32(2))))
+	})
+
+	It("falls back to the server default", func() {
+		viper.Set("revision-history-limit", 10)
+		Expect(revisionHistoryLimit(nil)).To(Equal(ptr.To(int32(10))))
+		Expect(revisionHistoryLimit(&models.ApplicationRollout{MaxSurge: "1"})).To(Equal(ptr.To(int32(10))))
+	})
+
+	It("keeps the chart default for a negative server default", func() {
+		viper.Set("revision-history-limit", -1)
+		Expect(revisionHistoryLimit(nil)).To(BeNil())
+	})
+})
@@ -0,0 +1,128 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// CheckCatalogServiceRepo checks that the helm repository of the catalog service is reachable,
+// and provides the chart of the service, in the requested version, if any. Nothing is added to
+// the repositories of the helm client, and no chart is downloaded.
+func CheckCatalogServiceRepo(catalogService models.CatalogService) error {
+	repoURL := catalogService.HelmRepo.URL
+	if repoURL == "" {
+		return errors.New("no helm repository url to check")
+	}
+
+	if registry.IsOCI(repoURL) {
+		return checkOCIChart(catalogService)
+	}
+
+	return checkRepoChart(catalogService)
+}
+
+// checkRepoChart checks for the chart of the catalog service in the index of its classic helm
+// repository. The index is downloaded into a temporary directory, removed afterward.
+func checkRepoChart(catalogService models.CatalogService) error {
+	cacheDir, err := os.MkdirTemp("", "epinio-repo-health-")
+	if err != nil {
+		return errors.Wrap(err, "creating the index cache")
+	}
+	defer func() {
+		_ = os.RemoveAll(cacheDir)
+	}()
+
+	chartRepo, err := repo.NewChartRepository(&repo.Entry{
+		Name:     "health",
+		URL:      catalogService.HelmRepo.URL,
+		Username: catalogService.HelmRepo.Auth.Username,
+		Password: catalogService.HelmRepo.Auth.Password,
+	}, getter.All(cli.New()))
+	if err != nil {
+		return errors.Wrap(err, "setting up the helm repository")
+	}
+	chartRepo.CachePath = cacheDir
+
+	indexPath, err := chartRepo.DownloadIndexFile()
+	if err != nil {
+		return errors.Wrap(err, "helm repository not reachable")
+	}
+
+	index, err := repo.LoadIndexFile(indexPath)
+	if err != nil {
+		return errors.Wrap(err, "loading the helm repository index")
+	}
+
+	_, err = index.Get(catalogService.HelmChart, catalogService.ChartVersion)
+	if err != nil {
+		return chartNotFound(catalogService)
+	}
+
+	return nil
+}
+
+// checkOCIChart checks for the chart of the catalog service in its OCI registry, through the
+// tags of the chart.
+func checkOCIChart(catalogService models.CatalogService) error {
+	options := []registry.ClientOption{}
+	if catalogService.HelmRepo.Auth.Username != "" && catalogService.HelmRepo.Auth.Password != "" {
+		options = append(options, registry.ClientOptBasicAuth(
+			catalogService.HelmRepo.Auth.Username,
+			catalogService.HelmRepo.Auth.Password))
+	}
+
+	client, err := registry.NewClient(options...)
+	if err != nil {
+		return errors.Wrap(err, "setting up the OCI registry client")
+	}
+
+	ref := strings.TrimPrefix(fmt.Sprintf("%s/%s", strings.TrimSuffix(catalogService.HelmRepo.URL, "/"),
+		catalogService.HelmChart), "oci://")
+
+	tags, err := client.Tags(ref)
+	if err != nil {
+		return errors.Wrap(err, "OCI registry not reachable")
+	}
+	if len(tags) == 0 {
+		return chartNotFound(catalogService)
+	}
+	if catalogService.ChartVersion == "" {
+		return nil
+	}
+
+	for _, tag := range tags {
+		if tag == catalogService.ChartVersion {
+			return nil
+		}
+	}
+
+	return chartNotFound(catalogService)
+}
+
+// chartNotFound returns the error for a repository lacking the chart of the catalog service.
+func chartNotFound(catalogService models.CatalogService) error {
+	if catalogService.ChartVersion != "" {
+		return errors.Errorf("chart '%s' version '%s' not found in the repository",
+			catalogService.HelmChart, catalogService.ChartVersion)
+	}
+	return errors.Errorf("chart '%s' not found in the repository", catalogService.HelmChart)
+}
@@ -0,0 +1,81 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckCatalogServiceRepo()", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/index.yaml" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(`apiVersion: v1
+entries:
+  mysql:
+  - apiVersion: v2
+    name: mysql
+    version: 9.4.1
+    urls:
+    - mysql-9.4.1.tgz
+`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	catalogService := func(url, chart, version string) models.CatalogService {
+		return models.CatalogService{
+			HelmRepo:     models.HelmRepo{URL: url},
+			HelmChart:    chart,
+			ChartVersion: version,
+		}
+	}
+
+	It("accepts a repository providing the chart", func() {
+		Expect(CheckCatalogServiceRepo(catalogService(server.URL, "mysql", "9.4.1"))).To(Succeed())
+		Expect(CheckCatalogServiceRepo(catalogService(server.URL, "mysql", ""))).To(Succeed())
+	})
+
+	It("reports a missing chart version", func() {
+		err := CheckCatalogServiceRepo(catalogService(server.URL, "mysql", "1.0.0"))
+		Expect(err).To(MatchError("chart 'mysql' version '1.0.0' not found in the repository"))
+	})
+
+	It("reports a missing chart", func() {
+		err := CheckCatalogServiceRepo(catalogService(server.URL, "redis", ""))
+		Expect(err).To(MatchError("chart 'redis' not found in the repository"))
+	})
+
+	It("reports an unreachable repository", func() {
+		err := CheckCatalogServiceRepo(catalogService(server.URL+"/missing", "mysql", ""))
+		Expect(err).To(MatchError(ContainSubstring("helm repository not reachable")))
+	})
+
+	It("reports a missing repository url", func() {
+		err := CheckCatalogServiceRepo(catalogService("", "mysql", ""))
+		Expect(err).To(MatchError("no helm repository url to check"))
+	})
+})
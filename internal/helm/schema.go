@@ -0,0 +1,243 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"sigs.k8s.io/yaml"
+)
+
+// CatalogServiceChart retrieves the chart of the catalog service from its repository or
+// registry.
+func CatalogServiceChart(ctx context.Context, cluster *kubernetes.Cluster, catalogService models.CatalogService) (*chart.Chart, error) {
+	client, err := GetHelmClient(cluster.RestConfig, helmchart.Namespace())
+	if err != nil {
+		return nil, errors.Wrap(err, "create a helm client")
+	}
+
+	helmChart, err := initHelmOCIRegistryOrRepository(client, catalogService)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing Helm repository or OCI registry")
+	}
+
+	chrt, _, err := client.GetChart(helmChart, &action.ChartPathOptions{
+		Username: catalogService.HelmRepo.Auth.Username,
+		Password: catalogService.HelmRepo.Auth.Password,
+		Version:  catalogService.ChartVersion,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking for the '%s' chart [version: %s]", helmChart, catalogService.ChartVersion)
+	}
+
+	return chrt, nil
+}
+
+// ValidateChartValues validates the values, in YAML format, against the `values.schema.json` of
+// the chart. Like helm the values are merged over the defaults of the chart before validation.
+// Charts without schema accept all values.
+func ValidateChartValues(chrt *chart.Chart, values string) (models.CatalogServiceValidateResponse, error) {
+	result := models.CatalogServiceValidateResponse{Valid: true}
+
+	if len(chrt.Schema) == 0 {
+		return result, nil
+	}
+	result.SchemaFound = true
+
+	userValues := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(values), &userValues); err != nil {
+		return result, errors.Wrap(err, "parsing values")
+	}
+
+	allValues, err := chartutil.CoalesceValues(chrt, userValues)
+	if err != nil {
+		return result, errors.Wrap(err, "merging values with the chart defaults")
+	}
+
+	result.Errors, err = ValidateAgainstSchema(chrt.Schema, allValues)
+	if err != nil {
+		return result, err
+	}
+	result.Valid = len(result.Errors) == 0
+
+	return result, nil
+}
+
+// ValidateAgainstSchema validates the values against the JSON schema. It returns the violations
+// found, each located by the JSON path of the offending value. The error is reserved for a
+// broken schema.
+func ValidateAgainstSchema(schema []byte, values map[string]interface{}) ([]models.SchemaValidationError, error) {
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing values schema")
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("values.schema.json", schemaDoc); err != nil {
+		return nil, errors.Wrap(err, "loading values schema")
+	}
+	compiled, err := compiler.Compile("values.schema.json")
+	if err != nil {
+		return nil, errors.Wrap(err, "compiling values schema")
+	}
+
+	// The validator expects the plain JSON data model
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return nil, errors.Wrap(err, "converting values")
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(valuesJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "converting values")
+	}
+
+	err = compiled.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, errors.Wrap(err, "validating values")
+	}
+
+	result := []models.SchemaValidationError{}
+	seen := map[models.SchemaValidationError]bool{}
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		violation := models.SchemaValidationError{
+			Path:    jsonPath(instance, unit.InstanceLocation),
+			Message: unit.Error.String(),
+		}
+		if seen[violation] {
+			continue
+		}
+		seen[violation] = true
+		result = append(result, violation)
+	}
+
+	return result, nil
+}
+
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// jsonPath converts the JSON pointer to a value of the instance into a JSON path, e.g.
+// `/auth/users/0` into `$.auth.users[0]`.
+func jsonPath(instance any, pointer string) string {
+	path := "$"
+	if pointer == "" {
+		return path
+	}
+
+	current := instance
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch value := current.(type) {
+		case []any:
+			path += "[" + token + "]"
+			if index, err := strconv.Atoi(token); err == nil && index >= 0 && index < len(value) {
+				current = value[index]
+			} else {
+				current = nil
+			}
+		case map[string]any:
+			path += pathKey(token)
+			current = value[token]
+		default:
+			path += pathKey(token)
+			current = nil
+		}
+	}
+
+	return path
+}
+
+func pathKey(key string) string {
+	if identifierRegexp.MatchString(key) {
+		return "." + key
+	}
+	return fmt.Sprintf("[%q]", key)
+}
+
+// OffendingKeys returns the value keys, in `--set` notation, causing the violations, sorted. A
+// key causes the violations located at its value, or below it. Violations located above the
+// value, e.g. a disallowed additional property, are attributed to the keys they name.
+func OffendingKeys(violations []models.SchemaValidationError, keys []string) []string {
+	result := []string{}
+	for _, key := range keys {
+		for _, violation := range violations {
+			if keyViolation(violation, key) {
+				result = append(result, key)
+				break
+			}
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// keyViolation returns true if the violation concerns the value of the key.
+func keyViolation(violation models.SchemaValidationError, key string) bool {
+	path := "$"
+	for _, segment := range keySegments(key) {
+		// Above the value the violation has to name the segment leading to it.
+		if violation.Path == path {
+			return strings.Contains(violation.Message, "'"+segment+"'")
+		}
+
+		if strings.HasPrefix(segment, "[") {
+			path += segment
+		} else {
+			path += pathKey(segment)
+		}
+	}
+
+	return violation.Path == path ||
+		strings.HasPrefix(violation.Path, path+".") ||
+		strings.HasPrefix(violation.Path, path+"[")
+}
+
+// keySegments splits a value key in `--set` notation, e.g. `auth.users[0].name`, into its
+// segments, e.g. `auth`, `users`, `[0]`, and `name`.
+func keySegments(key string) []string {
+	result := []string{}
+	for _, part := range strings.Split(key, ".") {
+		index := ""
+		if open := strings.Index(part, "["); open > 0 && strings.HasSuffix(part, "]") {
+			part, index = part[:open], part[open:]
+		}
+		result = append(result, part)
+		if index != "" {
+			result = append(result, index)
+		}
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"helm.sh/helm/v3/pkg/chart"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateChartValues()", func() {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["auth"],
+		"properties": {
+			"replicaCount": {"type": "integer", "minimum": 1},
+			"auth": {
+				"type": "object",
+				"properties": {
+					"rootPassword": {"type": "string", "minLength": 8}
+				}
+			},
+			"users": {
+				"type": "array",
+				"items": {"type": "object", "required": ["name"]}
+			},
+			"extra-labels": {"type": "object", "additionalProperties": {"type": "string"}}
+		}
+	}`)
+
+	var chrt *chart.Chart
+
+	BeforeEach(func() {
+		chrt = &chart.Chart{
+			Metadata: &chart.Metadata{Name: "db", Version: "1.0.0"},
+			Values: map[string]interface{}{
+				"replicaCount": 1,
+				"auth":         map[string]interface{}{"rootPassword": "changeme!"},
+			},
+			Schema: schema,
+		}
+	})
+
+	It("accepts all values for charts without schema", func() {
+		chrt.Schema = nil
+		result, err := ValidateChartValues(chrt, "replicaCount: nope")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(models.CatalogServiceValidateResponse{Valid: true}))
+	})
+
+	It("accepts valid values merged over the chart defaults", func() {
+		result, err := ValidateChartValues(chrt, "replicaCount: 3\nusers:\n- name: admin\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.SchemaFound).To(BeTrue())
+		Expect(result.Valid).To(BeTrue())
+		Expect(result.Errors).To(BeEmpty())
+	})
+
+	It("locates the violations by JSON path", func() {
+		values := `
+replicaCount: 0
+auth:
+  rootPassword: short
+users:
+- name: admin
+- role: reader
+extra-labels:
+  team: 42
+`
+		result, err := ValidateChartValues(chrt, values)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Valid).To(BeFalse())
+
+		paths := []string{}
+		for _, violation := range result.Errors {
+			Expect(violation.Message).ToNot(BeEmpty())
+			paths = append(paths, violation.Path)
+		}
+		Expect(paths).To(ConsistOf(
+			"$.replicaCount",
+			"$.auth.rootPassword",
+			"$.users[1]",
+			`$["extra-labels"].team`,
+		))
+	})
+
+	It("fails for unparsable values", func() {
+		_, err := ValidateChartValues(chrt, "replicaCount: [")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails for a broken schema", func() {
+		chrt.Schema = []byte(`{"type": 42}`)
+		_, err := ValidateChartValues(chrt, "")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("OffendingKeys()", func() {
+	schema := []byte(`{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"replicaCount": {"type": "integer", "minimum": 1},
+			"auth": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"rootPassword": {"type": "string", "minLength": 8}
+				}
+			},
+			"users": {
+				"type": "array",
+				"items": {"type": "object", "required": ["name"]}
+			}
+		}
+	}`)
+
+	violations := func(values map[string]interface{}) []models.SchemaValidationError {
+		result, err := ValidateAgainstSchema(schema, values)
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	It("returns nothing without violations", func() {
+		Expect(OffendingKeys(nil, []string{"replicaCount"})).To(BeEmpty())
+	})
+
+	It("returns the keys of invalid values", func() {
+		found := violations(map[string]interface{}{
+			"replicaCount": 0,
+			"auth":         map[string]interface{}{"rootPassword": "short"},
+		})
+		Expect(OffendingKeys(found, []string{"replicaCount", "auth.rootPassword"})).
+			To(Equal([]string{"auth.rootPassword", "replicaCount"}))
+	})
+
+	It("returns the keys of invalid list items", func() {
+		found := violations(map[string]interface{}{
+			"users": []interface{}{"admin"},
+		})
+		Expect(OffendingKeys(found, []string{"users[0]"})).To(Equal([]string{"users[0]"}))
+	})
+
+	It("returns the keys not allowed by the schema", func() {
+		found := violations(map[string]interface{}{
+			"replicaCount": 2,
+			"replicas":     2,
+			"auth":         map[string]interface{}{"password": "changeme!"},
+		})
+		Expect(OffendingKeys(found, []string{"replicaCount", "replicas", "auth.password"})).
+			To(Equal([]string{"auth.password", "replicas"}))
+	})
+
+	It("ignores valid keys", func() {
+		found := violations(map[string]interface{}{
+			"replicaCount": 0,
+			"auth":         map[string]interface{}{"rootPassword": "changeme!"},
+		})
+		Expect(OffendingKeys(found, []string{"auth.rootPassword"})).To(BeEmpty())
+	})
+})
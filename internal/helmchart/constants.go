@@ -24,3 +24,12 @@ const (
 func Namespace() string {
 	return viper.GetString("namespace")
 }
+
+// StagingNamespace returns the namespace of the staging jobs and their PVCs. It defaults to the
+// namespace of Epinio itself.
+func StagingNamespace() string {
+	if namespace := viper.GetString("staging-namespace"); namespace != "" {
+		return namespace
+	}
+	return Namespace()
+}
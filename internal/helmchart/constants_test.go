@@ -0,0 +1,37 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmchart
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestStagingNamespace(t *testing.T) {
+	viper.Set("namespace", "epinio")
+	defer viper.Set("namespace", "")
+
+	if namespace := StagingNamespace(); namespace != "epinio" {
+		t.Fatalf("expected staging to default to the epinio namespace, got %q", namespace)
+	}
+
+	viper.Set("staging-namespace", "staging")
+	defer viper.Set("staging-namespace", "")
+
+	if namespace := StagingNamespace(); namespace != "staging" {
+		t.Fatalf("expected the configured staging namespace, got %q", namespace)
+	}
+	if namespace := Namespace(); namespace != "epinio" {
+		t.Fatalf("expected the epinio namespace to be kept, got %q", namespace)
+	}
+}
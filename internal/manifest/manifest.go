@@ -66,6 +66,11 @@ func UpdateBASN(manifest models.ApplicationManifest, cmd *cobra.Command) (models
 		return manifest, err
 	}
 
+	manifest, err = UpdateBuildEnvironment(manifest, cmd)
+	if err != nil {
+		return manifest, err
+	}
+
 	// A:ppChart - Retrieve from options
 	manifest, err = UpdateAppChart(manifest, cmd)
 	if err != nil {
@@ -103,6 +108,39 @@ func UpdateBuilder(manifest models.ApplicationManifest, cmd *cobra.Command) (mod
 	return manifest, nil
 }
 
+// UpdateBuildEnvironment updates the incoming manifest with information pulled from the
+// --build-env and --build-env-secret options
+func UpdateBuildEnvironment(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	evAssignments, err := cmd.Flags().GetStringSlice("build-env")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --build-env")
+	}
+	secret, err := cmd.Flags().GetString("build-env-secret")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --build-env-secret")
+	}
+
+	environment := models.EnvVariableMap{}
+	for _, assignment := range evAssignments {
+		pieces := strings.SplitN(assignment, "=", 2)
+		if len(pieces) < 2 {
+			return manifest, errors.New("Bad --build-env assignment `" + assignment + "`, expected `name=value` as value")
+		}
+		environment[pieces[0]] = pieces[1]
+	}
+
+	// Build E:nvironment - Replace
+
+	if len(environment) > 0 {
+		manifest.Staging.Environment = environment
+	}
+	if secret != "" {
+		manifest.Staging.EnvironmentSecret = secret
+	}
+
+	return manifest, nil
+}
+
 // UpdateAppChart updates the incoming manifest with information pulled from the --app-chart option
 func UpdateAppChart(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
 	appChart, err := cmd.Flags().GetString("app-chart")
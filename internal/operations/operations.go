@@ -0,0 +1,290 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package operations tracks the long-running operations of the server, i.e. git imports and
+// service provisioning, and allows their cancellation. Staging runs in jobs of the cluster, not
+// in the server, and is therefore not tracked here.
+//
+// Each replica of the server holds the contexts of its own operations. With a Store the
+// operations are recorded in the cluster, where every replica lists them. An operation is
+// cancelled across replicas by removing its record, which its replica notices when syncing.
+package operations
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/google/uuid"
+)
+
+// Types of the tracked operations.
+const (
+	TypeImport           = "import"
+	TypeServiceProvision = "service-provision"
+	TypeStaging          = "staging"
+)
+
+// ErrNotFound is returned by Cancel for an unknown, or already completed, operation.
+var ErrNotFound = errors.New("operation not found")
+
+// Operation describes a running operation. The CorrelationID is the id of the request which
+// started it.
+type Operation struct {
+	ID            string
+	Type          string
+	Namespace     string
+	Resource      string
+	User          string
+	CorrelationID string
+	StartedAt     time.Time
+}
+
+type entry struct {
+	Operation
+	cancel context.CancelFunc
+	stored bool
+}
+
+// Store records the running operations of all replicas of the server.
+type Store interface {
+	// Put records the operation.
+	Put(ctx context.Context, op Operation) error
+	// Remove removes the record of the operation. It returns ErrNotFound for an unknown id.
+	Remove(ctx context.Context, id string) error
+	// List returns the recorded operations.
+	List(ctx context.Context) ([]Operation, error)
+}
+
+// Registry holds the running operations.
+type Registry struct {
+	mu         sync.Mutex
+	operations map[string]*entry
+	store      Store
+}
+
+// Default is the registry of the server.
+var Default = New()
+
+// New returns a registry without operations.
+func New() *Registry {
+	return &Registry{
+		operations: map[string]*entry{},
+	}
+}
+
+// UseStore makes the registry record its operations in the store. List and Cancel then cover
+// the operations of all replicas sharing the store.
+func (r *Registry) UseStore(store Store) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store = store
+}
+
+// Start registers a new operation of the given type, on the resource in the namespace. User
+// and correlation id are taken from the context. The returned context is cancelled when the
+// operation is. The returned function has to be called when the operation ends, to remove it
+// from the registry.
+func (r *Registry) Start(ctx context.Context, kind, namespace, resource string) (context.Context, func()) {
+	opCtx, cancel := context.WithCancel(ctx)
+
+	e := &entry{
+		Operation: Operation{
+			ID:            uuid.NewString(),
+			Type:          kind,
+			Namespace:     namespace,
+			Resource:      resource,
+			User:          requestctx.User(ctx).Username,
+			CorrelationID: requestctx.ID(ctx),
+			StartedAt:     time.Now(),
+		},
+		cancel: cancel,
+	}
+
+	// Note: The operation is recorded before it is registered. See sync.
+	store := r.getStore()
+	if store != nil {
+		err := store.Put(ctx, e.Operation)
+		if err != nil {
+			helpers.Logger.Errorw("recording operation", "id", e.ID, "error", err)
+		}
+		e.stored = err == nil
+	}
+
+	r.mu.Lock()
+	r.operations[e.ID] = e
+	r.mu.Unlock()
+
+	var once sync.Once
+	return opCtx, func() {
+		once.Do(func() {
+			r.mu.Lock()
+			delete(r.operations, e.ID)
+			r.mu.Unlock()
+			cancel()
+
+			if e.stored {
+				err := store.Remove(context.WithoutCancel(ctx), e.ID)
+				if err != nil && !errors.Is(err, ErrNotFound) {
+					helpers.Logger.Errorw("removing operation record", "id", e.ID, "error", err)
+				}
+			}
+		})
+	}
+}
+
+// List returns the running operations, oldest first.
+func (r *Registry) List(ctx context.Context) ([]Operation, error) {
+	var result []Operation
+
+	store := r.getStore()
+	if store != nil {
+		var err error
+		result, err = store.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		r.mu.Lock()
+		result = make([]Operation, 0, len(r.operations))
+		for _, e := range r.operations {
+			result = append(result, e.Operation)
+		}
+		r.mu.Unlock()
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result, nil
+}
+
+// Cancel cancels the context of the operation with the given id, and removes it from the
+// registry. The operation of another replica is cancelled by removing its record from the
+// store.
+func (r *Registry) Cancel(ctx context.Context, id string) error {
+	r.mu.Lock()
+	e, ok := r.operations[id]
+	if ok {
+		delete(r.operations, id)
+	}
+	store := r.store
+	r.mu.Unlock()
+
+	if ok {
+		e.cancel()
+		if e.stored {
+			err := store.Remove(ctx, id)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if store == nil {
+		return ErrNotFound
+	}
+	return store.Remove(ctx, id)
+}
+
+// Sync cancels the operations of this replica whose records were removed from the store, every
+// interval, until the returned function is called.
+func (r *Registry) Sync(interval time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var done sync.WaitGroup
+	done.Add(1)
+	go func() {
+		defer done.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.sync(ctx); err != nil && ctx.Err() == nil {
+					helpers.Logger.Errorw("syncing operations", "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		done.Wait()
+	}
+}
+
+// sync cancels the operations of this replica whose records were removed from the store, i.e.
+// which were cancelled through another replica.
+func (r *Registry) sync(ctx context.Context) error {
+	r.mu.Lock()
+	store := r.store
+	candidates := []string{}
+	for id, e := range r.operations {
+		if e.stored {
+			candidates = append(candidates, id)
+		}
+	}
+	r.mu.Unlock()
+
+	if store == nil || len(candidates) == 0 {
+		return nil
+	}
+
+	// The candidates were recorded before they were registered, and thus before the listing.
+	// A candidate missing from it was removed.
+	recorded, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := map[string]struct{}{}
+	for _, op := range recorded {
+		known[op.ID] = struct{}{}
+	}
+
+	for _, id := range candidates {
+		if _, ok := known[id]; ok {
+			continue
+		}
+
+		r.mu.Lock()
+		e, ok := r.operations[id]
+		if ok {
+			delete(r.operations, id)
+		}
+		r.mu.Unlock()
+
+		if ok {
+			helpers.Logger.Infow("operation cancelled by another replica", "id", id)
+			e.cancel()
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) getStore() Store {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.store
+}
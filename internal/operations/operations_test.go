@@ -0,0 +1,90 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations_test
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/internal/auth"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/operations"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var registry *operations.Registry
+	var ctx context.Context
+
+	list := func() []operations.Operation {
+		ops, err := registry.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		return ops
+	}
+
+	BeforeEach(func() {
+		registry = operations.New()
+		ctx = requestctx.WithID(context.Background(), "request-1")
+		ctx = requestctx.WithUser(ctx, auth.User{Username: "alice"})
+	})
+
+	It("lists the running operations, with their origin", func() {
+		_, done := registry.Start(ctx, operations.TypeImport, "workspace", "sample")
+		defer done()
+
+		ops := list()
+		Expect(ops).To(HaveLen(1))
+		Expect(ops[0].ID).ToNot(BeEmpty())
+		Expect(ops[0].Type).To(Equal(operations.TypeImport))
+		Expect(ops[0].Namespace).To(Equal("workspace"))
+		Expect(ops[0].Resource).To(Equal("sample"))
+		Expect(ops[0].User).To(Equal("alice"))
+		Expect(ops[0].CorrelationID).To(Equal("request-1"))
+		Expect(ops[0].StartedAt).ToNot(BeZero())
+	})
+
+	It("lists the operations oldest first", func() {
+		_, doneFirst := registry.Start(ctx, operations.TypeImport, "workspace", "first")
+		defer doneFirst()
+		_, doneSecond := registry.Start(ctx, operations.TypeServiceProvision, "workspace", "second")
+		defer doneSecond()
+
+		ops := list()
+		Expect(ops).To(HaveLen(2))
+		Expect(ops[0].Resource).To(Equal("first"))
+		Expect(ops[1].Resource).To(Equal("second"))
+	})
+
+	It("forgets completed operations", func() {
+		opCtx, done := registry.Start(ctx, operations.TypeImport, "workspace", "sample")
+		done()
+		done()
+
+		Expect(list()).To(BeEmpty())
+		Expect(opCtx.Err()).To(HaveOccurred())
+	})
+
+	It("cancels the context of an operation", func() {
+		opCtx, done := registry.Start(ctx, operations.TypeImport, "workspace", "sample")
+		defer done()
+
+		err := registry.Cancel(ctx, list()[0].ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(opCtx.Err()).To(MatchError(context.Canceled))
+		Expect(list()).To(BeEmpty())
+	})
+
+	It("fails to cancel an unknown operation", func() {
+		err := registry.Cancel(ctx, "unknown")
+		Expect(err).To(MatchError(operations.ErrNotFound))
+	})
+})
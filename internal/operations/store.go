@@ -0,0 +1,147 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// OperationSelector selects the secrets recording the operations.
+	OperationSelector = "app.kubernetes.io/component=operation"
+
+	// ReplicaLabel names the replica of the server running the operation.
+	ReplicaLabel = "epinio.io/replica"
+
+	secretPrefix = "epinio-operation-"
+	dataKey      = "operation"
+)
+
+// SecretStore records the operations as secrets in the namespace of Epinio. The secrets of a
+// replica are owned by its pod, and removed by the cluster together with it.
+type SecretStore struct {
+	client    kubernetes.Interface
+	namespace string
+	replica   string
+	owner     []metav1.OwnerReference
+}
+
+// NewSecretStore returns a store for the operations of the replica running in the pod named by
+// the hostname. The records left behind by a previous run of the replica are removed.
+func NewSecretStore(ctx context.Context, client kubernetes.Interface, namespace string) (*SecretStore, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	return newSecretStore(ctx, client, namespace, hostname)
+}
+
+func newSecretStore(ctx context.Context, client kubernetes.Interface, namespace, replica string) (*SecretStore, error) {
+	store := &SecretStore{
+		client:    client,
+		namespace: namespace,
+		replica:   replica,
+	}
+
+	// Outside of a pod, e.g. in development, the records are not owned.
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, replica, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "looking up the pod of the server")
+	}
+	if err == nil {
+		store.owner = []metav1.OwnerReference{{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Name:       pod.Name,
+			UID:        pod.UID,
+		}}
+	}
+
+	// A new run of the replica has no operations yet. Records of the replica are stale.
+	stale, err := client.CoreV1().Secrets(namespace).List(ctx,
+		metav1.ListOptions{LabelSelector: OperationSelector + "," + ReplicaLabel + "=" + replica})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing stale operation records")
+	}
+	for _, secret := range stale.Items {
+		err := client.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "removing stale operation records")
+		}
+	}
+
+	return store, nil
+}
+
+// Put records the operation.
+func (s *SecretStore) Put(ctx context.Context, op Operation) error {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.CoreV1().Secrets(s.namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretPrefix + op.ID,
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/component": "operation",
+				ReplicaLabel:                  s.replica,
+			},
+			OwnerReferences: s.owner,
+		},
+		Data: map[string][]byte{
+			dataKey: data,
+		},
+	}, metav1.CreateOptions{})
+
+	return err
+}
+
+// Remove removes the record of the operation. It returns ErrNotFound for an unknown id.
+func (s *SecretStore) Remove(ctx context.Context, id string) error {
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(ctx, secretPrefix+id, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// List returns the recorded operations of all replicas.
+func (s *SecretStore) List(ctx context.Context) ([]Operation, error) {
+	secrets, err := s.client.CoreV1().Secrets(s.namespace).List(ctx,
+		metav1.ListOptions{LabelSelector: OperationSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Operation, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		var op Operation
+		if err := json.Unmarshal(secret.Data[dataKey], &op); err != nil {
+			return nil, errors.Wrapf(err, "reading operation record %s", secret.Name)
+		}
+		result = append(result, op)
+	}
+
+	return result, nil
+}
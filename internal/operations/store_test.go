@@ -0,0 +1,165 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/epinio/epinio/internal/operations"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("SecretStore", func() {
+	const namespace = "epinio"
+
+	var ctx context.Context
+	var client *fake.Clientset
+	var hostname string
+
+	record := func(id, replica string) *corev1.Secret {
+		data, err := json.Marshal(operations.Operation{ID: id, Type: operations.TypeImport})
+		Expect(err).ToNot(HaveOccurred())
+
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "epinio-operation-" + id,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/component": "operation",
+					operations.ReplicaLabel:       replica,
+				},
+			},
+			Data: map[string][]byte{"operation": data},
+		}
+	}
+
+	ids := func(ops []operations.Operation) []string {
+		result := []string{}
+		for _, op := range ops {
+			result = append(result, op.ID)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		var err error
+		hostname, err = os.Hostname()
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx = context.Background()
+		client = fake.NewSimpleClientset(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: hostname, Namespace: namespace, UID: "pod-uid"}},
+			record("stale", hostname),
+			record("remote", "other-replica"),
+		)
+	})
+
+	It("removes the stale records of the replica", func() {
+		store, err := operations.NewSecretStore(ctx, client, namespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		ops, err := store.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ids(ops)).To(ConsistOf("remote"))
+	})
+
+	It("records the operations of the registry, owned by the pod of the replica", func() {
+		store, err := operations.NewSecretStore(ctx, client, namespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		registry := operations.New()
+		registry.UseStore(store)
+
+		_, done := registry.Start(ctx, operations.TypeImport, "workspace", "sample")
+
+		ops, err := registry.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ops).To(HaveLen(2))
+
+		var local string
+		for _, op := range ops {
+			if op.ID != "remote" {
+				local = op.ID
+			}
+		}
+		Expect(local).ToNot(BeEmpty())
+
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "epinio-operation-"+local, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Labels).To(HaveKeyWithValue(operations.ReplicaLabel, hostname))
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+		Expect(secret.OwnerReferences[0].Name).To(Equal(hostname))
+
+		done()
+
+		ops, err = registry.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ids(ops)).To(ConsistOf("remote"))
+	})
+
+	It("cancels the operations of other replicas through the store", func() {
+		store, err := operations.NewSecretStore(ctx, client, namespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		registry := operations.New()
+		registry.UseStore(store)
+
+		Expect(registry.Cancel(ctx, "remote")).To(Succeed())
+		Expect(registry.Cancel(ctx, "remote")).To(MatchError(operations.ErrNotFound))
+
+		ops, err := registry.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ops).To(BeEmpty())
+	})
+
+	It("cancels the local operations removed by another replica", func() {
+		store, err := operations.NewSecretStore(ctx, client, namespace)
+		Expect(err).ToNot(HaveOccurred())
+
+		local := operations.New()
+		local.UseStore(store)
+		remote := operations.New()
+		remote.UseStore(store)
+
+		stop := local.Sync(10 * time.Millisecond)
+		defer stop()
+
+		opCtx, done := local.Start(ctx, operations.TypeImport, "workspace", "sample")
+		defer done()
+		_, doneOther := local.Start(ctx, operations.TypeImport, "workspace", "other")
+		defer doneOther()
+
+		ops, err := remote.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		var id string
+		for _, op := range ops {
+			if op.Resource == "sample" {
+				id = op.ID
+			}
+		}
+		Expect(remote.Cancel(ctx, id)).To(Succeed())
+
+		Eventually(opCtx.Done()).Should(BeClosed())
+
+		ops, err = local.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ops).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,231 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reaper implements the background deletion of expired applications and services.
+package reaper
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/expiry"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/services"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// leaseName is the name of the Lease electing the replica of the server which reaps.
+	leaseName = "epinio-expiry-reaper"
+
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// Reaper periodically deletes the applications and services whose expiry time has passed.
+type Reaper struct {
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+// New returns a reaper checking for expired resources every interval.
+func New(interval time.Duration) *Reaper {
+	return &Reaper{
+		interval: interval,
+		logger:   helpers.Logger.With("component", "expiry-reaper"),
+	}
+}
+
+// Start runs the reaper in the background, until Stop is called. With several replicas of the
+// server only one of them reaps, the holder of a Lease in the Epinio namespace. The others stand
+// by, and take over when the leader goes away.
+func (r *Reaper) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.done.Add(1)
+	go func() {
+		defer r.done.Done()
+
+		for ctx.Err() == nil {
+			if err := r.lead(ctx); err != nil {
+				r.logger.Errorw("electing the reaping replica", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryPeriod):
+			}
+		}
+	}()
+}
+
+// Stop terminates the background reaper, and waits for it to finish. A held Lease is released.
+func (r *Reaper) Stop() {
+	r.cancel()
+	r.done.Wait()
+}
+
+// lead campaigns for the Lease, and reaps while holding it. It returns when the leadership is
+// lost, or the context is cancelled.
+func (r *Reaper) lead(ctx context.Context) error {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	identity := hostname + "_" + string(uuid.NewUUID())
+
+	elector, err := leaderelection.NewLeaderElector(
+		r.electionConfig(cluster.Kubectl.CoordinationV1(), helmchart.Namespace(), identity))
+	if err != nil {
+		return err
+	}
+
+	elector.Run(ctx)
+	return nil
+}
+
+// electionConfig returns the configuration of the election of the reaping replica, by the
+// given identity, with the Lease in the namespace.
+func (r *Reaper) electionConfig(client coordinationv1.CoordinationV1Interface, namespace, identity string) leaderelection.LeaderElectionConfig {
+	return leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      leaseName,
+				Namespace: namespace,
+			},
+			Client: client,
+			LockConfig: resourcelock.ResourceLockConfig{
+				Identity: identity,
+			},
+		},
+		Name:            leaseName,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				r.logger.Infow("leading, reaping expired resources", "identity", identity)
+				r.run(ctx)
+			},
+			OnStoppedLeading: func() {
+				r.logger.Infow("stopped leading", "identity", identity)
+			},
+		},
+	}
+}
+
+// run reaps every interval, until the context is cancelled.
+func (r *Reaper) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := r.Reap(ctx, now); err != nil {
+				r.logger.Errorw("reaping expired resources", "error", err)
+			}
+		}
+	}
+}
+
+// Reap deletes all applications and services which expired at or before now. Expired services
+// still bound to applications are kept, until they are unbound.
+func (r *Reaper) Reap(ctx context.Context, now time.Time) error {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	apps, err := application.ListWithOptions(ctx, cluster, "", application.ListOptions{SkipMetrics: true})
+	if err != nil {
+		return err
+	}
+
+	for _, app := range apps {
+		if !expiry.Expired(app.ExpiresAt, now) {
+			continue
+		}
+
+		r.logger.Infow("deleting expired application",
+			"namespace", app.Meta.Namespace, "app", app.Meta.Name, "expiresAt", app.ExpiresAt)
+
+		err := application.Delete(ctx, cluster, app.Meta, false)
+		if err != nil {
+			r.logger.Errorw("deleting expired application", "namespace", app.Meta.Namespace,
+				"app", app.Meta.Name, "error", err)
+		}
+	}
+
+	serviceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return err
+	}
+
+	serviceList, err := serviceClient.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range serviceList {
+		if !expiry.Expired(service.ExpiresAt, now) {
+			continue
+		}
+
+		namespace := service.Meta.Namespace
+		name := service.Meta.Name
+
+		boundApps, err := application.ServicesBoundAppsNamesFor(ctx, cluster, namespace, name)
+		if err != nil {
+			r.logger.Errorw("checking bindings of expired service", "namespace", namespace,
+				"service", name, "error", err)
+			continue
+		}
+		if len(boundApps) > 0 {
+			r.logger.Infow("keeping expired service, still bound", "namespace", namespace,
+				"service", name, "apps", boundApps)
+			continue
+		}
+
+		r.logger.Infow("deleting expired service",
+			"namespace", namespace, "service", name, "expiresAt", service.ExpiresAt)
+
+		err = serviceClient.Delete(ctx, namespace, name)
+		if err != nil {
+			r.logger.Errorw("deleting expired service", "namespace", namespace,
+				"service", name, "error", err)
+		}
+	}
+
+	return nil
+}
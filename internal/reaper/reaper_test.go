@@ -0,0 +1,99 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reaper
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Reaper leader election", func() {
+	const namespace = "epinio"
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var client *fake.Clientset
+	var reaper *Reaper
+
+	// elect runs the election for the identity in the background. The returned channel is
+	// closed when the election ends.
+	elect := func(identity string) (*leaderelection.LeaderElector, <-chan struct{}) {
+		elector, err := leaderelection.NewLeaderElector(
+			reaper.electionConfig(client.CoordinationV1(), namespace, identity))
+		Expect(err).ToNot(HaveOccurred())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			elector.Run(ctx)
+		}()
+		return elector, done
+	}
+
+	holder := func() string {
+		lease, err := client.CoordinationV1().Leases(namespace).Get(ctx, leaseName, metav1.GetOptions{})
+		if err != nil || lease.Spec.HolderIdentity == nil {
+			return ""
+		}
+		return *lease.Spec.HolderIdentity
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		client = fake.NewSimpleClientset()
+		// The interval is long enough for the reaper to never tick during the tests.
+		reaper = &Reaper{interval: time.Hour, logger: zap.NewNop().Sugar()}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("leads when the lease is free, and releases it when stopped", func() {
+		elector, done := elect("replica-a")
+
+		Eventually(elector.IsLeader).Should(BeTrue())
+		Expect(holder()).To(Equal("replica-a"))
+
+		cancel()
+		Eventually(done).Should(BeClosed())
+		Expect(holder()).To(BeEmpty())
+	})
+
+	It("stands by while another replica holds the lease", func() {
+		now := metav1.NewMicroTime(time.Now())
+		_, err := client.CoordinationV1().Leases(namespace).Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: leaseName, Namespace: namespace},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To("replica-a"),
+				LeaseDurationSeconds: ptr.To(int32(leaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		elector, _ := elect("replica-b")
+
+		Consistently(elector.IsLeader, "500ms").Should(BeFalse())
+		Expect(holder()).To(Equal("replica-a"))
+	})
+})
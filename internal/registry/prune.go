@@ -0,0 +1,360 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	parser "github.com/novln/docker-parser"
+	"github.com/pkg/errors"
+)
+
+const manifestAccept = "application/vnd.docker.distribution.manifest.v2+json, application/vnd.oci.image.manifest.v1+json"
+
+// nextLinkRE extracts the target of a `rel="next"` Link header, as used by the registry for
+// paginated listings.
+var nextLinkRE = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Image describes a single tag of a repository in a registry.
+type Image struct {
+	Repository string
+	Tag        string
+	Digest     string
+	Created    time.Time        // Zero when the image configuration carries no creation time.
+	Blobs      map[string]int64 // Config and layer blobs of the image, digest to size.
+}
+
+// Reference returns the repository and tag of the image, in the `repository:tag` form.
+func (i Image) Reference() string {
+	return fmt.Sprintf("%s:%s", i.Repository, i.Tag)
+}
+
+// Client talks to a registry through the Docker Registry HTTP API v2. It is restricted to the
+// repositories under the namespace of the registry credentials it was created from, if any.
+type Client struct {
+	scheme string
+	host   string
+	prefix string
+	auth   string
+	client *http.Client
+}
+
+// NewClient returns a client for the registry described by the credentials. The TLS
+// configuration is optional, for registries with self-signed certificates.
+func NewClient(credentials RegistryCredentials, tlsConfig *tls.Config) (*Client, error) {
+	scheme, rest := "https", credentials.URL
+	if strings.HasPrefix(rest, "http://") {
+		scheme, rest = "http", strings.TrimPrefix(rest, "http://")
+	} else if strings.HasPrefix(rest, "https://") {
+		rest = strings.TrimPrefix(rest, "https://")
+	} else if strings.HasPrefix(rest, "127.0.0.1") || strings.HasPrefix(rest, "localhost") {
+		scheme = "http"
+	}
+
+	host, prefix, _ := strings.Cut(strings.TrimSuffix(rest, "/"), "/")
+	if host == "" {
+		return nil, errors.New("registry credentials without registry url")
+	}
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &Client{
+		scheme: scheme,
+		host:   host,
+		prefix: prefix,
+		auth:   base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", credentials.Username, credentials.Password))),
+		client: &http.Client{Transport: transport},
+	}, nil
+}
+
+// ImageReference returns the repository and tag of the image url, without the registry. For
+// an image url referring to a digest the tag is that digest.
+func ImageReference(imageURL string) (string, string, error) {
+	ref, err := parser.Parse(imageURL)
+	if err != nil {
+		return "", "", err
+	}
+	return ref.ShortName(), ref.Tag(), nil
+}
+
+// Images lists all tags of all repositories in the registry, with their digest, creation time
+// and blobs.
+func (c *Client) Images(ctx context.Context) ([]Image, error) {
+	repositories, err := c.repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	images := []Image{}
+	for _, repository := range repositories {
+		tags, err := listRepositoryTags(ctx, c.scheme, c.host, repository, c.auth, c.client)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing tags of %s", repository)
+		}
+		sort.Strings(tags)
+
+		for _, tag := range tags {
+			image, err := c.image(ctx, repository, tag)
+			if err != nil {
+				return nil, errors.Wrapf(err, "inspecting %s:%s", repository, tag)
+			}
+			if image != nil {
+				images = append(images, *image)
+			}
+		}
+	}
+
+	return images, nil
+}
+
+// Delete removes the manifest of the image from the registry. Note that this removes all tags
+// referring to the same manifest. The space of the blobs is only reclaimed by the garbage
+// collection of the registry itself.
+func (c *Client) Delete(ctx context.Context, image Image) error {
+	resp, err := c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/v2/%s/manifests/%s", image.Repository, image.Digest), manifestAccept)
+	if err != nil {
+		return errors.Wrap(err, "deleting manifest")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusAccepted, http.StatusOK, http.StatusNotFound:
+		return nil
+	case http.StatusMethodNotAllowed:
+		return errors.New("image deletion is disabled on the registry")
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	return errors.Errorf("failed to delete manifest: status %d, body: %s", resp.StatusCode, string(body))
+}
+
+// repositories lists the repositories of the registry, following the pagination of the catalog.
+func (c *Client) repositories(ctx context.Context) ([]string, error) {
+	repositories := []string{}
+	next := "/v2/_catalog?n=1000"
+
+	for next != "" {
+		resp, err := c.do(ctx, http.MethodGet, next, "")
+		if err != nil {
+			return nil, errors.Wrap(err, "listing repositories")
+		}
+
+		var catalog struct {
+			Repositories []string `json:"repositories"`
+		}
+		err = decodeResponse(resp, &catalog)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing repositories")
+		}
+
+		for _, repository := range catalog.Repositories {
+			if strings.HasPrefix(repository, c.prefix) {
+				repositories = append(repositories, repository)
+			}
+		}
+
+		next = ""
+		if match := nextLinkRE.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			next = match[1]
+		}
+	}
+
+	return repositories, nil
+}
+
+// image retrieves digest, blobs and creation time of a tag. Manifests which are not single
+// images, i.e. indices, are ignored, and nil is returned for them.
+func (c *Client) image(ctx context.Context, repository, tag string) (*Image, error) {
+	resp, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/%s/manifests/%s", repository, tag), manifestAccept)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching manifest")
+	}
+
+	var manifest struct {
+		Config struct {
+			Digest string `json:"digest"`
+			Size   int64  `json:"size"`
+		} `json:"config"`
+		Layers []struct {
+			Digest string `json:"digest"`
+			Size   int64  `json:"size"`
+		} `json:"layers"`
+	}
+	body, err := readResponse(resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching manifest")
+	}
+	err = json.Unmarshal(body, &manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing manifest")
+	}
+	if manifest.Config.Digest == "" {
+		return nil, nil
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		hash := sha256.Sum256(body)
+		digest = fmt.Sprintf("sha256:%s", hex.EncodeToString(hash[:]))
+	}
+
+	image := &Image{
+		Repository: repository,
+		Tag:        tag,
+		Digest:     digest,
+		Blobs:      map[string]int64{manifest.Config.Digest: manifest.Config.Size},
+	}
+	for _, layer := range manifest.Layers {
+		image.Blobs[layer.Digest] = layer.Size
+	}
+
+	resp, err = c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/%s/blobs/%s", repository, manifest.Config.Digest), "")
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching image configuration")
+	}
+
+	var config struct {
+		Created time.Time `json:"created"`
+	}
+	err = decodeResponse(resp, &config)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching image configuration")
+	}
+	image.Created = config.Created
+
+	return image, nil
+}
+
+// do performs an authenticated request against the registry. The path may be absolute, as
+// found in pagination links.
+func (c *Client) do(ctx context.Context, method, path, accept string) (*http.Response, error) {
+	target := path
+	if u, err := url.Parse(path); err != nil || !u.IsAbs() {
+		target = fmt.Sprintf("%s://%s%s", c.scheme, c.host, path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", c.auth))
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	return c.client.Do(req)
+}
+
+// decodeResponse decodes the JSON body of a successful response, and closes it.
+func decodeResponse(resp *http.Response, result interface{}) error {
+	body, err := readResponse(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
+
+// readResponse returns the body of a successful response, and closes it.
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("status %d, body: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+// SelectPrunable splits the images into those to prune and those to keep. An image is kept when
+// it is referenced, created after the cutoff, or of unknown age. As deletion works per manifest,
+// an image is also kept when another tag of the same manifest is kept. Only one image is
+// returned per manifest to prune.
+func SelectPrunable(images []Image, referenced func(Image) bool, cutoff time.Time) ([]Image, []Image) {
+	keep := func(image Image) bool {
+		return referenced(image) || image.Created.IsZero() || image.Created.After(cutoff)
+	}
+
+	kept := map[string]bool{}
+	for _, image := range images {
+		if keep(image) {
+			kept[image.Repository+"@"+image.Digest] = true
+		}
+	}
+
+	var prune, keepList []Image
+	seen := map[string]bool{}
+	for _, image := range images {
+		manifest := image.Repository + "@" + image.Digest
+		if kept[manifest] {
+			keepList = append(keepList, image)
+			continue
+		}
+		if seen[manifest] {
+			continue
+		}
+		seen[manifest] = true
+		prune = append(prune, image)
+	}
+
+	return prune, keepList
+}
+
+// ReclaimableBytes returns the size of the blobs used only by the pruned images, i.e. the space
+// the registry garbage collection is able to reclaim after their deletion.
+func ReclaimableBytes(prune, keep []Image) int64 {
+	used := map[string]bool{}
+	for _, image := range keep {
+		for digest := range image.Blobs {
+			used[digest] = true
+		}
+	}
+
+	var reclaimed int64
+	for _, image := range prune {
+		for digest, size := range image.Blobs {
+			if used[digest] {
+				continue
+			}
+			used[digest] = true
+			reclaimed += size
+		}
+	}
+
+	return reclaimed
+}
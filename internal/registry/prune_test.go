@@ -0,0 +1,194 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/internal/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prune", func() {
+	var (
+		now    = time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		cutoff = now.Add(-30 * 24 * time.Hour)
+		old    = now.Add(-60 * 24 * time.Hour)
+		recent = now.Add(-1 * 24 * time.Hour)
+	)
+
+	image := func(repository, tag, digest string, created time.Time, blobs map[string]int64) registry.Image {
+		return registry.Image{
+			Repository: repository,
+			Tag:        tag,
+			Digest:     digest,
+			Created:    created,
+			Blobs:      blobs,
+		}
+	}
+
+	Describe("SelectPrunable", func() {
+		It("prunes only old images which are not referenced", func() {
+			images := []registry.Image{
+				image("apps/a", "1", "sha256:a1", old, nil),
+				image("apps/a", "2", "sha256:a2", old, nil),
+				image("apps/a", "3", "sha256:a3", recent, nil),
+				image("apps/b", "1", "sha256:b1", time.Time{}, nil),
+			}
+			referenced := func(image registry.Image) bool {
+				return image.Reference() == "apps/a:2"
+			}
+
+			prune, keep := registry.SelectPrunable(images, referenced, cutoff)
+			Expect(prune).To(HaveLen(1))
+			Expect(prune[0].Reference()).To(Equal("apps/a:1"))
+			Expect(keep).To(HaveLen(3))
+		})
+
+		It("keeps all tags of a manifest which has a kept tag", func() {
+			images := []registry.Image{
+				image("apps/a", "1", "sha256:same", old, nil),
+				image("apps/a", "2", "sha256:same", old, nil),
+			}
+			referenced := func(image registry.Image) bool {
+				return image.Tag == "2"
+			}
+
+			prune, keep := registry.SelectPrunable(images, referenced, cutoff)
+			Expect(prune).To(BeEmpty())
+			Expect(keep).To(HaveLen(2))
+		})
+
+		It("prunes a manifest only once", func() {
+			images := []registry.Image{
+				image("apps/a", "1", "sha256:same", old, nil),
+				image("apps/a", "2", "sha256:same", old, nil),
+			}
+
+			prune, keep := registry.SelectPrunable(images, func(registry.Image) bool { return false }, cutoff)
+			Expect(prune).To(HaveLen(1))
+			Expect(keep).To(BeEmpty())
+		})
+	})
+
+	Describe("ReclaimableBytes", func() {
+		It("counts only the blobs not shared with kept images, once", func() {
+			prune := []registry.Image{
+				image("apps/a", "1", "sha256:a1", old, map[string]int64{"base": 100, "l1": 10}),
+				image("apps/a", "2", "sha256:a2", old, map[string]int64{"base": 100, "l2": 20, "l3": 5}),
+			}
+			keep := []registry.Image{
+				image("apps/a", "3", "sha256:a3", recent, map[string]int64{"base": 100, "l3": 5}),
+			}
+
+			Expect(registry.ReclaimableBytes(prune, keep)).To(Equal(int64(30)))
+		})
+	})
+
+	Describe("Client", func() {
+		var (
+			server  *httptest.Server
+			deleted []string
+		)
+
+		BeforeEach(func() {
+			deleted = []string{}
+			mux := http.NewServeMux()
+
+			mux.HandleFunc("/v2/_catalog", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("last") == "" {
+					w.Header().Set("Link", `</v2/_catalog?n=1000&last=apps%2Fa>; rel="next"`)
+					_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"apps/a", "other/x"}})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"apps/b"}})
+			})
+			mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(HavePrefix("Basic "))
+				path := strings.TrimPrefix(r.URL.Path, "/v2/")
+
+				switch {
+				case strings.HasSuffix(path, "/tags/list"):
+					repository := strings.TrimSuffix(path, "/tags/list")
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{
+						"name": repository,
+						"tags": []string{"2", "1"},
+					})
+				case strings.Contains(path, "/manifests/") && r.Method == http.MethodDelete:
+					deleted = append(deleted, path)
+					w.WriteHeader(http.StatusAccepted)
+				case strings.Contains(path, "/manifests/"):
+					parts := strings.Split(path, "/manifests/")
+					w.Header().Set("Docker-Content-Digest", fmt.Sprintf("sha256:%s-%s", parts[0], parts[1]))
+					_, _ = fmt.Fprintf(w, `{"config":{"digest":"cfg-%s","size":1},"layers":[{"digest":"layer","size":10}]}`, parts[1])
+				case strings.Contains(path, "/blobs/"):
+					_, _ = fmt.Fprint(w, `{"created":"2026-01-01T00:00:00Z"}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			server = httptest.NewServer(mux)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newClient := func() *registry.Client {
+			client, err := registry.NewClient(registry.RegistryCredentials{
+				URL:      server.URL + "/apps",
+				Username: "user",
+				Password: "pass",
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			return client
+		}
+
+		It("lists the images of the repositories in its namespace", func() {
+			images, err := newClient().Images(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+
+			references := []string{}
+			for _, image := range images {
+				references = append(references, image.Reference())
+			}
+			Expect(references).To(Equal([]string{"apps/a:1", "apps/a:2", "apps/b:1", "apps/b:2"}))
+
+			Expect(images[0].Digest).To(Equal("sha256:apps/a-1"))
+			Expect(images[0].Created).To(Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(images[0].Blobs).To(Equal(map[string]int64{"cfg-1": 1, "layer": 10}))
+		})
+
+		It("deletes images by digest", func() {
+			err := newClient().Delete(context.Background(), image("apps/a", "1", "sha256:abc", old, nil))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(Equal([]string{"apps/a/manifests/sha256:abc"}))
+		})
+	})
+
+	Describe("ImageReference", func() {
+		It("strips the registry", func() {
+			repository, tag, err := registry.ImageReference("127.0.0.1:30500/apps/ns-app:abc")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repository).To(Equal("apps/ns-app"))
+			Expect(tag).To(Equal("abc"))
+		})
+	})
+})
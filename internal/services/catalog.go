@@ -14,9 +14,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	apiv1 "github.com/epinio/application/api/v1"
+	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/internal/helmchart"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
@@ -31,6 +33,14 @@ const (
 	CatalogServiceLabelKey              = "application.epinio.io/catalog-service-name"
 	CatalogServiceSecretTypesAnnotation = "application.epinio.io/catalog-service-secret-types"
 	CatalogServiceVersionLabelKey       = "application.epinio.io/catalog-service-version"
+	// CatalogServiceReplicasKeyAnnotation names the chart value controlling the number of
+	// replicas of a service instance. See the `Replicas` field of `ServiceUpdateRequest`.
+	CatalogServiceReplicasKeyAnnotation = "application.epinio.io/catalog-service-replicas-key"
+	// CatalogServiceDeprecatedAnnotation marks a catalog service as retired, when "true". It is
+	// hidden from the catalog listing, and services created from it come with a warning. The
+	// optional CatalogServiceDeprecationMessageAnnotation tells users what to use instead.
+	CatalogServiceDeprecatedAnnotation         = "application.epinio.io/catalog-service-deprecated"
+	CatalogServiceDeprecationMessageAnnotation = "application.epinio.io/catalog-service-deprecation-message"
 	// COMPATIBILITY SUPPORT for services from before https://github.com/epinio/epinio/issues/1704 fix
 	TargetNamespaceLabelKey = "application.epinio.io/target-namespace"
 	// ServiceNameLabelKey is used to keep the original name
@@ -114,6 +124,15 @@ func (s *ServiceClient) convertUnstructuredIntoCatalogService(unstructured unstr
 		repoPassword = string(authSecret.Data["password"])
 	}
 
+	// A broken readiness predicate is ignored, falling back to the status reported by helm
+	readiness, err := ParseReadiness(catalogService.GetAnnotations()[CatalogServiceReadinessAnnotation])
+	if err != nil {
+		helpers.Logger.Errorw("ignoring readiness predicate", "catalogService", unstructured.GetName(), "error", err)
+	}
+
+	// A malformed deprecation marker is treated as not deprecated
+	deprecated, _ := strconv.ParseBool(catalogService.GetAnnotations()[CatalogServiceDeprecatedAnnotation])
+
 	secretTypes := []string{}
 	secretTypesAnnotationValue := catalogService.GetAnnotations()[CatalogServiceSecretTypesAnnotation]
 	if len(secretTypesAnnotationValue) > 0 {
@@ -140,7 +159,12 @@ func (s *ServiceClient) convertUnstructuredIntoCatalogService(unstructured unstr
 				Password: repoPassword,
 			},
 		},
-		Values:   catalogService.Spec.Values,
-		Settings: settings,
+		Values:      catalogService.Spec.Values,
+		Settings:    settings,
+		ReplicasKey: catalogService.GetAnnotations()[CatalogServiceReplicasKeyAnnotation],
+		Readiness:   readiness,
+
+		Deprecated:         deprecated,
+		DeprecationMessage: catalogService.GetAnnotations()[CatalogServiceDeprecationMessageAnnotation],
 	}, nil
 }
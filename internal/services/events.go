@@ -0,0 +1,100 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Events returns the kubernetes events of the workload of the named service instance.
+func (s *ServiceClient) Events(ctx context.Context, namespace, name string) (models.EventList, error) {
+	return GetServiceEvents(ctx, s.kubeClient.Kubectl.CoreV1(), namespace, name)
+}
+
+// GetServiceEvents returns the kubernetes events of the resources of the service's helm release,
+// ordered from newest to oldest. The resources are the pods and PVCs labeled with the release
+// instance, and anything named after the release, like the controllers managing the pods.
+func GetServiceEvents(ctx context.Context, coreClient v1.CoreV1Interface, namespace, name string) (models.EventList, error) {
+	releaseName := names.ServiceReleaseName(name)
+	selector := metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/instance=" + releaseName,
+	}
+
+	owned := map[types.UID]struct{}{}
+
+	pods, err := coreClient.Pods(namespace).List(ctx, selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the pods")
+	}
+	for _, pod := range pods.Items {
+		owned[pod.UID] = struct{}{}
+	}
+
+	pvcs, err := coreClient.PersistentVolumeClaims(namespace).List(ctx, selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the persistent volume claims")
+	}
+	for _, pvc := range pvcs.Items {
+		owned[pvc.UID] = struct{}{}
+	}
+
+	events, err := coreClient.Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the events")
+	}
+
+	result := models.EventList{}
+	for _, event := range events.Items {
+		object := event.InvolvedObject
+		_, isOwned := owned[object.UID]
+		if !isOwned && !strings.HasPrefix(object.Name, releaseName) {
+			continue
+		}
+
+		result = append(result, ToEvent(event))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[j].LastSeen.Before(&result[i].LastSeen)
+	})
+
+	return result, nil
+}
+
+// ToEvent converts a kubernetes event into its API form.
+func ToEvent(event corev1.Event) models.Event {
+	lastSeen := event.LastTimestamp
+	if lastSeen.IsZero() {
+		lastSeen = metav1.NewTime(event.EventTime.Time)
+	}
+
+	return models.Event{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Kind:      event.InvolvedObject.Kind,
+		Object:    event.InvolvedObject.Name,
+		Count:     event.Count,
+		FirstSeen: event.FirstTimestamp,
+		LastSeen:  lastSeen,
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("GetServiceEvents", func() {
+	const namespace = "workspace"
+	const name = "mydb"
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	releaseName := names.ServiceReleaseName(name)
+
+	newEvent := func(eventName, kind, object, uid, reason string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: eventName, Namespace: namespace},
+			InvolvedObject: corev1.ObjectReference{
+				Kind: kind,
+				Name: object,
+				UID:  k8stypes.UID(uid),
+			},
+			Reason:        reason,
+			Type:          corev1.EventTypeWarning,
+			LastTimestamp: metav1.NewTime(lastSeen),
+		}
+	}
+
+	It("returns the events of the service's resources, newest first", func() {
+		objects := []runtime.Object{
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "data-pvc",
+					Namespace: namespace,
+					UID:       "pvc-uid",
+					Labels:    map[string]string{"app.kubernetes.io/instance": releaseName},
+				},
+			},
+			newEvent("e1", "PersistentVolumeClaim", "data-pvc", "pvc-uid", "ProvisioningFailed", base.Add(time.Minute)),
+			newEvent("e2", "StatefulSet", releaseName+"-postgresql", "sts-uid", "FailedCreate", base),
+			newEvent("e3", "Pod", "unrelated", "other-uid", "BackOff", base),
+		}
+
+		client := fake.NewSimpleClientset(objects...)
+
+		events, err := services.GetServiceEvents(context.Background(), client.CoreV1(), namespace, name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Reason).To(Equal("ProvisioningFailed"))
+		Expect(events[0].Object).To(Equal("data-pvc"))
+		Expect(events[1].Reason).To(Equal("FailedCreate"))
+		Expect(events[1].Kind).To(Equal("StatefulSet"))
+	})
+})
@@ -0,0 +1,46 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/epinio/epinio/internal/expiry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SetExpiry sets the time the named service instance expires at, i.e. is deleted
+// automatically. A nil time removes the expiry.
+func (s *ServiceClient) SetExpiry(ctx context.Context, namespace, name string, expiresAt *time.Time) error {
+	var value interface{} // nil, removes the annotation
+	if expiresAt != nil {
+		value = expiry.Format(*expiresAt)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				expiry.Annotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.kubeClient.Kubectl.CoreV1().Secrets(namespace).Patch(ctx,
+		serviceResourceName(name), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
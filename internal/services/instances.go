@@ -20,10 +20,12 @@ import (
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/expiry"
 	"github.com/epinio/epinio/internal/helm"
 	"github.com/epinio/epinio/internal/names"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/util/retry"
@@ -89,21 +91,39 @@ func (s *ServiceClient) Get(ctx context.Context, namespace, name string) (*model
 		},
 		SecretTypes:           secretTypes,
 		CatalogService:        fmt.Sprintf("%s%s", catalogServicePrefix, catalogServiceName),
+		CatalogServiceName:    catalogServiceName,
 		CatalogServiceVersion: catalogServiceVersion,
+		Chart:                 chartRef(catalogEntry),
 		InternalRoutes:        internalRoutes,
 	}
 
 	var settings map[string]models.ChartSetting
+	var readiness *models.ServiceReadiness
 	if catalogEntry != nil {
 		settings = catalogEntry.Settings
+		readiness = catalogEntry.Readiness
 	}
 
 	err = setServiceStatusAndCustomValues(&service, srv, ctx, s.kubeClient,
-		namespace, names.ServiceReleaseName(name), settings)
+		namespace, names.ServiceReleaseName(name), settings, readiness)
 
 	return &service, err
 }
 
+// chartRef returns the reference to the helm chart of the catalog service, or nil, if the
+// catalog service is missing.
+func chartRef(catalogEntry *models.CatalogService) *models.ServiceChartRef {
+	if catalogEntry == nil {
+		return nil
+	}
+
+	return &models.ServiceChartRef{
+		Chart:      catalogEntry.HelmChart,
+		Version:    catalogEntry.ChartVersion,
+		Repository: catalogEntry.HelmRepo.URL,
+	}
+}
+
 // GetInternalRoutes returns the internal routes of the service, finding them from the kubernetes services of the Helm release
 func GetInternalRoutes(ctx context.Context, servicesGetter v1.ServiceInterface, name string) ([]string, error) {
 	servicesList, err := servicesGetter.List(ctx, metav1.ListOptions{
@@ -161,6 +181,11 @@ func (s *ServiceClient) Create(ctx context.Context,
 		}
 	}
 
+	data, err := recordOriginalValues(data, settings, catalogService)
+	if err != nil {
+		return err
+	}
+
 	var annotations map[string]string // default: nil
 	if len(catalogService.SecretTypes) > 0 {
 		annotations = map[string]string{
@@ -168,7 +193,7 @@ func (s *ServiceClient) Create(ctx context.Context,
 		}
 	}
 
-	err := s.kubeClient.CreateLabeledSecret(ctx, namespace, service, data, labels, annotations)
+	err = s.kubeClient.CreateLabeledSecret(ctx, namespace, service, data, labels, annotations)
 	if err != nil {
 		return errors.Wrap(err, "failed to create service secret")
 	}
@@ -298,15 +323,22 @@ func (s *ServiceClient) list(ctx context.Context, namespace string) (models.Serv
 	}
 
 	// catalogServiceNameMap is a lookup map to check the available Catalog Services
-	catalogServiceNameMap := map[string]struct{}{}
+	catalogServiceNameMap := map[string]*models.CatalogService{}
 	for _, catalogService := range catalogServices {
-		catalogServiceNameMap[catalogService.Meta.Name] = struct{}{}
+		catalogServiceNameMap[catalogService.Meta.Name] = catalogService
 	}
 
 	for _, srv := range services.Items {
 		catalogServiceName := srv.GetLabels()[CatalogServiceLabelKey]
-		if _, exists := catalogServiceNameMap[catalogServiceName]; !exists {
-			catalogServiceName = "[Missing] " + catalogServiceName
+		catalogServiceDisplayName := catalogServiceName
+		catalogEntry, exists := catalogServiceNameMap[catalogServiceName]
+		if !exists {
+			catalogServiceDisplayName = "[Missing] " + catalogServiceName
+		}
+
+		var readiness *models.ServiceReadiness
+		if catalogEntry != nil {
+			readiness = catalogEntry.Readiness
 		}
 
 		serviceName := srv.GetLabels()[ServiceNameLabelKey]
@@ -317,14 +349,17 @@ func (s *ServiceClient) list(ctx context.Context, namespace string) (models.Serv
 				Namespace: srv.Namespace,
 				CreatedAt: srv.GetCreationTimestamp(),
 			},
-			CatalogService:        catalogServiceName,
+			CatalogService:        catalogServiceDisplayName,
+			CatalogServiceName:    catalogServiceName,
 			CatalogServiceVersion: srv.GetLabels()[CatalogServiceVersionLabelKey],
+			Chart:                 chartRef(catalogEntry),
 		}
 
 		theServiceSecret := srv
 		err = setServiceStatusAndCustomValues(&service, &theServiceSecret, ctx, s.kubeClient,
 			srv.Namespace, names.ServiceReleaseName(serviceName),
 			nil, // no settings information - TODO
+			readiness,
 		)
 		if err != nil {
 			return nil, err
@@ -358,11 +393,34 @@ func (s *ServiceClient) UpdateService(ctx context.Context, cluster *kubernetes.C
 
 	// Update the secret first. As part of that we get the updated settings as well.
 
+	newSettings, err := UpdateServiceSettings(ctx, cluster.Kubectl.CoreV1().Secrets(service.Namespace()),
+		serviceResourceName(service.Meta.Name), changes)
+	if err != nil {
+		return err
+	}
+
+	catalogService, err := s.GetCatalogService(ctx, service.CatalogService)
+	if err != nil {
+		return err
+	}
+
+	err = s.DeployOrUpdate(ctx, service.Meta.Namespace, service.Meta.Name, changes.Wait,
+		newSettings, catalogService, hook)
+
+	return errors.Wrap(err, "error deploying service helm chart")
+
+}
+
+// UpdateServiceSettings modifies the settings recorded in the named secret representing a
+// service as per the instructions, and returns the updated settings. These are the settings
+// the helm release of the service has to be deployed with.
+func UpdateServiceSettings(ctx context.Context, secrets v1.SecretInterface, serviceSecretName string,
+	changes models.ServiceUpdateRequest) (models.ChartValueSettings, error) {
+
 	var newSettings models.ChartValueSettings
-	serviceSecretName := serviceResourceName(service.Meta.Name)
 
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		serviceSecret, err := cluster.GetSecret(ctx, service.Meta.Namespace, serviceSecretName)
+		serviceSecret, err := secrets.Get(ctx, serviceSecretName, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
@@ -371,26 +429,13 @@ func (s *ServiceClient) UpdateService(ctx context.Context, cluster *kubernetes.C
 		// release of the service as `Get` does.
 
 		// Read existing settings
-		settings := models.ChartValueSettings{}
-
-		if serviceSecret.Data != nil {
-			yamlSettings, ok := serviceSecret.Data["settings"]
-			if ok {
-				// Found the exact settings in the K secret representing the E service
-				err := yaml.Unmarshal(yamlSettings, &settings)
-				if err != nil {
-					return errors.Wrap(err, "failed to unmarshall the settings")
-				}
-			}
+		settings, err := settingsFromSecret(serviceSecret)
+		if err != nil {
+			return err
 		}
 
 		// Modify the settings as per the instructions.
-		for _, remove := range changes.Remove {
-			delete(settings, remove)
-		}
-		for key, value := range changes.Set {
-			settings[key] = value
-		}
+		settings = UpdatedSettings(settings, changes)
 
 		yaml, err := yaml.Marshal(settings)
 		if err != nil {
@@ -404,30 +449,203 @@ func (s *ServiceClient) UpdateService(ctx context.Context, cluster *kubernetes.C
 
 		serviceSecret.Data["settings"] = yaml
 
-		_, err = cluster.Kubectl.CoreV1().Secrets(service.Namespace()).Update(
-			ctx, serviceSecret, metav1.UpdateOptions{})
-		if err != nil {
-			// publish to calling scope
+		_, err = secrets.Update(ctx, serviceSecret, metav1.UpdateOptions{})
+		if err == nil {
+			// publish to calling scope, only what was written
 			newSettings = settings
 		}
 
 		return err
 	})
 
+	return newSettings, err
+}
+
+// settingsFromSecret returns the settings recorded in the secret representing the service.
+func settingsFromSecret(serviceSecret *corev1.Secret) (models.ChartValueSettings, error) {
+	settings := models.ChartValueSettings{}
+
+	if serviceSecret.Data != nil {
+		yamlSettings, ok := serviceSecret.Data["settings"]
+		if ok {
+			// Found the exact settings in the K secret representing the E service
+			err := yaml.Unmarshal(yamlSettings, &settings)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshall the settings")
+			}
+		}
+	}
+
+	return settings, nil
+}
+
+// UpdatedSettings returns a copy of the settings, modified as per the update request.
+func UpdatedSettings(settings models.ChartValueSettings, changes models.ServiceUpdateRequest) models.ChartValueSettings {
+	result := models.ChartValueSettings{}
+	for key, value := range settings {
+		result[key] = value
+	}
+
+	for _, remove := range changes.Remove {
+		delete(result, remove)
+	}
+	for key, value := range changes.Set {
+		result[key] = value
+	}
+
+	return result
+}
+
+// UpdatedServiceValues returns the chart values of the service after applying the changes to
+// its settings, in YAML format. Nothing is changed.
+func (s *ServiceClient) UpdatedServiceValues(ctx context.Context, cluster *kubernetes.Cluster, service *models.Service,
+	changes models.ServiceUpdateRequest) (string, error) {
+
+	serviceSecret, err := cluster.GetSecret(ctx, service.Meta.Namespace, serviceResourceName(service.Meta.Name))
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	settings, err := settingsFromSecret(serviceSecret)
+	if err != nil {
+		return "", err
+	}
+
+	catalogService, err := s.GetCatalogService(ctx, service.CatalogService)
+	if err != nil {
+		return "", err
+	}
+
+	epinioValues, err := getEpinioValues(service.Meta.Name, catalogService.Meta.Name)
+	if err != nil {
+		return "", err
+	}
+
+	return mergeServiceValues(catalogService.Values+epinioValues, UpdatedSettings(settings, changes))
+}
+
+// PreviewUpdateService computes what UpdateService would do for the given changes, without
+// applying them. It returns the chart values of the service after the update, and the changes
+// of the values and of the rendered resources, as unified diffs. The resources are rendered by
+// a helm dry-run upgrade.
+func (s *ServiceClient) PreviewUpdateService(ctx context.Context, cluster *kubernetes.Cluster, service *models.Service,
+	changes models.ServiceUpdateRequest) (models.ServiceUpdatePreview, error) {
+
+	serviceSecret, err := cluster.GetSecret(ctx, service.Meta.Namespace, serviceResourceName(service.Meta.Name))
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+
+	settings, err := settingsFromSecret(serviceSecret)
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
 	}
 
 	catalogService, err := s.GetCatalogService(ctx, service.CatalogService)
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+
+	epinioValues, err := getEpinioValues(service.Meta.Name, catalogService.Meta.Name)
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+
+	currentValues, err := mergeServiceValues(catalogService.Values+epinioValues, settings)
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+	values, err := mergeServiceValues(catalogService.Values+epinioValues, UpdatedSettings(settings, changes))
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+
+	release, err := helm.Release(ctx, cluster, service.Meta.Namespace, names.ServiceReleaseName(service.Meta.Name))
+	if err != nil {
+		return models.ServiceUpdatePreview{}, errors.Wrap(err, "finding the service release")
+	}
+
+	manifest, err := helm.PreviewService(ctx, helm.ServiceParameters{
+		AppRef:         models.NewAppRef(service.Meta.Name, service.Meta.Namespace),
+		Cluster:        s.kubeClient,
+		CatalogService: *catalogService,
+		Values:         values,
+	})
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+
+	valuesDiff, err := unifiedDiff("values.yaml", currentValues, values)
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+	manifestDiff, err := unifiedDiff("manifest.yaml", release.Manifest, manifest)
+	if err != nil {
+		return models.ServiceUpdatePreview{}, err
+	}
+
+	return models.ServiceUpdatePreview{
+		Values:       values,
+		ValuesDiff:   valuesDiff,
+		ManifestDiff: manifestDiff,
+	}, nil
+}
+
+// unifiedDiff returns the changes from current to updated as a unified diff, empty when there
+// are none.
+func unifiedDiff(name, current, updated string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(updated),
+		FromFile: name + " (current)",
+		ToFile:   name + " (updated)",
+		Context:  3,
+	})
+}
+
+// RollbackService undoes a failed update of the service. The helm release is rolled back to its
+// previous revision, if the update got as far as creating a new one, and the settings recorded for
+// the service are restored to the given state.
+func (s *ServiceClient) RollbackService(ctx context.Context, cluster *kubernetes.Cluster, service *models.Service,
+	revision int, settings models.ChartValueSettings) error {
+
+	releaseName := names.ServiceReleaseName(service.Meta.Name)
+
+	release, err := helm.Release(ctx, cluster, service.Namespace(), releaseName)
 	if err != nil {
 		return err
 	}
 
-	err = s.DeployOrUpdate(ctx, service.Meta.Namespace, service.Meta.Name, changes.Wait,
-		newSettings, catalogService, hook)
+	if release.Version != revision {
+		err = helm.RollbackService(cluster, models.NewAppRef(service.Meta.Name, service.Namespace()))
+		if err != nil {
+			return err
+		}
+	}
 
-	return errors.Wrap(err, "error deploying service helm chart")
+	serviceSecretName := serviceResourceName(service.Meta.Name)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		serviceSecret, err := cluster.GetSecret(ctx, service.Namespace(), serviceSecretName)
+		if err != nil {
+			return err
+		}
+
+		yaml, err := yaml.Marshal(settings)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshall the settings")
+		}
+
+		if serviceSecret.Data == nil {
+			serviceSecret.Data = map[string][]byte{}
+		}
+
+		serviceSecret.Data["settings"] = yaml
 
+		_, err = cluster.Kubectl.CoreV1().Secrets(service.Namespace()).Update(
+			ctx, serviceSecret, metav1.UpdateOptions{})
+		return err
+	})
 }
 
 // ReplaceService replaces an existing service
@@ -523,10 +741,29 @@ func (s *ServiceClient) DeployOrUpdate(
 		logger.Errorw("getting epinio values", "error", err)
 	}
 
+	values, err := mergeServiceValues(catalogService.Values+epinioValues, settings)
+	if err != nil {
+		return err
+	}
+
+	return helm.DeployService(ctx,
+		helm.ServiceParameters{
+			AppRef:         models.NewAppRef(name, namespace),
+			Cluster:        s.kubeClient,
+			CatalogService: *catalogService,
+			Values:         values,
+			Wait:           wait,
+			PostDeployHook: hook,
+		})
+}
+
+// mergeServiceValues merges the class values, given as YAML, with the user settings, and returns
+// the result serialized back to YAML.
+func mergeServiceValues(classYAML string, settings models.ChartValueSettings) (string, error) {
 	// Ingest the service class YAML data into a proper values table
-	classValues, err := chartutil.ReadValues([]byte(catalogService.Values + epinioValues))
+	classValues, err := chartutil.ReadValues([]byte(classYAML))
 	if err != nil {
-		return errors.Wrap(err, "failed to read service class values")
+		return "", errors.Wrap(err, "failed to read service class values")
 	}
 
 	// Create proper values table from the --chart-value option data
@@ -534,7 +771,7 @@ func (s *ServiceClient) DeployOrUpdate(
 	for key, value := range settings {
 		err := strvals.ParseInto(key+"="+value, userValues)
 		if err != nil {
-			return errors.Wrap(err, "failed to parse `"+key+"="+value+"`")
+			return "", errors.Wrap(err, "failed to parse `"+key+"="+value+"`")
 		}
 	}
 
@@ -551,18 +788,10 @@ func (s *ServiceClient) DeployOrUpdate(
 
 	values, err := chartutil.Values(chartutil.CoalesceTables(classValues, userValues)).YAML()
 	if err != nil {
-		return errors.Wrap(err, "failed to merge class and user values")
+		return "", errors.Wrap(err, "failed to merge class and user values")
 	}
 
-	return helm.DeployService(ctx,
-		helm.ServiceParameters{
-			AppRef:         models.NewAppRef(name, namespace),
-			Cluster:        s.kubeClient,
-			CatalogService: *catalogService,
-			Values:         values,
-			Wait:           wait,
-			PostDeployHook: hook,
-		})
+	return values, nil
 }
 
 func getEpinioValues(serviceName, catalogServiceName string) (string, error) {
@@ -595,7 +824,10 @@ func setServiceStatusAndCustomValues(service *models.Service,
 	ctx context.Context, cluster *kubernetes.Cluster,
 	namespace, releaseName string,
 	settings map[string]models.ChartSetting,
+	readiness *models.ServiceReadiness,
 ) error {
+	service.ExpiresAt = expiry.FromAnnotations(serviceSecret.Annotations)
+
 	serviceRelease, err := helm.Release(ctx, cluster, namespace, releaseName)
 
 	if err != nil {
@@ -615,6 +847,23 @@ func setServiceStatusAndCustomValues(service *models.Service,
 
 	service.Status = NewServiceStatusFromHelmRelease(serviceStatus)
 
+	// The catalog service may demand more than helm for the service to count as deployed
+	if service.Status == models.ServiceStatusDeployed && readiness != nil {
+		ready, err := ReadinessSatisfied(ctx, cluster.Kubectl.CoreV1(), ExecRunner(cluster),
+			namespace, releaseName, readiness)
+		if err != nil {
+			helpers.Logger.Warnw("checking service readiness failed, service not ready",
+				"namespace", namespace, "service", service.Meta.Name, "error", err)
+		}
+		if !ready {
+			service.Status = models.ServiceStatusNotReady
+		}
+	}
+
+	if serviceSecret.Annotations[ServiceSuspendedAnnotation] == "true" {
+		service.Status = models.ServiceStatusSuspended
+	}
+
 	yamlSettings, ok := serviceSecret.Data["settings"]
 	if ok {
 		// Found the exact settings in the K secret representing the E service
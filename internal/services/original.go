@@ -0,0 +1,210 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helm"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/chartutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// originalValuesKey is the key of the service secret holding the catalog values the
+	// service was created from.
+	originalValuesKey = "original-values"
+
+	// originalSettingsKey is the key of the service secret holding the settings the service
+	// was created with.
+	originalSettingsKey = "original-settings"
+)
+
+// ErrNoOriginalValues is returned for services created before their original values were
+// recorded.
+var ErrNoOriginalValues = errors.New("original values not recorded")
+
+// OriginalValues is the starting point of a service instance, i.e. the catalog values and the
+// user settings it was created with, before any updates.
+type OriginalValues struct {
+	CatalogValues string
+	Settings      models.ChartValueSettings
+}
+
+// Values returns the catalog values merged with the settings, as they were at creation.
+func (o OriginalValues) Values() (map[string]interface{}, error) {
+	values, err := mergeServiceValues(o.CatalogValues, o.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := chartutil.ReadValues([]byte(values))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the original values")
+	}
+
+	return result.AsMap(), nil
+}
+
+// recordOriginalValues adds the catalog values and settings to the data of a new service
+// secret. The catalog service may change later, so its values are copied verbatim.
+func recordOriginalValues(data map[string][]byte, settings models.ChartValueSettings,
+	catalogService *models.CatalogService) (map[string][]byte, error) {
+
+	if settings == nil {
+		settings = models.ChartValueSettings{}
+	}
+
+	yamlSettings, err := yaml.Marshal(settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshall the original settings")
+	}
+
+	if data == nil {
+		data = map[string][]byte{}
+	}
+
+	data[originalValuesKey] = []byte(catalogService.Values)
+	data[originalSettingsKey] = yamlSettings
+
+	return data, nil
+}
+
+// OriginalValues returns the values the named service was created with. It returns
+// ErrNoOriginalValues if the service predates their recording.
+func (s *ServiceClient) OriginalValues(ctx context.Context, namespace, name string) (*OriginalValues, error) {
+	serviceSecret, err := s.kubeClient.GetSecret(ctx, namespace, serviceResourceName(name))
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the service instance")
+	}
+
+	return originalValuesFromData(serviceSecret.Data)
+}
+
+// originalValuesFromData extracts the original values from the data of a service secret.
+func originalValuesFromData(data map[string][]byte) (*OriginalValues, error) {
+	catalogValues, ok := data[originalValuesKey]
+	if !ok {
+		return nil, ErrNoOriginalValues
+	}
+
+	settings := models.ChartValueSettings{}
+	if yamlSettings, ok := data[originalSettingsKey]; ok {
+		err := yaml.Unmarshal(yamlSettings, &settings)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshall the original settings")
+		}
+	}
+
+	return &OriginalValues{
+		CatalogValues: string(catalogValues),
+		Settings:      settings,
+	}, nil
+}
+
+// ChangedSettings returns the sorted keys whose values differ between the two settings,
+// including keys present in only one of them.
+func ChangedSettings(current, desired models.ChartValueSettings) []string {
+	changed := []string{}
+	for key, value := range current {
+		if other, ok := desired[key]; !ok || other != value {
+			changed = append(changed, key)
+		}
+	}
+	for key := range desired {
+		if _, ok := current[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// ResetService reverts the service to the values it was created with, discarding all updates
+// made since. The helm release is upgraded with the original catalog values and settings. It
+// returns the keys of the settings which changed. Nothing is deployed when there are none.
+func (s *ServiceClient) ResetService(ctx context.Context, cluster *kubernetes.Cluster, service *models.Service,
+	wait bool, hook helm.PostDeployFunction) ([]string, error) {
+
+	var original *OriginalValues
+	changed := []string{}
+	serviceSecretName := serviceResourceName(service.Meta.Name)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		serviceSecret, err := cluster.GetSecret(ctx, service.Namespace(), serviceSecretName)
+		if err != nil {
+			return err
+		}
+
+		original, err = originalValuesFromData(serviceSecret.Data)
+		if err != nil {
+			return err
+		}
+
+		settings := models.ChartValueSettings{}
+		if yamlSettings, ok := serviceSecret.Data["settings"]; ok {
+			err := yaml.Unmarshal(yamlSettings, &settings)
+			if err != nil {
+				return errors.Wrap(err, "failed to unmarshall the settings")
+			}
+		}
+
+		changed = ChangedSettings(settings, original.Settings)
+		if len(changed) == 0 {
+			return nil
+		}
+
+		yaml, err := yaml.Marshal(original.Settings)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshall the settings")
+		}
+
+		serviceSecret.Data["settings"] = yaml
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(service.Namespace()).Update(
+			ctx, serviceSecret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changed) == 0 {
+		return changed, nil
+	}
+
+	catalogService, err := s.GetCatalogService(ctx, service.CatalogService)
+	if err != nil {
+		return nil, err
+	}
+
+	// The catalog service may have changed since the creation of the service. Deploy with the
+	// values it had back then.
+	originalCatalogService := *catalogService
+	originalCatalogService.Values = original.CatalogValues
+
+	err = s.DeployOrUpdate(ctx, service.Meta.Namespace, service.Meta.Name, wait,
+		original.Settings, &originalCatalogService, hook)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deploying service helm chart")
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	helmdriver "helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// ReleasePhasePending is the progress phase of a service whose helm release does not exist yet,
+// i.e. while the installation job is still running.
+const ReleasePhasePending = "pending"
+
+// Progress returns a snapshot of the provisioning progress of the service instance. The event
+// is not marked as completed, this is left to the caller.
+func (s *ServiceClient) Progress(ctx context.Context, service *models.Service) (models.ServiceProgressEvent, error) {
+	namespace := service.Meta.Namespace
+	releaseName := names.ServiceReleaseName(service.Meta.Name)
+
+	progress := models.ServiceProgressEvent{
+		Service:   service.Meta.Name,
+		Namespace: namespace,
+		Phase:     ReleasePhasePending,
+		Status:    service.Status,
+	}
+
+	release, err := helm.Release(ctx, s.kubeClient, namespace, releaseName)
+	if err != nil && !errors.Is(err, helmdriver.ErrReleaseNotFound) {
+		return progress, errors.Wrap(err, "finding helm release status")
+	}
+	if err == nil && release.Info != nil {
+		progress.Phase = release.Info.Status.String()
+		progress.Message = release.Info.Description
+	}
+
+	pods, err := GetServicePodsProgress(ctx, s.kubeClient.Kubectl.CoreV1(), namespace, service.Meta.Name)
+	if err != nil {
+		return progress, err
+	}
+	progress.Pods = pods
+
+	return progress, nil
+}
+
+// GetServicePodsProgress returns the readiness of the pods of the service's helm release,
+// ordered by name.
+func GetServicePodsProgress(ctx context.Context, podGetter v1.PodsGetter, namespace, name string) ([]models.ServicePodProgress, error) {
+	selector := metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/instance=" + names.ServiceReleaseName(name),
+	}
+
+	pods, err := podGetter.Pods(namespace).List(ctx, selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the pods")
+	}
+
+	result := []models.ServicePodProgress{}
+	for _, pod := range pods.Items {
+		result = append(result, models.ServicePodProgress{
+			Name:  pod.Name,
+			Phase: string(pod.Status.Phase),
+			Ready: podHasCondition(pod, string(corev1.PodReady)),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
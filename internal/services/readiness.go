@@ -0,0 +1,160 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
+	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/yaml"
+)
+
+// CatalogServiceReadinessAnnotation holds the readiness predicate of a catalog service, in
+// YAML or JSON format. See models.ServiceReadiness.
+const CatalogServiceReadinessAnnotation = "application.epinio.io/catalog-service-readiness"
+
+// ReadinessCommandTimeout limits the time the command of a readiness predicate may run in a pod.
+// A command running longer counts as failed.
+var ReadinessCommandTimeout = 10 * time.Second
+
+// CommandRunner runs the command in the container of the pod, and returns an error if the
+// command did not succeed.
+type CommandRunner func(ctx context.Context, namespace, pod, container string, command []string) error
+
+// ParseReadiness parses the readiness predicate of a catalog service. An empty annotation
+// declares no predicate, and returns nil.
+func ParseReadiness(annotation string) (*models.ServiceReadiness, error) {
+	if annotation == "" {
+		return nil, nil
+	}
+
+	readiness := &models.ServiceReadiness{}
+	if err := yaml.UnmarshalStrict([]byte(annotation), readiness); err != nil {
+		return nil, errors.Wrap(err, "parsing readiness predicate")
+	}
+
+	if readiness.PodSelector != "" {
+		if _, err := labels.Parse(readiness.PodSelector); err != nil {
+			return nil, errors.Wrap(err, "parsing readiness pod selector")
+		}
+	}
+	if readiness.Condition == "" {
+		readiness.Condition = string(corev1.PodReady)
+	}
+
+	return readiness, nil
+}
+
+// ReadinessSatisfied returns true if a pod of the release satisfies the readiness predicate.
+// The command of the predicate, if any, is run by the runner, limited to the
+// ReadinessCommandTimeout. A command which fails or times out leaves the pod not ready, and is
+// logged as a warning.
+func ReadinessSatisfied(ctx context.Context, podGetter v1.PodsGetter, runner CommandRunner,
+	namespace, releaseName string, readiness *models.ServiceReadiness) (bool, error) {
+
+	selector := fmt.Sprintf("app.kubernetes.io/instance=%s", releaseName)
+	if readiness.PodSelector != "" {
+		selector += "," + readiness.PodSelector
+	}
+
+	pods, err := podGetter.Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return false, errors.Wrap(err, "listing pods for readiness")
+	}
+
+	for _, pod := range pods.Items {
+		if !podHasCondition(pod, readiness.Condition) {
+			continue
+		}
+		if len(readiness.Command) == 0 {
+			return true, nil
+		}
+		if runReadinessCommand(ctx, runner, namespace, pod.Name, readiness) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// runReadinessCommand runs the command of the readiness predicate in the pod, and returns true
+// if it succeeded in time.
+func runReadinessCommand(ctx context.Context, runner CommandRunner, namespace, pod string,
+	readiness *models.ServiceReadiness) bool {
+
+	commandCtx, cancel := context.WithTimeout(ctx, ReadinessCommandTimeout)
+	defer cancel()
+
+	err := runner(commandCtx, namespace, pod, readiness.Container, readiness.Command)
+	if err != nil {
+		helpers.Logger.Warnw("readiness command failed, pod not ready",
+			"namespace", namespace, "pod", pod, "command", readiness.Command, "error", err)
+		return false
+	}
+
+	return true
+}
+
+func podHasCondition(pod corev1.Pod, condition string) bool {
+	for _, podCondition := range pod.Status.Conditions {
+		if string(podCondition.Type) == condition {
+			return podCondition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// ExecRunner returns a CommandRunner executing the commands in the pods of the cluster.
+func ExecRunner(cluster *kubernetes.Cluster) CommandRunner {
+	return func(ctx context.Context, namespace, pod, container string, command []string) error {
+		request := cluster.Kubectl.CoreV1().RESTClient().
+			Post().
+			Resource("pods").
+			Namespace(namespace).
+			Name(pod).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: container,
+				Command:   command,
+				Stdout:    true,
+				Stderr:    true,
+			}, scheme.ParameterCodec)
+
+		executor, err := remotecommand.NewSPDYExecutor(cluster.RestConfig, "POST", request.URL())
+		if err != nil {
+			return errors.Wrap(err, "creating readiness command executor")
+		}
+
+		var stdout, stderr bytes.Buffer
+		err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+			Stdout: &stdout,
+			Stderr: &stderr,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "readiness command failed: %s", stderr.String())
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("ParseReadiness", func() {
+	It("returns nil without a predicate", func() {
+		readiness, err := services.ParseReadiness("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readiness).To(BeNil())
+	})
+
+	It("parses the predicate, defaulting the condition", func() {
+		readiness, err := services.ParseReadiness(`
+podSelector: app.kubernetes.io/component=primary
+command: [pg_isready, -U, postgres]
+container: postgresql
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readiness).To(Equal(&models.ServiceReadiness{
+			PodSelector: "app.kubernetes.io/component=primary",
+			Condition:   "Ready",
+			Command:     []string{"pg_isready", "-U", "postgres"},
+			Container:   "postgresql",
+		}))
+	})
+
+	It("rejects unknown fields and bad selectors", func() {
+		_, err := services.ParseReadiness(`podSelecter: a=b`)
+		Expect(err).To(HaveOccurred())
+
+		_, err = services.ParseReadiness(`podSelector: "a in (b"`)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ReadinessSatisfied", func() {
+	const namespace = "workspace"
+	releaseName := names.ServiceReleaseName("mydb")
+
+	newPod := func(name, component string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":  releaseName,
+					"app.kubernetes.io/component": component,
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	var ran []string
+	succeed := func(_ context.Context, _, pod, _ string, _ []string) error {
+		ran = append(ran, pod)
+		return nil
+	}
+	fail := func(_ context.Context, _, pod, _ string, _ []string) error {
+		ran = append(ran, pod)
+		return errors.New("not accepting connections")
+	}
+
+	BeforeEach(func() {
+		ran = nil
+	})
+
+	It("requires a matching pod with the condition", func() {
+		client := fake.NewSimpleClientset(
+			newPod("mydb-primary-0", "primary", false),
+			newPod("mydb-read-0", "read", true),
+		)
+		readiness := &models.ServiceReadiness{
+			PodSelector: "app.kubernetes.io/component=primary",
+			Condition:   "Ready",
+		}
+
+		ready, err := services.ReadinessSatisfied(context.Background(), client.CoreV1(), succeed,
+			namespace, releaseName, readiness)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ready).To(BeFalse())
+
+		client = fake.NewSimpleClientset(newPod("mydb-primary-0", "primary", true))
+		ready, err = services.ReadinessSatisfied(context.Background(), client.CoreV1(), succeed,
+			namespace, releaseName, readiness)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ready).To(BeTrue())
+		Expect(ran).To(BeEmpty())
+	})
+
+	It("is not satisfied without pods of the release", func() {
+		client := fake.NewSimpleClientset()
+		ready, err := services.ReadinessSatisfied(context.Background(), client.CoreV1(), succeed,
+			namespace, releaseName, &models.ServiceReadiness{Condition: "Ready"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ready).To(BeFalse())
+	})
+
+	It("runs the command in the ready pods", func() {
+		client := fake.NewSimpleClientset(
+			newPod("mydb-primary-0", "primary", true),
+			newPod("mydb-primary-1", "primary", false),
+		)
+		readiness := &models.ServiceReadiness{
+			PodSelector: "app.kubernetes.io/component=primary",
+			Condition:   "Ready",
+			Command:     []string{"pg_isready"},
+		}
+
+		ready, err := services.ReadinessSatisfied(context.Background(), client.CoreV1(), fail,
+			namespace, releaseName, readiness)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ready).To(BeFalse())
+		Expect(ran).To(Equal([]string{"mydb-primary-0"}))
+
+		ready, err = services.ReadinessSatisfied(context.Background(), client.CoreV1(), succeed,
+			namespace, releaseName, readiness)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ready).To(BeTrue())
+	})
+
+	It("treats a command running past the timeout as not ready", func() {
+		saved := services.ReadinessCommandTimeout
+		services.ReadinessCommandTimeout = 10 * time.Millisecond
+		defer func() { services.ReadinessCommandTimeout = saved }()
+
+		hang := func(ctx context.Context, _, pod, _ string, _ []string) error {
+			ran = append(ran, pod)
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		client := fake.NewSimpleClientset(newPod("mydb-primary-0", "primary", true))
+		readiness := &models.ServiceReadiness{
+			Condition: "Ready",
+			Command:   []string{"pg_isready"},
+		}
+
+		ready, err := services.ReadinessSatisfied(context.Background(), client.CoreV1(), hang,
+			namespace, releaseName, readiness)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ready).To(BeFalse())
+		Expect(ran).To(Equal([]string{"mydb-primary-0"}))
+	})
+})
@@ -14,6 +14,9 @@ package services_test
 import (
 	"testing"
 
+	"github.com/epinio/epinio/helpers"
+	"go.uber.org/zap"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -22,3 +25,7 @@ func TestEpinio(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Epinio services suite")
 }
+
+var _ = BeforeSuite(func() {
+	helpers.Logger = zap.NewNop().Sugar()
+})
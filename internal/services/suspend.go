@@ -0,0 +1,177 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/epinio/epinio/internal/names"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ServiceSuspendedAnnotation marks the secret of a suspended service instance
+	ServiceSuspendedAnnotation = "application.epinio.io/service-suspended"
+	// SuspendedReplicasAnnotation records on a suspended workload the number of replicas to
+	// restore on resumption
+	SuspendedReplicasAnnotation = "application.epinio.io/suspended-replicas"
+)
+
+// Suspend scales the workload of the named service instance to zero, and marks the service as
+// suspended. Data in persistent volumes is retained. Note that a helm upgrade of the service,
+// i.e. an update, replace, or reset, returns the workload to the replicas of the chart. The API
+// refuses these for a suspended service.
+func (s *ServiceClient) Suspend(ctx context.Context, namespace, name string) error {
+	err := SuspendWorkloads(ctx, s.kubeClient.Kubectl.AppsV1(), namespace, names.ServiceReleaseName(name))
+	if err != nil {
+		return err
+	}
+
+	return s.setSuspended(ctx, namespace, name, true)
+}
+
+// Resume restores the workload of the named service instance to the replicas it had before it
+// was suspended, and removes the suspension mark.
+func (s *ServiceClient) Resume(ctx context.Context, namespace, name string) error {
+	err := ResumeWorkloads(ctx, s.kubeClient.Kubectl.AppsV1(), namespace, names.ServiceReleaseName(name))
+	if err != nil {
+		return err
+	}
+
+	return s.setSuspended(ctx, namespace, name, false)
+}
+
+// setSuspended sets or removes the suspension mark of the named service instance
+func (s *ServiceClient) setSuspended(ctx context.Context, namespace, name string, suspended bool) error {
+	secrets := s.kubeClient.Kubectl.CoreV1().Secrets(namespace)
+	serviceName := serviceResourceName(name)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := secrets.Get(ctx, serviceName, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrap(err, "fetching the service instance")
+		}
+
+		if suspended {
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[ServiceSuspendedAnnotation] = "true"
+		} else {
+			delete(secret.Annotations, ServiceSuspendedAnnotation)
+		}
+
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// SuspendWorkloads scales the deployments and statefulsets of the helm release to zero,
+// recording their current replicas for ResumeWorkloads. Workloads already suspended are left
+// untouched.
+func SuspendWorkloads(ctx context.Context, appsClient typedappsv1.AppsV1Interface, namespace, releaseName string) error {
+	return scaleWorkloads(ctx, appsClient, namespace, releaseName, func(meta *metav1.ObjectMeta, replicas **int32) {
+		if _, ok := meta.Annotations[SuspendedReplicasAnnotation]; ok {
+			return
+		}
+
+		current := int32(1)
+		if *replicas != nil {
+			current = **replicas
+		}
+
+		if meta.Annotations == nil {
+			meta.Annotations = map[string]string{}
+		}
+		meta.Annotations[SuspendedReplicasAnnotation] = strconv.Itoa(int(current))
+
+		zero := int32(0)
+		*replicas = &zero
+	})
+}
+
+// ResumeWorkloads restores the deployments and statefulsets of the helm release to the replicas
+// recorded by SuspendWorkloads.
+func ResumeWorkloads(ctx context.Context, appsClient typedappsv1.AppsV1Interface, namespace, releaseName string) error {
+	return scaleWorkloads(ctx, appsClient, namespace, releaseName, func(meta *metav1.ObjectMeta, replicas **int32) {
+		value, ok := meta.Annotations[SuspendedReplicasAnnotation]
+		if !ok {
+			return
+		}
+
+		restored, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || restored < 0 {
+			restored = 1
+		}
+
+		delete(meta.Annotations, SuspendedReplicasAnnotation)
+
+		n := int32(restored)
+		*replicas = &n
+	})
+}
+
+// scaleWorkloads applies the modifier to the deployments and statefulsets of the helm release,
+// and saves the results.
+func scaleWorkloads(ctx context.Context, appsClient typedappsv1.AppsV1Interface, namespace, releaseName string,
+	modify func(meta *metav1.ObjectMeta, replicas **int32)) error {
+
+	selector := metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/instance=" + releaseName,
+	}
+
+	deployments := appsClient.Deployments(namespace)
+	deploymentList, err := deployments.List(ctx, selector)
+	if err != nil {
+		return errors.Wrap(err, "fetching the deployments")
+	}
+	for _, item := range deploymentList.Items {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			deployment, err := deployments.Get(ctx, item.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			modify(&deployment.ObjectMeta, &deployment.Spec.Replicas)
+			_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "scaling deployment %s", item.Name)
+		}
+	}
+
+	statefulSets := appsClient.StatefulSets(namespace)
+	statefulSetList, err := statefulSets.List(ctx, selector)
+	if err != nil {
+		return errors.Wrap(err, "fetching the statefulsets")
+	}
+	for _, item := range statefulSetList.Items {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			statefulSet, err := statefulSets.Get(ctx, item.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			modify(&statefulSet.ObjectMeta, &statefulSet.Spec.Replicas)
+			_, err = statefulSets.Update(ctx, statefulSet, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "scaling statefulset %s", item.Name)
+		}
+	}
+
+	return nil
+}
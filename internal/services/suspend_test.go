@@ -0,0 +1,85 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/internal/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Suspending service workloads", func() {
+	const namespace = "workspace"
+	const releaseName = "xmydb"
+
+	var ctx context.Context
+	var client *fake.Clientset
+
+	replicas := func(n int32) *int32 { return &n }
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		labels := map[string]string{"app.kubernetes.io/instance": releaseName}
+		client = fake.NewSimpleClientset(
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: namespace, Labels: labels},
+				Spec:       appsv1.StatefulSetSpec{Replicas: replicas(3)},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: namespace, Labels: labels},
+				Spec:       appsv1.DeploymentSpec{Replicas: replicas(1)},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: namespace},
+				Spec:       appsv1.DeploymentSpec{Replicas: replicas(2)},
+			},
+		)
+	})
+
+	get := func() (*appsv1.StatefulSet, *appsv1.Deployment, *appsv1.Deployment) {
+		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, "db", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		metrics, err := client.AppsV1().Deployments(namespace).Get(ctx, "metrics", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		other, err := client.AppsV1().Deployments(namespace).Get(ctx, "other", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return sts, metrics, other
+	}
+
+	It("scales the release's workloads to zero and back", func() {
+		Expect(services.SuspendWorkloads(ctx, client.AppsV1(), namespace, releaseName)).To(Succeed())
+
+		sts, metrics, other := get()
+		Expect(*sts.Spec.Replicas).To(BeEquivalentTo(0))
+		Expect(sts.Annotations[services.SuspendedReplicasAnnotation]).To(Equal("3"))
+		Expect(*metrics.Spec.Replicas).To(BeEquivalentTo(0))
+		Expect(*other.Spec.Replicas).To(BeEquivalentTo(2))
+
+		// Suspending again keeps the recorded replicas
+		Expect(services.SuspendWorkloads(ctx, client.AppsV1(), namespace, releaseName)).To(Succeed())
+		sts, _, _ = get()
+		Expect(sts.Annotations[services.SuspendedReplicasAnnotation]).To(Equal("3"))
+
+		Expect(services.ResumeWorkloads(ctx, client.AppsV1(), namespace, releaseName)).To(Succeed())
+
+		sts, metrics, other = get()
+		Expect(*sts.Spec.Replicas).To(BeEquivalentTo(3))
+		Expect(sts.Annotations).ToNot(HaveKey(services.SuspendedReplicasAnnotation))
+		Expect(*metrics.Spec.Replicas).To(BeEquivalentTo(1))
+		Expect(*other.Spec.Replicas).To(BeEquivalentTo(2))
+	})
+})
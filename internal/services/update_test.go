@@ -0,0 +1,95 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("UpdateServiceSettings", func() {
+	const namespace = "workspace"
+	const secretName = "s-mydb"
+
+	var ctx context.Context
+	var client *fake.Clientset
+
+	changes := models.ServiceUpdateRequest{
+		Remove: []string{"auth.password"},
+		Set:    models.ChartValueSettings{"auth.username": "admin"},
+	}
+
+	storedSettings := func() models.ChartValueSettings {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		settings := models.ChartValueSettings{}
+		Expect(yaml.Unmarshal(secret.Data["settings"], &settings)).To(Succeed())
+		return settings
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		client = fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+			Data: map[string][]byte{
+				"settings": []byte("auth.username: epinio\nauth.password: s3cret\n"),
+			},
+		})
+	})
+
+	It("writes the updated settings, and returns them for the deployment", func() {
+		settings, err := services.UpdateServiceSettings(ctx, client.CoreV1().Secrets(namespace), secretName, changes)
+		Expect(err).ToNot(HaveOccurred())
+
+		expected := models.ChartValueSettings{"auth.username": "admin"}
+		Expect(settings).To(Equal(expected))
+		Expect(storedSettings()).To(Equal(expected))
+	})
+
+	It("starts from empty settings when the secret has none", func() {
+		client = fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+		})
+
+		settings, err := services.UpdateServiceSettings(ctx, client.CoreV1().Secrets(namespace), secretName, changes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(Equal(models.ChartValueSettings{"auth.username": "admin"}))
+	})
+
+	It("returns no settings when writing them fails", func() {
+		client.PrependReactor("update", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("update refused")
+		})
+
+		settings, err := services.UpdateServiceSettings(ctx, client.CoreV1().Secrets(namespace), secretName, changes)
+		Expect(err).To(MatchError("update refused"))
+		Expect(settings).To(BeNil())
+		Expect(storedSettings()).To(HaveKeyWithValue("auth.password", "s3cret"))
+	})
+
+	It("fails for an unknown service", func() {
+		_, err := services.UpdateServiceSettings(ctx, client.CoreV1().Secrets(namespace), "s-unknown", changes)
+		Expect(err).To(HaveOccurred())
+	})
+})
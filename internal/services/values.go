@@ -0,0 +1,117 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"strings"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// RedactedValue replaces sensitive values in redacted service values.
+const RedactedValue = "********"
+
+// sensitiveKeyFragments are the (lower-case) fragments of value keys considered to hold secrets.
+var sensitiveKeyFragments = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"credential",
+	"apikey",
+	"privatekey",
+}
+
+// RedactValues returns a copy of the values where all leaves under a sensitive key are replaced
+// by `RedactedValue`. The input is not modified.
+func RedactValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if isSensitiveKey(key) {
+			redacted[key] = redactAll(value)
+			continue
+		}
+		redacted[key] = redactValue(value)
+	}
+
+	return redacted
+}
+
+// redactValue descends into nested tables and lists, redacting sensitive keys found inside.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return RedactValues(v)
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, element := range v {
+			list[i] = redactValue(element)
+		}
+		return list
+	default:
+		return value
+	}
+}
+
+// redactAll replaces all leaves of the value, keeping the structure intact.
+func redactAll(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		table := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			table[key] = redactAll(element)
+		}
+		return table
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, element := range v {
+			list[i] = redactAll(element)
+		}
+		return list
+	case nil:
+		return nil
+	default:
+		return RedactedValue
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactSettings returns a copy of the settings where the values of sensitive keys are replaced
+// by `RedactedValue`. The input is not modified.
+func RedactSettings(settings models.ChartValueSettings) models.ChartValueSettings {
+	if settings == nil {
+		return nil
+	}
+
+	redacted := make(models.ChartValueSettings, len(settings))
+	for key, value := range settings {
+		if isSensitiveKey(key) {
+			value = RedactedValue
+		}
+		redacted[key] = value
+	}
+
+	return redacted
+}
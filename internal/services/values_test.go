@@ -0,0 +1,163 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"github.com/epinio/epinio/internal/services"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RedactValues", func() {
+	It("masks sensitive leaves and keeps everything else", func() {
+		values := map[string]interface{}{
+			"replicaCount": 2,
+			"auth": map[string]interface{}{
+				"username":         "epinio",
+				"password":         "s3cret",
+				"existingSecret":   "",
+				"postgresPassword": "root",
+			},
+			"tls": map[string]interface{}{
+				"secrets": []interface{}{
+					map[string]interface{}{"name": "cert", "key": "abc"},
+				},
+			},
+			"extraEnv": []interface{}{
+				map[string]interface{}{"name": "API_TOKEN", "apiToken": "xyz"},
+			},
+		}
+
+		redacted := services.RedactValues(values)
+
+		Expect(redacted["replicaCount"]).To(Equal(2))
+
+		auth := redacted["auth"].(map[string]interface{})
+		Expect(auth["username"]).To(Equal("epinio"))
+		Expect(auth["password"]).To(Equal(services.RedactedValue))
+		Expect(auth["existingSecret"]).To(Equal(services.RedactedValue))
+		Expect(auth["postgresPassword"]).To(Equal(services.RedactedValue))
+
+		tls := redacted["tls"].(map[string]interface{})
+		Expect(tls["secrets"]).To(Equal([]interface{}{
+			map[string]interface{}{"name": services.RedactedValue, "key": services.RedactedValue},
+		}))
+
+		env := redacted["extraEnv"].([]interface{})
+		Expect(env[0]).To(Equal(map[string]interface{}{"name": "API_TOKEN", "apiToken": services.RedactedValue}))
+	})
+
+	It("does not modify its input", func() {
+		values := map[string]interface{}{"password": "s3cret"}
+
+		_ = services.RedactValues(values)
+
+		Expect(values["password"]).To(Equal("s3cret"))
+	})
+})
+
+var _ = Describe("RedactSettings", func() {
+	It("masks the values of sensitive keys", func() {
+		settings := models.ChartValueSettings{
+			"auth.username": "epinio",
+			"auth.password": "s3cret",
+		}
+
+		redacted := services.RedactSettings(settings)
+
+		Expect(redacted).To(Equal(models.ChartValueSettings{
+			"auth.username": "epinio",
+			"auth.password": services.RedactedValue,
+		}))
+		Expect(settings["auth.password"]).To(Equal("s3cret"))
+	})
+})
+
+var _ = Describe("OriginalValues", func() {
+	It("merges the catalog values with the settings, catalog values first", func() {
+		original := services.OriginalValues{
+			CatalogValues: "auth:\n  username: epinio\nreplicaCount: 1\n",
+			Settings: models.ChartValueSettings{
+				"auth.username": "ignored",
+				"auth.database": "db",
+			},
+		}
+
+		values, err := original.Values()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal(map[string]interface{}{
+			"auth": map[string]interface{}{
+				"username": "epinio",
+				"database": "db",
+			},
+			"replicaCount": float64(1),
+		}))
+	})
+
+	It("fails for bad settings", func() {
+		original := services.OriginalValues{
+			Settings: models.ChartValueSettings{"a[": "b"},
+		}
+
+		_, err := original.Values()
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ChangedSettings", func() {
+	It("returns the sorted keys which were changed, added, or removed", func() {
+		current := models.ChartValueSettings{
+			"auth.username": "changed",
+			"auth.database": "db",
+			"replicaCount":  "2",
+		}
+		original := models.ChartValueSettings{
+			"auth.username": "epinio",
+			"auth.database": "db",
+			"auth.password": "s3cret",
+		}
+
+		Expect(services.ChangedSettings(current, original)).To(Equal([]string{
+			"auth.password",
+			"auth.username",
+			"replicaCount",
+		}))
+	})
+
+	It("returns nothing for equal settings", func() {
+		settings := models.ChartValueSettings{"auth.username": "epinio"}
+
+		Expect(services.ChangedSettings(settings, settings)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("UpdatedSettings", func() {
+	It("removes and sets the requested keys, leaving the input as is", func() {
+		settings := models.ChartValueSettings{
+			"auth.username": "epinio",
+			"auth.password": "s3cret",
+		}
+
+		updated := services.UpdatedSettings(settings, models.ServiceUpdateRequest{
+			Remove: []string{"auth.password"},
+			Set:    models.ChartValueSettings{"auth.username": "admin", "replicaCount": "2"},
+		})
+
+		Expect(updated).To(Equal(models.ChartValueSettings{
+			"auth.username": "admin",
+			"replicaCount":  "2",
+		}))
+		Expect(settings).To(HaveLen(2))
+		Expect(settings["auth.username"]).To(Equal("epinio"))
+	})
+})
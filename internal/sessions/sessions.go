@@ -0,0 +1,158 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessions limits the number of concurrent interactive sessions, i.e. exec and
+// port-forward, globally, and per user.
+package sessions
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLimitReached is returned by Acquire when no session slot became free in time.
+var ErrLimitReached = errors.New("too many concurrent interactive sessions")
+
+// Limits are the maximum numbers of concurrent sessions, in total, and per user. Zero means
+// unlimited. Wait is how long a session waits for a free slot before it is rejected.
+type Limits struct {
+	Global  int
+	PerUser int
+	Wait    time.Duration
+}
+
+// Stats is a snapshot of the state of a limiter. Users maps the users with active sessions to
+// the number of their sessions. Rejected counts the sessions refused since the start.
+type Stats struct {
+	Active   int
+	Users    map[string]int
+	Rejected int64
+}
+
+// Limiter tracks the active sessions, and hands out the slots for new ones.
+type Limiter struct {
+	mu       sync.Mutex
+	active   int
+	users    map[string]int
+	rejected int64
+	released chan struct{} // closed, and replaced, whenever a slot is freed
+}
+
+// Default is the limiter of the server.
+var Default = New()
+
+// New returns a limiter without active sessions.
+func New() *Limiter {
+	return &Limiter{
+		users:    map[string]int{},
+		released: make(chan struct{}),
+	}
+}
+
+// Acquire takes a session slot for the user. When the limits are reached it waits for a slot
+// to become free, up to the configured wait time, or until the context is done. On success the
+// returned function has to be called to free the slot again, when the session ends.
+func (l *Limiter) Acquire(ctx context.Context, user string, limits Limits) (func(), error) {
+	var timeout <-chan time.Time
+	if limits.Wait > 0 {
+		timer := time.NewTimer(limits.Wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		l.mu.Lock()
+		if l.fits(user, limits) {
+			l.active++
+			l.users[user]++
+			l.mu.Unlock()
+			return l.releaser(user), nil
+		}
+		released := l.released
+		if timeout == nil {
+			l.rejected++
+			l.mu.Unlock()
+			return nil, ErrLimitReached
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+			// Try again
+		case <-timeout:
+			l.reject()
+			return nil, ErrLimitReached
+		case <-ctx.Done():
+			l.reject()
+			return nil, ErrLimitReached
+		}
+	}
+}
+
+// Stats returns a snapshot of the active sessions.
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	users := make(map[string]int, len(l.users))
+	for user, count := range l.users {
+		users[user] = count
+	}
+
+	return Stats{
+		Active:   l.active,
+		Users:    users,
+		Rejected: l.rejected,
+	}
+}
+
+// fits returns true if another session of the user is within the limits. The caller has to
+// hold the lock.
+func (l *Limiter) fits(user string, limits Limits) bool {
+	if limits.Global > 0 && l.active >= limits.Global {
+		return false
+	}
+	if limits.PerUser > 0 && l.users[user] >= limits.PerUser {
+		return false
+	}
+	return true
+}
+
+// releaser returns the function freeing the slot of a session of the user. Calling it more
+// than once has no effect.
+func (l *Limiter) releaser(user string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			l.active--
+			l.users[user]--
+			if l.users[user] <= 0 {
+				delete(l.users, user)
+			}
+
+			// Wake up the waiting sessions
+			close(l.released)
+			l.released = make(chan struct{})
+		})
+	}
+}
+
+// reject counts a refused session.
+func (l *Limiter) reject() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rejected++
+}
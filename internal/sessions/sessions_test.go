@@ -0,0 +1,98 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessions_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/internal/sessions"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Limiter", func() {
+	var limiter *sessions.Limiter
+
+	BeforeEach(func() {
+		limiter = sessions.New()
+	})
+
+	It("is unlimited without limits", func() {
+		for i := 0; i < 10; i++ {
+			_, err := limiter.Acquire(context.Background(), "alice", sessions.Limits{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(limiter.Stats().Active).To(Equal(10))
+		Expect(limiter.Stats().Users).To(Equal(map[string]int{"alice": 10}))
+	})
+
+	It("rejects sessions beyond the global limit", func() {
+		limits := sessions.Limits{Global: 2}
+
+		_, err := limiter.Acquire(context.Background(), "alice", limits)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = limiter.Acquire(context.Background(), "bob", limits)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = limiter.Acquire(context.Background(), "carol", limits)
+		Expect(err).To(MatchError(sessions.ErrLimitReached))
+		Expect(limiter.Stats().Rejected).To(Equal(int64(1)))
+	})
+
+	It("rejects sessions beyond the per user limit", func() {
+		limits := sessions.Limits{PerUser: 1}
+
+		release, err := limiter.Acquire(context.Background(), "alice", limits)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = limiter.Acquire(context.Background(), "alice", limits)
+		Expect(err).To(MatchError(sessions.ErrLimitReached))
+
+		_, err = limiter.Acquire(context.Background(), "bob", limits)
+		Expect(err).ToNot(HaveOccurred())
+
+		release()
+		release() // no effect
+		Expect(limiter.Stats().Users).To(Equal(map[string]int{"bob": 1}))
+
+		_, err = limiter.Acquire(context.Background(), "alice", limits)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("queues sessions until a slot is freed", func() {
+		limits := sessions.Limits{Global: 1, Wait: 5 * time.Second}
+
+		release, err := limiter.Acquire(context.Background(), "alice", limits)
+		Expect(err).ToNot(HaveOccurred())
+
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			release()
+		}()
+
+		_, err = limiter.Acquire(context.Background(), "bob", limits)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(limiter.Stats().Users).To(Equal(map[string]int{"bob": 1}))
+	})
+
+	It("rejects queued sessions after the wait time", func() {
+		limits := sessions.Limits{Global: 1, Wait: 20 * time.Millisecond}
+
+		_, err := limiter.Acquire(context.Background(), "alice", limits)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = limiter.Acquire(context.Background(), "bob", limits)
+		Expect(err).To(MatchError(sessions.ErrLimitReached))
+		Expect(limiter.Stats().Rejected).To(Equal(int64(1)))
+	})
+})
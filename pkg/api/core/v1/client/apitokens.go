@@ -0,0 +1,51 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// APITokens returns the list of API tokens of the user
+func (c *Client) APITokens() (models.APITokenList, error) {
+	response := models.APITokenList{}
+	endpoint := api.Routes.Path("APITokens")
+
+	return Get(c, endpoint, response)
+}
+
+// APITokenCreate creates an API token. The response contains the token value.
+func (c *Client) APITokenCreate(request models.APITokenCreateRequest) (models.APITokenCreateResponse, error) {
+	response := models.APITokenCreateResponse{}
+	endpoint := api.Routes.Path("APITokenCreate")
+
+	return Post(c, endpoint, request, response)
+}
+
+// APITokenDelete revokes an API token. The owner is only needed by admins, to revoke the token
+// of another user.
+func (c *Client) APITokenDelete(name, owner string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("APITokenDelete", name)
+
+	if owner != "" {
+		queryParams := url.Values{}
+		queryParams.Add("owner", owner)
+		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams.Encode())
+	}
+
+	return Delete(c, endpoint, nil, response)
+}
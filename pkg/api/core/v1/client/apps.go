@@ -106,6 +106,44 @@ func (c *Client) AllApps() (models.AppList, error) {
 	return Get(c, endpoint, response)
 }
 
+// AllAppsPage returns a page of the apps in all namespaces matching the status filter, sorted by
+// namespace and name, and their total. An empty status matches all apps. A limit of zero places
+// no limit.
+func (c *Client) AllAppsPage(status string, limit, offset int) (models.AppListPageResponse, error) {
+	response := models.AppListPageResponse{}
+
+	queryParams := url.Values{}
+	queryParams.Add("offset", strconv.Itoa(offset))
+	if limit > 0 {
+		queryParams.Add("limit", strconv.Itoa(limit))
+	}
+	if status != "" {
+		queryParams.Add("status", status)
+	}
+
+	endpoint := fmt.Sprintf("%s?%s", api.Routes.Path("AllApps"), queryParams.Encode())
+
+	return Get(c, endpoint, response)
+}
+
+// AppsWithWarnings returns a list of all apps in an namespace, together with the warnings about
+// apps which could not be fully assembled.
+func (c *Client) AppsWithWarnings(namespace string) (models.AppListResponse, error) {
+	response := models.AppListResponse{}
+	endpoint := fmt.Sprintf("%s?warnings=true", api.Routes.Path("Apps", namespace))
+
+	return Get(c, endpoint, response)
+}
+
+// AllAppsWithWarnings returns a list of all apps, together with the warnings about apps which
+// could not be fully assembled.
+func (c *Client) AllAppsWithWarnings() (models.AppListResponse, error) {
+	response := models.AppListResponse{}
+	endpoint := fmt.Sprintf("%s?warnings=true", api.Routes.Path("AllApps"))
+
+	return Get(c, endpoint, response)
+}
+
 // AppShow shows an app
 func (c *Client) AppShow(namespace string, appName string) (models.App, error) {
 	response := models.App{}
@@ -114,6 +152,50 @@ func (c *Client) AppShow(namespace string, appName string) (models.App, error) {
 	return Get(c, endpoint, response)
 }
 
+// AppShowWithoutMetrics shows an app, without the per-replica metrics
+func (c *Client) AppShowWithoutMetrics(namespace string, appName string) (models.App, error) {
+	response := models.App{}
+	endpoint := fmt.Sprintf("%s?metrics=false", api.Routes.Path("AppShow", namespace, appName))
+
+	return Get(c, endpoint, response)
+}
+
+// AppStatuses returns the compact status of the named apps, or of all apps in the namespace when
+// no names are given. Per-replica metrics are only gathered when requested.
+func (c *Client) AppStatuses(namespace string, names []string, withMetrics bool) (models.AppStatusList, error) {
+	response := models.AppStatusList{}
+
+	queryParams := url.Values{}
+	for _, appName := range names {
+		queryParams.Add("applications[]", appName)
+	}
+	if withMetrics {
+		queryParams.Add("metrics", "true")
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s?%s",
+		api.Routes.Path("AppStatuses", namespace),
+		queryParams.Encode(),
+	)
+
+	return Get(c, endpoint, response)
+}
+
+// AppMetrics returns the gauges of the application replicas in the namespace, in the
+// Prometheus text exposition format.
+func (c *Client) AppMetrics(namespace string) ([]byte, error) {
+	endpoint := api.Routes.Path("AppMetrics", namespace)
+
+	httpResponse, err := c.Do(endpoint, http.MethodGet, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "executing AppMetrics request")
+	}
+	defer httpResponse.Body.Close()
+
+	return io.ReadAll(httpResponse.Body)
+}
+
 // AppGetPart retrieves part of an app (values.yaml, chart, image)
 func (c *Client) AppGetPart(namespace, appName, part string) (models.AppPartResponse, error) {
 	response := models.AppPartResponse{}
@@ -130,6 +212,28 @@ func (c *Client) AppGetPart(namespace, appName, part string) (models.AppPartResp
 	}, nil
 }
 
+// AppGetImagePart retrieves the image of an app, pulling it from its registry with the
+// credentials of the named pull secret, in the namespace of the app.
+func (c *Client) AppGetImagePart(namespace, appName, pullSecret string) (models.AppPartResponse, error) {
+	response := models.AppPartResponse{}
+
+	queryParams := url.Values{}
+	queryParams.Add("pullsecret", pullSecret)
+
+	endpoint := fmt.Sprintf("%s?%s",
+		api.Routes.Path("AppPart", namespace, appName, "image"), queryParams.Encode())
+
+	httpResponse, err := c.Do(endpoint, http.MethodGet, nil)
+	if err != nil {
+		return response, errors.Wrap(err, "executing AppPart request")
+	}
+
+	return models.AppPartResponse{
+		Data:          httpResponse.Body,
+		ContentLength: httpResponse.ContentLength,
+	}, nil
+}
+
 // AppExport triggers an export of the app to a registry
 func (c *Client) AppExport(namespace, appName string, request models.AppExportRequest) (models.Response, error) {
 	response := models.Response{}
@@ -231,8 +335,12 @@ type LogOptions struct {
 	Tail              *int64
 	Since             *time.Duration
 	SinceTime         *time.Time
+	SinceRestart      bool     // Logs since the most recent restart of the app. Not for staging logs.
+	Timestamps        bool     // Prefix each line with its RFC3339 timestamp.
 	IncludeContainers []string // List of container names/patterns to include (regex patterns supported)
 	ExcludeContainers []string // List of container names/patterns to exclude (regex patterns supported)
+	Instance          string   // Name of the app instance to restrict the logs to. Not for staging logs.
+	Container         string   // Name of the container to restrict the logs to.
 }
 
 // AppLogs streams the logs of all the application instances, in the targeted namespace
@@ -255,17 +363,112 @@ func (c *Client) AppLogs(namespace, appName, stageID string, follow bool, option
 		queryParams.Add("stage_id", stageID)
 	}
 	queryParams.Add("authtoken", tokenResponse.Token)
+	addLogOptions(queryParams, options)
 
-	if options != nil {
-		if options.Tail != nil {
-			queryParams.Add("tail", strconv.FormatInt(*options.Tail, 10))
+	var endpoint string
+	if stageID == "" {
+		endpoint = api.WsRoutes.Path("AppLogs", namespace, appName)
+	} else {
+		endpoint = api.WsRoutes.Path("StagingLogs", namespace, stageID)
+	}
+
+	websocketURL := fmt.Sprintf("%s%s/%s?%s", c.Settings.WSS, api.WsRoot, endpoint, queryParams.Encode())
+	webSocketConn, resp, err := websocket.DefaultDialer.Dial(websocketURL, c.Headers())
+	if err != nil {
+		// Report detailed error found in the server response
+		if resp != nil && resp.StatusCode != http.StatusOK {
+			return handleError(c.log, resp)
 		}
-		if options.Since != nil {
-			queryParams.Add("since", options.Since.String())
+
+		// Report the dialer error if response claimed to be OK
+		return errors.Wrap(err, fmt.Sprintf("Failed to connect to websockets endpoint. Response was = %+v\nThe error is", resp))
+	}
+
+	var logLine tailer.ContainerLogLine
+	for {
+		_, message, err := webSocketConn.ReadMessage()
+		if err != nil {
+			return nil
 		}
-		if options.SinceTime != nil {
-			queryParams.Add("since_time", options.SinceTime.Format(time.RFC3339))
+
+		if err := json.Unmarshal(message, &logLine); err != nil {
+			return errors.Wrap(err, "error parsing staging message")
 		}
+
+		printCallback(logLine)
+	}
+}
+
+// addLogOptions adds the log selection options to the query parameters of a logs request.
+func addLogOptions(queryParams url.Values, options *LogOptions) {
+	if options == nil {
+		return
+	}
+	if options.Tail != nil {
+		queryParams.Add("tail", strconv.FormatInt(*options.Tail, 10))
+	}
+	if options.Since != nil {
+		queryParams.Add("since", options.Since.String())
+	}
+	if options.SinceTime != nil {
+		queryParams.Add("since_time", options.SinceTime.Format(time.RFC3339))
+	}
+	if options.SinceRestart {
+		queryParams.Add("sinceRestart", "true")
+	}
+	if options.Timestamps {
+		queryParams.Add("timestamps", "true")
+	}
+	if len(options.IncludeContainers) > 0 {
+		queryParams.Add("include_containers", strings.Join(options.IncludeContainers, ","))
+	}
+	if len(options.ExcludeContainers) > 0 {
+		queryParams.Add("exclude_containers", strings.Join(options.ExcludeContainers, ","))
+	}
+	if options.Instance != "" {
+		queryParams.Add("instance", options.Instance)
+	}
+	if options.Container != "" {
+		queryParams.Add("container", options.Container)
+	}
+}
+
+// AppLogSearch searches the logs of the application for lines matching the regular expression,
+// returning them with contextLines lines before and after. A limit of 0 leaves the maximum
+// number of matches to the server. The options select the logs to search.
+func (c *Client) AppLogSearch(namespace, appName, pattern string, contextLines, limit int, options *LogOptions) (models.AppLogSearchResponse, error) {
+	response := models.AppLogSearchResponse{}
+
+	queryParams := url.Values{}
+	queryParams.Add("pattern", pattern)
+	queryParams.Add("context", strconv.Itoa(contextLines))
+	if limit > 0 {
+		queryParams.Add("limit", strconv.Itoa(limit))
+	}
+	addLogOptions(queryParams, options)
+
+	endpoint := fmt.Sprintf(
+		"%s?%s",
+		api.Routes.Path("AppLogSearch", namespace, appName),
+		queryParams.Encode(),
+	)
+
+	return Get(c, endpoint, response)
+}
+
+// AppPushLogs streams the logs of the staging run identified by stageID, followed by the logs
+// of the application once staging is done. The switch is signaled by a log line carrying
+// models.PushLogsHandoffMarker, a failed staging by models.PushLogsFailedMarker.
+// The method returns when the websocket connection closes.
+func (c *Client) AppPushLogs(namespace, appName, stageID string, options *LogOptions, printCallback func(tailer.ContainerLogLine)) error {
+	tokenResponse, err := c.AuthToken()
+	if err != nil {
+		return err
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("authtoken", tokenResponse.Token)
+	if options != nil {
 		if len(options.IncludeContainers) > 0 {
 			queryParams.Add("include_containers", strings.Join(options.IncludeContainers, ","))
 		}
@@ -274,34 +477,73 @@ func (c *Client) AppLogs(namespace, appName, stageID string, follow bool, option
 		}
 	}
 
-	var endpoint string
-	if stageID == "" {
-		endpoint = api.WsRoutes.Path("AppLogs", namespace, appName)
-	} else {
-		endpoint = api.WsRoutes.Path("StagingLogs", namespace, stageID)
+	endpoint := api.WsRoutes.Path("AppPushLogs", namespace, appName, stageID)
+	websocketURL := fmt.Sprintf("%s%s/%s?%s", c.Settings.WSS, api.WsRoot, endpoint, queryParams.Encode())
+	webSocketConn, resp, err := websocket.DefaultDialer.Dial(websocketURL, c.Headers())
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusOK {
+			return handleError(c.log, resp)
+		}
+		return errors.Wrap(err, fmt.Sprintf("Failed to connect to websockets endpoint. Response was = %+v\nThe error is", resp))
+	}
+	defer func() { _ = webSocketConn.Close() }()
+
+	for {
+		_, message, err := webSocketConn.ReadMessage()
+		if err != nil {
+			return nil
+		}
+
+		var logLine tailer.ContainerLogLine
+		if err := json.Unmarshal(message, &logLine); err != nil {
+			return errors.Wrap(err, "error parsing log message")
+		}
+
+		printCallback(logLine)
+	}
+}
+
+// AppStagingLogs streams the logs of the active staging run of the application, until the
+// staging is done. A failed staging is signaled by a log line carrying
+// models.PushLogsFailedMarker. An application which is not staging is reported as an APIError
+// with status 404.
+func (c *Client) AppStagingLogs(namespace, appName string, options *LogOptions, printCallback func(tailer.ContainerLogLine)) error {
+	tokenResponse, err := c.AuthToken()
+	if err != nil {
+		return err
 	}
 
+	queryParams := url.Values{}
+	queryParams.Add("authtoken", tokenResponse.Token)
+	if options != nil {
+		if len(options.IncludeContainers) > 0 {
+			queryParams.Add("include_containers", strings.Join(options.IncludeContainers, ","))
+		}
+		if len(options.ExcludeContainers) > 0 {
+			queryParams.Add("exclude_containers", strings.Join(options.ExcludeContainers, ","))
+		}
+	}
+
+	endpoint := api.WsRoutes.Path("AppStagingLogs", namespace, appName)
 	websocketURL := fmt.Sprintf("%s%s/%s?%s", c.Settings.WSS, api.WsRoot, endpoint, queryParams.Encode())
 	webSocketConn, resp, err := websocket.DefaultDialer.Dial(websocketURL, c.Headers())
 	if err != nil {
-		// Report detailed error found in the server response
 		if resp != nil && resp.StatusCode != http.StatusOK {
 			return handleError(c.log, resp)
 		}
-
-		// Report the dialer error if response claimed to be OK
 		return errors.Wrap(err, fmt.Sprintf("Failed to connect to websockets endpoint. Response was = %+v\nThe error is", resp))
 	}
+	defer func() { _ = webSocketConn.Close() }()
 
-	var logLine tailer.ContainerLogLine
 	for {
 		_, message, err := webSocketConn.ReadMessage()
 		if err != nil {
 			return nil
 		}
 
+		var logLine tailer.ContainerLogLine
 		if err := json.Unmarshal(message, &logLine); err != nil {
-			return errors.Wrap(err, "error parsing staging message")
+			return errors.Wrap(err, "error parsing log message")
 		}
 
 		printCallback(logLine)
@@ -309,8 +551,8 @@ func (c *Client) AppLogs(namespace, appName, stageID string, follow bool, option
 }
 
 // StagingComplete checks if the staging process is complete
-func (c *Client) StagingComplete(namespace string, id string) (models.Response, error) {
-	response := models.Response{}
+func (c *Client) StagingComplete(namespace string, id string) (models.StagingCompleteResponse, error) {
+	response := models.StagingCompleteResponse{}
 	endpoint := api.Routes.Path("StagingComplete", namespace, id)
 
 	return Get(c, endpoint, response)
@@ -365,6 +607,94 @@ func (c *Client) StagingCompleteStream(ctx context.Context, namespace, id string
 	}
 }
 
+// StagingCacheStream opens a websocket that emits the build cache stats of the given staging
+// run as they change, and closes once the job finishes.
+func (c *Client) StagingCacheStream(ctx context.Context, namespace, id string, callback func(models.StageCacheStats) error) error {
+	tokenResponse, err := c.AuthToken()
+	if err != nil {
+		return err
+	}
+
+	endpoint := api.WsRoutes.Path("StagingCacheWs", namespace, id)
+	queryParams := url.Values{}
+	queryParams.Add("authtoken", tokenResponse.Token)
+	websocketURL := fmt.Sprintf("%s%s/%s?%s", c.Settings.WSS, api.WsRoot, endpoint, queryParams.Encode())
+
+	webSocketConn, resp, err := websocket.DefaultDialer.DialContext(ctx, websocketURL, c.Headers())
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusOK {
+			return handleError(c.log, resp)
+		}
+		return errors.Wrap(err, "failed to connect to staging cache websocket")
+	}
+	defer func() { _ = webSocketConn.Close() }()
+
+	for {
+		_, message, readErr := webSocketConn.ReadMessage()
+		if readErr != nil {
+			// Normal close means the server is done sending updates.
+			if websocket.IsCloseError(readErr, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return errors.Wrap(readErr, "reading staging cache websocket message")
+		}
+
+		var stats models.StageCacheStats
+		if unmarshalErr := json.Unmarshal(message, &stats); unmarshalErr != nil {
+			return errors.Wrap(unmarshalErr, "decoding staging cache stats")
+		}
+
+		if callback != nil {
+			if cbErr := callback(stats); cbErr != nil {
+				return cbErr
+			}
+		}
+
+		if stats.Completed {
+			return nil
+		}
+	}
+}
+
+// AppExpiry sets the time to live of the app. An empty ttl removes the expiry.
+func (c *Client) AppExpiry(namespace, name, ttl string) (models.ExpiryResponse, error) {
+	response := models.ExpiryResponse{}
+	endpoint := api.Routes.Path("AppExpiry", namespace, name)
+
+	return Post(c, endpoint, models.ExpiryRequest{TTL: ttl}, response)
+}
+
+// AppHistory returns the deploy history of the app, oldest revision first
+func (c *Client) AppHistory(namespace, appName string) (models.AppHistoryResponse, error) {
+	response := models.AppHistoryResponse{}
+	endpoint := api.Routes.Path("AppHistory", namespace, appName)
+
+	return Get(c, endpoint, response)
+}
+
+// AppEvents returns the kubernetes events of the workload of the app, newest first
+func (c *Client) AppEvents(namespace, appName string) (models.EventList, error) {
+	response := models.EventList{}
+	endpoint := api.Routes.Path("AppEvents", namespace, appName)
+
+	return Get(c, endpoint, response)
+}
+
+// AppHistoryManifest retrieves the manifest of the app as deployed in the given revision
+func (c *Client) AppHistoryManifest(namespace, appName string, revision int) (models.AppPartResponse, error) {
+	endpoint := api.Routes.Path("AppHistoryManifest", namespace, appName, strconv.Itoa(revision))
+
+	httpResponse, err := c.Do(endpoint, http.MethodGet, nil)
+	if err != nil {
+		return models.AppPartResponse{}, errors.Wrap(err, "executing AppHistoryManifest request")
+	}
+
+	return models.AppPartResponse{
+		Data:          httpResponse.Body,
+		ContentLength: httpResponse.ContentLength,
+	}, nil
+}
+
 // AppRunning checks if the app is running
 func (c *Client) AppRunning(app models.AppRef) (models.Response, error) {
 	response := models.Response{}
@@ -373,9 +703,23 @@ func (c *Client) AppRunning(app models.AppRef) (models.Response, error) {
 	return Get(c, endpoint, response)
 }
 
-func (c *Client) AppExec(ctx context.Context, namespace string, appName, instance string, tty kubectlterm.TTY) error {
+// AppExec attaches the terminal to a shell in an instance of the application. With a command
+// that command is run instead, without a terminal, and its stderr is written to errOut. A
+// failing command returns an error carrying its exit code.
+func (c *Client) AppExec(ctx context.Context, namespace string, appName, instance string, command []string, tty kubectlterm.TTY, errOut io.Writer) error {
+	return c.appTerminal(ctx, "AppExec", namespace, appName, instance, command, tty, errOut)
+}
+
+// AppDebug attaches the terminal to a new debug container in an instance of the application
+func (c *Client) AppDebug(ctx context.Context, namespace string, appName, instance string, tty kubectlterm.TTY) error {
+	return c.appTerminal(ctx, "AppDebug", namespace, appName, instance, nil, tty, tty.Out)
+}
+
+// appTerminal streams a session of the named websocket route to the terminal
+func (c *Client) appTerminal(ctx context.Context, route, namespace, appName, instance string,
+	command []string, tty kubectlterm.TTY, errOut io.Writer) error {
 	endpoint := fmt.Sprintf("%s%s/%s",
-		c.Settings.API, api.WsRoot, api.WsRoutes.Path("AppExec", namespace, appName))
+		c.Settings.API, api.WsRoot, api.WsRoutes.Path(route, namespace, appName))
 
 	upgradeRoundTripper, err := NewUpgrader(spdy.RoundTripperConfig{
 		TLS:        http.DefaultTransport.(*http.Transport).TLSClientConfig, // See `ExtendLocalTrust`
@@ -394,11 +738,14 @@ func (c *Client) AppExec(ctx context.Context, namespace string, appName, instanc
 		return err
 	}
 
+	values := execURL.Query()
 	if instance != "" {
-		values := execURL.Query()
 		values.Add("instance", instance)
-		execURL.RawQuery = values.Encode()
 	}
+	for _, arg := range command {
+		values.Add("command", arg)
+	}
+	execURL.RawQuery = values.Encode()
 
 	// upgradeRoundTripper implements both interfaces, Roundtripper and Upgrader
 	exec, err := remotecommand.NewSPDYExecutorForTransports(upgradeRoundTripper, upgradeRoundTripper, "GET", execURL)
@@ -410,7 +757,7 @@ func (c *Client) AppExec(ctx context.Context, namespace string, appName, instanc
 		options := remotecommand.StreamOptions{
 			Stdin:             tty.In,
 			Stdout:            tty.Out,
-			Stderr:            tty.Out, // Not used when tty. Check `exec.Stream` docs.
+			Stderr:            errOut, // Not used when tty. Check `exec.Stream` docs.
 			Tty:               tty.Raw,
 			TerminalSizeQueue: tty.MonitorSize(tty.GetSize()),
 		}
@@ -424,6 +771,7 @@ func (c *Client) AppExec(ctx context.Context, namespace string, appName, instanc
 type PortForwardOpts struct {
 	Address      []string
 	Ports        []string
+	Container    string
 	StopChannel  chan struct{}
 	ReadyChannel chan struct{}
 	Out          io.Writer
@@ -466,11 +814,18 @@ func (c *Client) AppPortForward(namespace string, appName, instance string, opts
 		return err
 	}
 
+	values := portForwardURL.Query()
 	if instance != "" {
-		values := portForwardURL.Query()
 		values.Add("instance", instance)
-		portForwardURL.RawQuery = values.Encode()
 	}
+	if opts.Container != "" {
+		values.Add("container", opts.Container)
+	}
+	// The remote ports let the server check them against the containers of the instance
+	for _, port := range opts.Ports {
+		values.Add("port", remotePort(port))
+	}
+	portForwardURL.RawQuery = values.Encode()
 
 	upgradeRoundTripper, err := NewUpgrader(spdy.RoundTripperConfig{
 		TLS:        http.DefaultTransport.(*http.Transport).TLSClientConfig, // See `ExtendLocalTrust`
@@ -491,6 +846,14 @@ func (c *Client) AppPortForward(namespace string, appName, instance string, opts
 	return fw.ForwardPorts()
 }
 
+// remotePort returns the remote part of a [LOCAL_PORT:]REMOTE_PORT specification
+func remotePort(spec string) string {
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		return spec[i+1:]
+	}
+	return spec
+}
+
 func (c *Client) addAuthTokenToURL(url *url.URL) error {
 	tokenResponse, err := c.AuthToken()
 	if err != nil {
@@ -504,14 +867,30 @@ func (c *Client) addAuthTokenToURL(url *url.URL) error {
 	return nil
 }
 
-// AppRestart restarts an app
-func (c *Client) AppRestart(namespace string, appName string) (models.Response, error) {
-	response := models.Response{}
+// AppRestart restarts an app, returning the new desired generation of its deployment
+func (c *Client) AppRestart(namespace string, appName string) (models.AppRestartResponse, error) {
+	response := models.AppRestartResponse{}
 	endpoint := api.Routes.Path("AppRestart", namespace, appName)
 
 	return Post(c, endpoint, nil, response)
 }
 
+// AppStop scales an app to zero instances, remembering their number for AppStart
+func (c *Client) AppStop(namespace string, appName string) (models.AppStateResponse, error) {
+	response := models.AppStateResponse{}
+	endpoint := api.Routes.Path("AppStop", namespace, appName)
+
+	return Post(c, endpoint, nil, response)
+}
+
+// AppStart restores the instances of an app stopped by AppStop
+func (c *Client) AppStart(namespace string, appName string) (models.AppStateResponse, error) {
+	response := models.AppStateResponse{}
+	endpoint := api.Routes.Path("AppStart", namespace, appName)
+
+	return Post(c, endpoint, nil, response)
+}
+
 func (c *Client) AuthToken() (models.AuthTokenResponse, error) {
 	response := models.AuthTokenResponse{}
 	endpoint := api.Routes.Path("AuthToken")
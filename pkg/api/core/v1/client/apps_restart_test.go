@@ -52,7 +52,21 @@ func DescribeAppRestart() {
 		It("returns no error", func() {
 			res, err := epinioClient.AppRestart("namespace-foo", "appname")
 			Expect(err).ToNot(HaveOccurred())
-			Expect(res).To(Equal(models.ResponseOK))
+			Expect(res.Response).To(Equal(models.ResponseOK))
+		})
+	})
+
+	When("app restart reports the generation", func() {
+
+		BeforeEach(func() {
+			statusCode = 200
+			responseBody = `{ "status": "ok", "generation": 3 }`
+		})
+
+		It("returns the generation", func() {
+			res, err := epinioClient.AppRestart("namespace-foo", "appname")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal(models.AppRestartResponse{Response: models.ResponseOK, Generation: 3}))
 		})
 	})
 
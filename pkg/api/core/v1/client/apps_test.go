@@ -80,12 +80,27 @@ func DescribeAppsErrors() {
 			Entry("all apps", func() (any, error) {
 				return epinioClient.AllApps()
 			}),
+			Entry("apps with warnings", func() (any, error) {
+				return epinioClient.AppsWithWarnings("namespace")
+			}),
+			Entry("all apps with warnings", func() (any, error) {
+				return epinioClient.AllAppsWithWarnings()
+			}),
 			Entry("app show", func() (any, error) {
 				return epinioClient.AppShow("namespace", "appname")
 			}),
+			Entry("app show without metrics", func() (any, error) {
+				return epinioClient.AppShowWithoutMetrics("namespace", "appname")
+			}),
 			Entry("app update", func() (any, error) {
 				return epinioClient.AppUpdate(models.ApplicationUpdateRequest{}, "namespace", "appname")
 			}),
+			Entry("app stop", func() (any, error) {
+				return epinioClient.AppStop("namespace", "appname")
+			}),
+			Entry("app start", func() (any, error) {
+				return epinioClient.AppStart("namespace", "appname")
+			}),
 			Entry("app delete", func() (any, error) {
 				return epinioClient.AppDelete("namespace", []string{"appname"}, false)
 			}),
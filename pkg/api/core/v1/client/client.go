@@ -16,6 +16,7 @@ import (
 	"context"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/epinio/epinio/helpers"
 	"github.com/epinio/epinio/internal/auth"
@@ -25,6 +26,10 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// tokenRefreshMargin is how long before its expiry the access token is refreshed, so that it
+// does not expire in the middle of a long running operation.
+const tokenRefreshMargin = time.Minute
+
 // Client provides functionality for talking to an Epinio API
 // server
 type Client struct {
@@ -62,7 +67,7 @@ func New(ctx context.Context, settings *epiniosettings.Settings) *Client {
 		} else {
 			// ask a token for the 'epinio-api' client
 			oidcProvider.AddScopes("audience:server:client_id:epinio-api")
-			tokenSource = oidcProvider.Config.Oauth2.TokenSource(ctx, token)
+			tokenSource = oidcProvider.TokenSource(ctx, token, tokenRefreshMargin)
 		}
 	}
 
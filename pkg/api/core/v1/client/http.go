@@ -35,6 +35,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ErrSessionExpired is returned when the access token expired and could not be refreshed
+var ErrSessionExpired = errors.New("session expired, please login again with `epinio login`")
+
 // RequestHandler is a method that will return a *http.Request from a method and url
 type RequestHandler func(method, url string) (*http.Request, error)
 
@@ -405,12 +408,10 @@ func responseLogger(log logr.Logger, response *http.Response, body string) logr.
 
 func (c *Client) handleAuthorization(request *http.Request) error {
 	if c.Settings.Token.AccessToken != "" {
-		request.Header.Set("Authorization", "Bearer "+c.Settings.Token.AccessToken)
-
 		if oauth2Transport, ok := c.HttpClient.Transport.(*oauth2.Transport); ok {
 			newToken, err := oauth2Transport.Source.Token()
 			if err != nil {
-				return errors.Wrap(err, "failed getting token")
+				return fmt.Errorf("%w: %s", ErrSessionExpired, err.Error())
 			}
 			if newToken.AccessToken != c.Settings.Token.AccessToken {
 				c.log.V(1).Info("Refreshed expired token")
@@ -426,6 +427,8 @@ func (c *Client) handleAuthorization(request *http.Request) error {
 				}
 			}
 		}
+
+		request.Header.Set("Authorization", "Bearer "+c.Settings.Token.AccessToken)
 	} else if c.Settings.User != "" && c.Settings.Password != "" {
 		request.SetBasicAuth(c.Settings.User, c.Settings.Password)
 	}
@@ -13,15 +13,18 @@ package client_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/epinio/epinio/internal/cli/settings"
 	"github.com/epinio/epinio/pkg/api/core/v1/client"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
 )
 
 var _ = Describe("Client HTTP", func() {
@@ -132,4 +135,54 @@ var _ = Describe("Client HTTP", func() {
 			})
 		})
 	})
+
+	Describe("authorizing a request", func() {
+
+		BeforeEach(func() {
+			statusHeader = http.StatusOK
+			responseBody = `{"status":"ok"}`
+		})
+
+		JustBeforeEach(func() {
+			epinioClient.Settings.Token.AccessToken = "access-token"
+			epinioClient.Settings.Token.Expiry = time.Now().Add(time.Hour)
+		})
+
+		When("the access token is still valid", func() {
+			It("sends the access token", func() {
+				epinioClient.HttpClient = &http.Client{
+					Transport: &oauth2.Transport{
+						Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access-token"}),
+					},
+				}
+
+				requestInterceptor = func(r *http.Request) {
+					Expect(r.Header.Get("Authorization")).To(Equal("Bearer access-token"))
+				}
+
+				_, err := client.Do(epinioClient, "any", http.MethodGet, nil, &models.Response{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the access token cannot be refreshed", func() {
+			It("asks to login again", func() {
+				epinioClient.HttpClient = &http.Client{
+					Transport: &oauth2.Transport{Source: failingTokenSource{}},
+				}
+
+				_, err := client.Do(epinioClient, "any", http.MethodGet, nil, &models.Response{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("session expired, please login again with `epinio login`"))
+				Expect(errors.Is(err, client.ErrSessionExpired)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("invalid refresh token"))
+			})
+		})
+	})
 })
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("invalid refresh token")
+}
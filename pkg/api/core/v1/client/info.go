@@ -12,6 +12,11 @@
 package client
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
 
@@ -30,3 +35,40 @@ func (c *Client) Me() (models.MeResponse, error) {
 
 	return Get(c, endpoint, response)
 }
+
+// Ready checks the readiness endpoint of the Epinio server. It is not part of the API,
+// and does not require authentication. Transport errors (unreachable server, untrusted
+// certificate, ...) are returned as is, a non-ready server as an APIError.
+func (c *Client) Ready() error {
+	response, err := http.DefaultClient.Get(c.Settings.API + "/ready")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			c.log.Error(err, "failed to close the response body")
+		}
+	}()
+
+	if response.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	title := http.StatusText(response.StatusCode)
+
+	body := struct {
+		Message string `json:"message"`
+	}{}
+	if bodyBytes, err := io.ReadAll(response.Body); err == nil {
+		if json.Unmarshal(bodyBytes, &body) == nil && body.Message != "" {
+			title = body.Message
+		}
+	}
+
+	return &APIError{
+		StatusCode: response.StatusCode,
+		Err: &apierrors.ErrorResponse{
+			Errors: []apierrors.APIError{{Status: response.StatusCode, Title: title}},
+		},
+	}
+}
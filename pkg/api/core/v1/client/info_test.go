@@ -13,6 +13,7 @@ package client_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -25,6 +26,7 @@ import (
 
 var _ = Describe("Client info", func() {
 	Describe("info Errors", DescribeInfoErrors)
+	Describe("Ready", DescribeReady)
 })
 
 func DescribeInfoErrors() {
@@ -68,3 +70,64 @@ func DescribeInfoErrors() {
 		)
 	})
 }
+
+func DescribeReady() {
+
+	var epinioClient *client.Client
+	var statusCode int
+	var responseBody string
+	var requestPath string
+
+	JustBeforeEach(func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestPath = r.URL.Path
+			w.WriteHeader(statusCode)
+			fmt.Fprint(w, responseBody)
+		}))
+		DeferCleanup(srv.Close)
+
+		epinioClient = client.New(context.Background(), &settings.Settings{API: srv.URL})
+	})
+
+	When("the server is ready", func() {
+		BeforeEach(func() {
+			statusCode = 200
+			responseBody = `{}`
+		})
+
+		It("returns no error", func() {
+			err := epinioClient.Ready()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requestPath).To(Equal("/ready"))
+		})
+	})
+
+	When("the server is not ready", func() {
+		BeforeEach(func() {
+			statusCode = 500
+			responseBody = `{"message": "Roles are not yet initialized"}`
+		})
+
+		It("returns an API error with the server message", func() {
+			err := epinioClient.Ready()
+			Expect(err).To(HaveOccurred())
+
+			apiErr := &client.APIError{}
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(500))
+			Expect(err.Error()).To(Equal("Roles are not yet initialized"))
+		})
+	})
+
+	When("the server is unreachable", func() {
+		It("returns the transport error", func() {
+			epinioClient.Settings.API = "http://127.0.0.1:1"
+
+			err := epinioClient.Ready()
+			Expect(err).To(HaveOccurred())
+
+			apiErr := &client.APIError{}
+			Expect(errors.As(err, &apiErr)).To(BeFalse())
+		})
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// RegistryPrune deletes the registry images older than staleDays days which are not in use.
+// A staleDays of 0 leaves the choice to the server. With dryRun nothing is deleted.
+func (c *Client) RegistryPrune(dryRun bool, staleDays int) (models.RegistryPruneResponse, error) {
+	response := models.RegistryPruneResponse{}
+
+	queryParams := url.Values{}
+	queryParams.Add("dryRun", strconv.FormatBool(dryRun))
+	if staleDays > 0 {
+		queryParams.Add("staleDays", strconv.Itoa(staleDays))
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s?%s",
+		api.Routes.Path("RegistryPrune"),
+		queryParams.Encode(),
+	)
+
+	return Post(c, endpoint, nil, response)
+}
+
+// CatalogHealth returns the state of the helm repositories of all catalog services.
+func (c *Client) CatalogHealth() (models.CatalogHealthResponse, error) {
+	response := models.CatalogHealthResponse{}
+	endpoint := api.Routes.Path("CatalogHealth")
+
+	return Get(c, endpoint, response)
+}
+
+// SessionStats returns the interactive sessions active on the server, and its limits.
+func (c *Client) SessionStats() (models.SessionStatsResponse, error) {
+	response := models.SessionStatsResponse{}
+	endpoint := api.Routes.Path("SessionStats")
+
+	return Get(c, endpoint, response)
+}
+
+// Operations returns the long-running operations in progress.
+func (c *Client) Operations() (models.OperationsResponse, error) {
+	response := models.OperationsResponse{}
+	endpoint := api.Routes.Path("Operations")
+
+	return Get(c, endpoint, response)
+}
+
+// OperationCancel cancels the long-running operation with the given id.
+func (c *Client) OperationCancel(id string) (models.Response, error) {
+	response := models.Response{}
+	request := models.OperationCancelRequest{ID: id}
+	endpoint := api.Routes.Path("OperationCancel")
+
+	return Post(c, endpoint, request, response)
+}
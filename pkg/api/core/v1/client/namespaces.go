@@ -68,3 +68,27 @@ func (c *Client) Namespaces() (models.NamespaceList, error) {
 
 	return Get(c, endpoint, response)
 }
+
+// NamespaceRoles returns the role bindings of the users in a namespace
+func (c *Client) NamespaceRoles(namespace string) (models.NamespaceRoleBindingList, error) {
+	response := models.NamespaceRoleBindingList{}
+	endpoint := api.Routes.Path("NamespaceRoles", namespace)
+
+	return Get(c, endpoint, response)
+}
+
+// NamespaceRoleGrant assigns a role to a user in a namespace
+func (c *Client) NamespaceRoleGrant(namespace string, request models.NamespaceRoleGrantRequest) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("NamespaceRoleGrant", namespace)
+
+	return Post(c, endpoint, request, response)
+}
+
+// NamespaceRoleRevoke removes the role of a user in a namespace
+func (c *Client) NamespaceRoleRevoke(namespace, user, role string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("NamespaceRoleRevoke", namespace, user, role)
+
+	return Delete(c, endpoint, nil, response)
+}
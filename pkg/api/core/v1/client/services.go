@@ -12,11 +12,17 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 
 	api "github.com/epinio/epinio/internal/api/v1"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
 )
 
 func (c *Client) ServiceCatalog() (models.CatalogServices, error) {
@@ -33,6 +39,15 @@ func (c *Client) ServiceCatalogShow(serviceName string) (*models.CatalogService,
 	return Get(c, endpoint, response)
 }
 
+// ServiceCatalogValidate validates values against the schema of the catalog service's chart.
+// Empty values validate the values of the catalog service itself.
+func (c *Client) ServiceCatalogValidate(serviceName, values string) (models.CatalogServiceValidateResponse, error) {
+	response := models.CatalogServiceValidateResponse{}
+	endpoint := api.Routes.Path("ServiceCatalogValidate", serviceName)
+
+	return Post(c, endpoint, models.CatalogServiceValidateRequest{Values: values}, response)
+}
+
 // ServiceCatalogMatch returns all matching namespaces for the prefix
 func (c *Client) ServiceCatalogMatch(prefix string) (models.CatalogMatchResponse, error) {
 	response := models.CatalogMatchResponse{}
@@ -48,21 +63,29 @@ func (c *Client) AllServices() (models.ServiceList, error) {
 	return Get(c, endpoint, response)
 }
 
-func (c *Client) ServiceCreate(request models.ServiceCreateRequest, namespace string) (models.Response, error) {
-	response := models.Response{}
+func (c *Client) ServiceCreate(request models.ServiceCreateRequest, namespace string) (models.ServiceCreateResponse, error) {
+	response := models.ServiceCreateResponse{}
 	endpoint := api.Routes.Path("ServiceCreate", namespace)
 
 	return Post(c, endpoint, request, response)
 }
 
 // ServiceUpdate updates a service by invoking the associated API endpoint
-func (c *Client) ServiceUpdate(request models.ServiceUpdateRequest, namespace, name string) (models.Response, error) {
-	response := models.Response{}
+func (c *Client) ServiceUpdate(request models.ServiceUpdateRequest, namespace, name string) (models.ServiceUpdateResponse, error) {
+	response := models.ServiceUpdateResponse{}
 	endpoint := api.Routes.Path("ServiceUpdate", namespace, name)
 
 	return Patch(c, endpoint, request, response)
 }
 
+// ServiceUpdatePreview computes the changes of a service update, without applying them
+func (c *Client) ServiceUpdatePreview(request models.ServiceUpdateRequest, namespace, name string) (models.ServiceUpdatePreview, error) {
+	response := models.ServiceUpdatePreview{}
+	endpoint := fmt.Sprintf("%s?dryRun=true", api.Routes.Path("ServiceUpdate", namespace, name))
+
+	return Patch(c, endpoint, request, response)
+}
+
 func (c *Client) ServiceShow(namespace, name string) (*models.Service, error) {
 	response := &models.Service{}
 	endpoint := api.Routes.Path("ServiceShow", namespace, name)
@@ -70,6 +93,86 @@ func (c *Client) ServiceShow(namespace, name string) (*models.Service, error) {
 	return Get(c, endpoint, response)
 }
 
+// ServiceReset resets a service to the values it was created with by invoking the associated API endpoint
+func (c *Client) ServiceReset(request models.ServiceResetRequest, namespace, name string) (models.ServiceResetResponse, error) {
+	response := models.ServiceResetResponse{}
+	endpoint := api.Routes.Path("ServiceReset", namespace, name)
+
+	return Post(c, endpoint, request, response)
+}
+
+// ServiceCatalogService returns the catalog service the named service was created from
+func (c *Client) ServiceCatalogService(namespace, name string) (*models.CatalogService, error) {
+	response := &models.CatalogService{}
+	endpoint := api.Routes.Path("ServiceCatalogService", namespace, name)
+
+	return Get(c, endpoint, response)
+}
+
+// ServiceValues returns the effective values of the named service
+func (c *Client) ServiceValues(namespace, name string) (models.ServiceValuesResponse, error) {
+	response := models.ServiceValuesResponse{}
+	endpoint := api.Routes.Path("ServiceValues", namespace, name)
+
+	return Get(c, endpoint, response)
+}
+
+// ServiceOriginalValues returns the values the named service was created with
+func (c *Client) ServiceOriginalValues(namespace, name string) (models.ServiceOriginalValuesResponse, error) {
+	response := models.ServiceOriginalValuesResponse{}
+	endpoint := api.Routes.Path("ServiceOriginalValues", namespace, name)
+
+	return Get(c, endpoint, response)
+}
+
+// ServiceEvents returns the kubernetes events of the workload of the named service
+func (c *Client) ServiceEvents(namespace, name string) (models.EventList, error) {
+	response := models.EventList{}
+	endpoint := api.Routes.Path("ServiceEvents", namespace, name)
+
+	return Get(c, endpoint, response)
+}
+
+// ServiceSuspend scales the workload of the named service to zero, retaining its data
+func (c *Client) ServiceSuspend(namespace, name string) (models.ServiceSuspendResponse, error) {
+	response := models.ServiceSuspendResponse{}
+	endpoint := api.Routes.Path("ServiceSuspend", namespace, name)
+
+	return Post(c, endpoint, nil, response)
+}
+
+// ServiceResume restores the workload of the named, suspended service
+func (c *Client) ServiceResume(namespace, name string) (models.Response, error) {
+	response := models.Response{}
+	endpoint := api.Routes.Path("ServiceResume", namespace, name)
+
+	return Post(c, endpoint, nil, response)
+}
+
+// ServiceClone creates a copy of the named service in the target namespace of the request
+func (c *Client) ServiceClone(request models.ServiceCloneRequest, namespace, name string) (models.ServiceCreateResponse, error) {
+	response := models.ServiceCreateResponse{}
+	endpoint := api.Routes.Path("ServiceClone", namespace, name)
+
+	return Post(c, endpoint, request, response)
+}
+
+// ServiceRestart restarts the running applications bound to the named service
+func (c *Client) ServiceRestart(namespace, name string) (models.ServiceRestartResponse, error) {
+	response := models.ServiceRestartResponse{}
+	endpoint := api.Routes.Path("ServiceRestart", namespace, name)
+
+	return Post(c, endpoint, nil, response)
+}
+
+// ServiceExpiry sets the time to live of the named service. An empty ttl removes the expiry.
+func (c *Client) ServiceExpiry(namespace, name, ttl string) (models.ExpiryResponse, error) {
+	response := models.ExpiryResponse{}
+	endpoint := api.Routes.Path("ServiceExpiry", namespace, name)
+
+	return Post(c, endpoint, models.ExpiryRequest{TTL: ttl}, response)
+}
+
 // ServiceMatch returns all matching services for the prefix
 func (c *Client) ServiceMatch(namespace, prefix string) (models.ServiceMatchResponse, error) {
 	response := models.ServiceMatchResponse{}
@@ -95,8 +198,8 @@ func (c *Client) ServiceDelete(request models.ServiceDeleteRequest, namespace st
 	return Delete(c, endpoint, request, response)
 }
 
-func (c *Client) ServiceBind(request models.ServiceBindRequest, namespace, name string) (models.Response, error) {
-	response := models.Response{}
+func (c *Client) ServiceBind(request models.ServiceBindRequest, namespace, name string) (models.ServiceBindResponse, error) {
+	response := models.ServiceBindResponse{}
 	endpoint := api.Routes.Path("ServiceBind", namespace, name)
 
 	return Post(c, endpoint, request, response)
@@ -109,14 +212,23 @@ func (c *Client) ServiceUnbind(request models.ServiceUnbindRequest, namespace, n
 	return Post(c, endpoint, request, response)
 }
 
-// ServiceBatchBind binds multiple services to an application at once
-func (c *Client) ServiceBatchBind(request models.ServiceBatchBindRequest, namespace, appName string) (models.Response, error) {
-	response := models.Response{}
+// ServiceBatchBind binds multiple services to an application at once. The per service results
+// are only returned for a partial request.
+func (c *Client) ServiceBatchBind(request models.ServiceBatchBindRequest, namespace, appName string) (models.ServiceBatchBindResponse, error) {
+	response := models.ServiceBatchBindResponse{}
 	endpoint := api.Routes.Path("ServiceBatchBind", namespace, appName)
 
 	return Post(c, endpoint, request, response)
 }
 
+// ServiceRebind moves an application between services, unbinding and binding them at once
+func (c *Client) ServiceRebind(request models.ServiceRebindRequest, namespace, appName string) (models.ServiceBindResponse, error) {
+	response := models.ServiceBindResponse{}
+	endpoint := api.Routes.Path("ServiceRebind", namespace, appName)
+
+	return Post(c, endpoint, request, response)
+}
+
 func (c *Client) ServiceList(namespace string) (models.ServiceList, error) {
 	response := models.ServiceList{}
 	endpoint := api.Routes.Path("ServiceList", namespace)
@@ -124,6 +236,29 @@ func (c *Client) ServiceList(namespace string) (models.ServiceList, error) {
 	return Get(c, endpoint, response)
 }
 
+// ServiceListPage returns a page of the services in the namespace whose names start with the
+// prefix, sorted by name, and their total. A limit of zero places no limit.
+func (c *Client) ServiceListPage(namespace, prefix string, limit, offset int) (models.ServiceListResponse, error) {
+	response := models.ServiceListResponse{}
+
+	queryParams := url.Values{}
+	queryParams.Add("offset", strconv.Itoa(offset))
+	if limit > 0 {
+		queryParams.Add("limit", strconv.Itoa(limit))
+	}
+	if prefix != "" {
+		queryParams.Add("prefix", prefix)
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s?%s",
+		api.Routes.Path("ServiceList", namespace),
+		queryParams.Encode(),
+	)
+
+	return Get(c, endpoint, response)
+}
+
 // ServiceApps lists a map from services to bound apps, for the namespace
 func (c *Client) ServiceApps(namespace string) (models.ServiceAppsResponse, error) {
 	response := models.ServiceAppsResponse{}
@@ -142,3 +277,52 @@ func (c *Client) ServicePortForward(namespace string, serviceName string, opts *
 		return fw.ForwardPorts()
 	}
 }
+
+// ServiceProgressStream opens a websocket that emits the provisioning progress of the named
+// service whenever it changes, and closes once the service is deployed, or failed.
+func (c *Client) ServiceProgressStream(ctx context.Context, namespace, serviceName string, callback func(models.ServiceProgressEvent) error) error {
+	tokenResponse, err := c.AuthToken()
+	if err != nil {
+		return err
+	}
+
+	endpoint := api.WsRoutes.Path("ServiceProgressWs", namespace, serviceName)
+	queryParams := url.Values{}
+	queryParams.Add("authtoken", tokenResponse.Token)
+	websocketURL := fmt.Sprintf("%s%s/%s?%s", c.Settings.WSS, api.WsRoot, endpoint, queryParams.Encode())
+
+	webSocketConn, resp, err := websocket.DefaultDialer.DialContext(ctx, websocketURL, c.Headers())
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusOK {
+			return handleError(c.log, resp)
+		}
+		return errors.Wrap(err, "failed to connect to service progress websocket")
+	}
+	defer func() { _ = webSocketConn.Close() }()
+
+	for {
+		_, message, readErr := webSocketConn.ReadMessage()
+		if readErr != nil {
+			// Normal close means the server is done sending updates.
+			if websocket.IsCloseError(readErr, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return errors.Wrap(readErr, "reading service progress websocket message")
+		}
+
+		var event models.ServiceProgressEvent
+		if unmarshalErr := json.Unmarshal(message, &event); unmarshalErr != nil {
+			return errors.Wrap(unmarshalErr, "decoding service progress event")
+		}
+
+		if callback != nil {
+			if cbErr := callback(event); cbErr != nil {
+				return cbErr
+			}
+		}
+
+		if event.Completed {
+			return nil
+		}
+	}
+}
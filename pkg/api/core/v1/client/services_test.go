@@ -85,6 +85,33 @@ func DescribeServicesErrors() {
 		})
 	})
 
+	Describe("updating a service", func() {
+		BeforeEach(func() {
+			statusCode = 200
+		})
+
+		It("returns the status and the restarted apps", func() {
+			responseBody = `{ "status": "ok", "restartedApps": ["app1", "app2"] }`
+
+			resp, err := epinioClient.ServiceUpdate(models.ServiceUpdateRequest{}, "namespace-foo", "srv1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp).To(Equal(models.ServiceUpdateResponse{
+				Response:      models.ResponseOK,
+				RestartedApps: []string{"app1", "app2"},
+			}))
+		})
+
+		It("returns the apps pending the restart", func() {
+			responseBody = `{ "status": "ok", "pendingRestartApps": ["app1"] }`
+
+			resp, err := epinioClient.ServiceUpdate(models.ServiceUpdateRequest{}, "namespace-foo", "srv1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Response).To(Equal(models.ResponseOK))
+			Expect(resp.RestartedApps).To(BeEmpty())
+			Expect(resp.PendingRestartApps).To(Equal([]string{"app1"}))
+		})
+	})
+
 	When("a 500 status code and a JSON error was returned", func() {
 
 		BeforeEach(func() {
@@ -117,9 +144,18 @@ func DescribeServicesErrors() {
 			Entry("service create", func() (any, error) {
 				return epinioClient.ServiceCreate(models.ServiceCreateRequest{}, "namespace")
 			}),
+			Entry("service reset", func() (any, error) {
+				return epinioClient.ServiceReset(models.ServiceResetRequest{}, "namespace", "servicename")
+			}),
 			Entry("service update", func() (any, error) {
 				return epinioClient.ServiceUpdate(models.ServiceUpdateRequest{}, "namespace", "prefix")
 			}),
+			Entry("service restart", func() (any, error) {
+				return epinioClient.ServiceRestart("namespace", "servicename")
+			}),
+			Entry("service clone", func() (any, error) {
+				return epinioClient.ServiceClone(models.ServiceCloneRequest{TargetNamespace: "production"}, "namespace", "servicename")
+			}),
 			Entry("service catalog match", func() (any, error) {
 				return epinioClient.ServiceCatalogMatch("servicenameprefix")
 			}),
@@ -132,15 +168,31 @@ func DescribeServicesErrors() {
 				ServiceNames: []string{"service1", "service2"},
 			}, "namespace", "testapp")
 		}),
+		Entry("service rebind", func() (any, error) {
+			return epinioClient.ServiceRebind(models.ServiceRebindRequest{
+				AppName: "testapp",
+				Remove:  []string{"service1"},
+				Add:     []string{"service2"},
+			}, "namespace", "testapp")
+		}),
 		Entry("service unbind", func() (any, error) {
 			return epinioClient.ServiceUnbind(models.ServiceUnbindRequest{}, "namespace", "servicename")
 		}),
 			Entry("service list", func() (any, error) {
 				return epinioClient.ServiceList("namespace")
 			}),
+			Entry("service list page", func() (any, error) {
+				return epinioClient.ServiceListPage("namespace", "prefix", 10, 0)
+			}),
 			Entry("service show", func() (any, error) {
 				return epinioClient.ServiceShow("namespace", "servicename")
 			}),
+			Entry("service catalog service", func() (any, error) {
+				return epinioClient.ServiceCatalogService("namespace", "servicename")
+			}),
+			Entry("service original values", func() (any, error) {
+				return epinioClient.ServiceOriginalValues("namespace", "servicename")
+			}),
 			Entry("service match", func() (any, error) {
 				return epinioClient.ServiceMatch("namespace", "servicenameprefix")
 			}),
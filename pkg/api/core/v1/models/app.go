@@ -17,6 +17,7 @@ import (
 	"net/url"
 
 	"github.com/epinio/epinio/internal/names"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -92,29 +93,69 @@ type GitRef struct {
 }
 
 // App has all the application's properties, for at rest (Configuration), and active (Workload).
+// The workloads of the additional process types, if any, are keyed by the name of the type.
 // The main structure has identifying information.
+// GitRevision is the resolved git revision of the deployed sources. It is empty for apps not
+// deployed from git. ImageDigest is the digest of the deployed image, from the image url when
+// deployed by digest, and else from the running instances. It is empty when not known.
+// ConfigurationValidation is only computed for the details of a single app.
 // It is used in the CLI and API responses.
 type App struct {
-	Meta          AppRef                   `json:"meta"`
-	Configuration ApplicationConfiguration `json:"configuration"`
-	Origin        ApplicationOrigin        `json:"origin"`
-	Workload      *AppDeployment           `json:"deployment,omitempty"`
-	Staging       ApplicationStage         `json:"staging,omitempty"`
-	StagingStatus ApplicationStagingStatus `json:"stagingstatus"`
-	Status        ApplicationStatus        `json:"status"`
-	StatusMessage string                   `json:"statusmessage"`
-	StageID       string                   `json:"stage_id,omitempty"` // staging id, last run
-	ImageURL      string                   `json:"image_url"`
+	Meta          AppRef                    `json:"meta"`
+	Configuration ApplicationConfiguration  `json:"configuration"`
+	Origin        ApplicationOrigin         `json:"origin"`
+	Workload      *AppDeployment            `json:"deployment,omitempty"`
+	Processes     map[string]*AppDeployment `json:"processes,omitempty"`
+	Staging       ApplicationStage          `json:"staging,omitempty"`
+	StagingStatus ApplicationStagingStatus  `json:"stagingstatus"`
+	Status        ApplicationStatus         `json:"status"`
+	StatusMessage string                    `json:"statusmessage"`
+	StageID       string                    `json:"stage_id,omitempty"` // staging id, last run
+	ImageURL      string                    `json:"image_url"`
+	GitRevision   string                    `json:"gitRevision,omitempty"`
+	ImageDigest   string                    `json:"imageDigest,omitempty"`
+	ExpiresAt     *metav1.Time              `json:"expiresAt,omitempty"`    // automatic deletion, if set
+	Stopped       bool                      `json:"stopped,omitempty"`      // scaled to zero by AppStop
+	ScaledToZero  bool                      `json:"scaledToZero,omitempty"` // deployed, without instances, no workload
+
+	ConfigurationValidation *ConfigurationValidation `json:"configurationValidation,omitempty"`
 }
 
+// ConfigurationValidation is the outcome of validating the chart values of an application against
+// the settings declared by its app chart. Error is set instead when the validation itself failed.
+type ConfigurationValidation struct {
+	Valid    bool     `json:"valid"`
+	Messages []string `json:"messages,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// PodInfo is the state of an application replica. MemoryBytes and MilliCPUs are only
+// measurements when MetricsOk is set, otherwise they are unknown. The metrics and the restart
+// count are always serialized, also when zero, so that consumers can tell "0" from "unknown".
 type PodInfo struct {
-	Name        string `json:"name"`
-	MetricsOk   bool   `json:"metricsOk"`
-	MemoryBytes int64  `json:"memoryBytes"`
-	MilliCPUs   int64  `json:"millicpus"`
-	CreatedAt   string `json:"createdAt,omitempty"`
-	Restarts    int32  `json:"restarts"`
-	Ready       bool   `json:"ready"`
+	Name        string               `json:"name"`
+	MetricsOk   bool                 `json:"metricsOk"`
+	MemoryBytes int64                `json:"memoryBytes"`
+	MilliCPUs   int64                `json:"millicpus"`
+	CreatedAt   string               `json:"createdAt,omitempty"`
+	Restarts    int32                `json:"restarts"`
+	Ready       bool                 `json:"ready"`
+	Containers  []ContainerResources `json:"containers,omitempty"`
+}
+
+// ContainerResources are the resource requests and limits configured for a container of an
+// application pod, from the pod spec.
+type ContainerResources struct {
+	Name     string          `json:"name"`
+	Requests ResourceAmounts `json:"requests"`
+	Limits   ResourceAmounts `json:"limits"`
+}
+
+// ResourceAmounts is an amount of cpu and memory. A zero value means that the amount is not
+// set.
+type ResourceAmounts struct {
+	MilliCPUs   int64 `json:"millicpus"`
+	MemoryBytes int64 `json:"memoryBytes"`
 }
 
 // AppDeployment contains all the information specific to an active
@@ -127,10 +168,73 @@ type AppDeployment struct {
 	DesiredReplicas int32               `json:"desiredreplicas"`
 	ReadyReplicas   int32               `json:"readyreplicas"`
 	Replicas        map[string]*PodInfo `json:"replicas"`
-	Username        string              `json:"username,omitempty"` // app creator
-	StageID         string              `json:"stage_id,omitempty"` // staging id, running app
-	Status          string              `json:"status,omitempty"`   // app replica status
-	Routes          []string            `json:"routes,omitempty"`   // app routes
+	Username        string              `json:"username,omitempty"`    // app creator
+	StageID         string              `json:"stage_id,omitempty"`    // staging id, running app
+	Status          string              `json:"status,omitempty"`      // app replica status
+	Routes          []string            `json:"routes,omitempty"`      // app routes
+	ImageDigest     string              `json:"imageDigest,omitempty"` // digest of the running image
+}
+
+// AppStateResponse is the response of the AppStop and AppStart endpoints. For a stopped
+// application StoppedInstances is the number of instances restored by AppStart.
+type AppStateResponse struct {
+	Stopped          bool  `json:"stopped"`
+	Instances        int32 `json:"instances"`
+	StoppedInstances int32 `json:"stoppedInstances,omitempty"`
+}
+
+// AppRestartResponse is the response of the AppRestart endpoint. Generation is the new desired
+// generation of the application's deployment, which is reached when the restart has rolled out.
+type AppRestartResponse struct {
+	Response
+	Generation int64 `json:"generation"`
+}
+
+// AppStatus is the compact status of an application, as returned by the bulk status endpoint.
+// The replicas are only present when metrics were requested. MetricsError is set when they were,
+// yet could not be queried, leaving the replicas without metrics.
+type AppStatus struct {
+	Name            string              `json:"name"`
+	Status          ApplicationStatus   `json:"status"`
+	DesiredReplicas int32               `json:"desiredreplicas"`
+	ReadyReplicas   int32               `json:"readyreplicas"`
+	RoutesReady     bool                `json:"routesready"`
+	Replicas        map[string]*PodInfo `json:"replicas,omitempty"`
+	MetricsError    string              `json:"metricsError,omitempty"`
+}
+
+// AppStatusList is a collection of compact application statuses
+type AppStatusList []AppStatus
+
+// AppRevision is an entry of the deploy history of an application. It records what was deployed,
+// when, and by whom. The configuration is the one in effect at the time of the deployment.
+// ImageDigest is only known when the image was deployed by digest.
+type AppRevision struct {
+	Revision      int                      `json:"revision"              yaml:"revision"`
+	DeployedAt    metav1.Time              `json:"deployedAt"            yaml:"deployedAt"`
+	DeployedBy    string                   `json:"deployedBy,omitempty"  yaml:"deployedBy,omitempty"`
+	StageID       string                   `json:"stageId,omitempty"     yaml:"stageId,omitempty"`
+	ImageURL      string                   `json:"imageURL"              yaml:"imageURL"`
+	ImageDigest   string                   `json:"imageDigest,omitempty" yaml:"imageDigest,omitempty"`
+	Configuration ApplicationConfiguration `json:"configuration"         yaml:"configuration"`
+	Origin        ApplicationOrigin        `json:"origin"                yaml:"origin,omitempty"`
+	Staging       ApplicationStage         `json:"staging"               yaml:"staging,omitempty"`
+}
+
+// Manifest returns the manifest of the application as deployed in the revision.
+func (r AppRevision) Manifest(appRef AppRef) ApplicationManifest {
+	return ApplicationManifest{
+		Name:          appRef.Name,
+		Configuration: r.Configuration,
+		Namespace:     appRef.Namespace,
+		Origin:        r.Origin,
+		Staging:       r.Staging,
+	}
+}
+
+// AppHistoryResponse contains the deploy history of an application, oldest revision first.
+type AppHistoryResponse struct {
+	Revisions []AppRevision `json:"revisions" yaml:"revisions"`
 }
 
 // AppMatchResponse contains the list of names for matching apps
@@ -163,6 +267,21 @@ func (a *App) AppRef() AppRef {
 // AppList is a collection of app references
 type AppList []App
 
+// AppListResponse is a list of applications, together with the warnings about the parts of the
+// list which could not be fully assembled. Broken applications are listed with an error status.
+type AppListResponse struct {
+	Apps     AppList  `json:"apps"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// AppListPageResponse is a page of an application listing, sorted by namespace and name. The
+// total counts all the applications matching the listing, across all pages.
+type AppListPageResponse struct {
+	Items    AppList  `json:"items"`
+	Total    int      `json:"total"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 // Implement the Sort interface for application slices
 
 // Len (Sort interface) returns the length of the AppList
@@ -233,6 +352,36 @@ func (ar *AppRef) MakeScaleSecretName() string {
 	return names.GenerateResourceName(ar.Name + "-scale")
 }
 
+// MakePlacementSecretName returns the name of the kube secret holding the
+// scheduling placement (spread, node pool, affinity) for referenced application
+func (ar *AppRef) MakePlacementSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-placement")
+}
+
+// MakeRolloutSecretName returns the name of the kube secret holding the
+// rolling update settings for referenced application
+func (ar *AppRef) MakeRolloutSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-rollout")
+}
+
+// MakeProcessSecretName returns the name of the kube secret holding the
+// container command and args overrides for referenced application
+func (ar *AppRef) MakeProcessSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-process")
+}
+
+// MakeNetworkSecretName returns the name of the kube secret holding the
+// network settings for referenced application
+func (ar *AppRef) MakeNetworkSecretName() string {
+	return names.GenerateResourceName(ar.Name + "-network")
+}
+
+// MakeHistorySecretName returns the name of the kube secret holding the
+// deploy history of the referenced application
+func (ar *AppRef) MakeHistorySecretName() string {
+	return names.GenerateResourceName(ar.Name + "-history")
+}
+
 // MakePVCName returns the name of the kube pvc to use with/for the referenced application.
 func (ar *AppRef) MakeCachePVCName() string {
 	return names.GenerateResourceName(ar.Namespace, "cache", ar.Name)
@@ -242,6 +391,12 @@ func (ar *AppRef) MakeSourceBlobsPVCName() string {
 	return names.GenerateResourceName(ar.Namespace, "sourceblobs", ar.Name)
 }
 
+// MakeSharedCachePVCName returns the name of the kube pvc holding the build cache shared by
+// the applications of the namespace, when staging uses a ReadWriteMany cache.
+func MakeSharedCachePVCName(namespace string) string {
+	return names.GenerateResourceName(namespace, "shared-cache")
+}
+
 // StageRef references a staging run by ID, currently randomly generated
 // for each POST to the staging endpoint
 type StageRef struct {
@@ -12,6 +12,8 @@
 package models_test
 
 import (
+	"encoding/json"
+
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -85,3 +87,27 @@ var _ = Describe("GitProvider", func() {
 		Expect(err).To(HaveOccurred())
 	})
 })
+
+var _ = Describe("PodInfo", func() {
+	It("serializes zero metrics as 0", func() {
+		data, err := json.Marshal(models.PodInfo{Name: "replica", MetricsOk: true})
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := map[string]interface{}{}
+		Expect(json.Unmarshal(data, &fields)).To(Succeed())
+		Expect(fields).To(HaveKeyWithValue("metricsOk", true))
+		Expect(fields).To(HaveKeyWithValue("millicpus", BeNumerically("==", 0)))
+		Expect(fields).To(HaveKeyWithValue("memoryBytes", BeNumerically("==", 0)))
+		Expect(fields).To(HaveKeyWithValue("restarts", BeNumerically("==", 0)))
+	})
+
+	It("marks unknown metrics", func() {
+		data, err := json.Marshal(models.PodInfo{Name: "replica"})
+		Expect(err).ToNot(HaveOccurred())
+
+		fields := map[string]interface{}{}
+		Expect(json.Unmarshal(data, &fields)).To(Succeed())
+		Expect(fields).To(HaveKeyWithValue("metricsOk", false))
+		Expect(fields).To(HaveKey("millicpus"))
+	})
+})
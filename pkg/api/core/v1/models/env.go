@@ -15,6 +15,7 @@ package models
 // Identical structures
 
 import (
+	"regexp"
 	"sort"
 )
 
@@ -67,6 +68,94 @@ func (evm EnvVariableMap) List() EnvVariableList {
 	return result
 }
 
+// envReferencePattern matches the kubernetes dependent variable references `$(NAME)`,
+// and the escaped form `$$`, which is not a reference.
+var envReferencePattern = regexp.MustCompile(`\$\$|\$\(([-._a-zA-Z][-._a-zA-Z0-9]*)\)`)
+
+// EnvReferences returns the names of the variables referenced by the value, using the
+// kubernetes `$(NAME)` syntax for dependent variables. Escaped references, i.e. `$$(NAME)`,
+// are ignored. Each name is reported once, in order of first occurrence.
+func EnvReferences(value string) []string {
+	result := []string{}
+	seen := map[string]struct{}{}
+
+	for _, match := range envReferencePattern.FindAllStringSubmatch(value, -1) {
+		name := match[1]
+		if name == "" {
+			continue // escape
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+
+	return result
+}
+
+// DanglingReferences returns, per variable of the map, the referenced variables which are not
+// defined in the map. Variables without dangling references are not reported. The result is nil
+// when there are none.
+func (evm EnvVariableMap) DanglingReferences() map[string][]string {
+	var result map[string][]string
+
+	for name, value := range evm {
+		for _, ref := range EnvReferences(value) {
+			if _, ok := evm[ref]; ok {
+				continue
+			}
+			if result == nil {
+				result = map[string][]string{}
+			}
+			result[name] = append(result[name], ref)
+		}
+	}
+
+	return result
+}
+
+// DependencyList returns the variables of the map as a list ordered such that each variable
+// comes after the variables it references. This is the order kubernetes needs to expand the
+// `$(NAME)` references of dependent variables. Variables are otherwise sorted by name, and
+// variables caught in a reference cycle are placed at the end, also sorted by name.
+func (evm EnvVariableMap) DependencyList() EnvVariableList {
+	result := EnvVariableList{}
+	placed := map[string]struct{}{}
+	pending := evm.List()
+
+	for len(pending) > 0 {
+		remaining := EnvVariableList{}
+		for _, ev := range pending {
+			ready := true
+			for _, ref := range EnvReferences(ev.Value) {
+				if _, ok := evm[ref]; !ok || ref == ev.Name {
+					continue
+				}
+				if _, ok := placed[ref]; !ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				result = append(result, ev)
+				placed[ev.Name] = struct{}{}
+			} else {
+				remaining = append(remaining, ev)
+			}
+		}
+
+		if len(remaining) == len(pending) {
+			// No progress, the remainder is cyclic
+			result = append(result, remaining...)
+			break
+		}
+		pending = remaining
+	}
+
+	return result
+}
+
 // Implement the Sort interface for EV definition slices
 
 // Len (Sort interface) returns the length of the EnvVariableList
@@ -0,0 +1,63 @@
+// Copyright © 2021 - 2023 SUSE LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models_test
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Environment references", func() {
+	names := func(evl models.EnvVariableList) []string {
+		result := []string{}
+		for _, ev := range evl {
+			result = append(result, ev.Name)
+		}
+		return result
+	}
+
+	It("finds the referenced variables, ignoring escapes", func() {
+		Expect(models.EnvReferences("https://$(HOST):$(PORT)/$(HOST)")).To(Equal([]string{"HOST", "PORT"}))
+		Expect(models.EnvReferences("$$(HOST) costs $5")).To(BeEmpty())
+	})
+
+	It("reports dangling references only", func() {
+		env := models.EnvVariableMap{
+			"URL":  "https://$(HOST):$(PORT)",
+			"HOST": "example.com",
+		}
+		Expect(env.DanglingReferences()).To(Equal(map[string][]string{"URL": {"PORT"}}))
+
+		env["PORT"] = "8080"
+		Expect(env.DanglingReferences()).To(BeNil())
+	})
+
+	It("orders variables after the variables they reference", func() {
+		env := models.EnvVariableMap{
+			"A_URL":    "$(B_HOST):$(C_PORT)",
+			"B_HOST":   "$(D_DOMAIN)",
+			"C_PORT":   "8080",
+			"D_DOMAIN": "example.com",
+		}
+		Expect(names(env.DependencyList())).To(Equal([]string{"C_PORT", "D_DOMAIN", "B_HOST", "A_URL"}))
+	})
+
+	It("places cyclic variables at the end", func() {
+		env := models.EnvVariableMap{
+			"A": "$(B)",
+			"B": "$(A)",
+			"C": "plain",
+		}
+		Expect(names(env.DependencyList())).To(Equal([]string{"C", "A", "B"}))
+	})
+})
@@ -20,6 +20,7 @@ package models
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/epinio/epinio/helpers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -133,23 +134,119 @@ type ApplicationManifest struct {
 }
 
 // ApplicationStage is the part of the manifest holding information
-// relevant to staging the application's sources. This is, currently,
-// only the reference to the Paketo builder image to use.
+// relevant to staging the application's sources. This is the reference to the Paketo
+// builder image to use, and the environment available only to the build, see StageRequest.
 type ApplicationStage struct {
-	Builder string `yaml:"builder,omitempty" json:"builder,omitempty"`
+	Builder           string          `yaml:"builder,omitempty"           json:"builder,omitempty"`
+	Environment       EnvVariableMap  `yaml:"environment,omitempty"       json:"environment,omitempty"`
+	EnvironmentSecret string          `yaml:"environmentSecret,omitempty" json:"environmentSecret,omitempty"`
+	EmbedEnvironment  bool            `yaml:"embedEnvironment,omitempty"  json:"embedEnvironment,omitempty"`
+	Storage           *StagingStorage `yaml:"storage,omitempty"           json:"storage,omitempty"`
+}
+
+// StagingStorage sizes the volumes used by the staging of the application, i.e. the cache
+// and the source blobs. Unset fields fall back to the defaults of the staging configuration.
+// Existing volumes are expanded when the size grows, and never shrunk.
+// The access modes apply to the cache of the application only, and are limited to
+// ReadWriteOnce and ReadWriteOncePod. A cache shared by the namespace is configured by the
+// operator.
+type StagingStorage struct {
+	Size        string   `yaml:"size,omitempty"        json:"size,omitempty"`
+	AccessModes []string `yaml:"accessModes,omitempty" json:"accessModes,omitempty"`
 }
 
 // ApplicationConfiguration is the part of the manifest describing the configuration of the application
 type ApplicationConfiguration struct {
-	Instances      *int32             `json:"instances"          yaml:"instances,omitempty"`
-	Configurations []string           `json:"configurations"     yaml:"configurations,omitempty"`
-	Environment    EnvVariableMap     `json:"environment"        yaml:"environment,omitempty"`
-	ReplaceEnv     *bool              `json:"replace_env,omitempty" yaml:"replace_env,omitempty"`
-	Services       []string           `json:"services,omitempty" yaml:"services,omitempty"`
-	Routes         []string           `json:"routes"             yaml:"routes,omitempty"`
-	AppChart       string             `json:"appchart,omitempty" yaml:"appchart,omitempty"`
-	Settings       ChartValueSettings `json:"settings,omitempty" yaml:"settings,omitempty"`
-	Ignore         []string           `json:"ignore,omitempty"   yaml:"ignore,omitempty"`
+	Instances      *int32                   `json:"instances"          yaml:"instances,omitempty"`
+	Configurations []string                 `json:"configurations"     yaml:"configurations,omitempty"`
+	Environment    EnvVariableMap           `json:"environment"        yaml:"environment,omitempty"`
+	ReplaceEnv     *bool                    `json:"replace_env,omitempty" yaml:"replace_env,omitempty"`
+	Services       []string                 `json:"services,omitempty" yaml:"services,omitempty"`
+	Routes         []string                 `json:"routes"             yaml:"routes,omitempty"`
+	AppChart       string                   `json:"appchart,omitempty" yaml:"appchart,omitempty"`
+	Settings       ChartValueSettings       `json:"settings,omitempty" yaml:"settings,omitempty"`
+	Ignore         []string                 `json:"ignore,omitempty"   yaml:"ignore,omitempty"`
+	Placement      *ApplicationPlacement    `json:"placement,omitempty" yaml:"placement,omitempty"`
+	Rollout        *ApplicationRollout      `json:"rollout,omitempty"   yaml:"rollout,omitempty"`
+	Process        *ApplicationProcess      `json:"process,omitempty"   yaml:"process,omitempty"`
+	ProcessTypes   []ApplicationProcessType `json:"processTypes,omitempty" yaml:"processTypes,omitempty"`
+	IngressClass   string                   `json:"ingressClass,omitempty" yaml:"ingressClass,omitempty"`
+	DNS            *ApplicationDNS          `json:"dns,omitempty"          yaml:"dns,omitempty"`
+	PodMetadata    *ApplicationPodMetadata  `json:"podMetadata,omitempty"  yaml:"podMetadata,omitempty"`
+}
+
+// ApplicationPodMetadata holds the custom annotations and labels added to the application's
+// pods. They are set by deploying the application, and kept until the next deployment setting
+// them.
+type ApplicationPodMetadata struct {
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"      yaml:"labels,omitempty"`
+}
+
+// ApplicationDNS is the part of the manifest describing the DNS resolution of the application's
+// pods. DNSPolicy is one of the kubernetes DNS policies, i.e. "ClusterFirst" (the default),
+// "ClusterFirstWithHostNet", "Default", or "None". DNSConfig is merged into the DNS
+// configuration generated by the policy. For policy "None" it is the whole configuration.
+type ApplicationDNS struct {
+	DNSPolicy string                `json:"dnsPolicy,omitempty" yaml:"dnsPolicy,omitempty"`
+	DNSConfig *ApplicationDNSConfig `json:"dnsConfig,omitempty" yaml:"dnsConfig,omitempty"`
+}
+
+// ApplicationDNSConfig holds the nameservers, search domains, and resolver options added to
+// the DNS configuration of the application's pods. Nameservers are IP addresses.
+type ApplicationDNSConfig struct {
+	Nameservers []string               `json:"nameservers,omitempty" yaml:"nameservers,omitempty"`
+	Searches    []string               `json:"searches,omitempty"    yaml:"searches,omitempty"`
+	Options     []ApplicationDNSOption `json:"options,omitempty"     yaml:"options,omitempty"`
+}
+
+// ApplicationDNSOption is a resolver option, as in "ndots" with value "2".
+type ApplicationDNSOption struct {
+	Name  string  `json:"name"            yaml:"name"`
+	Value *string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// ApplicationProcessType is a named process type of the application, deployed from the same
+// image as the application, as its own workload, without routes. The web process type is the
+// application itself. Instances defaults to one. Without a Command the process type is started
+// through the launcher of the buildpacks, i.e. as declared in the Procfile of the application.
+type ApplicationProcessType struct {
+	Name      string   `json:"name"                yaml:"name"`
+	Instances *int32   `json:"instances,omitempty" yaml:"instances,omitempty"`
+	Command   []string `json:"command,omitempty"   yaml:"command,omitempty"`
+	Args      []string `json:"args,omitempty"      yaml:"args,omitempty"`
+}
+
+// ApplicationProcess is the part of the manifest overriding the entrypoint of the
+// application's image. Command replaces the entrypoint, and Args its arguments, as for any
+// kubernetes container. This runs a different process type from the same image, e.g. a worker
+// instead of the web process. Empty fields keep the defaults of the image.
+type ApplicationProcess struct {
+	Command []string `json:"command,omitempty" yaml:"command,omitempty"`
+	Args    []string `json:"args,omitempty"    yaml:"args,omitempty"`
+}
+
+// ApplicationRollout is the part of the manifest describing how the application's pods are
+// replaced during a rolling update. Both fields take either a number of pods, or a percentage
+// of the desired instances, as in "1" or "25%". Empty fields keep the deployment defaults.
+// Setting maxSurge "1" and maxUnavailable "0" gives restarts without downtime, even for a
+// single instance. RevisionHistoryLimit is the number of old ReplicaSets kept for rollbacks.
+// Without it the server default applies.
+type ApplicationRollout struct {
+	MaxSurge             string `json:"maxSurge,omitempty"             yaml:"maxSurge,omitempty"`
+	MaxUnavailable       string `json:"maxUnavailable,omitempty"       yaml:"maxUnavailable,omitempty"`
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty" yaml:"revisionHistoryLimit,omitempty"`
+}
+
+// ApplicationPlacement is the part of the manifest describing how the application's pods
+// are scheduled. Spread is a shorthand asking the scheduler to spread the replicas across
+// nodes ("node") or zones ("zone"). NodePool pins the pods to the nodes of the named pool, as
+// identified by the node pool label configured for the server. Affinity is a raw kubernetes
+// affinity and is merged with the shorthands.
+type ApplicationPlacement struct {
+	Spread   string                 `json:"spread,omitempty"   yaml:"spread,omitempty"`
+	NodePool string                 `json:"nodePool,omitempty" yaml:"nodePool,omitempty"`
+	Affinity map[string]interface{} `json:"affinity,omitempty" yaml:"affinity,omitempty"`
 }
 
 // ApplicationOrigin is the part of the manifest describing the origin of the application
@@ -217,19 +314,34 @@ type ApplicationCreateRequest struct {
 // run, and the configurations bound to it.
 // Note: Instances is a pointer to give us a nil value separate from
 // actual integers, as means of communicating `default`/`no change`.
+// Zero instances scale the application to zero, keeping its release
+// and routes, until it is scaled up again.
 type ApplicationUpdateRequest struct {
-	Restart        *bool              `json:"restart,omitempty"`
-	Instances      *int32             `json:"instances"          yaml:"instances,omitempty"`
-	Configurations []string           `json:"configurations"     yaml:"configurations,omitempty"`
-	Environment    EnvVariableMap     `json:"environment"        yaml:"environment,omitempty"`
-	ReplaceEnv     *bool              `json:"replace_env,omitempty" yaml:"replace_env,omitempty"`
-	Routes         []string           `json:"routes"             yaml:"routes,omitempty"`
-	AppChart       string             `json:"appchart,omitempty" yaml:"appchart,omitempty"`
-	Settings       ChartValueSettings `json:"settings,omitempty" yaml:"settings,omitempty"`
+	Restart        *bool                    `json:"restart,omitempty"`
+	Instances      *int32                   `json:"instances"          yaml:"instances,omitempty"`
+	Configurations []string                 `json:"configurations"     yaml:"configurations,omitempty"`
+	Environment    EnvVariableMap           `json:"environment"        yaml:"environment,omitempty"`
+	ReplaceEnv     *bool                    `json:"replace_env,omitempty" yaml:"replace_env,omitempty"`
+	Routes         []string                 `json:"routes"             yaml:"routes,omitempty"`
+	AppChart       string                   `json:"appchart,omitempty" yaml:"appchart,omitempty"`
+	Settings       ChartValueSettings       `json:"settings,omitempty" yaml:"settings,omitempty"`
+	Placement      *ApplicationPlacement    `json:"placement,omitempty" yaml:"placement,omitempty"`
+	Rollout        *ApplicationRollout      `json:"rollout,omitempty"   yaml:"rollout,omitempty"`
+	Process        *ApplicationProcess      `json:"process,omitempty"   yaml:"process,omitempty"`
+	ProcessTypes   []ApplicationProcessType `json:"processTypes"    yaml:"processTypes,omitempty"`
+	IngressClass   *string                  `json:"ingressClass,omitempty" yaml:"ingressClass,omitempty"`
+	DNS            *ApplicationDNS          `json:"dns,omitempty"          yaml:"dns,omitempty"`
 }
 
 func NewApplicationUpdateRequest(manifest ApplicationManifest) ApplicationUpdateRequest {
 	manifestConfig := manifest.Configuration
+
+	// An empty ingress class in the manifest means `no change`, not `server default`.
+	var ingressClass *string
+	if manifestConfig.IngressClass != "" {
+		ingressClass = &manifestConfig.IngressClass
+	}
+
 	return ApplicationUpdateRequest{
 		Instances:      manifestConfig.Instances,
 		Configurations: manifestConfig.Configurations,
@@ -238,6 +350,12 @@ func NewApplicationUpdateRequest(manifest ApplicationManifest) ApplicationUpdate
 		Routes:         manifestConfig.Routes,
 		AppChart:       manifestConfig.AppChart,
 		Settings:       manifestConfig.Settings,
+		Placement:      manifestConfig.Placement,
+		Rollout:        manifestConfig.Rollout,
+		Process:        manifestConfig.Process,
+		ProcessTypes:   manifestConfig.ProcessTypes,
+		IngressClass:   ingressClass,
+		DNS:            manifestConfig.DNS,
 	}
 }
 
@@ -254,27 +372,73 @@ type UploadResponse struct {
 	BlobUID string `json:"blobuid,omitempty"`
 }
 
-// StageRequest represents and contains the data needed to stage an application
+// StageRequest represents and contains the data needed to stage an application.
+// BuildEnvironment holds variables available to the build only, not to the workload. They
+// are added to the variables of the secret named by BuildEnvironmentSecret, in the namespace
+// of the app, and override the app environment for the build. Variables the buildpacks embed
+// into the image, i.e. `BPE_*`, are rejected, unless EmbedBuildEnvironment is set.
+// Storage, when present, overrides the size and access modes of the staging volumes.
 type StageRequest struct {
-	App          AppRef `json:"app,omitempty"`
-	BlobUID      string `json:"blobuid,omitempty"`
-	BuilderImage string `json:"builderimage,omitempty"`
-}
-
-// StageResponse represents the server's response to a successful app staging
+	App                    AppRef          `json:"app,omitempty"`
+	BlobUID                string          `json:"blobuid,omitempty"`
+	BuilderImage           string          `json:"builderimage,omitempty"`
+	BuildEnvironment       EnvVariableMap  `json:"buildenvironment,omitempty"`
+	BuildEnvironmentSecret string          `json:"buildenvironmentsecret,omitempty"`
+	EmbedBuildEnvironment  bool            `json:"embedbuildenvironment,omitempty"`
+	Storage                *StagingStorage `json:"storage,omitempty"`
+}
+
+// StageResponse represents the server's response to a successful app staging. BuilderImage
+// is the builder used. BuilderSignal names the file of the sources which selected it, when the
+// builder was chosen by the language of the sources.
 type StageResponse struct {
-	Stage    StageRef `json:"stage,omitempty"`
-	ImageURL string   `json:"image,omitempty"`
+	Stage         StageRef `json:"stage,omitempty"`
+	ImageURL      string   `json:"image,omitempty"`
+	BuilderImage  string   `json:"builderimage,omitempty"`
+	BuilderSignal string   `json:"buildersignal,omitempty"`
 }
 
 // StageCompleteEvent is sent over the staging completion websocket endpoint
-// to signal the status of a staging job.
+// to signal the status of a staging job. Attempts counts the builds run so far, and Retries
+// is the number of automatic re-attempts a failed build is allowed.
 type StageCompleteEvent struct {
 	StageID   string `json:"stage_id"`
 	Namespace string `json:"namespace"`
 	Status    string `json:"status"`
 	Message   string `json:"message,omitempty"`
 	Completed bool   `json:"completed"`
+	Attempts  int32  `json:"attempts,omitempty"`
+	Retries   int32  `json:"retries,omitempty"`
+}
+
+// StageCacheStats is sent over the staging cache websocket endpoint, to report the use of the
+// build cache by a staging run. Layers lists the cached layers seen so far, in order, and
+// whether they were restored from the cache (hit), or had to be built (miss). The sizes of the
+// cache before and after the build are reported when known. Completed marks the final stats.
+type StageCacheStats struct {
+	StageID         string            `json:"stage_id"`
+	Namespace       string            `json:"namespace"`
+	Layers          []StageCacheLayer `json:"layers"`
+	Hits            int               `json:"hits"`
+	Misses          int               `json:"misses"`
+	SizeBeforeBytes *int64            `json:"size_before_bytes,omitempty"`
+	SizeAfterBytes  *int64            `json:"size_after_bytes,omitempty"`
+	Completed       bool              `json:"completed"`
+}
+
+// StageCacheLayer is the use of the build cache for a single layer of a staging run.
+type StageCacheLayer struct {
+	Layer string `json:"layer"`
+	Hit   bool   `json:"hit"`
+}
+
+// StagingCompleteResponse is returned when a staging run completed successfully. Attempts
+// counts the builds it took, and Retries is the number of automatic re-attempts a failed build
+// was allowed.
+type StagingCompleteResponse struct {
+	Response
+	Attempts int32 `json:"attempts,omitempty"`
+	Retries  int32 `json:"retries,omitempty"`
 }
 
 // StageComplete statuses used in websocket payloads.
@@ -285,16 +449,28 @@ const (
 	StageStatusError     = "error"
 )
 
+// Marker messages sent over the push logs websocket endpoint. The handoff marker separates
+// the staging logs from the application logs following them. The failed marker ends the
+// stream when staging did not succeed.
+const (
+	PushLogsHandoffMarker = "___STAGING_COMPLETE___"
+	PushLogsFailedMarker  = "___STAGING_FAILED___"
+)
+
 // DeployRequest represents and contains the data needed to deploy an application
 // Note that the overall application configuration (instances, configurations, EVs) is
 // already known server side, through AppCreate/AppUpdate requests.
 // This request not only comes with the image to deploy, but also the
 // information where the sources of that image came from.
+// The annotations and labels are added to the application's pods. Without either the ones of
+// the previous deployment are kept.
 type DeployRequest struct {
-	App      AppRef            `json:"app,omitempty"`
-	Stage    StageRef          `json:"stage,omitempty"`
-	ImageURL string            `json:"image,omitempty"`
-	Origin   ApplicationOrigin `json:"origin,omitempty"`
+	App         AppRef            `json:"app,omitempty"`
+	Stage       StageRef          `json:"stage,omitempty"`
+	ImageURL    string            `json:"image,omitempty"`
+	Origin      ApplicationOrigin `json:"origin,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
 }
 
 // DeployResponse represents the server's response to a successful app deployment
@@ -361,6 +537,35 @@ type AuthTokenResponse struct {
 	Token string `json:"token,omitempty"`
 }
 
+// APITokenCreateRequest contains the name of the API token to create, and its restrictions
+type APITokenCreateRequest struct {
+	Name      string `json:"name"`
+	Role      string `json:"role,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// ExpiresIn is a duration, i.e. "720h". The token never expires when empty.
+	ExpiresIn string `json:"expires_in,omitempty"`
+}
+
+// APIToken describes an API token. It does not contain the token value.
+type APIToken struct {
+	Name      string       `json:"name"`
+	Owner     string       `json:"owner"`
+	Role      string       `json:"role"`
+	Namespace string       `json:"namespace,omitempty"`
+	CreatedAt metav1.Time  `json:"createdAt,omitempty"`
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// APITokenList is a collection of API tokens
+type APITokenList []APIToken
+
+// APITokenCreateResponse contains the created API token, with its value.
+// The value is returned only on creation.
+type APITokenCreateResponse struct {
+	APIToken
+	Token string `json:"token"`
+}
+
 // NamespaceCreateRequest contains the name of the namespace that should be created
 type NamespaceCreateRequest struct {
 	Name string `json:"name,omitempty"`
@@ -443,4 +648,101 @@ type AppExportRequest struct {
 	ChartName    string `json:"chart-name,omitempty"`
 	ImageTag     string `json:"image-tag,omitempty"`
 	ChartVersion string `json:"chart-version,omitempty"`
+	PullSecret   string `json:"pull-secret,omitempty"`
+}
+
+// RegistryPruneResponse reports the images a registry prune removed, or would remove on a dry
+// run. ReclaimedBytes is the size of the blobs only used by the pruned images. The registry
+// releases that space with its next garbage collection.
+type RegistryPruneResponse struct {
+	DryRun         bool                  `json:"dryRun"`
+	StaleDays      int                   `json:"staleDays"`
+	Pruned         []RegistryPrunedImage `json:"pruned"`
+	Kept           int                   `json:"kept"`
+	ReclaimedBytes int64                 `json:"reclaimedBytes"`
+	Errors         []string              `json:"errors,omitempty"`
+}
+
+// RegistryPrunedImage describes an image removed by a registry prune.
+type RegistryPrunedImage struct {
+	Image   string    `json:"image"`
+	Digest  string    `json:"digest"`
+	Created time.Time `json:"created"`
+}
+
+// CatalogHealthResponse reports the state of the helm repositories of all catalog services.
+type CatalogHealthResponse struct {
+	Services []CatalogServiceHealth `json:"services"`
+}
+
+// CatalogServiceHealth reports whether the helm repository of a catalog service is reachable,
+// and provides the chart of the service. Error describes the problem of an unhealthy service.
+type CatalogServiceHealth struct {
+	Name       string `json:"name"`
+	Repository string `json:"repository"`
+	Chart      string `json:"chart"`
+	Version    string `json:"version,omitempty"`
+	Healthy    bool   `json:"healthy"`
+	Error      string `json:"error,omitempty"`
+}
+
+// SessionStatsResponse reports the interactive sessions, i.e. exec and port-forward, active on
+// the server, in total, and per user, together with the configured limits. A limit of zero
+// means unlimited. Rejected counts the sessions refused since the server started.
+type SessionStatsResponse struct {
+	Active    int            `json:"active"`
+	Users     map[string]int `json:"users"`
+	Rejected  int64          `json:"rejected"`
+	Limit     int            `json:"limit"`
+	UserLimit int            `json:"userLimit"`
+}
+
+// ServerSaturation reports the load of the server, i.e. the requests it currently serves, and
+// the active interactive sessions, against their limits. Zero limits are not enforced.
+type ServerSaturation struct {
+	InFlightRequests int64 `json:"inFlightRequests"`
+	RequestLimit     int   `json:"requestLimit"`
+	ActiveSessions   int   `json:"activeSessions"`
+	SessionLimit     int   `json:"sessionLimit"`
+	Saturated        bool  `json:"saturated"`
+}
+
+// Operation describes a long-running operation of the server, i.e. a git import, a staging
+// build, or a service provisioning. The CorrelationID is the id of the request which started it.
+type Operation struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Namespace     string    `json:"namespace"`
+	Resource      string    `json:"resource"`
+	User          string    `json:"user,omitempty"`
+	CorrelationID string    `json:"correlationID,omitempty"`
+	StartedAt     time.Time `json:"startedAt"`
+}
+
+// OperationsResponse lists the long-running operations in progress, oldest first.
+type OperationsResponse struct {
+	Operations []Operation `json:"operations"`
+}
+
+// OperationCancelRequest names the operation to cancel.
+type OperationCancelRequest struct {
+	ID string `json:"id"`
+}
+
+// AppLogSearchResponse lists the log lines of an application matching a search pattern, with
+// their context. Truncated is set when more lines matched than the limit of the search.
+type AppLogSearchResponse struct {
+	Matches   []AppLogMatch `json:"matches"`
+	Truncated bool          `json:"truncated"`
+}
+
+// AppLogMatch is a log line matching a search pattern, together with the lines written before
+// and after it by the same container, as with `grep -C`.
+type AppLogMatch struct {
+	PodName       string   `json:"podName"`
+	ContainerName string   `json:"containerName"`
+	Timestamp     string   `json:"timestamp,omitempty"`
+	Line          string   `json:"line"`
+	Before        []string `json:"before,omitempty"`
+	After         []string `json:"after,omitempty"`
 }
@@ -42,3 +42,19 @@ func (al NamespaceList) Swap(i, j int) {
 func (al NamespaceList) Less(i, j int) bool {
 	return al[i].Meta.Name < al[j].Meta.Name
 }
+
+// NamespaceRoleBinding assigns a role to a user, scoped to a namespace
+type NamespaceRoleBinding struct {
+	User      string `json:"user"`
+	Namespace string `json:"namespace"`
+	Role      string `json:"role"`
+}
+
+// NamespaceRoleBindingList is a collection of namespace role bindings
+type NamespaceRoleBindingList []NamespaceRoleBinding
+
+// NamespaceRoleGrantRequest contains the user and the role to assign to it in the namespace
+type NamespaceRoleGrantRequest struct {
+	User string `json:"user"`
+	Role string `json:"role"`
+}
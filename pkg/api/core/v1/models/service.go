@@ -11,28 +11,42 @@
 
 package models
 
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 type Service struct {
 	Meta                  Meta               `json:"meta,omitempty"`
 	SecretTypes           []string           `json:"secretTypes,omitempty"`
 	CatalogService        string             `json:"catalog_service,omitempty"`
+	CatalogServiceName    string             `json:"catalog_service_name,omitempty"` // CatalogService, without `[Missing]` marker
 	CatalogServiceVersion string             `json:"catalog_service_version,omitempty"`
+	Chart                 *ServiceChartRef   `json:"chart,omitempty"` // nil for a missing catalog service
 	Status                ServiceStatus      `json:"status,omitempty"`
 	BoundApps             []string           `json:"boundapps"`
 	InternalRoutes        []string           `json:"internal_routes,omitempty"`
 	Settings              ChartValueSettings `json:"settings,omitempty"`
-	Details               map[string]string  `json:"details,omitempty"` // Details from associated configs
+	Details               map[string]string  `json:"details,omitempty"`   // Details from associated configs
+	ExpiresAt             *metav1.Time       `json:"expiresAt,omitempty"` // automatic deletion, if set
 }
 
 func (s Service) Namespace() string {
 	return s.Meta.Namespace
 }
 
+// ServiceChartRef references the helm chart a service instance is deployed from, as declared
+// by its catalog service.
+type ServiceChartRef struct {
+	Chart      string `json:"chart,omitempty"`
+	Version    string `json:"version,omitempty"`
+	Repository string `json:"repository,omitempty"`
+}
+
 type ServiceStatus string
 
 const (
-	ServiceStatusDeployed ServiceStatus = "deployed"
-	ServiceStatusNotReady ServiceStatus = "not-ready"
-	ServiceStatusUnknown  ServiceStatus = "unknown"
+	ServiceStatusDeployed  ServiceStatus = "deployed"
+	ServiceStatusNotReady  ServiceStatus = "not-ready"
+	ServiceStatusUnknown   ServiceStatus = "unknown"
+	ServiceStatusSuspended ServiceStatus = "suspended"
 )
 
 func (s ServiceStatus) String() string { return string(s) }
@@ -40,6 +54,13 @@ func (s ServiceStatus) String() string { return string(s) }
 // ServiceList represents a collection of service instances
 type ServiceList []Service
 
+// ServiceListResponse is a page of a service listing, sorted by name. The total counts all the
+// services matching the listing, across all pages.
+type ServiceListResponse struct {
+	Items ServiceList `json:"items"`
+	Total int         `json:"total"`
+}
+
 // ServiceMatchResponse contains the list of names for matching services
 type ServiceMatchResponse struct {
 	Names []string `json:"names,omitempty"`
@@ -57,6 +78,21 @@ type ServiceCreateRequest struct {
 	Settings       ChartValueSettings `json:"settings,omitempty" yaml:"settings,omitempty"`
 }
 
+// ServiceCreateResponse is the response of a successful service creation. The warning is set
+// when the catalog service is deprecated.
+type ServiceCreateResponse struct {
+	Response
+	Warning string `json:"warning,omitempty"`
+}
+
+// ServiceCloneRequest represents the target of cloning a service, i.e. the namespace to create
+// the clone in, and its name. The name defaults to the name of the cloned service.
+type ServiceCloneRequest struct {
+	TargetNamespace string `json:"targetNamespace"`
+	Name            string `json:"name,omitempty"`
+	Wait            bool   `json:"wait,omitempty"`
+}
+
 // NOTE: The `Update` and `Replace` requests below serve the same function, the modification and
 // redeployment of an existing service with changed custom values. The two endpoint differ in the
 // representation of the change and through that which user they are suitable for.
@@ -70,11 +106,39 @@ type ServiceCreateRequest struct {
 
 // ServiceUpdateRequest represents and contains the data needed to
 // update a service instance (add/change, and remove custom value keys)
+//
+// The convenience fields (`Replicas`) are translated into the chart value the catalog service
+// maps them to, sparing the user knowledge of chart internals. `Set` remains the escape hatch
+// for anything not mapped.
+//
+// With `Validate` set the changed values are checked against the `values.schema.json` of the
+// service's chart, if it has one, before anything is changed.
 type ServiceUpdateRequest struct {
-	Remove  []string           `json:"remove,omitempty"`
-	Set     ChartValueSettings `json:"edit,omitempty"`
-	Wait    bool               `json:"wait,omitempty"`
-	Restart *bool              `json:"restart,omitempty"`
+	Remove   []string           `json:"remove,omitempty"`
+	Set      ChartValueSettings `json:"edit,omitempty"`
+	Wait     bool               `json:"wait,omitempty"`
+	Restart  *bool              `json:"restart,omitempty"`
+	Replicas *int32             `json:"replicas,omitempty"`
+	Validate *bool              `json:"validate,omitempty"`
+}
+
+// ServiceUpdateResponse is the response of a successful service update. When waiting for the
+// update RestartedApps lists the applications restarted to pick up the changes. Else the restart
+// happens after the response, and PendingRestartApps lists the running bound applications which
+// are going to be restarted. Both are empty when restart was not requested.
+type ServiceUpdateResponse struct {
+	Response
+	RestartedApps      []string `json:"restartedApps,omitempty"`
+	PendingRestartApps []string `json:"pendingRestartApps,omitempty"`
+}
+
+// ServiceUpdatePreview is the response to a dry-run service update. Values are the chart values
+// of the service after the update. The diffs show the changes of the values, and of the resources
+// rendered from them, in unified format. They are empty when nothing changes.
+type ServiceUpdatePreview struct {
+	Values       string `json:"values"`
+	ValuesDiff   string `json:"valuesDiff"`
+	ManifestDiff string `json:"manifestDiff"`
 }
 
 // ServiceReplaceRequest represents and contains the data needed to
@@ -85,6 +149,100 @@ type ServiceReplaceRequest struct {
 	Restart  *bool              `json:"restart,omitempty"`
 }
 
+// ServiceResetRequest represents and contains the data needed to reset a service instance to the
+// values it was created with
+type ServiceResetRequest struct {
+	Wait    bool  `json:"wait,omitempty"`
+	Restart *bool `json:"restart,omitempty"`
+}
+
+// ServiceResetResponse lists the keys of the settings changed by resetting a service instance
+type ServiceResetResponse struct {
+	Changed []string `json:"changed"`
+}
+
+// ServiceValuesResponse contains the effective values of a service instance, i.e. the merger of
+// chart defaults, catalog values, and user settings. Sensitive values are masked when `Redacted`
+// is set.
+type ServiceValuesResponse struct {
+	Values   map[string]interface{} `json:"values"`
+	Redacted bool                   `json:"redacted"`
+}
+
+// ServiceOriginalValuesResponse contains the values a service instance was created with, before
+// any updates. These are the catalog values, verbatim, and the user settings given at creation,
+// plus their merger. Sensitive values are masked when `Redacted` is set. The verbatim catalog
+// values are not returned then.
+type ServiceOriginalValuesResponse struct {
+	CatalogValues string                 `json:"catalogValues,omitempty"`
+	Settings      ChartValueSettings     `json:"settings,omitempty"`
+	Values        map[string]interface{} `json:"values"`
+	Redacted      bool                   `json:"redacted"`
+}
+
+// ServiceSuspendResponse is the response of a successful service suspension. The warnings
+// list the bound applications which are running, and lose access to the service.
+type ServiceSuspendResponse struct {
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ServiceRestartResponse lists the names of the applications restarted for a service. It is
+// empty when no running application is bound to the service.
+type ServiceRestartResponse struct {
+	Restarted []string `json:"restarted"`
+}
+
+// ExpiryRequest sets the time to live of an application or service. The resource is deleted
+// automatically when it expires. The ttl is a duration, as in "2h" or "90m", counted from the
+// time of the request. Use it again to extend the life of the resource. An empty ttl removes
+// the expiry.
+type ExpiryRequest struct {
+	TTL string `json:"ttl"`
+}
+
+// ExpiryResponse returns the time an application or service expires at, if any.
+type ExpiryResponse struct {
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// Event is a kubernetes event concerning one of the resources making up an epinio object,
+// like the pods and volumes of a service instance, or the pods of an application.
+type Event struct {
+	Type      string      `json:"type"`
+	Reason    string      `json:"reason"`
+	Message   string      `json:"message"`
+	Kind      string      `json:"kind"`
+	Object    string      `json:"object"`
+	Count     int32       `json:"count"`
+	FirstSeen metav1.Time `json:"firstSeen,omitempty"`
+	LastSeen  metav1.Time `json:"lastSeen,omitempty"`
+}
+
+// EventList is a collection of events, ordered from newest to oldest.
+type EventList []Event
+
+// ServiceProgressEvent is sent over the service progress websocket endpoint, to report the
+// provisioning progress of a service. Phase is the status of the service's helm release
+// (pending-install, deployed, failed, ...), with Message the release's description of it, and
+// Status the epinio status of the service. Pods reports the readiness of the release's pods.
+// Completed marks the final event, sent once the service is deployed, or failed.
+type ServiceProgressEvent struct {
+	Service   string               `json:"service"`
+	Namespace string               `json:"namespace"`
+	Phase     string               `json:"phase"`
+	Message   string               `json:"message,omitempty"`
+	Status    ServiceStatus        `json:"status"`
+	Pods      []ServicePodProgress `json:"pods"`
+	Completed bool                 `json:"completed"`
+}
+
+// ServicePodProgress is the readiness of a pod of a service's helm release
+type ServicePodProgress struct {
+	Name  string `json:"name"`
+	Phase string `json:"phase"`
+	Ready bool   `json:"ready"`
+}
+
 // ServiceDeleteRequest represents and contains the data needed to delete a service
 type ServiceDeleteRequest struct {
 	Unbind bool `json:"unbind"`
@@ -97,16 +255,77 @@ type ServiceDeleteResponse struct {
 
 type ServiceBindRequest struct {
 	AppName string `json:"app_name,omitempty"`
+	Restart *bool  `json:"restart,omitempty"`
+}
+
+// ServiceBindResponse is the response to binding services to an application. It lists what
+// each bound service contributes to the application. ApplyOnDeploy is set when the application
+// is not deployed yet. The bindings are recorded, and applied by its first deployment.
+// ApplyOnRestart is set when the restart of the deployed application was declined. The bindings
+// are recorded, and applied by its next restart or deployment.
+type ServiceBindResponse struct {
+	Response
+	Services       []ServiceBindingKeys `json:"services,omitempty"`
+	ApplyOnDeploy  bool                 `json:"applyOnDeploy,omitempty"`
+	ApplyOnRestart bool                 `json:"applyOnRestart,omitempty"`
+}
+
+// ServiceBindingKeys lists the configurations a bound service contributes to an application.
+type ServiceBindingKeys struct {
+	Service        string                   `json:"service"`
+	Configurations []BoundConfigurationKeys `json:"configurations"`
+}
+
+// BoundConfigurationKeys lists the keys of a bound configuration, and the path they are mounted
+// at in the application's containers, as files named by the keys.
+type BoundConfigurationKeys struct {
+	Name      string   `json:"name"`
+	MountPath string   `json:"mountPath"`
+	Keys      []string `json:"keys"`
 }
 
 type ServiceUnbindRequest struct {
 	AppName string `json:"app_name,omitempty"`
 }
 
-// ServiceBatchBindRequest represents a request to bind multiple services to an application at once
+// ServiceBatchBindRequest represents a request to bind multiple services to an application at once.
+// By default the request is atomic, binding nothing when any service fails. With Partial set the
+// services which can be bound are, and the response reports the result per service. Restart
+// defaults to true, restarting a deployed application to apply the bindings.
 type ServiceBatchBindRequest struct {
 	AppName      string   `json:"app_name,omitempty"`
 	ServiceNames []string `json:"service_names,omitempty"`
+	Partial      bool     `json:"partial,omitempty"`
+	Restart      *bool    `json:"restart,omitempty"`
+}
+
+// Statuses of a service in a partial batch bind
+const (
+	ServiceBindStatusBound  = "bound"
+	ServiceBindStatusFailed = "failed"
+)
+
+// ServiceBatchBindResult is the outcome of binding a single service in a partial batch bind.
+type ServiceBatchBindResult struct {
+	Service string `json:"service"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ServiceBatchBindResponse is the response to binding multiple services to an application at
+// once. Results are only present for a partial batch bind, in the order of the request.
+type ServiceBatchBindResponse struct {
+	ServiceBindResponse
+	Results []ServiceBatchBindResult `json:"results,omitempty"`
+}
+
+// ServiceRebindRequest represents a request to move an application between services. The
+// services in Remove are unbound and the services in Add are bound, with a single restart of
+// the application.
+type ServiceRebindRequest struct {
+	AppName string   `json:"app_name,omitempty"`
+	Remove  []string `json:"remove,omitempty"`
+	Add     []string `json:"add,omitempty"`
 }
 
 // CatalogServices is a list of catalog service elements
@@ -131,6 +350,45 @@ type CatalogService struct {
 	HelmRepo         HelmRepo                `json:"helm_repo,omitempty"`
 	Values           string                  `json:"values,omitempty"`
 	Settings         map[string]ChartSetting `json:"settings,omitempty"`
+	ReplicasKey      string                  `json:"replicasKey,omitempty"`
+	Readiness        *ServiceReadiness       `json:"readiness,omitempty"`
+
+	// Deprecated catalog services are retired. They are not listed by default, and creating a
+	// service from them is warned about.
+	Deprecated         bool   `json:"deprecated,omitempty"`
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+}
+
+// ServiceReadiness is the readiness predicate of a catalog service. It refines the `deployed`
+// status of the service instances beyond the view of Helm. An instance is deployed when a pod of
+// its release, matching the `PodSelector`, has the pod `Condition` (default `Ready`), and, if
+// specified, the `Command` succeeds in the `Container` of that pod.
+type ServiceReadiness struct {
+	PodSelector string   `json:"podSelector,omitempty"`
+	Condition   string   `json:"condition,omitempty"`
+	Command     []string `json:"command,omitempty"`
+	Container   string   `json:"container,omitempty"`
+}
+
+// CatalogServiceValidateRequest holds the values to validate against the schema of a catalog
+// service's chart. Empty values stand for the values of the catalog service itself.
+type CatalogServiceValidateRequest struct {
+	Values string `json:"values,omitempty"`
+}
+
+// CatalogServiceValidateResponse is the result of validating values against the schema of a
+// catalog service's chart. Charts without a schema accept all values.
+type CatalogServiceValidateResponse struct {
+	Valid       bool                    `json:"valid"`
+	SchemaFound bool                    `json:"schemaFound"`
+	Errors      []SchemaValidationError `json:"errors,omitempty"`
+}
+
+// SchemaValidationError is a single schema violation, located by the JSON path of the offending
+// value, e.g. `$.auth.rootPassword`.
+type SchemaValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
 }
 
 // HelmRepo matches github.com/epinio/application/api/v1 HelmRepo